@@ -1,33 +1,290 @@
 package main
 
 import (
+	"encoding/hex"
+	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/abdorrahmani/cryptolens/internal/cli"
 	"github.com/abdorrahmani/cryptolens/internal/config"
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
+// options holds the command-line flags
+type options struct {
+	audit      bool
+	wipeKeys   bool
+	resetKeys  bool
+	resultOnly bool
+	noSteps    bool
+	aad        string
+	configPath string
+	version    bool
+	json       bool
+	qrCode     bool
+	copy       bool
+
+	// Streaming file encryption
+	encryptFile string
+	decryptFile string
+
+	// Key format conversion
+	convertKey string
+	keyFormat  string
+	keyType    string
+	outFile    string
+}
+
+// parseFlags parses the command-line arguments into options
+func parseFlags(args []string) (*options, error) {
+	opts := &options{}
+	flags := flag.NewFlagSet("cryptolens", flag.ContinueOnError)
+	flags.BoolVar(&opts.audit, "audit", false, "report randomness sources and libraries used by each operation")
+	flags.BoolVar(&opts.wipeKeys, "wipe-keys", false, "securely delete generated key files and exit")
+	flags.BoolVar(&opts.resetKeys, "reset-keys", false, "delete generated key files so they are regenerated on next use, and exit")
+	flags.BoolVar(&opts.resultOnly, "result-only", false, "print only the result, without steps or formatting")
+	flags.BoolVar(&opts.resultOnly, "q", false, "shorthand for --result-only")
+	flags.BoolVar(&opts.noSteps, "no-steps", false, "show formatted results without the step-by-step explanation (overrides general.verbose)")
+	flags.BoolVar(&opts.qrCode, "qr", false, "also draw each result as a terminal QR code (needs a build with -tags qr)")
+	flags.BoolVar(&opts.copy, "copy", false, "copy each result to the system clipboard")
+	flags.BoolVar(&opts.version, "version", false, "print the version and exit")
+	flags.BoolVar(&opts.json, "json", false, "with --version, print version, algorithms, and features as JSON")
+	flags.StringVar(&opts.convertKey, "convert-key", "", "convert the key in this file to the --to format and exit")
+	flags.StringVar(&opts.keyFormat, "to", crypto.KeyFormatPKCS8, "target key format: pkcs1, pkcs8, pkix, der, raw, or openssh")
+	flags.StringVar(&opts.keyType, "key-type", "", "type of a raw input key: ed25519-private, ed25519-public, x25519-private, or x25519-public")
+	flags.StringVar(&opts.aad, "aad", "", "additional authenticated data for ChaCha20-Poly1305 (overrides chacha20poly1305.aad)")
+	flags.StringVar(&opts.encryptFile, "encrypt-file", "", "encrypt this file with chunked ChaCha20-Poly1305 and exit")
+	flags.StringVar(&opts.decryptFile, "decrypt-file", "", "decrypt a file written by --encrypt-file and exit")
+	flags.StringVar(&opts.outFile, "out", "", "write the converted key or the processed file here instead of stdout")
+	flags.StringVar(&opts.configPath, "config", "", "path to a YAML config file (default ~/.cryptolens/config.yaml)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfigCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	opts, err := parseFlags(os.Args[1:])
+	if err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		os.Exit(2)
+	}
+
+	if opts.version {
+		if err := printVersion(opts.json); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.convertKey != "" {
+		if err := convertKeyFile(opts); err != nil {
+			fmt.Printf("Error converting key: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Load configuration
-	cfg, err := config.LoadConfig("")
+	cfg, err := config.LoadConfig(opts.configPath)
 	if err != nil {
 		fmt.Printf("Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
+	general := cfg.GetGeneralConfig()
+	if err := utils.ConfigureLogger(os.Stderr, general.LogLevel, general.Debug); err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	configPath := opts.configPath
+	if configPath == "" {
+		configPath, _ = config.DefaultConfigPath()
+	}
+	slog.Debug("configuration resolved", "file", configPath, "keysDir", filepath.Dir(cfg.GetAESConfig().KeyFile),
+		"logLevel", general.LogLevel, "outputEncoding", general.OutputEncoding, "maxWorkers", general.MaxWorkers)
+	if opts.aad != "" {
+		cfg.ChaCha20Poly1305.AAD = opts.aad
+	}
+
+	if opts.encryptFile != "" || opts.decryptFile != "" {
+		if err := streamFile(cfg, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Create components
 	display := cli.NewConsoleDisplay()
+	display.SetResultOnly(opts.resultOnly)
+	display.SetVerbose(cfg.GetGeneralConfig().IsVerbose() && !opts.noSteps)
+	display.SetQRCode(cfg.GetGeneralConfig().QRCode || opts.qrCode)
+	display.SetClipboard(cfg.GetGeneralConfig().Clipboard || opts.copy)
 	input := cli.NewConsoleInput()
+	if cfg.GetGeneralConfig().Pager && utils.StdinIsTerminal() && utils.StdoutIsTerminal() {
+		display.SetPager(input)
+	}
 	factory := cli.NewCryptoProcessorFactory()
 
 	// Configure factory with settings
 	factory.SetConfig(cfg)
+	if _, err := factory.LoadPlugins(cfg.GetPluginsConfig()); err != nil {
+		display.ShowError(err)
+	}
+	display.SetMenuEntries(factory.MenuEntries())
 
 	// Create and run menu
 	menu := cli.NewMenu(display, input, factory)
+	menu.SetAuditMode(opts.audit)
+	keysDir := filepath.Dir(cfg.GetAESConfig().KeyFile)
+	menu.SetKeysDir(keysDir)
+	menu.SetMaxRunDuration(cfg.GetGeneralConfig().MaxRunDuration)
+	if path := cfg.GetGeneralConfig().SessionLog; path != "" {
+		sessionLog, file, err := cli.OpenSessionLog(path, cfg.GetGeneralConfig().SessionLogOutputs)
+		if err != nil {
+			display.ShowError(err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		menu.SetSessionLog(sessionLog)
+	}
+	if opts.wipeKeys {
+		if err := menu.WipeKeys(keysDir); err != nil {
+			display.ShowError(err)
+			os.Exit(1)
+		}
+		return
+	}
+	if opts.resetKeys {
+		if err := menu.ResetKeys(keysDir); err != nil {
+			display.ShowError(err)
+			os.Exit(1)
+		}
+		return
+	}
 	if err := menu.Run(); err != nil {
 		display.ShowError(err)
 		os.Exit(1)
 	}
 }
+
+// configUsage describes the config subcommands
+const configUsage = `usage:
+  cryptolens config show [--config path]          print the effective configuration
+  cryptolens config init [--force] [path]         write a default config file`
+
+// runConfigCommand runs "config show" or "config init"
+func runConfigCommand(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing config subcommand\n%s", configUsage)
+	}
+
+	flags := flag.NewFlagSet("config "+args[0], flag.ContinueOnError)
+	configPath := flags.String("config", "", "path to a YAML config file (default ~/.cryptolens/config.yaml)")
+	force := flags.Bool("force", false, "with init, overwrite an existing file")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		*configPath = flags.Arg(0)
+	}
+
+	switch args[0] {
+	case "show":
+		cfg, err := config.LoadConfig(*configPath)
+		if err != nil {
+			return fmt.Errorf("error loading configuration: %w", err)
+		}
+		data, err := cfg.Marshal()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "# Effective configuration: file values, computed defaults, and CRYPTOLENS_* overrides")
+		_, err = w.Write(data)
+		return err
+	case "init":
+		path, err := config.InitConfig(*configPath, *force)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Wrote default configuration to %s\n", path)
+		return nil
+	default:
+		return fmt.Errorf("unknown config subcommand: %s\n%s", args[0], configUsage)
+	}
+}
+
+// printVersion prints the version line, or the full capabilities as JSON
+func printVersion(asJSON bool) error {
+	if !asJSON {
+		fmt.Println(cli.VersionString())
+		return nil
+	}
+	data, err := cli.CapabilitiesJSON()
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// streamFile runs --encrypt-file or --decrypt-file, reporting on stderr so stdout can carry data
+func streamFile(cfg *config.Config, opts *options) error {
+	if opts.encryptFile != "" && opts.decryptFile != "" {
+		return fmt.Errorf("use only one of --encrypt-file and --decrypt-file")
+	}
+	path, operation := opts.encryptFile, crypto.OperationEncrypt
+	if opts.decryptFile != "" {
+		path, operation = opts.decryptFile, crypto.OperationDecrypt
+	}
+	summary, err := cli.StreamFile(cfg, path, opts.outFile, operation)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, summary)
+	return nil
+}
+
+// convertKeyFile converts a key file between formats. Binary output is hex encoded on stdout.
+func convertKeyFile(opts *options) error {
+	data, err := os.ReadFile(opts.convertKey)
+	if err != nil {
+		return fmt.Errorf("failed to read key: %w", err)
+	}
+	converted, steps, err := crypto.ConvertKey(data, opts.keyType, opts.keyFormat)
+	if err != nil {
+		return err
+	}
+
+	if opts.outFile != "" {
+		if err := os.WriteFile(opts.outFile, converted, 0600); err != nil {
+			return fmt.Errorf("failed to write key: %w", err)
+		}
+		display := cli.NewConsoleDisplay()
+		display.ShowResult(fmt.Sprintf("Wrote %s key to %s", opts.keyFormat, opts.outFile), steps)
+		return nil
+	}
+
+	if opts.keyFormat == crypto.KeyFormatDER || opts.keyFormat == crypto.KeyFormatRaw {
+		fmt.Println(hex.EncodeToString(converted))
+		return nil
+	}
+	fmt.Print(string(converted))
+	return nil
+}
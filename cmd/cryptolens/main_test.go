@@ -1,9 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/config"
 )
 
 func TestMain(m *testing.M) {
@@ -45,3 +49,89 @@ func TestMainInitialization(t *testing.T) {
 	// Test with valid config path
 	os.Setenv("CRYPTOLENS_CONFIG", filepath.Join(configDir, "config.yaml"))
 }
+
+func TestConfigFlagTakesPrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // For Windows
+
+	// Default location selects sha256
+	defaultDir := filepath.Join(home, ".cryptolens")
+	if err := os.MkdirAll(defaultDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(defaultDir, "config.yaml"), []byte("hash:\n  algorithm: sha256\n"), 0600); err != nil {
+		t.Fatalf("Failed to write default config: %v", err)
+	}
+
+	// Profile passed with --config selects sha3-256
+	profile := filepath.Join(t.TempDir(), "profile.yaml")
+	if err := os.WriteFile(profile, []byte("hash:\n  algorithm: sha3-256\n"), 0600); err != nil {
+		t.Fatalf("Failed to write profile config: %v", err)
+	}
+
+	opts, err := parseFlags([]string{"--config", profile, "-q"})
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if opts.configPath != profile || !opts.resultOnly || opts.noSteps {
+		t.Fatalf("Unexpected options: %+v", opts)
+	}
+
+	// -q stays the shorthand for --result-only; --no-steps only hides the steps
+	if noSteps, err := parseFlags([]string{"--no-steps"}); err != nil || !noSteps.noSteps || noSteps.resultOnly {
+		t.Fatalf("Unexpected options for --no-steps: %+v, %v", noSteps, err)
+	}
+
+	cfg, err := config.LoadConfig(opts.configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if got := cfg.GetHashConfig().Algorithm; got != "sha3-256" {
+		t.Errorf("Expected hash algorithm from --config file (sha3-256), got %s", got)
+	}
+
+	// Without the flag the default location is used
+	opts, err = parseFlags(nil)
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	cfg, err = config.LoadConfig(opts.configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if got := cfg.GetHashConfig().Algorithm; got != "sha256" {
+		t.Errorf("Expected hash algorithm from default config (sha256), got %s", got)
+	}
+}
+
+func TestConfigCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles", "config.yaml")
+
+	var out bytes.Buffer
+	if err := runConfigCommand([]string{"init", path}, &out); err != nil {
+		t.Fatalf("config init failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected config file to be written: %v", err)
+	}
+	if err := runConfigCommand([]string{"init", path}, &out); err == nil {
+		t.Error("Expected config init to refuse to overwrite without --force")
+	}
+	if err := runConfigCommand([]string{"init", "--force", path}, &out); err != nil {
+		t.Errorf("config init --force failed: %v", err)
+	}
+
+	t.Setenv("CRYPTOLENS_HASH_ALGORITHM", "sha3-512")
+	out.Reset()
+	if err := runConfigCommand([]string{"show", "--config", path}, &out); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "algorithm: sha3-512") {
+		t.Errorf("Expected config show to reflect the env override, got:\n%s", out.String())
+	}
+
+	if err := runConfigCommand([]string{"edit"}, &out); err == nil {
+		t.Error("Expected an error for an unknown subcommand")
+	}
+}
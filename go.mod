@@ -5,7 +5,9 @@ go 1.24.0
 toolchain go1.24.3
 
 require (
+	github.com/atotto/clipboard v0.1.4
 	github.com/golang-jwt/jwt/v5 v5.2.2
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/stretchr/testify v1.8.4
 	github.com/zeebo/blake3 v0.2.4
 	golang.org/x/crypto v0.45.0
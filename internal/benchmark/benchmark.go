@@ -1,6 +1,8 @@
 package benchmark
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sort"
@@ -16,11 +18,28 @@ import (
 type BenchmarkResult struct {
 	name         string
 	duration     time.Duration
+	iterations   int // Iterations completed; fewer than requested when the run hit its time limit
 	memoryUsage  uint64
 	allocations  uint64
 	platformInfo PlatformInfo
 }
 
+// average returns the mean time per completed iteration
+func (r BenchmarkResult) average() time.Duration {
+	if r.iterations == 0 {
+		return 0
+	}
+	return r.duration / time.Duration(r.iterations)
+}
+
+// opsLabel describes the iterations a result completed, e.g. "1000 ops" or "250 of 1000 ops"
+func opsLabel(result BenchmarkResult, iterations int) string {
+	if result.iterations < iterations {
+		return fmt.Sprintf("%d of %d ops", result.iterations, iterations)
+	}
+	return fmt.Sprintf("%d ops", result.iterations)
+}
+
 // PlatformInfo contains information about the system running the benchmark
 type PlatformInfo struct {
 	OS           string
@@ -40,27 +59,30 @@ func getPlatformInfo() PlatformInfo {
 }
 
 // RunHMACBenchmark runs a benchmark of all HMAC algorithms
-func RunHMACBenchmark() (string, []string, error) {
+func RunHMACBenchmark() (string, []utils.Step, error) {
+	return RunHMACBenchmarkContext(context.Background())
+}
+
+// RunHMACBenchmarkContext is RunHMACBenchmark, stopping between iterations once ctx is done
+func RunHMACBenchmarkContext(ctx context.Context) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 	setupBenchmark(v, "HMAC")
 
 	text := getSampleText("Hello, World!")
-	iterations := getIterations(10000, 1000000)
-
-	v.AddStep(fmt.Sprintf("Running benchmark with %d iterations...", iterations))
-	v.AddStep(fmt.Sprintf("Sample text: %s", text))
-	v.AddSeparator()
+	iterations := getIterations(1000000)
 
 	algorithms := []string{
 		"sha1",
 		"sha256",
 		"sha512",
+		"sha3-256",
+		"sha3-512",
 		"blake2b-256",
 		"blake2b-512",
 		"blake3",
 	}
 
-	results := runAlgorithmBenchmark(algorithms, text, iterations, func(algo string) (crypto.Processor, error) {
+	createProcessor := func(algo string) (crypto.Processor, error) {
 		processor := crypto.NewHMACProcessor()
 		if err := processor.Configure(map[string]interface{}{
 			"hashAlgorithm": algo,
@@ -68,32 +90,59 @@ func RunHMACBenchmark() (string, []string, error) {
 			return nil, fmt.Errorf("failed to configure %s: %w", algo, err)
 		}
 		return processor, nil
-	})
+	}
+
+	if getHMACSweepMode() {
+		maxIterations := iterations
+		if maxIterations == 0 {
+			maxIterations = 1000000
+		}
+		if err := runHMACSweep(ctx, v, algorithms, maxIterations); err != nil {
+			return "", nil, err
+		}
+		return "", v.GetSteps(), nil
+	}
+
+	iterations, err := resolveIterations(v, iterations, algorithms, text, 1000000, createProcessor)
+	if err != nil {
+		return "", nil, err
+	}
+
+	v.AddStep(fmt.Sprintf("Running benchmark with %d iterations...", iterations))
+	v.AddStep(fmt.Sprintf("Sample text: %s", text))
+	v.AddSeparator()
+
+	results, err := runAlgorithmBenchmark(ctx, algorithms, text, iterations, createProcessor)
+	if err := partialResults(v, results, err); err != nil {
+		return "", nil, err
+	}
 
 	displayHMACResults(v, results, iterations)
 	return "", v.GetSteps(), nil
 }
 
 // RunPBKDFBenchmark runs a benchmark of all PBKDF algorithms
-func RunPBKDFBenchmark() (string, []string, error) {
+func RunPBKDFBenchmark() (string, []utils.Step, error) {
+	return RunPBKDFBenchmarkContext(context.Background())
+}
+
+// RunPBKDFBenchmarkContext is RunPBKDFBenchmark, stopping between iterations once ctx is done
+func RunPBKDFBenchmarkContext(ctx context.Context) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 	setupBenchmark(v, "PBKDF")
 
 	text := getSampleText("Hello")
 	iterations := getPBKDFIterations()
 
-	v.AddStep(fmt.Sprintf("Running benchmark with %d iterations...", iterations))
-	v.AddStep(fmt.Sprintf("Sample text: %s", text))
-	v.AddStep(fmt.Sprintf("Estimated time: %v", estimatePBKDFTime(iterations)))
-	v.AddSeparator()
-
 	algorithms := []string{
 		"pbkdf2",
 		"argon2id",
+		"argon2i",
 		"scrypt",
+		"bcrypt",
 	}
 
-	results := runAlgorithmBenchmark(algorithms, text, iterations, func(algo string) (crypto.Processor, error) {
+	createProcessor := func(algo string) (crypto.Processor, error) {
 		processor := crypto.NewPBKDFProcessor()
 		if err := processor.Configure(map[string]interface{}{
 			"algorithm": algo,
@@ -101,15 +150,29 @@ func RunPBKDFBenchmark() (string, []string, error) {
 			return nil, fmt.Errorf("failed to configure %s: %w", algo, err)
 		}
 		return processor, nil
-	})
+	}
+
+	iterations, err := resolveIterations(v, iterations, algorithms, text, 1000, createProcessor)
+	if err != nil {
+		return "", nil, err
+	}
+
+	v.AddStep(fmt.Sprintf("Running benchmark with %d iterations...", iterations))
+	v.AddStep(fmt.Sprintf("Sample text: %s", text))
+	v.AddStep(fmt.Sprintf("Estimated time: %v", estimatePBKDFTime(iterations)))
+	v.AddSeparator()
+
+	results, err := runAlgorithmBenchmark(ctx, algorithms, text, iterations, createProcessor)
+	if err := partialResults(v, results, err); err != nil {
+		return "", nil, err
+	}
 
 	displayPBKDFResults(v, results, iterations)
 	return "", v.GetSteps(), nil
 }
 
 func setupBenchmark(v *utils.Visualizer, name string) {
-	v.AddStep(fmt.Sprintf("%s Benchmark", name))
-	v.AddStep("=============================")
+	v.AddHeader(fmt.Sprintf("%s Benchmark", name))
 	v.AddNote(fmt.Sprintf("This benchmark will test all available %s algorithms", name))
 	v.AddNote("The test will use a sample text and run multiple iterations")
 	v.AddSeparator()
@@ -120,56 +183,79 @@ func getSampleText(defaultValue string) string {
 	return input.GetTextInput(defaultValue)
 }
 
-func getIterations(defaultValue, maxValue int) int {
-	iterations := input.GetIntInput("\nEnter number of iterations (default: 10000): ", 1, maxValue)
-	if iterations == 0 {
-		iterations = defaultValue
+// getIterations asks for an iteration count; zero means auto-calibrate
+func getIterations(maxValue int) int {
+	return input.GetIntInput("\nEnter number of iterations (press Enter to auto-calibrate): ", 1, maxValue)
+}
+
+// resolveIterations returns the user's iteration count, or calibrates one when it is zero
+func resolveIterations(
+	v *utils.Visualizer,
+	iterations int,
+	algorithms []string,
+	text string,
+	maxIterations int,
+	createProcessor func(string) (crypto.Processor, error),
+) (int, error) {
+	if iterations != 0 {
+		return iterations, nil
+	}
+	iterations, err := autoIterations(algorithms, text, maxIterations, createProcessor)
+	if err != nil {
+		return 0, err
 	}
-	return iterations
+	v.AddStep(fmt.Sprintf("Auto-calibrated to %d iterations (target: ~%v total)", iterations, calibrationTarget))
+	return iterations, nil
 }
 
 func getPBKDFIterations() int {
-	fmt.Print("\nEnter number of iterations (default: 100): ")
+	fmt.Print("\nEnter number of iterations (press Enter to auto-calibrate): ")
 	fmt.Print("\n⚠️  Warning: Large numbers will take a long time to complete")
 	fmt.Print("\n    Recommended: 10-100 iterations")
 	fmt.Print("\n    PBKDF2: ~15ms per operation")
 	fmt.Print("\n    Argon2id: ~36ms per operation")
+	fmt.Print("\n    Argon2i: ~36ms per operation")
 	fmt.Print("\n    Scrypt: ~266ms per operation")
-	fmt.Print("\n    (1000 iterations ≈ 4.5 minutes total)\n")
+	fmt.Print("\n    bcrypt (cost 10): ~60ms per operation")
+	fmt.Print("\n    (1000 iterations ≈ 6.9 minutes total)\n")
 
-	iterations := input.GetIntInput("\nEnter your choice: ", 1, 1000)
-	if iterations == 0 {
-		iterations = 100
-	}
-	return iterations
+	return input.GetIntInput("\nEnter your choice: ", 1, 1000)
 }
 
 func estimatePBKDFTime(iterations int) time.Duration {
-	return time.Duration(iterations) * (15 + 36 + 266) * time.Millisecond
+	return time.Duration(iterations) * (15 + 36 + 36 + 266 + 60) * time.Millisecond
 }
 
+// runAlgorithmBenchmark times iterations of each algorithm, checking ctx between iterations.
+// Once ctx is done it returns the results so far, including the iterations of the algorithm
+// that was running, along with an error wrapping ctx.Err().
 func runAlgorithmBenchmark(
+	ctx context.Context,
 	algorithms []string,
 	text string,
 	iterations int,
 	createProcessor func(string) (crypto.Processor, error),
-) []BenchmarkResult {
-	results := make([]BenchmarkResult, len(algorithms))
+) ([]BenchmarkResult, error) {
+	results := make([]BenchmarkResult, 0, len(algorithms))
 	platformInfo := getPlatformInfo()
 
 	done := make(chan bool)
 	go showLoadingAnimation(done)
+	defer func() { done <- true }()
 
-	for i, algo := range algorithms {
+	var stopErr error
+	for _, algo := range algorithms {
 		processor, err := createProcessor(algo)
 		if err != nil {
-			done <- true
-			return nil
+			return nil, err
 		}
 
-		if _, _, err := processor.Process(text, "encrypt"); err != nil {
-			done <- true
-			return nil
+		if _, _, err := crypto.ProcessContext(ctx, processor, text, "encrypt"); err != nil {
+			if ctx.Err() != nil {
+				stopErr = fmt.Errorf("benchmark stopped before %s: %w", algo, ctx.Err())
+				break
+			}
+			return nil, fmt.Errorf("failed to run %s: %w", algo, err)
 		}
 
 		// Reset memory stats
@@ -179,10 +265,13 @@ func runAlgorithmBenchmark(
 		startMemory := m.Alloc
 
 		start := time.Now()
-		for j := 0; j < iterations; j++ {
-			if _, _, err := processor.Process(text, "encrypt"); err != nil {
-				done <- true
-				return nil
+		completed := 0
+		for ; completed < iterations && ctx.Err() == nil; completed++ {
+			if _, _, err := crypto.ProcessContext(ctx, processor, text, "encrypt"); err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				return nil, fmt.Errorf("failed to run %s: %w", algo, err)
 			}
 		}
 		duration := time.Since(start)
@@ -192,21 +281,55 @@ func runAlgorithmBenchmark(
 		memoryUsage := m.Alloc - startMemory
 		allocations := m.TotalAlloc - startAllocs
 
-		results[i] = BenchmarkResult{
-			name:         algo,
-			duration:     duration,
-			memoryUsage:  memoryUsage,
-			allocations:  allocations,
-			platformInfo: platformInfo,
+		if completed > 0 {
+			results = append(results, BenchmarkResult{
+				name:         algo,
+				duration:     duration,
+				iterations:   completed,
+				memoryUsage:  memoryUsage,
+				allocations:  allocations,
+				platformInfo: platformInfo,
+			})
+		}
+		if completed < iterations {
+			stopErr = fmt.Errorf("benchmark stopped during %s after %d of %d iterations: %w", algo, completed, iterations, ctx.Err())
+			break
 		}
 	}
 
-	done <- true
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].duration < results[j].duration
+		return results[i].average() < results[j].average()
 	})
 
-	return results
+	return results, stopErr
+}
+
+// partialResults decides whether results cut short by err can still be shown. A run that hit
+// its time limit with some results gets a note in v and nil; any other error is returned as is.
+func partialResults(v *utils.Visualizer, results []BenchmarkResult, err error) error {
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || len(results) == 0 {
+		return err
+	}
+	v.AddNote("⏱ Time limit reached: results cover the iterations completed in time")
+	v.AddNote("Algorithms not reached before the limit are left out")
+	v.AddSeparator()
+	return nil
+}
+
+// addSpeedComparison notes how much faster the fastest result was than algo, when algo ran
+func addSpeedComparison(v *utils.Visualizer, results []BenchmarkResult, algo, label string) {
+	for _, result := range results {
+		if result.name == algo && results[0].average() > 0 {
+			v.AddStep(fmt.Sprintf("• %s is %.1f%% faster than %s",
+				strings.ToUpper(results[0].name),
+				(float64(result.average())/float64(results[0].average())*100)-100,
+				label))
+			return
+		}
+	}
 }
 
 func showLoadingAnimation(done chan bool) {
@@ -226,7 +349,7 @@ func showLoadingAnimation(done chan bool) {
 }
 
 func displayHMACResults(v *utils.Visualizer, results []BenchmarkResult, iterations int) {
-	fastestDuration := results[0].duration
+	fastest := results[0].average()
 
 	// Display platform information
 	v.AddStep("Platform Information:")
@@ -238,10 +361,10 @@ func displayHMACResults(v *utils.Visualizer, results []BenchmarkResult, iteratio
 
 	v.AddStep("Benchmark Results:")
 	for i, result := range results {
-		avgTime := float64(result.duration.Microseconds()) / float64(iterations)
-		percentageDiff := float64(result.duration) / float64(fastestDuration) * 100
-		memoryPerOp := float64(result.memoryUsage) / float64(iterations)
-		allocsPerOp := float64(result.allocations) / float64(iterations)
+		avgTime := result.average()
+		percentageDiff := float64(avgTime) / float64(fastest) * 100
+		memoryPerOp := result.memoryUsage / uint64(result.iterations)
+		allocsPerOp := float64(result.allocations) / float64(result.iterations)
 
 		var diffStr string
 		if i == 0 {
@@ -251,12 +374,12 @@ func displayHMACResults(v *utils.Visualizer, results []BenchmarkResult, iteratio
 		}
 
 		v.AddStep(fmt.Sprintf("%d. HMAC-%s:", i+1, strings.ToUpper(result.name)))
-		v.AddStep(fmt.Sprintf("   • Time: %d ops in %dms → avg: %.1fµs%s",
-			iterations,
-			result.duration.Milliseconds(),
-			avgTime,
+		v.AddStep(fmt.Sprintf("   • Time: %s in %s → avg: %s%s",
+			opsLabel(result, iterations),
+			utils.FormatDuration(result.duration),
+			utils.FormatDuration(avgTime),
 			diffStr))
-		v.AddStep(fmt.Sprintf("   • Memory: %.2f KB per operation", memoryPerOp/1024))
+		v.AddStep(fmt.Sprintf("   • Memory: %s per operation", utils.FormatBytes(memoryPerOp)))
 		v.AddStep(fmt.Sprintf("   • Allocations: %.1f per operation", allocsPerOp))
 	}
 
@@ -265,18 +388,18 @@ func displayHMACResults(v *utils.Visualizer, results []BenchmarkResult, iteratio
 	v.AddStep("Benchmark Visual Comparison:")
 
 	// Calculate the scale factor for visualization
-	maxChars := 50
-	scaleFactor := float64(maxChars) / float64(results[len(results)-1].duration.Milliseconds())
+	maxChars := utils.BarChartWidth(28)
+	scaleFactor := float64(maxChars) / float64(results[len(results)-1].average())
 
 	for _, result := range results {
-		avgTime := float64(result.duration.Microseconds()) / float64(iterations)
-		barLength := int(float64(result.duration.Milliseconds()) * scaleFactor)
+		avgTime := result.average()
+		barLength := int(float64(avgTime) * scaleFactor)
 		bar := strings.Repeat("█", barLength)
 		// Add background color and spacing
-		v.AddStep(fmt.Sprintf("\033[32m%-15s \033[40m%s\033[0m\033[32m (%.1fµs)\033[0m",
+		v.AddStep(fmt.Sprintf("\033[32m%-15s \033[40m%s\033[0m\033[32m (%s)\033[0m",
 			"HMAC-"+strings.ToUpper(result.name),
 			bar,
-			avgTime))
+			utils.FormatDuration(avgTime)))
 	}
 
 	v.AddSeparator()
@@ -287,16 +410,12 @@ func displayHMACResults(v *utils.Visualizer, results []BenchmarkResult, iteratio
 
 	v.AddSeparator()
 	v.AddStep("Performance Comparison:")
-	v.AddStep(fmt.Sprintf("• %s is %.1f%% faster than SHA-256",
-		strings.ToUpper(results[0].name),
-		(float64(results[4].duration)/float64(results[0].duration)*100)-100))
-	v.AddStep(fmt.Sprintf("• %s is %.1f%% faster than SHA-512",
-		strings.ToUpper(results[0].name),
-		(float64(results[5].duration)/float64(results[0].duration)*100)-100))
+	addSpeedComparison(v, results, "sha256", "SHA-256")
+	addSpeedComparison(v, results, "sha512", "SHA-512")
 }
 
 func displayPBKDFResults(v *utils.Visualizer, results []BenchmarkResult, iterations int) {
-	fastestDuration := results[0].duration
+	fastest := results[0].average()
 
 	// Display platform information
 	v.AddStep("Platform Information:")
@@ -308,10 +427,10 @@ func displayPBKDFResults(v *utils.Visualizer, results []BenchmarkResult, iterati
 
 	v.AddStep("Benchmark Results:")
 	for i, result := range results {
-		avgTime := float64(result.duration.Microseconds()) / float64(iterations)
-		percentageDiff := float64(result.duration) / float64(fastestDuration) * 100
-		memoryPerOp := float64(result.memoryUsage) / float64(iterations)
-		allocsPerOp := float64(result.allocations) / float64(iterations)
+		avgTime := result.average()
+		percentageDiff := float64(avgTime) / float64(fastest) * 100
+		memoryPerOp := result.memoryUsage / uint64(result.iterations)
+		allocsPerOp := float64(result.allocations) / float64(result.iterations)
 
 		var diffStr string
 		if i == 0 {
@@ -321,12 +440,12 @@ func displayPBKDFResults(v *utils.Visualizer, results []BenchmarkResult, iterati
 		}
 
 		v.AddStep(fmt.Sprintf("%d. %s:", i+1, strings.ToUpper(result.name)))
-		v.AddStep(fmt.Sprintf("   • Time: %d ops in %dms → avg: %.2fms%s",
-			iterations,
-			result.duration.Milliseconds(),
-			avgTime/1000,
+		v.AddStep(fmt.Sprintf("   • Time: %s in %s → avg: %s%s",
+			opsLabel(result, iterations),
+			utils.FormatDuration(result.duration),
+			utils.FormatDuration(avgTime),
 			diffStr))
-		v.AddStep(fmt.Sprintf("   • Memory: %.2f MB per operation", memoryPerOp/1024/1024))
+		v.AddStep(fmt.Sprintf("   • Memory: %s per operation", utils.FormatBytes(memoryPerOp)))
 		v.AddStep(fmt.Sprintf("   • Allocations: %.1f per operation", allocsPerOp))
 	}
 
@@ -335,18 +454,18 @@ func displayPBKDFResults(v *utils.Visualizer, results []BenchmarkResult, iterati
 	v.AddStep("Benchmark Visual Comparison:")
 
 	// Calculate the scale factor for visualization
-	maxChars := 50
-	scaleFactor := float64(maxChars) / float64(results[len(results)-1].duration.Milliseconds())
+	maxChars := utils.BarChartWidth(23)
+	scaleFactor := float64(maxChars) / float64(results[len(results)-1].average())
 
 	for _, result := range results {
-		avgTime := float64(result.duration.Microseconds()) / float64(iterations) / 1000 // Convert to ms
-		barLength := int(float64(result.duration.Milliseconds()) * scaleFactor)
+		avgTime := result.average()
+		barLength := int(float64(avgTime) * scaleFactor)
 		bar := strings.Repeat("█", barLength)
 		// Add background color and spacing
-		v.AddStep(fmt.Sprintf("\033[32m%-10s \033[40m%s\033[0m\033[32m (%.1fms)\033[0m",
+		v.AddStep(fmt.Sprintf("\033[32m%-10s \033[40m%s\033[0m\033[32m (%s)\033[0m",
 			strings.ToUpper(result.name),
 			bar,
-			avgTime))
+			utils.FormatDuration(avgTime)))
 	}
 
 	v.AddSeparator()
@@ -357,10 +476,6 @@ func displayPBKDFResults(v *utils.Visualizer, results []BenchmarkResult, iterati
 
 	v.AddSeparator()
 	v.AddStep("Performance Comparison:")
-	v.AddStep(fmt.Sprintf("• %s is %.1f%% faster than Argon2id",
-		strings.ToUpper(results[0].name),
-		(float64(results[1].duration)/float64(results[0].duration)*100)-100))
-	v.AddStep(fmt.Sprintf("• %s is %.1f%% faster than Scrypt",
-		strings.ToUpper(results[0].name),
-		(float64(results[2].duration)/float64(results[0].duration)*100)-100))
+	addSpeedComparison(v, results, "argon2id", "Argon2id")
+	addSpeedComparison(v, results, "scrypt", "Scrypt")
 }
@@ -0,0 +1,81 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// sleepProcessor takes a fixed time per call
+type sleepProcessor struct {
+	delay time.Duration
+}
+
+func (p *sleepProcessor) Process(text string, _ string) (string, []utils.Step, error) {
+	time.Sleep(p.delay)
+	return text, nil, nil
+}
+
+func newSleepProcessor(string) (crypto.Processor, error) {
+	return &sleepProcessor{delay: 2 * time.Millisecond}, nil
+}
+
+func TestRunAlgorithmBenchmark_TimeLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results, err := runAlgorithmBenchmark(ctx, []string{"first", "second"}, "text", 1000, newSleepProcessor)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(results) != 1 || results[0].name != "first" {
+		t.Fatalf("Expected a partial result for the first algorithm only, got %+v", results)
+	}
+	if results[0].iterations <= 0 || results[0].iterations >= 1000 {
+		t.Errorf("Expected some but not all iterations to complete, got %d", results[0].iterations)
+	}
+	if label := opsLabel(results[0], 1000); !strings.HasSuffix(label, "of 1000 ops") {
+		t.Errorf("Expected the label to show the completed share, got %q", label)
+	}
+
+	v := utils.NewVisualizer()
+	if err := partialResults(v, results, err); err != nil {
+		t.Errorf("Expected partial results to be shown after a time limit, got %v", err)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(v.GetSteps()), "\n"), "Time limit reached") {
+		t.Error("Expected a note about the time limit")
+	}
+}
+
+func TestRunAlgorithmBenchmark_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := runAlgorithmBenchmark(ctx, []string{"first"}, "text", 10, newSleepProcessor)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if err := partialResults(utils.NewVisualizer(), results, err); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected an interruption to stay an error, got %v", err)
+	}
+}
+
+func TestRunAlgorithmBenchmark_Complete(t *testing.T) {
+	results, err := runAlgorithmBenchmark(context.Background(), []string{"first", "second"}, "text", 3, newSleepProcessor)
+	if err != nil {
+		t.Fatalf("runAlgorithmBenchmark() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.iterations != 3 || opsLabel(result, 3) != "3 ops" {
+			t.Errorf("Expected 3 completed iterations for %s, got %d", result.name, result.iterations)
+		}
+	}
+}
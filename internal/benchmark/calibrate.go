@@ -0,0 +1,78 @@
+package benchmark
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+)
+
+// Auto-calibration settings
+const (
+	calibrationTarget = 2 * time.Second       // Total runtime an auto-calibrated benchmark aims for
+	calibrationWarmup = 50 * time.Millisecond // Warm-up time spent estimating each algorithm
+)
+
+// estimateOpTime runs op for at least warmup (and at least once) and returns the mean time per op
+func estimateOpTime(op func() error, warmup time.Duration) (time.Duration, error) {
+	runs := 0
+	start := time.Now()
+	for runs == 0 || time.Since(start) < warmup {
+		if err := op(); err != nil {
+			return 0, err
+		}
+		runs++
+	}
+	return time.Since(start) / time.Duration(runs), nil
+}
+
+// calibrateIterations picks one iteration count so that running every op that many times
+// takes about target in total, clamped to [1, maxIterations]
+func calibrateIterations(ops []func() error, target, warmup time.Duration, maxIterations int) (int, error) {
+	var perRound time.Duration
+	for _, op := range ops {
+		perOp, err := estimateOpTime(op, warmup)
+		if err != nil {
+			return 0, err
+		}
+		perRound += perOp
+	}
+	if perRound <= 0 {
+		return maxIterations, nil
+	}
+
+	iterations := int(target / perRound)
+	if iterations < 1 {
+		iterations = 1
+	}
+	if iterations > maxIterations {
+		iterations = maxIterations
+	}
+	return iterations, nil
+}
+
+// autoIterations calibrates the iteration count for a set of algorithms processing text
+func autoIterations(
+	algorithms []string,
+	text string,
+	maxIterations int,
+	createProcessor func(string) (crypto.Processor, error),
+) (int, error) {
+	ops := make([]func() error, len(algorithms))
+	for i, algo := range algorithms {
+		processor, err := createProcessor(algo)
+		if err != nil {
+			return 0, err
+		}
+		ops[i] = func() error {
+			_, _, err := processor.Process(text, "encrypt")
+			return err
+		}
+	}
+
+	iterations, err := calibrateIterations(ops, calibrationTarget, calibrationWarmup, maxIterations)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calibrate iterations: %w", err)
+	}
+	return iterations, nil
+}
@@ -0,0 +1,67 @@
+package benchmark
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCalibrateIterations_HitsTargetRuntime(t *testing.T) {
+	data := make([]byte, 256)
+	op := func() error {
+		sha256.Sum256(data)
+		return nil
+	}
+
+	target := 300 * time.Millisecond
+	iterations, err := calibrateIterations([]func() error{op, op}, target, 20*time.Millisecond, 100000000)
+	if err != nil {
+		t.Fatalf("calibrateIterations failed: %v", err)
+	}
+	if iterations <= 1 {
+		t.Fatalf("Expected many iterations for a fast op, got %d", iterations)
+	}
+
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		op()
+		op()
+	}
+	elapsed := time.Since(start)
+
+	// Allow generous slack for noisy CI machines
+	if elapsed < target/2 || elapsed > target*2 {
+		t.Errorf("Calibrated %d iterations ran in %v, want within 2x of %v", iterations, elapsed, target)
+	}
+}
+
+func TestCalibrateIterations_Clamps(t *testing.T) {
+	fast := func() error { return nil }
+	iterations, err := calibrateIterations([]func() error{fast}, time.Second, time.Millisecond, 1000)
+	if err != nil {
+		t.Fatalf("calibrateIterations failed: %v", err)
+	}
+	if iterations != 1000 {
+		t.Errorf("Expected the count to be clamped to 1000, got %d", iterations)
+	}
+
+	slow := func() error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+	iterations, err = calibrateIterations([]func() error{slow}, 10*time.Millisecond, time.Millisecond, 1000)
+	if err != nil {
+		t.Fatalf("calibrateIterations failed: %v", err)
+	}
+	if iterations != 1 {
+		t.Errorf("Expected at least one iteration, got %d", iterations)
+	}
+}
+
+func TestCalibrateIterations_Error(t *testing.T) {
+	failing := func() error { return errors.New("boom") }
+	if _, err := calibrateIterations([]func() error{failing}, time.Second, time.Millisecond, 1000); err == nil {
+		t.Error("Expected the op error to be returned")
+	}
+}
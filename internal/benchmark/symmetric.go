@@ -0,0 +1,210 @@
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/input"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Symmetric cipher modes covered by the symmetric benchmark
+const (
+	SymmetricAESCBC   = "aes-cbc"
+	SymmetricAESGCM   = "aes-gcm"
+	SymmetricAESCTR   = "aes-ctr"
+	SymmetricChaCha20 = "chacha20-poly1305"
+)
+
+// defaultPayloadSize is the default symmetric benchmark payload in bytes
+const defaultPayloadSize = 4096
+
+// primitiveProcessor runs a raw primitive so the benchmark measures the primitive
+// rather than the educational visualization around it
+type primitiveProcessor struct {
+	run func(input []byte) []byte
+}
+
+// Process runs the primitive on the text and discards the output
+func (p *primitiveProcessor) Process(text string, _ string) (string, []utils.Step, error) {
+	p.run([]byte(text))
+	return "", nil, nil
+}
+
+// newSymmetricCipherProcessor creates a processor for one symmetric mode with a random key
+func newSymmetricCipherProcessor(algo string) (crypto.Processor, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	// A fixed IV/nonce is fine for a throughput benchmark; real code must never reuse one
+	iv := make([]byte, aes.BlockSize)
+
+	if algo == SymmetricChaCha20 {
+		aead, err := chacha20poly1305.New(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", algo, err)
+		}
+		nonce := make([]byte, aead.NonceSize())
+		return &primitiveProcessor{run: func(plaintext []byte) []byte {
+			return aead.Seal(nil, nonce, plaintext, nil)
+		}}, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", algo, err)
+	}
+	switch algo {
+	case SymmetricAESCBC:
+		return &primitiveProcessor{run: func(plaintext []byte) []byte {
+			padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+			padded := append(append([]byte{}, plaintext...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+			cipher.NewCBCEncrypter(block, iv).CryptBlocks(padded, padded)
+			return padded
+		}}, nil
+	case SymmetricAESGCM:
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", algo, err)
+		}
+		nonce := make([]byte, aead.NonceSize())
+		return &primitiveProcessor{run: func(plaintext []byte) []byte {
+			return aead.Seal(nil, nonce, plaintext, nil)
+		}}, nil
+	case SymmetricAESCTR:
+		return &primitiveProcessor{run: func(plaintext []byte) []byte {
+			ciphertext := make([]byte, len(plaintext))
+			cipher.NewCTR(block, iv).XORKeyStream(ciphertext, plaintext)
+			return ciphertext
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported symmetric algorithm: %s", algo)
+	}
+}
+
+// RunSymmetricBenchmark runs an encryption throughput benchmark of AES modes and ChaCha20-Poly1305
+func RunSymmetricBenchmark() (string, []utils.Step, error) {
+	return RunSymmetricBenchmarkContext(context.Background())
+}
+
+// RunSymmetricBenchmarkContext is RunSymmetricBenchmark, stopping between iterations once ctx is done
+func RunSymmetricBenchmarkContext(ctx context.Context) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+	setupBenchmark(v, "Symmetric Cipher")
+
+	payloadSize := getPayloadSize()
+	iterations := getIterations(1000000)
+	payload := strings.Repeat("A", payloadSize)
+
+	algorithms := []string{
+		SymmetricAESCBC,
+		SymmetricAESGCM,
+		SymmetricAESCTR,
+		SymmetricChaCha20,
+	}
+
+	iterations, err := resolveIterations(v, iterations, algorithms, payload, 1000000, newSymmetricCipherProcessor)
+	if err != nil {
+		return "", nil, err
+	}
+
+	v.AddStep(fmt.Sprintf("Running benchmark with %d iterations...", iterations))
+	v.AddStep(fmt.Sprintf("Payload size: %d bytes", payloadSize))
+	v.AddSeparator()
+
+	results, err := runAlgorithmBenchmark(ctx, algorithms, payload, iterations, newSymmetricCipherProcessor)
+	if err := partialResults(v, results, err); err != nil {
+		return "", nil, fmt.Errorf("symmetric benchmark failed: %w", err)
+	}
+
+	displaySymmetricResults(v, results, iterations, payloadSize)
+	return "", v.GetSteps(), nil
+}
+
+func getPayloadSize() int {
+	size := input.GetIntInput(fmt.Sprintf("\nEnter payload size in bytes (default: %d): ", defaultPayloadSize), 1, 64*1024*1024)
+	if size == 0 {
+		size = defaultPayloadSize
+	}
+	return size
+}
+
+// throughputMBps returns the encryption throughput in MB/s for a result
+func throughputMBps(result BenchmarkResult, payloadSize int) float64 {
+	seconds := result.duration.Seconds()
+	if seconds == 0 {
+		return 0
+	}
+	return float64(result.iterations) * float64(payloadSize) / (1024 * 1024) / seconds
+}
+
+func displaySymmetricResults(v *utils.Visualizer, results []BenchmarkResult, iterations, payloadSize int) {
+	fastest := results[0].average()
+
+	// Display platform information
+	v.AddStep("Platform Information:")
+	v.AddStep(fmt.Sprintf("OS: %s", results[0].platformInfo.OS))
+	v.AddStep(fmt.Sprintf("Architecture: %s", results[0].platformInfo.Architecture))
+	v.AddStep(fmt.Sprintf("CPU Cores: %d", results[0].platformInfo.CPUCount))
+	v.AddStep(fmt.Sprintf("Go Version: %s", results[0].platformInfo.GoVersion))
+	v.AddSeparator()
+
+	v.AddStep("Benchmark Results:")
+	for i, result := range results {
+		avgTime := result.average()
+		percentageDiff := float64(avgTime) / float64(fastest) * 100
+		memoryPerOp := result.memoryUsage / uint64(result.iterations)
+		allocsPerOp := float64(result.allocations) / float64(result.iterations)
+
+		var diffStr string
+		if i == 0 {
+			diffStr = " (baseline)"
+		} else {
+			diffStr = fmt.Sprintf(" (+%.1f%%)", percentageDiff-100)
+		}
+
+		v.AddStep(fmt.Sprintf("%d. %s:", i+1, strings.ToUpper(result.name)))
+		v.AddStep(fmt.Sprintf("   • Throughput: %.1f MB/s", throughputMBps(result, payloadSize)))
+		v.AddStep(fmt.Sprintf("   • Time: %s in %s → avg: %s%s",
+			opsLabel(result, iterations),
+			utils.FormatDuration(result.duration),
+			utils.FormatDuration(avgTime),
+			diffStr))
+		v.AddStep(fmt.Sprintf("   • Memory: %s per operation", utils.FormatBytes(memoryPerOp)))
+		v.AddStep(fmt.Sprintf("   • Allocations: %.1f per operation", allocsPerOp))
+	}
+
+	// Add ASCII art visualization, longest bar for the highest throughput
+	v.AddSeparator()
+	v.AddStep("Benchmark Visual Comparison (MB/s):")
+
+	// Leave room for the name and the value
+	maxChars := utils.BarChartWidth(35)
+	maxThroughput := throughputMBps(results[0], payloadSize)
+	for _, result := range results {
+		throughput := throughputMBps(result, payloadSize)
+		barLength := 0
+		if maxThroughput > 0 {
+			barLength = int(throughput / maxThroughput * float64(maxChars))
+		}
+		bar := strings.Repeat("█", barLength)
+		v.AddStep(fmt.Sprintf("\033[32m%-18s \033[40m%s\033[0m\033[32m (%.1f MB/s)\033[0m",
+			strings.ToUpper(result.name),
+			bar,
+			throughput))
+	}
+
+	v.AddSeparator()
+	v.AddStep("Recommendations:")
+	v.AddStep("🚀 Fastest Cipher: " + strings.ToUpper(results[0].name))
+	v.AddStep("🛡️ Best Security (Balanced): AES-GCM with AES-NI, ChaCha20-Poly1305 without it")
+	v.AddStep("⚠️ AES-CBC and AES-CTR provide no integrity; add a MAC or use an AEAD")
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/crypto/attacks"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Capabilities describes what a build supports, for tools that integrate with CryptoLens
+type Capabilities struct {
+	Version    string              `json:"version"`
+	Algorithms map[string][]string `json:"algorithms"`
+	Attacks    []string            `json:"attacks"`
+	Features   []string            `json:"features"`
+}
+
+// GetCapabilities returns the version, algorithms, attacks, and features of this build
+func GetCapabilities() Capabilities {
+	caps := Capabilities{
+		Version: version,
+		Algorithms: map[string][]string{
+			"encoding":  {"base64", crypto.Base16, crypto.Base58, crypto.Base62, crypto.Base85},
+			"classical": {"caesar", crypto.ROT13, crypto.ROT47},
+			"symmetric": {"aes-128-cbc", "aes-192-cbc", "aes-256-cbc", "chacha20-poly1305", "aes-gcm-siv", "blowfish-cbc", "3des-cbc", "rc4", "one-time-pad", "feistel-toy"},
+			"hash": {crypto.HashSHA1, crypto.HashSHA256, crypto.HashSHA384, crypto.HashSHA512,
+				crypto.HashSHA3256, crypto.HashBLAKE2b, crypto.HashBLAKE2s, crypto.HashBLAKE3},
+			"hmac": {crypto.HashSHA1, crypto.HashSHA256, crypto.HashSHA512, crypto.HashSHA3256, crypto.HashSHA3512,
+				crypto.HashBLAKE2b256, crypto.HashBLAKE2b512, crypto.HashBLAKE2s256, crypto.HashBLAKE2b, crypto.HashBLAKE2s, crypto.HashBLAKE3},
+			"kdf":         {"pbkdf2", "argon2id", "argon2i", "scrypt", "bcrypt", "hkdf"},
+			"rsa":         {"rsa-" + crypto.RSAPaddingOAEP, "rsa-" + crypto.RSAPaddingPKCS1v15, "rsa-" + crypto.RSAModeHybrid},
+			"keyExchange": {"dh", "x25519"},
+			"jwt":         {"HS256", "RS256", "PS256", "EdDSA", "ES256", "ES384"},
+			"signature":   {"RSA-PKCS1v15", "RSA-PSS", "ECDSA-P256", "Ed25519"},
+			"checksum":    crypto.ChecksumAlgorithms(),
+			"otp":         {"totp-sha1", "totp-sha256", "totp-sha512"},
+			"scorer":      {attacks.ScorerChiSquared, attacks.ScorerBigram, attacks.ScorerDictionary},
+		},
+		Attacks: []string{"ecb", "nonce-reuse", "timing", "brute-force", "jwt-none", "frequency-analysis", "jwt-alg-confusion", "weak-rng", "predictable-iv", "rsa-key-separation", "md5-collision"},
+		Features: []string{
+			"audit",
+			"result-only",
+			"no-steps",
+			"stream-files",
+			"wipe-keys",
+			"reset-keys",
+			"config-profiles",
+			"config-command",
+			"plugins",
+			"key-challenge",
+			"key-rotation",
+			"phc-strings",
+			"symmetric-benchmark",
+			"blob-diagnostic",
+			"password-strength",
+			"salted-hashing",
+			"merkle-proofs",
+			"avalanche",
+			"clipboard",
+		},
+	}
+	if utils.QRAvailable {
+		caps.Features = append(caps.Features, "qr-output")
+	}
+	return caps
+}
+
+// CapabilitiesJSON returns the build capabilities as indented JSON
+func CapabilitiesJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(GetCapabilities(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal capabilities: %w", err)
+	}
+	return data, nil
+}
+
+// VersionString returns the human-readable version line
+func VersionString() string {
+	return fmt.Sprintf("CryptoLens v%s", version)
+}
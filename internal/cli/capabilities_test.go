@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCapabilitiesJSON(t *testing.T) {
+	data, err := CapabilitiesJSON()
+	if err != nil {
+		t.Fatalf("CapabilitiesJSON() error = %v", err)
+	}
+
+	var caps Capabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if caps.Version != version {
+		t.Errorf("version = %q, want %q", caps.Version, version)
+	}
+	if len(caps.Algorithms) == 0 {
+		t.Fatal("algorithm list is empty")
+	}
+	for category, algorithms := range caps.Algorithms {
+		if len(algorithms) == 0 {
+			t.Errorf("category %q has no algorithms", category)
+		}
+	}
+	if len(caps.Features) == 0 {
+		t.Error("feature list is empty")
+	}
+}
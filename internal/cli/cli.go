@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 type CLI struct {
@@ -30,7 +31,7 @@ func (c *CLI) Run() error {
 	}
 
 	if algorithm == "hmac" {
-		hashAlgo := GetHMACHashAlgorithm()
+		hashAlgo := GetHMACHashAlgorithm(&ConsoleInput{scanner: c.scanner, theme: utils.DefaultTheme})
 		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
 			if err := configurable.Configure(map[string]interface{}{
 				"hashAlgorithm": hashAlgo,
@@ -58,7 +59,7 @@ func (c *CLI) Run() error {
 		// Display results
 		c.PrintResult(result)
 		for _, step := range steps {
-			c.PrintTextln(step)
+			c.PrintTextln(step.Text)
 		}
 	}
 
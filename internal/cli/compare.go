@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// compareOutputWidth is how much of each output the comparison table shows
+const compareOutputWidth = 64
+
+// compareTarget is one algorithm offered by the comparison mode: a menu processor plus the
+// configuration that selects a variant of it, such as a hash algorithm
+type compareTarget struct {
+	Label   string
+	EntryID int
+	Config  map[string]interface{}
+}
+
+// compareRun is the outcome of running one target
+type compareRun struct {
+	target   compareTarget
+	result   string
+	duration time.Duration
+	err      error
+}
+
+// compareTargets lists the processors that can run unattended on a single input, using only
+// the menu metadata so that no processor is built (and no key generated) until it is selected.
+// Entries marked NoCompare are returned separately so the menu can say why they are missing.
+func compareTargets(factory ProcessorFactory) (targets []compareTarget, excluded []MenuEntry) {
+	for _, entry := range factory.MenuEntries() {
+		switch {
+		case entry.Creator == nil:
+			continue
+		case entry.NoCompare != "":
+			excluded = append(excluded, entry)
+		case entry.CompareVariants != nil:
+			for _, target := range entry.CompareVariants() {
+				target.EntryID = entry.ID
+				targets = append(targets, target)
+			}
+		default:
+			targets = append(targets, compareTarget{Label: entry.Label, EntryID: entry.ID})
+		}
+	}
+	return targets, excluded
+}
+
+// hashCompareVariants offers the hash processor once per algorithm
+func hashCompareVariants() []compareTarget {
+	var targets []compareTarget
+	for _, algorithm := range crypto.HashAlgorithms() {
+		targets = append(targets, compareTarget{
+			Label:  fmt.Sprintf("Hashing (%s)", algorithm),
+			Config: map[string]interface{}{"algorithm": algorithm},
+		})
+	}
+	return targets
+}
+
+// parseCompareSelection parses numbers and ranges such as "2, 5-7" into indexes between 1 and
+// count, dropping duplicates
+func parseCompareSelection(text string, count int) ([]int, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("select at least one algorithm")
+	}
+
+	seen := make(map[int]bool)
+	var selected []int
+	for _, field := range fields {
+		first, last := field, field
+		if before, after, found := strings.Cut(field, "-"); found {
+			first, last = before, after
+		}
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", field)
+		}
+		to, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", field)
+		}
+		if from < 1 || to > count || from > to {
+			return nil, fmt.Errorf("invalid selection %q: please use numbers between 1 and %d", field, count)
+		}
+		for i := from; i <= to; i++ {
+			if !seen[i] {
+				seen[i] = true
+				selected = append(selected, i)
+			}
+		}
+	}
+	return selected, nil
+}
+
+// compareAlgorithms runs every target on text and renders the outputs and timings as a table.
+// Once ctx is done the remaining targets are skipped and reported with the context's error.
+func compareAlgorithms(ctx context.Context, factory ProcessorFactory, targets []compareTarget, text string) (string, []utils.Step, error) {
+	runs := make([]compareRun, 0, len(targets))
+	for _, target := range targets {
+		run := compareRun{target: target}
+		processor, err := factory.CreateProcessor(target.EntryID)
+		if err == nil && target.Config != nil {
+			if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
+				err = configurable.Configure(target.Config)
+			}
+		}
+		if err != nil {
+			run.err = err
+			runs = append(runs, run)
+			continue
+		}
+
+		start := time.Now()
+		run.result, _, run.err = crypto.ProcessContext(ctx, processor, text, crypto.OperationEncrypt)
+		run.duration = time.Since(start)
+		runs = append(runs, run)
+	}
+
+	v := utils.NewVisualizer()
+	v.AddHeader("Algorithm Comparison")
+	v.AddTextStep("Input", text)
+	v.AddSeparator()
+
+	labelWidth := len("Algorithm")
+	for _, run := range runs {
+		labelWidth = max(labelWidth, len(run.target.Label))
+	}
+	v.AddStep(fmt.Sprintf("%-*s  %12s  %8s  %s", labelWidth, "Algorithm", "Time", "Length", "Output"))
+	v.AddStep(strings.Repeat("-", labelWidth+2+12+2+8+2+compareOutputWidth))
+
+	var fastest *compareRun
+	for i, run := range runs {
+		if run.err != nil {
+			v.AddStep(fmt.Sprintf("%-*s  %12s  %8s  ❌ %v", labelWidth, run.target.Label, "-", "-", run.err))
+			continue
+		}
+		v.AddStep(fmt.Sprintf("%-*s  %12s  %8d  %s", labelWidth, run.target.Label,
+			run.duration.Round(time.Microsecond), len(run.result), truncateOutput(run.result, compareOutputWidth)))
+		if fastest == nil || run.duration < fastest.duration {
+			fastest = &runs[i]
+		}
+	}
+	v.AddSeparator()
+	v.AddNote("Each algorithm ran once in encrypt mode; timings include the educational visualization and key loading")
+	v.AddNote("Use the benchmark entries for repeatable throughput measurements")
+
+	if fastest == nil {
+		return "", v.GetSteps(), fmt.Errorf("every algorithm failed")
+	}
+	return fmt.Sprintf("Compared %d algorithm(s); fastest: %s", len(runs), fastest.target.Label), v.GetSteps(), nil
+}
+
+// truncateOutput shortens s to width runes, marking the cut with an ellipsis
+func truncateOutput(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// runCompare asks for several algorithms and one input, then shows their outputs side by side
+func (m *Menu) runCompare() error {
+	targets, excluded := compareTargets(m.factory)
+	fmt.Println("\nSelect algorithms to compare:")
+	for i, target := range targets {
+		fmt.Printf("%d. %s\n", i+1, target.Label)
+	}
+	if len(excluded) > 0 {
+		fmt.Println("\nNot available for comparison:")
+		for _, entry := range excluded {
+			fmt.Printf("- %s: %s\n", entry.Label, entry.NoCompare)
+		}
+	}
+	fmt.Print("Enter numbers or ranges separated by commas (e.g. 2,6-7): ")
+	selection, err := m.input.GetText()
+	if err != nil {
+		return err
+	}
+	indexes, err := parseCompareSelection(selection, len(targets))
+	if err != nil {
+		return err
+	}
+	selected := make([]compareTarget, 0, len(indexes))
+	for _, i := range indexes {
+		selected = append(selected, targets[i-1])
+	}
+
+	fmt.Printf("\n%s", m.display.(*ConsoleDisplay).theme.Format("Enter text to process: ", "brightGreen bold"))
+	text, err := m.input.GetText()
+	if err != nil {
+		return err
+	}
+
+	result, steps, err := m.interruptible(func(ctx context.Context) (string, []utils.Step, error) {
+		return compareAlgorithms(ctx, m.factory, selected, text)
+	})
+	if err != nil {
+		return err
+	}
+	m.display.ShowResult(result, steps)
+	return nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/config"
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestParseCompareSelection(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    []int
+		wantErr bool
+	}{
+		{"2, 5-7", []int{2, 5, 6, 7}, false},
+		{"3 3 1", []int{3, 1}, false},
+		{"", nil, true},
+		{"0", nil, true},
+		{"9-11", nil, true},
+		{"4-2", nil, true},
+		{"sha", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCompareSelection(tt.text, 10)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCompareSelection(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCompareSelection(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+// countingFactory counts the processors built through it
+type countingFactory struct {
+	ProcessorFactory
+	created int
+}
+
+func (f *countingFactory) CreateProcessor(choice int) (crypto.Processor, error) {
+	f.created++
+	return f.ProcessorFactory.CreateProcessor(choice)
+}
+
+func TestCompareAlgorithms(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg, err := config.LoadConfig("config.yaml")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	factory := NewCryptoProcessorFactory()
+	factory.SetConfig(cfg)
+
+	counting := &countingFactory{ProcessorFactory: factory}
+	targets, excluded := compareTargets(counting)
+	if counting.created != 0 {
+		t.Errorf("Listing the targets built %d processors, want none", counting.created)
+	}
+	var selected []compareTarget
+	for _, target := range targets {
+		switch target.Label {
+		case "Hashing (sha256)", "Hashing (blake3)", "Base64 Encoding/Decoding":
+			selected = append(selected, target)
+		case "Diffie-Hellman Key Exchange", "ChaCha20-Poly1305 Encryption":
+			t.Errorf("Expected %q to be left out of the comparison", target.Label)
+		}
+	}
+	if len(selected) != 3 {
+		t.Fatalf("Expected 3 targets, got %v", selected)
+	}
+
+	var excludedLabels []string
+	for _, entry := range excluded {
+		excludedLabels = append(excludedLabels, entry.Label)
+	}
+	wantExcluded := []string{"Diffie-Hellman Key Exchange", "X25519 Key Exchange", "ChaCha20-Poly1305 Encryption", "Signature Verification Matrix",
+		"TOTP One-Time Passwords (otpauth:// URI)"}
+	if !reflect.DeepEqual(excludedLabels, wantExcluded) {
+		t.Errorf("excluded = %v, want %v", excludedLabels, wantExcluded)
+	}
+
+	result, steps, err := compareAlgorithms(context.Background(), factory, selected, "hello")
+	if err != nil {
+		t.Fatalf("compareAlgorithms() error = %v", err)
+	}
+	if !strings.HasPrefix(result, "Compared 3 algorithm(s)") {
+		t.Errorf("Unexpected result %q", result)
+	}
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", // SHA-256 of "hello"
+		"aGVsbG8=",
+		"Hashing (blake3)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the comparison, got:\n%s", want, output)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := compareAlgorithms(ctx, factory, selected, "hello"); err == nil {
+		t.Error("Expected an error when every target is skipped after cancellation")
+	}
+}
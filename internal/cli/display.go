@@ -1,47 +1,125 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 const (
 	version = "1.3.0"
+
+	// minPageSize is the fewest lines the pager shows at once, however small the terminal
+	minPageSize = 5
+
+	// minArtHeight is the smallest terminal height that shows the welcome art
+	minArtHeight = 24
 )
 
 // ConsoleDisplay implements DisplayHandler for console output
 type ConsoleDisplay struct {
-	theme utils.Theme
+	theme      utils.Theme
+	resultOnly bool
+	verbose    bool
+	qrCode     bool
+	copyResult func(string) error // Copies each result to the clipboard; nil leaves it off
+	entries    []MenuEntry
+	pager      utils.Prompter
+	height     func() int
 }
 
-// NewConsoleDisplay creates a new console display handler
+// NewConsoleDisplay creates a new console display handler listing the built-in menu
 func NewConsoleDisplay() *ConsoleDisplay {
 	return &ConsoleDisplay{
-		theme: utils.DefaultTheme,
+		theme:   utils.DefaultTheme,
+		verbose: true,
+		entries: NewCryptoProcessorFactory().MenuEntries(),
+		height:  utils.GetTerminalHeight,
+	}
+}
+
+// SetMenuEntries sets the entries listed by the main menu
+func (d *ConsoleDisplay) SetMenuEntries(entries []MenuEntry) {
+	d.entries = entries
+}
+
+// SetResultOnly makes ShowResult print only the bare result, for use in shell pipelines
+func (d *ConsoleDisplay) SetResultOnly(enabled bool) {
+	d.resultOnly = enabled
+}
+
+// SetVerbose controls whether ShowResult prints the processing steps after the result
+func (d *ConsoleDisplay) SetVerbose(enabled bool) {
+	d.verbose = enabled
+}
+
+// SetQRCode makes ShowResult also draw the result as a QR code, for scanning public keys or
+// otpauth URIs with a phone
+func (d *ConsoleDisplay) SetQRCode(enabled bool) {
+	d.qrCode = enabled
+}
+
+// SetClipboard makes ShowResult copy each result to the system clipboard, so a ciphertext or
+// derived key can be pasted elsewhere
+func (d *ConsoleDisplay) SetClipboard(enabled bool) {
+	d.copyResult = nil
+	if enabled {
+		d.copyResult = utils.CopyToClipboard
 	}
 }
 
+// SetPager makes ShowResult pause after each screenful of steps and ask pager to continue;
+// nil prints all steps at once, as needed when the output is not a terminal
+func (d *ConsoleDisplay) SetPager(pager utils.Prompter) {
+	d.pager = pager
+}
+
 // ShowMenu displays the main menu
 func (d *ConsoleDisplay) ShowMenu() {
 	fmt.Printf("\n%s\n", d.theme.Format("CryptoLens - Cryptographic Operations", "bold brightCyan"))
 	fmt.Printf("%s\n", d.theme.Format("=================================", "dim blue"))
 	fmt.Printf("%s\n", d.theme.Format("Select an operation:", "bold"))
-	fmt.Printf("%s\n", d.theme.Format("1. Base64 Encoding/Decoding", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("2. Caesar Cipher", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("3. AES Encryption/Decryption", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("4. SHA-256 Hashing", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("5. RSA Encryption/Decryption", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("6. HMAC (Hash-based Message Authentication)", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("7. PBKDF (Password-Based Key Derivation)", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("8. Diffie-Hellman Key Exchange", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("9. X25519 Key Exchange", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("10. JWT (JSON Web Token)", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("11. ChaCha20-Poly1305 Encryption", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("12. Attack Simulations", "red"))
-	fmt.Printf("%s\n", d.theme.Format("13. Exit", "red"))
-	fmt.Printf("\n%s", d.theme.Format("Enter your choice (1-13): ", "green"))
+	var plugins, exits []MenuEntry
+	for _, entry := range d.entries {
+		switch {
+		case entry.Exit:
+			exits = append(exits, entry)
+		case entry.ID >= pluginIDBase:
+			plugins = append(plugins, entry)
+		default:
+			d.showMenuEntry(entry)
+		}
+	}
+	if len(plugins) > 0 {
+		fmt.Printf("%s\n", d.theme.Format("Plugins:", "bold"))
+		for _, entry := range plugins {
+			d.showMenuEntry(entry)
+		}
+	}
+	last := 0
+	for _, entry := range exits {
+		d.showMenuEntry(entry)
+		last = entry.ID
+	}
+	fmt.Printf("\n%s", d.theme.Format(fmt.Sprintf("Enter your choice (1-%d) or type to search: ", last), "green"))
+}
+
+// ShowMenuMatches lists the main menu entries matching a search, numbered from 1
+func (d *ConsoleDisplay) ShowMenuMatches(query string, matches []MenuEntry) {
+	fmt.Printf("\n%s\n", d.theme.Format(fmt.Sprintf("Entries matching %q:", query), "bold"))
+	for i, entry := range matches {
+		fmt.Printf("%s\n", d.theme.Format(fmt.Sprintf("%d. %s", i+1, entry.Label), entry.Color))
+	}
+	fmt.Printf("\n%s", d.theme.Format(fmt.Sprintf("Enter your choice (1-%d): ", len(matches)), "green"))
+}
+
+// showMenuEntry prints one numbered main menu line in the entry's color
+func (d *ConsoleDisplay) showMenuEntry(entry MenuEntry) {
+	fmt.Printf("%s\n", d.theme.Format(fmt.Sprintf("%d. %s", entry.ID, entry.Label), entry.Color))
 }
 
 // ShowAttackMenu displays the attack simulation menu
@@ -54,92 +132,118 @@ func (d *ConsoleDisplay) ShowAttackMenu() {
 	fmt.Printf("%s\n", d.theme.Format("3. Timing Attack (HMAC verification)", "yellow"))
 	fmt.Printf("%s\n", d.theme.Format("4. Brute Force on Weak Keys or Passwords", "yellow"))
 	fmt.Printf("%s\n", d.theme.Format("5. JWT None Algorithm Attack", "yellow"))
-	fmt.Printf("%s\n", d.theme.Format("6. Back to Main Menu", "red"))
-	fmt.Printf("\n%s", d.theme.Format("Enter your choice (1-6): ", "green"))
+	fmt.Printf("%s\n", d.theme.Format("6. Frequency Analysis (Caesar/Vigenère)", "yellow"))
+	fmt.Printf("%s\n", d.theme.Format("7. JWT Algorithm Confusion (RS256→HS256)", "yellow"))
+	fmt.Printf("%s\n", d.theme.Format("8. Weak RNG Key Generation (math/rand vs crypto/rand)", "yellow"))
+	fmt.Printf("%s\n", d.theme.Format("9. Predictable CBC IV (Unpredictable vs Unique)", "yellow"))
+	fmt.Printf("%s\n", d.theme.Format("10. RSA Key Separation (Signing vs Encryption)", "yellow"))
+	fmt.Printf("%s\n", d.theme.Format("11. MD5 Collision", "yellow"))
+	fmt.Printf("%s\n", d.theme.Format(fmt.Sprintf("%d. Back to Main Menu", attackBackChoice), "red"))
+	fmt.Printf("\n%s", d.theme.Format(fmt.Sprintf("Enter your choice (1-%d): ", attackBackChoice), "green"))
 }
 
 // ShowResult displays the processing result and steps
-func (d *ConsoleDisplay) ShowResult(result string, steps []string) {
+func (d *ConsoleDisplay) ShowResult(result string, steps []utils.Step) {
+	if d.resultOnly {
+		fmt.Println(result)
+		// Keep stdout to the bare result so pipelines are unaffected
+		if d.copyResult != nil {
+			if err := d.copyResult(result); err != nil {
+				fmt.Fprintf(os.Stderr, "Clipboard unavailable: %v\n", err)
+			}
+		}
+		return
+	}
+
 	fmt.Printf("\n%s\n", d.theme.Format("Result:", "brightGreen"))
 	fmt.Printf("%s\n", d.theme.Format(result, "brightGreen"))
+	if d.qrCode {
+		d.showQRCode(result)
+	}
+	if d.copyResult != nil {
+		if err := d.copyResult(result); err != nil {
+			fmt.Printf("%s\n", d.theme.Format(fmt.Sprintf("Clipboard unavailable: %v", err), "dim"))
+		} else {
+			fmt.Printf("%s\n", d.theme.Format("✓ Result copied to clipboard", "dim"))
+		}
+	}
+	if !d.verbose {
+		return
+	}
 
 	fmt.Printf("\n%s\n", d.theme.Format("Processing Steps:", "brightCyan"))
 
-	// Process steps in sequence
+	var output strings.Builder
 	for _, step := range steps {
-		// Handle section headers
-		if strings.HasPrefix(step, "📌") || strings.HasPrefix(step, "🔢") ||
-			strings.HasPrefix(step, "📈") || strings.HasPrefix(step, "🔒") ||
-			strings.HasPrefix(step, "📚") {
-			fmt.Printf("\n%s\n", d.theme.Format(step, "bold"))
-			fmt.Printf("%s\n", d.theme.Format(strings.Repeat("=", len(step)), "dim"))
-			continue
-		}
-
-		// Handle separators
-		if strings.HasPrefix(step, "----------------------------------------") {
-			fmt.Printf("%s\n", d.theme.Format(step, "dim blue"))
-			continue
-		}
-
-		// Handle arrows
-		if strings.Contains(step, "↓") {
-			fmt.Printf("%s\n", d.theme.Format(step, "brightYellow bold"))
-			continue
-		}
-
-		// Handle success indicators
-		if strings.Contains(step, "✅") {
-			fmt.Printf("%s\n", d.theme.Format(step, "brightGreen"))
-			continue
-		}
-
-		// Handle warning indicators
-		if strings.Contains(step, "⚠️") {
-			fmt.Printf("%s\n", d.theme.Format(step, "brightRed"))
-			continue
-		}
+		output.WriteString(d.formatStep(step))
+	}
+	d.printPaged(strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n"))
+}
 
-		// Handle step numbers
-		if strings.HasPrefix(step, "Step") {
-			fmt.Printf("\n%s\n", d.theme.Format(step, "bold brightCyan"))
-			continue
-		}
+// showQRCode draws result as a QR code, or explains why it cannot
+func (d *ConsoleDisplay) showQRCode(result string) {
+	code, err := utils.RenderQR(result)
+	if err != nil {
+		fmt.Printf("%s\n", d.theme.Format(fmt.Sprintf("QR code unavailable: %v", err), "dim"))
+		return
+	}
+	fmt.Printf("\n%s\n%s", d.theme.Format("Result as QR code:", "brightCyan"), code)
+}
 
-		// Handle bullet points
-		if strings.HasPrefix(step, "•") {
-			fmt.Printf("%s\n", d.theme.Format(step, "brightYellow"))
-			continue
+// formatStep returns a step formatted for the console by its type, ending in a newline
+func (d *ConsoleDisplay) formatStep(step utils.Step) string {
+	switch step.Type {
+	case utils.StepHeader:
+		return fmt.Sprintf("\n%s\n%s\n", d.theme.Format(step.Text, "bold brightCyan"),
+			d.theme.Format(strings.Repeat("=", utf8.RuneCountInString(step.Text)), "dim"))
+	case utils.StepSubheader:
+		return fmt.Sprintf("\n%s\n", d.theme.Format(step.Text, "bold brightCyan"))
+	case utils.StepDiagram:
+		return fmt.Sprintf("%s\n", d.theme.Format(step.Text, "brightBlue"))
+	case utils.StepNote, utils.StepArrow, utils.StepSeparator:
+		// Already styled by the visualizer
+		return fmt.Sprintf("%s\n", step.Text)
+	default:
+		// Data steps are "label: value" pairs or free text
+		if label, value, ok := strings.Cut(step.Text, ":"); ok {
+			return fmt.Sprintf("%s %s\n", d.theme.Format(label+":", "bold"), d.theme.Format(value, "white"))
 		}
+		return fmt.Sprintf("%s\n", d.theme.Format(step.Text, "white"))
+	}
+}
 
-		// Handle ASCII diagrams
-		if strings.Contains(step, "┌") || strings.Contains(step, "│") ||
-			strings.Contains(step, "└") || strings.Contains(step, "─") {
-			fmt.Printf("%s\n", d.theme.Format(step, "brightBlue"))
-			continue
+// printPaged prints lines, pausing after each screenful when a pager is set. Answering "q"
+// skips the rest; if the pager cannot read, the remaining lines are printed without pausing.
+func (d *ConsoleDisplay) printPaged(lines []string) {
+	pageSize := 0
+	if d.pager != nil {
+		// Leave a row for the prompt and one for the line the user types
+		pageSize = d.height() - 2
+		if pageSize < minPageSize {
+			pageSize = minPageSize
 		}
+	}
 
-		// Handle labels with colons
-		if strings.Contains(step, ":") {
-			parts := strings.SplitN(step, ":", 2)
-			if len(parts) == 2 {
-				fmt.Printf("%s %s\n", d.theme.Format(parts[0]+":", "bold"), d.theme.Format(parts[1], "white"))
-			} else {
-				fmt.Printf("%s\n", d.theme.Format(step, "white"))
+	pager := d.pager
+	for i, line := range lines {
+		if pager != nil && i > 0 && i%pageSize == 0 {
+			answer, err := pager.Prompt(fmt.Sprintf("-- More (%d/%d lines) -- Enter to continue, q to skip: ", i, len(lines)))
+			if err != nil {
+				pager = nil
+			} else if strings.EqualFold(answer, "q") {
+				fmt.Printf("%s\n", d.theme.Format(fmt.Sprintf("(%d more lines skipped)", len(lines)-i), "dim"))
+				return
 			}
-			continue
 		}
-
-		// Default case
-		fmt.Printf("%s\n", d.theme.Format(step, "white"))
+		fmt.Println(line)
 	}
 }
 
 // ShowError displays an error message
 func (d *ConsoleDisplay) ShowError(err error) {
 	fmt.Printf("\n%s %s\n", d.theme.Format("Error:", "brightRed"), d.theme.Format(err.Error(), "red"))
-	if err.Error() == "invalid base64 string: illegal base64 data at input byte 0" {
-		fmt.Printf("%s\n", d.theme.Format("Note: For AES decryption, please enter the previously encrypted text in base64 format", "yellow"))
+	if errors.Is(err, utils.ErrUnknownEncoding) || strings.Contains(err.Error(), "invalid base64 string") {
+		fmt.Printf("%s\n", d.theme.Format("Note: To decrypt, paste the exact result of encryption (Base64, Base64URL, or hex)", "yellow"))
 	}
 	fmt.Printf("%s\n", d.theme.Format("----------------------------------------", "blue"))
 }
@@ -177,7 +281,10 @@ func (d *ConsoleDisplay) ShowWelcome() {
 		centeredArt += strings.Repeat(" ", padding) + line + "\n"
 	}
 
-	fmt.Printf("%s\n", d.theme.Format(centeredArt, "blue"))
+	// Short terminals skip the art so the menu stays on screen
+	if d.height() >= minArtHeight {
+		fmt.Printf("%s\n", d.theme.Format(centeredArt, "blue"))
+	}
 
 	// Center welcome messages
 	welcomeMsg := fmt.Sprintf("Welcome to CryptoLens! v%s", version)
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
@@ -86,10 +87,57 @@ func TestConsoleDisplay(t *testing.T) {
 	}
 
 	// Test ShowResult
-	output = captureOutput(func() { display.ShowResult("test result", []string{"step1", "step2"}) })
+	output = captureOutput(func() { display.ShowResult("test result", utils.DataSteps([]string{"step1", "step2"})) })
 	if !strings.Contains(output, "test result") || !strings.Contains(output, "step1") || !strings.Contains(output, "step2") {
 		t.Error("ShowResult did not produce expected output")
 	}
+
+	// Test ShowResult without steps
+	display.SetVerbose(false)
+	output = captureOutput(func() { display.ShowResult("test result", utils.DataSteps([]string{"step1", "step2"})) })
+	if !strings.Contains(output, "test result") || strings.Contains(output, "step1") || strings.Contains(output, "Processing Steps") {
+		t.Errorf("ShowResult without steps should print only the result, got %q", output)
+	}
+	display.SetVerbose(true)
+
+	// Test ShowResult with a QR code, which depends on the qr build tag
+	display.SetQRCode(true)
+	output = captureOutput(func() { display.ShowResult("test result", nil) })
+	want := "QR code unavailable"
+	if utils.QRAvailable {
+		want = "Result as QR code:"
+	}
+	if !strings.Contains(output, "test result") || !strings.Contains(output, want) {
+		t.Errorf("ShowResult with a QR code should contain %q, got %q", want, output)
+	}
+	display.SetQRCode(false)
+
+	// Test ShowResult copying to the clipboard, and the notice when there is none
+	var copied string
+	display.copyResult = func(text string) error {
+		copied = text
+		return nil
+	}
+	output = captureOutput(func() { display.ShowResult("test result", nil) })
+	if copied != "test result" || !strings.Contains(output, "Result copied to clipboard") {
+		t.Errorf("ShowResult should copy the result, copied %q, got %q", copied, output)
+	}
+	display.copyResult = func(string) error { return utils.ErrClipboardUnavailable }
+	output = captureOutput(func() { display.ShowResult("test result", nil) })
+	if !strings.Contains(output, "test result") || !strings.Contains(output, "Clipboard unavailable") {
+		t.Errorf("ShowResult should show the result and a notice without a clipboard, got %q", output)
+	}
+	display.SetClipboard(false)
+
+	// Test ShowResult in result-only mode
+	display.SetResultOnly(true)
+	output = captureOutput(func() { display.ShowResult("test result", utils.DataSteps([]string{"step1", "step2"})) })
+	if output != "test result\n" {
+		t.Errorf("ShowResult in result-only mode = %q, want %q", output, "test result\n")
+	}
+	if strings.Contains(output, "\x1b[") {
+		t.Error("ShowResult in result-only mode should not emit ANSI codes")
+	}
 }
 
 func TestDisplayTheme(t *testing.T) {
@@ -98,3 +146,50 @@ func TestDisplayTheme(t *testing.T) {
 		t.Errorf("Expected default theme, got %v", display.theme)
 	}
 }
+
+func TestConsoleDisplayPager(t *testing.T) {
+	lines := make([]string, 12)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	steps := utils.DataSteps(lines)
+
+	display := NewConsoleDisplay()
+	display.height = func() int { return 7 }
+	display.SetPager(&ConsoleInput{scanner: bufio.NewScanner(strings.NewReader("\nq\n")), theme: utils.DefaultTheme})
+	output := captureStdout(t, func() { display.ShowResult("test result", steps) })
+
+	// Five lines per page: the first prompt continues, the second skips the rest
+	if strings.Count(output, "-- More") != 2 {
+		t.Errorf("Expected two pager prompts, got:\n%s", output)
+	}
+	if !strings.Contains(output, "line 10") || strings.Contains(output, "line 11") {
+		t.Errorf("Expected output to stop after two pages, got:\n%s", output)
+	}
+	if !strings.Contains(output, "(2 more lines skipped)") {
+		t.Errorf("Expected a note about the skipped lines, got:\n%s", output)
+	}
+
+	// Without a pager every step is printed at once
+	display.SetPager(nil)
+	output = captureStdout(t, func() { display.ShowResult("test result", steps) })
+	if strings.Contains(output, "-- More") || !strings.Contains(output, "line 12") {
+		t.Errorf("Expected all lines without prompts, got:\n%s", output)
+	}
+}
+
+func TestConsoleDisplayStepTypes(t *testing.T) {
+	v := utils.NewVisualizer()
+	v.AddHeader("Key Schedule")
+	v.AddDiagram("┌──┐")
+	v.AddStep("Label: value")
+
+	display := NewConsoleDisplay()
+	output := captureStdout(t, func() { display.ShowResult("test result", v.GetSteps()) })
+	if !strings.Contains(output, "Key Schedule") || !strings.Contains(output, strings.Repeat("=", len("Key Schedule"))) {
+		t.Errorf("Expected an underlined header, got %q", output)
+	}
+	if !strings.Contains(output, "┌──┐") || !strings.Contains(output, "value") {
+		t.Errorf("Expected the diagram and data steps, got %q", output)
+	}
+}
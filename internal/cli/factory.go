@@ -2,49 +2,65 @@ package cli
 
 import (
 	"fmt"
+	"log/slog"
+	"sort"
 
 	"github.com/abdorrahmani/cryptolens/internal/config"
 	"github.com/abdorrahmani/cryptolens/internal/crypto"
 	"github.com/abdorrahmani/cryptolens/internal/crypto/attacks"
 )
 
-// ProcessorRegistry maps processor IDs to their creation functions
-type ProcessorRegistry map[int]ProcessorCreator
-
 // ProcessorCreator is a function type that creates a new processor
 type ProcessorCreator func(cfg *config.Config) (crypto.Processor, error)
 
+// pluginIDBase is the first menu ID given to plugins, leaving room for built-in entries
+const pluginIDBase = 100
+
 // CryptoProcessorFactory implements ProcessorFactory for creating encryption processors
 type CryptoProcessorFactory struct {
 	config   *config.Config
-	registry ProcessorRegistry
+	registry MenuRegistry
 }
 
-// NewCryptoProcessorFactory creates a new processor factory
+// NewCryptoProcessorFactory creates a new processor factory holding the built-in main menu
 func NewCryptoProcessorFactory() *CryptoProcessorFactory {
 	factory := &CryptoProcessorFactory{
-		registry: make(ProcessorRegistry),
+		registry: make(MenuRegistry),
 	}
+	for i, entry := range mainMenu {
+		entry.ID = i + 1
+		factory.registry[entry.ID] = entry
+	}
+	return factory
+}
 
-	// Register default processors
-	factory.RegisterProcessor(1, createBase64Processor)
-	factory.RegisterProcessor(2, createCaesarProcessor)
-	factory.RegisterProcessor(3, createAESProcessor)
-	factory.RegisterProcessor(4, createSHA256Processor)
-	factory.RegisterProcessor(5, createRSAProcessor)
-	factory.RegisterProcessor(6, createHMACProcessor)
-	factory.RegisterProcessor(7, createPBKDFProcessor)
-	factory.RegisterProcessor(8, createDHProcessor)
-	factory.RegisterProcessor(9, createX25519Processor)
-	factory.RegisterProcessor(10, createJWTProcessor)
-	factory.RegisterProcessor(11, createChaCha20Poly1305Processor)
+// RegisterProcessor registers a processor under a menu ID and label, replacing any entry with that ID
+func (f *CryptoProcessorFactory) RegisterProcessor(id int, label string, creator ProcessorCreator) {
+	f.registry[id] = MenuEntry{ID: id, Label: label, Color: "yellow", Creator: creator}
+}
 
-	return factory
+// RegisterPlugin registers a third-party processor under the next free plugin ID and returns it
+func (f *CryptoProcessorFactory) RegisterPlugin(label string, creator ProcessorCreator) int {
+	id := pluginIDBase
+	for existing := range f.registry {
+		if existing >= id {
+			id = existing + 1
+		}
+	}
+	f.registry[id] = MenuEntry{ID: id, Label: label, Color: "cyan", Creator: creator}
+	return id
 }
 
-// RegisterProcessor registers a new processor creator function
-func (f *CryptoProcessorFactory) RegisterProcessor(id int, creator ProcessorCreator) {
-	f.registry[id] = creator
+// MenuEntries returns the registered main menu entries sorted by ID
+func (f *CryptoProcessorFactory) MenuEntries() []MenuEntry {
+	entries := make([]MenuEntry, 0, len(f.registry))
+	for _, entry := range f.registry {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
 }
 
 // SetConfig sets the configuration for the factory
@@ -54,22 +70,24 @@ func (f *CryptoProcessorFactory) SetConfig(cfg *config.Config) {
 
 // CreateProcessor creates a processor based on the given choice
 func (f *CryptoProcessorFactory) CreateProcessor(choice int) (crypto.Processor, error) {
-	creator, exists := f.registry[choice]
-	if !exists {
+	entry, exists := f.registry[choice]
+	if !exists || entry.Creator == nil {
 		return nil, fmt.Errorf("invalid processor choice: %d", choice)
 	}
+	slog.Debug("creating processor", "choice", choice, "label", entry.Label)
 
-	return creator(f.config)
+	return entry.Creator(f.config)
 }
 
 // CreateAttackProcessor creates an attack processor based on the given choice
 func (f *CryptoProcessorFactory) CreateAttackProcessor(choice int) (crypto.Processor, error) {
+	slog.Debug("creating attack processor", "choice", choice)
 	switch choice {
 	case 1:
 		processor := attacks.NewECBProcessor()
 		if f.config != nil {
 			if err := processor.Configure(map[string]interface{}{
-				"keySize": f.config.GetAESConfig().DefaultKeySize,
+				"keySize": f.config.GetAttackConfig().ECBKeySize,
 			}); err != nil {
 				return nil, fmt.Errorf("failed to configure ECB processor: %w", err)
 			}
@@ -79,7 +97,7 @@ func (f *CryptoProcessorFactory) CreateAttackProcessor(choice int) (crypto.Proce
 		processor := attacks.NewNonceReuseProcessor()
 		if f.config != nil {
 			if err := processor.Configure(map[string]interface{}{
-				"keySize": f.config.GetChaCha20Poly1305Config().KeySize,
+				"keySize": f.config.GetAttackConfig().NonceReuseKeySize,
 			}); err != nil {
 				return nil, fmt.Errorf("failed to configure nonce reuse processor: %w", err)
 			}
@@ -88,15 +106,28 @@ func (f *CryptoProcessorFactory) CreateAttackProcessor(choice int) (crypto.Proce
 	case 3:
 		processor := attacks.NewTimingAttackProcessor()
 		// Always configure the timing attack processor with a 256-bit key
-		if err := processor.Configure(map[string]interface{}{
+		config := map[string]interface{}{
 			"keySize": 256, // HMAC-SHA256 uses 256-bit keys
-		}); err != nil {
+		}
+		if f.config != nil {
+			config["iterations"] = f.config.GetAttackConfig().TimingIterations
+			config["delayPerByte"] = f.config.GetAttackConfig().TimingDelayPerByte
+		}
+		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure timing attack processor: %w", err)
 		}
 		return processor, nil
 	case 4:
 		processor := attacks.NewBruteForceProcessor()
-		if err := processor.Configure(nil); err != nil {
+		var config map[string]interface{}
+		if f.config != nil {
+			config = map[string]interface{}{
+				"iterations":   f.config.GetAttackConfig().BruteForceIterations,
+				"maxWorkers":   f.config.GetGeneralConfig().MaxWorkers,
+				"wordlistFile": f.config.GetAttackConfig().WordlistFile,
+			}
+		}
+		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure brute force processor: %w", err)
 		}
 		return processor, nil
@@ -106,6 +137,48 @@ func (f *CryptoProcessorFactory) CreateAttackProcessor(choice int) (crypto.Proce
 			return nil, fmt.Errorf("failed to configure JWT none processor: %w", err)
 		}
 		return processor, nil
+	case 6:
+		processor := attacks.NewFrequencyAnalysisProcessor()
+		var config map[string]interface{}
+		if f.config != nil {
+			config = map[string]interface{}{
+				"scorer": f.config.GetAttackConfig().Scorer,
+			}
+		}
+		if err := processor.Configure(config); err != nil {
+			return nil, fmt.Errorf("failed to configure frequency analysis processor: %w", err)
+		}
+		return processor, nil
+	case 7:
+		processor := attacks.NewJWTAlgConfusionProcessor()
+		if err := processor.Configure(nil); err != nil {
+			return nil, fmt.Errorf("failed to configure JWT algorithm confusion processor: %w", err)
+		}
+		return processor, nil
+	case 8:
+		processor := attacks.NewWeakRNGProcessor()
+		if err := processor.Configure(nil); err != nil {
+			return nil, fmt.Errorf("failed to configure weak RNG processor: %w", err)
+		}
+		return processor, nil
+	case 9:
+		processor := attacks.NewPredictableIVProcessor()
+		if err := processor.Configure(nil); err != nil {
+			return nil, fmt.Errorf("failed to configure predictable IV processor: %w", err)
+		}
+		return processor, nil
+	case 10:
+		processor := attacks.NewKeySeparationProcessor()
+		if err := processor.Configure(nil); err != nil {
+			return nil, fmt.Errorf("failed to configure key separation processor: %w", err)
+		}
+		return processor, nil
+	case 11:
+		processor := attacks.NewMD5CollisionProcessor()
+		if err := processor.Configure(nil); err != nil {
+			return nil, fmt.Errorf("failed to configure MD5 collision processor: %w", err)
+		}
+		return processor, nil
 	default:
 		return nil, fmt.Errorf("invalid attack choice: %d", choice)
 	}
@@ -129,7 +202,8 @@ func createCaesarProcessor(cfg *config.Config) (crypto.Processor, error) {
 	processor := crypto.NewCaesarProcessor()
 	if cfg != nil {
 		config := map[string]interface{}{
-			"shift": cfg.GetCaesarConfig().DefaultShift,
+			"shift":    cfg.GetCaesarConfig().DefaultShift,
+			"alphabet": cfg.GetCaesarConfig().Alphabet,
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure Caesar cipher processor: %w", err)
@@ -142,8 +216,11 @@ func createAESProcessor(cfg *config.Config) (crypto.Processor, error) {
 	processor := crypto.NewAESProcessor()
 	if cfg != nil {
 		config := map[string]interface{}{
-			"keySize": cfg.GetAESConfig().DefaultKeySize,
-			"keyFile": cfg.GetAESConfig().KeyFile,
+			"keySize":        cfg.GetAESConfig().DefaultKeySize,
+			"keyFile":        cfg.GetAESConfig().KeyFile,
+			"maxKeyAgeDays":  cfg.GetAESConfig().MaxKeyAgeDays,
+			"opensslCompat":  cfg.GetAESConfig().OpenSSLCompat,
+			"outputEncoding": cfg.GetGeneralConfig().OutputEncoding,
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure AES processor: %w", err)
@@ -152,8 +229,18 @@ func createAESProcessor(cfg *config.Config) (crypto.Processor, error) {
 	return processor, nil
 }
 
-func createSHA256Processor(_ *config.Config) (crypto.Processor, error) {
-	return crypto.NewSHA256Processor(), nil
+func createHashProcessor(cfg *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewHashProcessor()
+	if cfg != nil {
+		config := map[string]interface{}{
+			"algorithm":  cfg.GetHashConfig().Algorithm,
+			"digestSize": cfg.GetHashConfig().DigestSize,
+		}
+		if err := processor.Configure(config); err != nil {
+			return nil, fmt.Errorf("failed to configure hash processor: %w", err)
+		}
+	}
+	return processor, nil
 }
 
 func createRSAProcessor(cfg *config.Config) (crypto.Processor, error) {
@@ -168,6 +255,10 @@ func createRSAProcessor(cfg *config.Config) (crypto.Processor, error) {
 			"keySize":        keySize,
 			"publicKeyFile":  cfg.GetRSAConfig().PublicKeyFile,
 			"privateKeyFile": cfg.GetRSAConfig().PrivateKeyFile,
+			"padding":        cfg.GetRSAConfig().Padding,
+			"mode":           cfg.GetRSAConfig().Mode,
+			"keyFormat":      cfg.GetRSAConfig().KeyFormat,
+			"outputEncoding": cfg.GetGeneralConfig().OutputEncoding,
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure RSA processor: %w", err)
@@ -180,9 +271,12 @@ func createHMACProcessor(cfg *config.Config) (crypto.Processor, error) {
 	processor := crypto.NewHMACProcessor()
 	if cfg != nil {
 		config := map[string]interface{}{
-			"keySize":       cfg.GetHMACConfig().KeySize,
-			"keyFile":       cfg.GetHMACConfig().KeyFile,
-			"hashAlgorithm": cfg.GetHMACConfig().HashAlgorithm,
+			"keySize":        cfg.GetHMACConfig().KeySize,
+			"keyFile":        cfg.GetHMACConfig().KeyFile,
+			"hashAlgorithm":  cfg.GetHMACConfig().HashAlgorithm,
+			"digestSize":     cfg.GetHMACConfig().DigestSize,
+			"maxKeyAgeDays":  cfg.GetHMACConfig().MaxKeyAgeDays,
+			"outputEncoding": cfg.GetGeneralConfig().OutputEncoding,
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure HMAC processor: %w", err)
@@ -200,6 +294,8 @@ func createPBKDFProcessor(cfg *config.Config) (crypto.Processor, error) {
 			"memory":     cfg.GetPBKDFConfig().Memory,
 			"threads":    cfg.GetPBKDFConfig().Threads,
 			"keyLength":  cfg.GetPBKDFConfig().KeyLength,
+			"cost":       cfg.GetPBKDFConfig().Cost,
+			"salt":       cfg.GetPBKDFConfig().Salt,
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure PBKDF processor: %w", err)
@@ -214,6 +310,7 @@ func createDHProcessor(cfg *config.Config) (crypto.Processor, error) {
 		config := map[string]interface{}{
 			"keySize":        cfg.GetDHConfig().KeySize,
 			"generator":      cfg.GetDHConfig().Generator,
+			"group":          cfg.GetDHConfig().Group,
 			"primeFile":      cfg.GetDHConfig().PrimeFile,
 			"privateKeyFile": cfg.GetDHConfig().PrivateKeyFile,
 			"publicKeyFile":  cfg.GetDHConfig().PublicKeyFile,
@@ -242,8 +339,18 @@ func createJWTProcessor(cfg *config.Config) (crypto.Processor, error) {
 	processor := crypto.NewJWTProcessor()
 	if cfg != nil {
 		config := map[string]interface{}{
-			"algorithm": cfg.GetJWTConfig().Algorithm,
-			"keyFile":   cfg.GetJWTConfig().KeyFile,
+			"algorithm":        cfg.GetJWTConfig().Algorithm,
+			"keyFile":          cfg.GetJWTConfig().KeyFile,
+			"issuer":           cfg.GetJWTConfig().Issuer,
+			"audience":         cfg.GetJWTConfig().Audience,
+			"leeway":           cfg.GetJWTConfig().Leeway,
+			"requireNotBefore": cfg.GetJWTConfig().RequireNotBefore,
+		}
+		if keyFile := cfg.GetJWTConfig().VerificationKeyFile; keyFile != "" {
+			config["verificationKeyFile"] = keyFile
+		}
+		if keyPEM := cfg.GetJWTConfig().VerificationKeyPEM; keyPEM != "" {
+			config["verificationKeyPEM"] = keyPEM
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure JWT processor: %w", err)
@@ -256,10 +363,16 @@ func createChaCha20Poly1305Processor(cfg *config.Config) (crypto.Processor, erro
 	processor := crypto.NewChaCha20Poly1305Processor()
 	if cfg != nil {
 		config := map[string]interface{}{
-			"keySize":   cfg.GetChaCha20Poly1305Config().KeySize,
-			"keyFile":   cfg.GetChaCha20Poly1305Config().KeyFile,
-			"nonceSize": cfg.GetChaCha20Poly1305Config().NonceSize,
-			"tagSize":   cfg.GetChaCha20Poly1305Config().TagSize,
+			"keySize":        cfg.GetChaCha20Poly1305Config().KeySize,
+			"keyFile":        cfg.GetChaCha20Poly1305Config().KeyFile,
+			"nonceSize":      cfg.GetChaCha20Poly1305Config().NonceSize,
+			"tagSize":        cfg.GetChaCha20Poly1305Config().TagSize,
+			"maxKeyAgeDays":  cfg.GetChaCha20Poly1305Config().MaxKeyAgeDays,
+			"chunkSize":      cfg.GetChaCha20Poly1305Config().ChunkSize,
+			"outputEncoding": cfg.GetGeneralConfig().OutputEncoding,
+		}
+		if aad := cfg.GetChaCha20Poly1305Config().AAD; aad != "" {
+			config["aad"] = aad
 		}
 		if err := processor.Configure(config); err != nil {
 			return nil, fmt.Errorf("failed to configure ChaCha20-Poly1305 processor: %w", err)
@@ -267,3 +380,128 @@ func createChaCha20Poly1305Processor(cfg *config.Config) (crypto.Processor, erro
 	}
 	return processor, nil
 }
+
+func createChecksumProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewChecksumProcessor(), nil
+}
+
+func createROTProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewROTProcessor(), nil
+}
+
+func createHKDFProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewHKDFProcessor(), nil
+}
+
+func createMerkleTreeProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewMerkleTreeProcessor(), nil
+}
+
+func createFeistelProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewFeistelProcessor(), nil
+}
+
+func createAvalancheProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewAvalancheProcessor(), nil
+}
+
+func createBaseNProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewBaseNProcessor(), nil
+}
+
+func createTOTPProcessor(cfg *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewTOTPProcessor()
+	if cfg != nil {
+		config := map[string]interface{}{
+			"issuer":    cfg.GetTOTPConfig().Issuer,
+			"account":   cfg.GetTOTPConfig().Account,
+			"algorithm": cfg.GetTOTPConfig().Algorithm,
+			"digits":    cfg.GetTOTPConfig().Digits,
+			"period":    cfg.GetTOTPConfig().Period,
+		}
+		if err := processor.Configure(config); err != nil {
+			return nil, fmt.Errorf("failed to configure TOTP processor: %w", err)
+		}
+	}
+	return processor, nil
+}
+
+func createPasswordStrengthProcessor(cfg *config.Config) (crypto.Processor, error) {
+	processor := attacks.NewPasswordStrengthProcessor()
+	if cfg != nil {
+		// The weak scenario uses the same iteration count as the brute-force demo
+		if err := processor.Configure(map[string]interface{}{
+			"iterations": cfg.GetAttackConfig().BruteForceIterations,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to configure password strength processor: %w", err)
+		}
+	}
+	return processor, nil
+}
+
+func createSaltingProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return attacks.NewSaltingProcessor(), nil
+}
+
+func createOneTimePadProcessor(cfg *config.Config) (crypto.Processor, error) {
+	return crypto.NewOneTimePadProcessor(), nil
+}
+
+func createAESGCMSIVProcessor(cfg *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewAESGCMSIVProcessor()
+	if cfg != nil {
+		config := map[string]interface{}{
+			"keySize":       cfg.GetAESGCMSIVConfig().KeySize,
+			"keyFile":       cfg.GetAESGCMSIVConfig().KeyFile,
+			"maxKeyAgeDays": cfg.GetAESGCMSIVConfig().MaxKeyAgeDays,
+		}
+		if err := processor.Configure(config); err != nil {
+			return nil, fmt.Errorf("failed to configure AES-GCM-SIV processor: %w", err)
+		}
+	}
+	return processor, nil
+}
+
+func createBlowfishProcessor(cfg *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewBlowfishProcessor()
+	if cfg != nil {
+		config := map[string]interface{}{
+			"keySize": cfg.GetBlowfishConfig().KeySize,
+			"keyFile": cfg.GetBlowfishConfig().KeyFile,
+		}
+		if err := processor.Configure(config); err != nil {
+			return nil, fmt.Errorf("failed to configure Blowfish processor: %w", err)
+		}
+	}
+	return processor, nil
+}
+
+func createTripleDESProcessor(cfg *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewTripleDESProcessor()
+	if cfg != nil {
+		config := map[string]interface{}{
+			"keyingOption": cfg.GetTripleDESConfig().KeyingOption,
+			"keyFile":      cfg.GetTripleDESConfig().KeyFile,
+		}
+		if err := processor.Configure(config); err != nil {
+			return nil, fmt.Errorf("failed to configure Triple-DES processor: %w", err)
+		}
+	}
+	return processor, nil
+}
+
+func createRC4Processor(_ *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewRC4Processor()
+	if err := processor.Configure(map[string]interface{}{}); err != nil {
+		return nil, fmt.Errorf("failed to configure RC4 processor: %w", err)
+	}
+	return processor, nil
+}
+
+func createSignatureMatrixProcessor(_ *config.Config) (crypto.Processor, error) {
+	processor := crypto.NewSignatureMatrixProcessor()
+	if err := processor.Configure(map[string]interface{}{}); err != nil {
+		return nil, fmt.Errorf("failed to configure signature matrix processor: %w", err)
+	}
+	return processor, nil
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -31,14 +32,32 @@ func (i *ConsoleInput) GetChoice() (int, error) {
 	i.scanner.Scan()
 	choice, err := strconv.Atoi(strings.TrimSpace(i.scanner.Text()))
 	if err != nil {
-		return 0, fmt.Errorf("invalid input: please enter a number between 1 and 13")
+		return 0, fmt.Errorf("invalid input: please enter a menu number")
 	}
-	if choice < 1 || choice > 13 {
-		return 0, fmt.Errorf("invalid choice: please enter a number between 1 and 13")
+	// Whether the number is on the menu is checked against the menu registry
+	if choice < 1 {
+		return 0, fmt.Errorf("invalid choice: please enter a menu number")
 	}
 	return choice, nil
 }
 
+// GetMenuSelection reads a main menu number, or any other text as a search query
+func (i *ConsoleInput) GetMenuSelection() (int, string, error) {
+	i.scanner.Scan()
+	text := strings.TrimSpace(i.scanner.Text())
+	if text == "" {
+		return 0, "", fmt.Errorf("invalid input: enter a menu number or part of an algorithm name")
+	}
+	choice, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, text, nil
+	}
+	if choice < 1 {
+		return 0, "", fmt.Errorf("invalid choice: please enter a menu number")
+	}
+	return choice, "", nil
+}
+
 func (i *ConsoleInput) GetText() (string, error) {
 	i.scanner.Scan()
 	text := i.scanner.Text()
@@ -73,6 +92,34 @@ func (i *ConsoleInput) GetOperation() (string, error) {
 	return crypto.OperationDecrypt, nil
 }
 
+// Choose prints a titled list of options and reads the 1-based option picked
+func (i *ConsoleInput) Choose(title string, options []string) (int, error) {
+	fmt.Printf("\n%s", i.theme.Format(title, "brightCyan"))
+	for n, option := range options {
+		fmt.Printf("\n%s", i.theme.Format(fmt.Sprintf("%d. %s", n+1, option), "yellow"))
+	}
+	fmt.Printf("\n%s", i.theme.Format(fmt.Sprintf("Enter your choice (1-%d): ", len(options)), "brightGreen"))
+
+	i.scanner.Scan()
+	choice, err := strconv.Atoi(strings.TrimSpace(i.scanner.Text()))
+	if err != nil || choice < 1 || choice > len(options) {
+		return 0, fmt.Errorf("invalid choice: please enter a number between 1 and %d", len(options))
+	}
+	return choice, nil
+}
+
+// Prompt prints a prompt and reads one trimmed line
+func (i *ConsoleInput) Prompt(prompt string) (string, error) {
+	fmt.Printf("%s", i.theme.Format(prompt, "brightGreen"))
+	if !i.scanner.Scan() {
+		if err := i.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSpace(i.scanner.Text()), nil
+}
+
 // GetTextInput gets text input with a default value
 func GetTextInput(defaultValue string) string {
 	reader := bufio.NewReader(os.Stdin)
@@ -102,6 +149,32 @@ func GetIntInput(prompt string, minValue, maxValue int) int {
 	}
 }
 
+// promptText asks p for a line, returning defaultValue when the answer is empty or unreadable
+func promptText(p utils.Prompter, prompt, defaultValue string) string {
+	line, err := p.Prompt(prompt)
+	if err != nil || line == "" {
+		return defaultValue
+	}
+	return line
+}
+
+// promptInt asks p for a number between minValue and maxValue, asking again after an invalid
+// answer. An empty or unreadable answer returns 0.
+func promptInt(p utils.Prompter, prompt string, minValue, maxValue int) int {
+	for {
+		line, err := p.Prompt(prompt)
+		if err != nil || line == "" {
+			return 0
+		}
+		value, err := strconv.Atoi(line)
+		if err != nil || value < minValue || value > maxValue {
+			fmt.Printf("Please enter a number between %d and %d\n", minValue, maxValue)
+			continue
+		}
+		return value
+	}
+}
+
 // SetDHMode sets the DH mode flag
 func (i *ConsoleInput) SetDHMode(isDH bool) {
 	i.isDHMode = isDH
@@ -112,10 +185,10 @@ func (i *ConsoleInput) GetAttackChoice() (int, error) {
 	i.scanner.Scan()
 	choice, err := strconv.Atoi(strings.TrimSpace(i.scanner.Text()))
 	if err != nil {
-		return 0, fmt.Errorf("invalid input: please enter a number between 1 and 6")
+		return 0, fmt.Errorf("invalid input: please enter a number between 1 and %d", attackBackChoice)
 	}
-	if choice < 1 || choice > 6 {
-		return 0, fmt.Errorf("invalid choice: please enter a number between 1 and 6")
+	if choice < 1 || choice > attackBackChoice {
+		return 0, fmt.Errorf("invalid choice: please enter a number between 1 and %d", attackBackChoice)
 	}
 	return choice, nil
 }
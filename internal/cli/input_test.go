@@ -47,6 +47,47 @@ func TestConsoleInput(t *testing.T) {
 	}
 }
 
+func TestGetMenuSelection(t *testing.T) {
+	inputHandler := &ConsoleInput{
+		scanner: bufio.NewScanner(strings.NewReader("11\n  chacha \n\n")),
+		theme:   utils.DefaultTheme,
+	}
+
+	choice, query, err := inputHandler.GetMenuSelection()
+	if err != nil || choice != 11 || query != "" {
+		t.Errorf("Expected choice 11, got %d %q %v", choice, query, err)
+	}
+	choice, query, err = inputHandler.GetMenuSelection()
+	if err != nil || choice != 0 || query != "chacha" {
+		t.Errorf("Expected query chacha, got %d %q %v", choice, query, err)
+	}
+	if _, _, err = inputHandler.GetMenuSelection(); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+}
+
+func TestConsoleInputPrompter(t *testing.T) {
+	inputHandler := &ConsoleInput{
+		scanner: bufio.NewScanner(strings.NewReader("2\n  deadbeef \n7\n")),
+		theme:   utils.DefaultTheme,
+	}
+
+	choice, err := inputHandler.Choose("Key Management:", []string{"Use existing key", "Enter custom key"})
+	if err != nil || choice != 2 {
+		t.Errorf("Expected choice 2, got %d %v", choice, err)
+	}
+	line, err := inputHandler.Prompt("Enter key: ")
+	if err != nil || line != "deadbeef" {
+		t.Errorf("Expected deadbeef, got %q %v", line, err)
+	}
+	if _, err := inputHandler.Choose("Pick:", []string{"a", "b"}); err == nil {
+		t.Error("Expected an error for a choice out of range")
+	}
+	if _, err := inputHandler.Prompt("Enter key: "); err == nil {
+		t.Error("Expected an error at end of input")
+	}
+}
+
 func TestGetIntInput(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -138,3 +179,24 @@ func TestGetTextInput(t *testing.T) {
 		})
 	}
 }
+
+func TestPromptHelpersShareTheInputHandler(t *testing.T) {
+	// A prompt must not read ahead, or the menu's next read loses its line
+	inputHandler := &ConsoleInput{
+		scanner: bufio.NewScanner(strings.NewReader("0\n3\n\nmessage\n")),
+		theme:   utils.DefaultTheme,
+	}
+
+	if got := GetChallengeCipher(inputHandler); got != crypto.ChallengeRC4 {
+		t.Errorf("GetChallengeCipher() = %s, want %s after re-asking", got, crypto.ChallengeRC4)
+	}
+	if got := promptText(inputHandler, "Secret: ", "my-secret-key"); got != "my-secret-key" {
+		t.Errorf("promptText() = %q, want the default for an empty line", got)
+	}
+	if text, err := inputHandler.GetText(); err != nil || text != "message" {
+		t.Errorf("GetText() = %q, %v, want the line after the prompts", text, err)
+	}
+	if got := promptInt(inputHandler, "Number: ", 1, 3); got != 0 {
+		t.Errorf("promptInt() at end of input = %d, want 0", got)
+	}
+}
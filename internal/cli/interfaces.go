@@ -1,6 +1,9 @@
 package cli
 
-import "github.com/abdorrahmani/cryptolens/internal/crypto"
+import (
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
 
 // MenuInterface defines the contract for menu operations
 type MenuInterface interface {
@@ -11,11 +14,14 @@ type MenuInterface interface {
 type ProcessorFactory interface {
 	CreateProcessor(choice int) (crypto.Processor, error)
 	CreateAttackProcessor(choice int) (crypto.Processor, error)
+	MenuEntries() []MenuEntry
 }
 
 // UserInputHandler defines the contract for handling user input
 type UserInputHandler interface {
+	utils.Prompter
 	GetChoice() (int, error)
+	GetMenuSelection() (choice int, query string, err error)
 	GetAttackChoice() (int, error)
 	GetText() (string, error)
 	GetOperation() (string, error)
@@ -24,8 +30,9 @@ type UserInputHandler interface {
 // DisplayHandler defines the contract for displaying output
 type DisplayHandler interface {
 	ShowMenu()
+	ShowMenuMatches(query string, matches []MenuEntry)
 	ShowAttackMenu()
-	ShowResult(result string, steps []string)
+	ShowResult(result string, steps []utils.Step)
 	ShowError(err error)
 	ShowWelcome()
 	ShowGoodbye()
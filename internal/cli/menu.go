@@ -1,18 +1,46 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/abdorrahmani/cryptolens/internal/benchmark"
 	"github.com/abdorrahmani/cryptolens/internal/crypto"
-	"github.com/abdorrahmani/cryptolens/internal/input"
+	"github.com/abdorrahmani/cryptolens/internal/crypto/attacks"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Key challenge settings
+const (
+	challengeDecoys      = 3
+	challengeMaxAttempts = 5
+)
+
+// Attack menu entries with extra prompts, and the entry that returns to the main menu
+const (
+	nonceReuseAttackChoice    = 2
+	timingAttackChoice        = 3
+	jwtConfusionAttackChoice  = 7
+	keySeparationAttackChoice = 10
+	attackBackChoice          = 12
 )
 
 // Menu implements MenuInterface for handling the main application flow
 type Menu struct {
-	display DisplayHandler
-	input   UserInputHandler
-	factory ProcessorFactory
+	display    DisplayHandler
+	input      UserInputHandler
+	factory    ProcessorFactory
+	audit      bool
+	keysDir    string
+	sessionLog *SessionLog
+	maxRun     time.Duration
 }
 
 // NewMenu creates a new menu instance
@@ -21,40 +49,104 @@ func NewMenu(display DisplayHandler, input UserInputHandler, factory ProcessorFa
 		display: display,
 		input:   input,
 		factory: factory,
+		keysDir: "keys",
 	}
 }
 
+// SetAuditMode enables a dependency audit after each operation
+func (m *Menu) SetAuditMode(enabled bool) {
+	m.audit = enabled
+}
+
+// SetSessionLog records each operation in log; nil turns recording off
+func (m *Menu) SetSessionLog(log *SessionLog) {
+	m.sessionLog = log
+}
+
+// SetMaxRunDuration limits how long benchmarks, attack simulations and comparisons run before
+// stopping with partial results; 0 means no limit
+func (m *Menu) SetMaxRunDuration(d time.Duration) {
+	m.maxRun = d
+}
+
+// SetKeysDir sets the directory the key reset menu entry cleans
+func (m *Menu) SetKeysDir(dir string) {
+	m.keysDir = dir
+}
+
 // Run executes the main menu loop
 func (m *Menu) Run() error {
 	m.display.ShowWelcome()
 
+	menuEntries := m.factory.MenuEntries()
+	entries := make(map[int]MenuEntry)
+	for _, entry := range menuEntries {
+		entries[entry.ID] = entry
+	}
+
 	for {
 		m.display.ShowMenu()
 
-		choice, err := m.input.GetChoice()
+		choice, query, err := m.input.GetMenuSelection()
 		if err != nil {
 			m.display.ShowError(err)
 			continue
 		}
 
-		if choice == 13 {
-			m.display.ShowGoodbye()
-			return nil
-		}
-
-		if choice == 12 {
-			if err := m.handleAttackMenu(); err != nil {
+		var entry MenuEntry
+		if query != "" {
+			entry, err = m.searchMenu(menuEntries, query)
+			if err != nil {
 				m.display.ShowError(err)
+				continue
+			}
+			choice = entry.ID
+		} else {
+			var ok bool
+			entry, ok = entries[choice]
+			if !ok {
+				m.display.ShowError(fmt.Errorf("invalid choice: %d is not on the menu", choice))
+				continue
 			}
-			continue
 		}
 
-		if err := m.processChoice(choice); err != nil {
+		switch {
+		case entry.Exit:
+			m.display.ShowGoodbye()
+			return nil
+		case entry.Action != nil:
+			err = entry.Action(m)
+		default:
+			err = m.processChoice(choice)
+		}
+		if err != nil {
 			m.display.ShowError(err)
 		}
 	}
 }
 
+// searchMenu picks the menu entry matching query, asking the user to choose when several match
+func (m *Menu) searchMenu(entries []MenuEntry, query string) (MenuEntry, error) {
+	matches := filterMenuEntries(entries, query)
+	switch len(matches) {
+	case 0:
+		return MenuEntry{}, fmt.Errorf("no menu entry matches %q", query)
+	case 1:
+		m.display.ShowMessage(fmt.Sprintf("Selected: %s", matches[0].Label))
+		return matches[0], nil
+	}
+
+	m.display.ShowMenuMatches(query, matches)
+	choice, err := m.input.GetChoice()
+	if err != nil {
+		return MenuEntry{}, err
+	}
+	if choice > len(matches) {
+		return MenuEntry{}, fmt.Errorf("invalid choice: please enter a number between 1 and %d", len(matches))
+	}
+	return matches[choice-1], nil
+}
+
 // handleAttackMenu handles the attack simulation menu
 func (m *Menu) handleAttackMenu() error {
 	for {
@@ -65,7 +157,7 @@ func (m *Menu) handleAttackMenu() error {
 			return err
 		}
 
-		if choice == 6 {
+		if choice == attackBackChoice {
 			return nil // Back to main menu
 		}
 
@@ -75,6 +167,81 @@ func (m *Menu) handleAttackMenu() error {
 	}
 }
 
+// runBlobDiagnostic asks for an unknown ciphertext and a key, then tries every known algorithm
+func (m *Menu) runBlobDiagnostic() error {
+	processor := crypto.NewBlobDiagnosticProcessor()
+	if err := processor.Configure(map[string]interface{}{"key": promptText(m.input, "\nEnter the key in hex: ", "")}); err != nil {
+		return err
+	}
+
+	blob := promptText(m.input, "Enter the ciphertext blob (Base64 or hex): ", "")
+	result, steps, err := processor.Process(blob, crypto.OperationDecrypt)
+	if err != nil {
+		return fmt.Errorf("failed to diagnose blob: %w", err)
+	}
+	m.display.ShowResult(result, steps)
+	return nil
+}
+
+// runKeyChallenge asks the user to find the key that decrypts a random ciphertext
+func (m *Menu) runKeyChallenge() error {
+	challenge, err := crypto.NewKeyChallenge(GetChallengeCipher(m.input), challengeDecoys)
+	if err != nil {
+		return fmt.Errorf("failed to create challenge: %w", err)
+	}
+
+	fmt.Printf("\nA secret message was encrypted with %s:\n  %s\n", challenge.CipherName(), challenge.Ciphertext())
+	fmt.Println("\nOne of these keys decrypts it:")
+	candidates := challenge.Candidates()
+	for i, candidate := range candidates {
+		fmt.Printf("  %d. %s\n", i+1, candidate)
+	}
+
+	for challenge.Attempts() < challengeMaxAttempts {
+		guess := promptText(m.input, fmt.Sprintf("\nEnter the key in hex or its number (attempt %d of %d, Enter to give up): ",
+			challenge.Attempts()+1, challengeMaxAttempts), "")
+		if guess == "" {
+			break
+		}
+		if n, err := strconv.Atoi(guess); err == nil && n >= 1 && n <= len(candidates) {
+			guess = candidates[n-1]
+		}
+
+		grade := challenge.Grade(guess)
+		if grade.Correct {
+			m.display.ShowResult(grade.Plaintext, utils.DataSteps([]string{
+				fmt.Sprintf("✅ Correct! You found the key in %d attempt(s)", challenge.Attempts()),
+				"Anyone holding the key can decrypt; the cipher itself is public",
+			}))
+			return nil
+		}
+		m.display.ShowMessage("❌ " + grade.Hint)
+	}
+
+	key, message := challenge.Reveal()
+	m.display.ShowMessage(fmt.Sprintf("The key was %s and the message was %q", key, message))
+	return nil
+}
+
+// GetChallengeCipher prompts user to select the cipher used by the key challenge
+func GetChallengeCipher(p utils.Prompter) string {
+	fmt.Println("\nSelect Challenge Cipher:")
+	fmt.Println("1. AES-128-CBC")
+	fmt.Println("2. Blowfish-CBC (Legacy)")
+	fmt.Println("3. RC4 (Stream Cipher)")
+
+	choice := promptInt(p, "Enter your choice (1-3): ", 1, 3)
+
+	switch choice {
+	case 2:
+		return crypto.ChallengeBlowfish
+	case 3:
+		return crypto.ChallengeRC4
+	default:
+		return crypto.ChallengeAES
+	}
+}
+
 // processAttackChoice handles the user's attack menu choice
 func (m *Menu) processAttackChoice(choice int) error {
 	processor, err := m.factory.CreateAttackProcessor(choice)
@@ -82,6 +249,56 @@ func (m *Menu) processAttackChoice(choice int) error {
 		return fmt.Errorf("failed to create attack processor: %w", err)
 	}
 
+	// The algorithm confusion attack needs the server's public key, or simulates a server
+	if choice == jwtConfusionAttackChoice {
+		keyFile := promptText(m.input, "Enter path to the server's RSA public key PEM (press Enter to simulate a server): ", "")
+		if keyFile != "" {
+			if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
+				if err := configurable.Configure(map[string]interface{}{"publicKeyFile": keyFile}); err != nil {
+					return err
+				}
+			}
+			m.display.ShowMessage("Paste an RS256 token signed by that key")
+		} else {
+			m.display.ShowMessage("Enter a user name; the simulated server will issue an RS256 token for it")
+		}
+	}
+
+	// The nonce reuse demo asks for the second message through the input handler
+	if choice == nonceReuseAttackChoice {
+		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
+			if err := configurable.Configure(map[string]interface{}{"input": m.input}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The timing demo can also run against a constant-time comparison
+	if choice == timingAttackChoice {
+		fmt.Println("\nVerifier setup:")
+		fmt.Println("1. Vulnerable byte-by-byte comparison only")
+		fmt.Println("2. Also re-run against subtle.ConstantTimeCompare")
+		constantTime := promptInt(m.input, "Enter your choice (1-2): ", 1, 2) == 2
+		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
+			if err := configurable.Configure(map[string]interface{}{"useConstantTime": constantTime}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The key separation demo can run with one dual-use key or separate keys
+	if choice == keySeparationAttackChoice {
+		fmt.Println("\nServer key setup:")
+		fmt.Println("1. One RSA key for signing and encryption")
+		fmt.Println("2. Separate signing and encryption keys")
+		separate := promptInt(m.input, "Enter your choice (1-2): ", 1, 2) == 2
+		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
+			if err := configurable.Configure(map[string]interface{}{"separateKeys": separate}); err != nil {
+				return err
+			}
+		}
+	}
+
 	fmt.Printf("\n%s", m.display.(*ConsoleDisplay).theme.Format("Enter text to demonstrate the attack: ", "brightGreen bold"))
 	text, err := m.input.GetText()
 	if err != nil {
@@ -90,7 +307,7 @@ func (m *Menu) processAttackChoice(choice int) error {
 
 	m.display.ShowProcessingMessage(text)
 
-	result, steps, err := processor.Process(text, crypto.OperationEncrypt)
+	result, steps, err := m.process(processor, text, crypto.OperationEncrypt)
 	if err != nil {
 		return fmt.Errorf("failed to process: %w", err)
 	}
@@ -107,48 +324,208 @@ func (m *Menu) processChoice(choice int) error {
 		return fmt.Errorf("failed to create processor: %w", err)
 	}
 
-	// Get operation choice (skip for SHA-256, HMAC, PBKDF, DH, and X25519)
+	// Get operation choice (skip for hashing, checksums, password demos, HMAC, PBKDF, DH, X25519,
+	// the signature matrix, HKDF, Merkle trees, the avalanche demo, TOTP, and ROT13/ROT47, which are their own inverse)
 	operation := crypto.OperationEncrypt
-	if choice != 4 && choice != 6 && choice != 7 && choice != 8 && choice != 9 { // Skip for SHA-256 (4), HMAC (6), PBKDF (7), DH (8), and X25519 (9)
+	switch processor.(type) {
+	case *crypto.HashProcessor, *crypto.ChecksumProcessor, *attacks.PasswordStrengthProcessor, *attacks.SaltingProcessor,
+		*crypto.HMACProcessor, *crypto.PBKDFProcessor, *crypto.DHProcessor, *crypto.X25519Processor,
+		*crypto.SignatureMatrixProcessor, *crypto.ROTProcessor, *crypto.HKDFProcessor,
+		*crypto.MerkleTreeProcessor, *crypto.AvalancheProcessor, *crypto.TOTPProcessor:
+	default:
 		operation, err = m.input.GetOperation()
 		if err != nil {
 			return err
 		}
 	}
 
+	// ChaCha20-Poly1305 asks for custom keys, nonces, and tampering through the input handler
+	if chacha, ok := processor.(*crypto.ChaCha20Poly1305Processor); ok {
+		if err := chacha.Configure(map[string]interface{}{"input": m.input}); err != nil {
+			return err
+		}
+	}
+
+	// One-time pad decryption needs the pad printed at encryption
+	if otp, ok := processor.(*crypto.OneTimePadProcessor); ok && operation == crypto.OperationDecrypt {
+		key, err := m.input.Prompt("Enter the key (Base64): ")
+		if err != nil {
+			return err
+		}
+		if err := otp.Configure(map[string]interface{}{"key": key}); err != nil {
+			return err
+		}
+	}
+
+	// Caesar decryption can try every shift when the key is unknown
+	if caesar, ok := processor.(*crypto.CaesarProcessor); ok && operation == crypto.OperationDecrypt {
+		if utils.PromptChoice(m.input, "Select Shift:", "Configured shift", "Unknown shift (show all 26 decryptions)") == 2 {
+			if err := caesar.Configure(map[string]interface{}{"shift": "auto"}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// ROT offers ROT13 for letters and ROT47 for printable ASCII
+	if rot, ok := processor.(*crypto.ROTProcessor); ok {
+		variant := crypto.ROT13
+		if utils.PromptChoice(m.input, "Select Variant:", "ROT13 (letters)", "ROT47 (printable ASCII)") == 2 {
+			variant = crypto.ROT47
+		}
+		if err := rot.Configure(map[string]interface{}{"variant": variant}); err != nil {
+			return err
+		}
+	}
+
+	// HKDF asks for the hash, salt, info string, and output length; blank answers keep the defaults
+	if hkdfProcessor, ok := processor.(*crypto.HKDFProcessor); ok {
+		hashes := []string{crypto.HashSHA256, crypto.HashSHA384, crypto.HashSHA512, crypto.HashSHA1}
+		choice := utils.PromptChoice(m.input, "Select Hash:", "SHA-256", "SHA-384", "SHA-512", "SHA-1")
+		hkdfConfig := map[string]interface{}{
+			"hashAlgorithm": hashes[choice-1],
+			"salt":          utils.PromptLine(m.input, "Enter salt (hex or Base64, blank for none): "),
+			"info":          utils.PromptLine(m.input, "Enter info string (blank for none): "),
+		}
+		if length := utils.PromptLine(m.input, "Enter output length in bytes (blank for 32): "); length != "" {
+			n, err := strconv.Atoi(length)
+			if err != nil {
+				return fmt.Errorf("invalid output length: %s", length)
+			}
+			hkdfConfig["length"] = n
+		}
+		if err := hkdfProcessor.Configure(hkdfConfig); err != nil {
+			return err
+		}
+	}
+
+	// Merkle trees either build a tree with a proof for one leaf or verify such a proof
+	if merkle, ok := processor.(*crypto.MerkleTreeProcessor); ok {
+		hashes := []string{crypto.HashSHA256, crypto.HashSHA512, crypto.HashSHA3256, crypto.HashBLAKE3, crypto.HashSHA1}
+		choice := utils.PromptChoice(m.input, "Select Hash:", "SHA-256", "SHA-512", "SHA3-256", "BLAKE3", "SHA-1")
+		merkleConfig := map[string]interface{}{"hashAlgorithm": hashes[choice-1]}
+		if utils.PromptChoice(m.input, "Select Operation:", "Build tree and inclusion proof", "Verify an inclusion proof") == 2 {
+			merkleConfig["proof"] = utils.PromptLine(m.input, "Enter the proof (root:<hex>;proof:...): ")
+			operation = crypto.OperationVerify
+		} else if index := utils.PromptLine(m.input, "Enter the leaf to prove, counting from 0 (blank for 0): "); index != "" {
+			n, err := strconv.Atoi(index)
+			if err != nil {
+				return fmt.Errorf("invalid leaf index: %s", index)
+			}
+			merkleConfig["leafIndex"] = n
+		}
+		if err := merkle.Configure(merkleConfig); err != nil {
+			return err
+		}
+	}
+
+	// The avalanche demo flips a bit before AES or one of the hashes
+	if avalanche, ok := processor.(*crypto.AvalancheProcessor); ok {
+		targets := []string{crypto.HashSHA256, crypto.AvalancheAES, crypto.HashSHA3256, crypto.HashBLAKE3, crypto.HashSHA1}
+		choice := utils.PromptChoice(m.input, "Select Target:", "SHA-256", "AES-128 (one block)", "SHA3-256", "BLAKE3", "SHA-1")
+		if err := avalanche.Configure(map[string]interface{}{"target": targets[choice-1]}); err != nil {
+			return err
+		}
+	}
+
+	// Base-N offers hex, Bitcoin's Base58, Base62, and Ascii85
+	if baseN, ok := processor.(*crypto.BaseNProcessor); ok {
+		bases := []string{crypto.Base58, crypto.Base62, crypto.Base85, crypto.Base16}
+		choice := utils.PromptChoice(m.input, "Select Base:", "Base58 (Bitcoin)", "Base62", "Base85 (Ascii85)", "Base16 (hex)")
+		if err := baseN.Configure(map[string]interface{}{"base": bases[choice-1]}); err != nil {
+			return err
+		}
+	}
+
+	// TOTP asks who the code is for; blank answers keep the configured issuer and account
+	if totp, ok := processor.(*crypto.TOTPProcessor); ok {
+		if err := totp.Configure(map[string]interface{}{
+			"issuer":  utils.PromptLine(m.input, "Enter issuer (blank for the configured one): "),
+			"account": utils.PromptLine(m.input, "Enter account name (blank for the configured one): "),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Checksums offer CRC-32, CRC-32C, and Adler-32
+	if checksum, ok := processor.(*crypto.ChecksumProcessor); ok {
+		algorithms := crypto.ChecksumAlgorithms()
+		choice := utils.PromptChoice(m.input, "Select Checksum:", "CRC-32 (IEEE)", "CRC-32C (Castagnoli)", "Adler-32")
+		if err := checksum.Configure(map[string]interface{}{"algorithm": algorithms[choice-1]}); err != nil {
+			return err
+		}
+	}
+
+	// Configure hash processor if selected
+	if _, ok := processor.(*crypto.HashProcessor); ok {
+		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
+			if algorithm := GetHashAlgorithm(m.input); algorithm != "" {
+				hashConfig := map[string]interface{}{
+					"algorithm": algorithm,
+				}
+				if algorithm == crypto.HashBLAKE2b || algorithm == crypto.HashBLAKE2s {
+					hashConfig["digestSize"] = GetBLAKE2DigestSize(m.input, algorithm)
+				}
+				if err := configurable.Configure(hashConfig); err != nil {
+					return fmt.Errorf("failed to configure hash processor: %w", err)
+				}
+			}
+		}
+	}
+
 	// Configure HMAC processor if selected
-	if choice == 6 { // HMAC option
+	if _, ok := processor.(*crypto.HMACProcessor); ok {
 		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
-			hashAlgo := GetHMACHashAlgorithm()
+			hashAlgo := GetHMACHashAlgorithm(m.input)
 			if hashAlgo == "benchmark" {
-				result, steps, err := benchmark.RunHMACBenchmark()
+				result, steps, err := m.interruptible(benchmark.RunHMACBenchmarkContext)
 				if err != nil {
 					return err
 				}
 				m.display.ShowResult(result, steps)
 				return nil
 			}
-			if err := configurable.Configure(map[string]interface{}{
+			hmacConfig := map[string]interface{}{
 				"hashAlgorithm": hashAlgo,
-			}); err != nil {
+			}
+			if hashAlgo == crypto.HashBLAKE2b || hashAlgo == crypto.HashBLAKE2s {
+				hmacConfig["digestSize"] = GetBLAKE2DigestSize(m.input, hashAlgo)
+			}
+			if err := configurable.Configure(hmacConfig); err != nil {
 				return fmt.Errorf("failed to configure HMAC processor: %w", err)
 			}
+			if utils.PromptChoice(m.input, "Select Operation:", "Compute HMAC", "Verify HMAC (enter message||MAC)") == 2 {
+				operation = crypto.OperationVerify
+			}
 		}
 	}
 
 	// Configure PBKDF processor if selected
-	if choice == 7 { // PBKDF option
+	if _, ok := processor.(*crypto.PBKDFProcessor); ok {
 		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
-			algo := GetPBKDFAlgorithm()
+			algo := GetPBKDFAlgorithm(m.input)
 			if algo == "benchmark" {
-				result, steps, err := benchmark.RunPBKDFBenchmark()
+				result, steps, err := m.interruptible(benchmark.RunPBKDFBenchmarkContext)
 				if err != nil {
 					return err
 				}
 				m.display.ShowResult(result, steps)
 				return nil
 			}
-			if err := configurable.Configure(map[string]interface{}{
+			if algo == "verify" {
+				if err := configurable.Configure(map[string]interface{}{
+					"storedHash": promptText(m.input, "Enter the stored value (PHC string or bcrypt hash): ", ""),
+				}); err != nil {
+					return fmt.Errorf("failed to configure PBKDF processor: %w", err)
+				}
+				operation = crypto.OperationVerify
+			} else if algo == "compare" {
+				if err := configurable.Configure(map[string]interface{}{
+					"algorithm": crypto.PBKDFAlgorithmArgon2id,
+					"compare":   true,
+				}); err != nil {
+					return fmt.Errorf("failed to configure PBKDF processor: %w", err)
+				}
+			} else if err := configurable.Configure(map[string]interface{}{
 				"algorithm": algo,
 			}); err != nil {
 				return fmt.Errorf("failed to configure PBKDF processor: %w", err)
@@ -157,18 +534,27 @@ func (m *Menu) processChoice(choice int) error {
 	}
 
 	// Configure JWT processor if selected
-	if choice == 10 { // JWT option
+	if _, ok := processor.(*crypto.JWTProcessor); ok {
 		if configurable, ok := processor.(crypto.ConfigurableProcessor); ok {
-			algorithm := GetJWTAlgorithm()
+			algorithm := GetJWTAlgorithm(m.input)
 			if err := configurable.Configure(map[string]interface{}{
 				"algorithm": algorithm,
 			}); err != nil {
 				return fmt.Errorf("failed to configure JWT processor: %w", err)
 			}
+			// Optionally verify with a key generated outside CryptoLens
+			if operation == crypto.OperationDecrypt && algorithm != "HS256" {
+				if keyFile := promptText(m.input, "Enter path to an external verification key (press Enter to use generated keys): ", ""); keyFile != "" {
+					if err := configurable.Configure(map[string]interface{}{
+						"verificationKeyFile": keyFile,
+					}); err != nil {
+						return fmt.Errorf("failed to configure JWT verification key: %w", err)
+					}
+				}
+			}
 			// Get secret key for HS256
 			if algorithm == "HS256" {
-				fmt.Print("Enter secret key (default = my-secret-key): ")
-				secretKey := input.GetTextInput("my-secret-key")
+				secretKey := promptText(m.input, "Enter secret key (default = my-secret-key): ", "my-secret-key")
 				if secretKey != "" {
 					if err := configurable.Configure(map[string]interface{}{
 						"secretKey": secretKey,
@@ -181,26 +567,88 @@ func (m *Menu) processChoice(choice int) error {
 	}
 
 	// Special handling for DH and X25519 demonstration
-	if choice == 8 || choice == 9 {
-		fmt.Printf("\n%s", m.display.(*ConsoleDisplay).theme.Format("Press Enter to start key exchange demonstration...", "brightGreen bold"))
+	switch processor.(type) {
+	case *crypto.DHProcessor, *crypto.X25519Processor:
+		fmt.Printf("\n%s", m.display.(*ConsoleDisplay).theme.Format("Enter a message to encrypt with the shared key (press Enter for a sample message): ", "brightGreen bold"))
 		// Set DH mode to allow empty input
 		if input, ok := m.input.(*ConsoleInput); ok {
 			input.SetDHMode(true)
 		}
-		// Wait for Enter key
-		if _, err := m.input.GetText(); err != nil {
-			return err
-		}
+		message, err := m.input.GetText()
 		// Reset DH mode
 		if input, ok := m.input.(*ConsoleInput); ok {
 			input.SetDHMode(false)
 		}
-		// Process with empty string for demonstration
-		result, steps, err := processor.Process("", operation)
+		if err != nil {
+			return err
+		}
+		// An empty message makes the processor fall back to its sample message
+		result, steps, err := m.process(processor, message, operation)
 		if err != nil {
 			return fmt.Errorf("failed to process: %w", err)
 		}
 		m.display.ShowResult(result, steps)
+		m.showAudit(processor)
+		if provider, ok := processor.(crypto.TranscriptProvider); ok {
+			return m.exportTranscript(provider.Transcript())
+		}
+		return nil
+	}
+
+	// Offer to review and tweak the processor's parameters
+	if parameterized, ok := processor.(crypto.ParameterizedProcessor); ok {
+		if err := editParameters(m.input, parameterized); err != nil {
+			return err
+		}
+	}
+
+	// OpenSSL-compatible AES derives its key from a passphrase instead of the key file
+	if aesProcessor, ok := processor.(*crypto.AESProcessor); ok && aesProcessor.OpenSSLCompat() {
+		passphrase := promptText(m.input, "Enter passphrase: ", "")
+		if passphrase == "" {
+			return fmt.Errorf("a passphrase is required in OpenSSL-compatible mode")
+		}
+		if err := aesProcessor.Configure(map[string]interface{}{"passphrase": passphrase}); err != nil {
+			return fmt.Errorf("failed to configure AES passphrase: %w", err)
+		}
+	}
+
+	// Let the user pick a key when several are available
+	if selectable, ok := processor.(crypto.KeySelectableProcessor); ok {
+		if err := selectKeyFile(m.input, selectable); err != nil {
+			return err
+		}
+	}
+
+	// Merkle trees take one leaf per line, ending with a blank line
+	if _, ok := processor.(*crypto.MerkleTreeProcessor); ok && operation == crypto.OperationEncrypt {
+		fmt.Printf("\n%s\n", m.display.(*ConsoleDisplay).theme.Format("Enter one leaf per line (blank line to finish):", "brightGreen bold"))
+		var leaves []string
+		for {
+			line := utils.PromptLine(m.input, "> ")
+			if line == "" {
+				break
+			}
+			leaves = append(leaves, line)
+		}
+		result, steps, err := m.process(processor, strings.Join(leaves, "\n"), operation)
+		if err != nil {
+			return fmt.Errorf("failed to process: %w", err)
+		}
+		m.display.ShowResult(result, steps)
+		m.showAudit(processor)
+		return nil
+	}
+
+	// TOTP takes the Base32 secret an authenticator app shows; a blank line generates one
+	if _, ok := processor.(*crypto.TOTPProcessor); ok {
+		secret := utils.PromptLine(m.input, "\nEnter the Base32 secret (blank to generate one): ")
+		result, steps, err := m.process(processor, secret, operation)
+		if err != nil {
+			return fmt.Errorf("failed to process: %w", err)
+		}
+		m.display.ShowResult(result, steps)
+		m.showAudit(processor)
 		return nil
 	}
 
@@ -213,27 +661,333 @@ func (m *Menu) processChoice(choice int) error {
 
 	m.display.ShowProcessingMessage(text)
 
-	result, steps, err := processor.Process(text, operation)
+	result, steps, err := m.process(processor, text, operation)
 	if err != nil {
 		return fmt.Errorf("failed to process: %w", err)
 	}
 
 	m.display.ShowResult(result, steps)
+	m.showAudit(processor)
+	return nil
+}
+
+// process runs processor and records the operation in the session log
+func (m *Menu) process(processor crypto.Processor, text, operation string) (string, []utils.Step, error) {
+	var result string
+	var steps []utils.Step
+	var err error
+	if _, ok := processor.(crypto.ContextProcessor); ok {
+		result, steps, err = m.interruptible(func(ctx context.Context) (string, []utils.Step, error) {
+			return crypto.ProcessContext(ctx, processor, text, operation)
+		})
+	} else {
+		result, steps, err = processor.Process(text, operation)
+	}
+	if logErr := m.sessionLog.Record(processor, operation, text, result, err); logErr != nil {
+		m.display.ShowError(logErr)
+	}
+	return result, steps, err
+}
+
+// interruptible runs fn with a context cancelled by Ctrl-C, so a long operation stops and
+// returns to the menu instead of ending the program. With a maximum run duration set the context
+// also expires after that long; fn then returns its partial results rather than an error.
+func (m *Menu) interruptible(fn func(ctx context.Context) (string, []utils.Step, error)) (string, []utils.Step, error) {
+	parent := context.Background()
+	if m.maxRun > 0 {
+		var cancel context.CancelFunc
+		parent, cancel = context.WithTimeout(parent, m.maxRun)
+		defer cancel()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+
+	result, steps, err := fn(ctx)
+	if ctx.Err() != nil {
+		utils.RestoreTerminal()
+	}
+	switch {
+	case err == nil:
+		return result, steps, nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return result, steps, fmt.Errorf("operation timed out after %s: %w", m.maxRun, err)
+	case errors.Is(err, context.Canceled):
+		return result, steps, fmt.Errorf("operation interrupted: %w", err)
+	default:
+		return result, steps, err
+	}
+}
+
+// WipeKeys securely deletes every key file in dir after the user confirms
+func (m *Menu) WipeKeys(dir string) error {
+	files, err := crypto.ListKeyDirectory(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		m.display.ShowMessage(fmt.Sprintf("No key files found in %s", dir))
+		return nil
+	}
+
+	fmt.Printf("\nThe following key files will be overwritten and deleted:\n")
+	for _, file := range files {
+		fmt.Printf("  - %s\n", file)
+	}
+	if answer := strings.ToLower(promptText(m.input, "Anything encrypted under these keys becomes unrecoverable. Continue? (y/N): ", "n")); answer != "y" && answer != "yes" {
+		m.display.ShowMessage("Secure deletion cancelled")
+		return nil
+	}
+
+	result, steps, err := crypto.SecureDeleteKeys(files)
+	m.display.ShowResult(result, steps)
+	return err
+}
+
+// ResetKeys deletes the generated key files in dir after the user confirms, so processors
+// regenerate them on next use
+func (m *Menu) ResetKeys(dir string) error {
+	files, err := crypto.GeneratedKeyFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		m.display.ShowMessage(fmt.Sprintf("No generated key files found in %s", dir))
+		return nil
+	}
+
+	fmt.Printf("\nThe following key files will be deleted and regenerated on next use:\n")
+	for _, file := range files {
+		fmt.Printf("  - %s\n", file)
+	}
+	if answer := strings.ToLower(promptText(m.input, "Anything encrypted under these keys becomes unrecoverable. Continue? (y/N): ", "n")); answer != "y" && answer != "yes" {
+		m.display.ShowMessage("Key reset cancelled")
+		return nil
+	}
+
+	result, steps, err := crypto.ResetKeys(files)
+	m.display.ShowResult(result, steps)
+	return err
+}
+
+// showAudit lists the processor's randomness sources and libraries when audit mode is on
+func (m *Menu) showAudit(processor crypto.Processor) {
+	if !m.audit {
+		return
+	}
+	if _, ok := processor.(crypto.AuditableProcessor); !ok {
+		return
+	}
+	result, steps, err := crypto.AuditReport(processor)
+	if err != nil {
+		m.display.ShowError(err)
+		return
+	}
+	m.display.ShowResult(result, steps)
+}
+
+// editParameters shows the processor's effective parameters and applies inline edits
+func editParameters(p utils.Prompter, processor crypto.ParameterizedProcessor) error {
+	if answer := strings.ToLower(promptText(p, "\nReview or edit parameters before running? (y/N): ", "n")); answer != "y" && answer != "yes" {
+		return nil
+	}
+
+	edits := make(map[string]string)
+	for {
+		params := processor.Parameters()
+		fmt.Println("\nCurrent Parameters:")
+		for i, param := range params {
+			value := fmt.Sprint(param.Value)
+			if edited, ok := edits[param.Name]; ok {
+				value = edited + " (edited)"
+			}
+			fmt.Printf("%d. %s = %s  - %s\n", i+1, param.Name, value, param.Description)
+		}
+
+		choice := promptInt(p, fmt.Sprintf("Parameter to edit (1-%d, Enter to run): ", len(params)), 1, len(params))
+		if choice == 0 {
+			break
+		}
+
+		param := params[choice-1]
+		raw := promptText(p, fmt.Sprintf("New value for %s (current %v): ", param.Name, param.Value), fmt.Sprint(param.Value))
+		if _, err := crypto.ParseParameterValue(param, raw); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		edits[param.Name] = raw
+	}
+
+	if len(edits) == 0 {
+		return nil
+	}
+	if err := crypto.ApplyParameters(processor, edits); err != nil {
+		return fmt.Errorf("failed to apply parameters: %w", err)
+	}
+	return nil
+}
+
+// selectKeyFile prompts for a key file when more than one key is available
+func selectKeyFile(p utils.Prompter, processor crypto.KeySelectableProcessor) error {
+	current := processor.KeyFile()
+	if current == "" {
+		return nil
+	}
+
+	files, err := crypto.ListKeyFiles(current)
+	if err != nil || len(files) < 2 {
+		return nil
+	}
+
+	path := GetKeyFileChoice(p, files)
+	if path == "" || path == current {
+		return nil
+	}
+	if err := processor.UseKeyFile(path); err != nil {
+		return fmt.Errorf("failed to use key file: %w", err)
+	}
+	fmt.Printf("Using key file %s\n", path)
 	return nil
 }
 
+// GetKeyFileChoice prompts user to select one of the available key files
+func GetKeyFileChoice(p utils.Prompter, files []string) string {
+	fmt.Println("\nMultiple keys available:")
+	for i, file := range files {
+		label := filepath.Base(file)
+		if i == 0 {
+			label += " (current)"
+		}
+		fmt.Printf("%d. %s\n", i+1, label)
+	}
+
+	choice := promptInt(p, fmt.Sprintf("Select a key (1-%d, Enter for current): ", len(files)), 1, len(files))
+	if choice == 0 {
+		return ""
+	}
+	return files[choice-1]
+}
+
+// exportTranscript offers to save the key exchange as a sequence diagram at a path the user
+// chooses, asking before it replaces an existing file
+func (m *Menu) exportTranscript(transcript *crypto.KeyExchangeTranscript) error {
+	if transcript == nil {
+		return nil
+	}
+
+	format := GetDiagramFormat(m.input)
+	if format == "" {
+		return nil
+	}
+
+	diagram, err := transcript.Export(format)
+	if err != nil {
+		return err
+	}
+
+	extension := ".mmd"
+	if format == crypto.DiagramPlantUML {
+		extension = ".puml"
+	}
+	defaultName := strings.ToLower(strings.ReplaceAll(transcript.Protocol, "-", "")) + "_sequence" + extension
+	name := promptText(m.input, fmt.Sprintf("Save diagram to (default = %s): ", defaultName), defaultName)
+
+	// Create the file only if it is new, unless the user agrees to replace it
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if _, err := os.Stat(name); err == nil {
+		if answer := strings.ToLower(promptText(m.input, fmt.Sprintf("%s already exists. Overwrite? (y/N): ", name), "n")); answer != "y" && answer != "yes" {
+			m.display.ShowMessage("Sequence diagram not saved")
+			return nil
+		}
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(name, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write sequence diagram: %w", err)
+	}
+	if _, err := file.WriteString(diagram); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write sequence diagram: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write sequence diagram: %w", err)
+	}
+
+	m.display.ShowMessage(fmt.Sprintf("Sequence diagram saved to %s", name))
+	return nil
+}
+
+// GetDiagramFormat prompts user to select a sequence diagram export format
+func GetDiagramFormat(p utils.Prompter) string {
+	fmt.Println("\nExport key exchange as a sequence diagram?")
+	fmt.Println("1. No")
+	fmt.Println("2. Mermaid")
+	fmt.Println("3. PlantUML")
+
+	choice := promptInt(p, "Enter your choice (1-3): ", 1, 3)
+
+	switch choice {
+	case 2:
+		return crypto.DiagramMermaid
+	case 3:
+		return crypto.DiagramPlantUML
+	default:
+		return ""
+	}
+}
+
+// GetHashAlgorithm prompts user to select a hash algorithm, returning "" to keep the configured default
+func GetHashAlgorithm(p utils.Prompter) string {
+	fmt.Println("\nSelect Hash Algorithm (press Enter for the configured default):")
+	fmt.Println("1. SHA-1 (legacy)")
+	fmt.Println("2. SHA-256")
+	fmt.Println("3. SHA-384")
+	fmt.Println("4. SHA-512")
+	fmt.Println("5. SHA3-256")
+	fmt.Println("6. BLAKE2b (custom digest size)")
+	fmt.Println("7. BLAKE2s (custom digest size, for 32-bit platforms)")
+	fmt.Println("8. BLAKE3")
+
+	choice := promptInt(p, "Enter your choice (1-8): ", 1, 8)
+
+	switch choice {
+	case 1:
+		return "sha1"
+	case 2:
+		return "sha256"
+	case 3:
+		return "sha384"
+	case 4:
+		return "sha512"
+	case 5:
+		return "sha3-256"
+	case 6:
+		return "blake2b"
+	case 7:
+		return "blake2s"
+	case 8:
+		return "blake3"
+	default:
+		return ""
+	}
+}
+
 // GetHMACHashAlgorithm prompts user to select a hash algorithm for HMAC
-func GetHMACHashAlgorithm() string {
+func GetHMACHashAlgorithm(p utils.Prompter) string {
 	fmt.Println("\nSelect Hash Algorithm:")
 	fmt.Println("1. SHA-1")
 	fmt.Println("2. SHA-256")
 	fmt.Println("3. SHA-512")
-	fmt.Println("4. BLAKE2b-256")
-	fmt.Println("5. BLAKE2b-512")
-	fmt.Println("6. BLAKE3")
-	fmt.Println("7. Run Benchmark")
+	fmt.Println("4. SHA3-256")
+	fmt.Println("5. SHA3-512")
+	fmt.Println("6. BLAKE2b-256")
+	fmt.Println("7. BLAKE2b-512")
+	fmt.Println("8. BLAKE2s-256")
+	fmt.Println("9. BLAKE2b (custom digest size)")
+	fmt.Println("10. BLAKE2s (custom digest size)")
+	fmt.Println("11. BLAKE3")
+	fmt.Println("12. Run Benchmark")
 
-	choice := input.GetIntInput("Enter your choice (1-7): ", 1, 7)
+	choice := promptInt(p, "Enter your choice (1-12): ", 1, 12)
 
 	switch choice {
 	case 1:
@@ -243,12 +997,22 @@ func GetHMACHashAlgorithm() string {
 	case 3:
 		return "sha512"
 	case 4:
-		return "blake2b-256"
+		return "sha3-256"
 	case 5:
-		return "blake2b-512"
+		return "sha3-512"
 	case 6:
-		return "blake3"
+		return "blake2b-256"
 	case 7:
+		return "blake2b-512"
+	case 8:
+		return "blake2s-256"
+	case 9:
+		return "blake2b"
+	case 10:
+		return "blake2s"
+	case 11:
+		return "blake3"
+	case 12:
 		return "benchmark"
 	default:
 		fmt.Println("Invalid choice. Defaulting to SHA-256")
@@ -256,15 +1020,32 @@ func GetHMACHashAlgorithm() string {
 	}
 }
 
+// GetBLAKE2DigestSize prompts user for a custom BLAKE2 digest size in bytes
+func GetBLAKE2DigestSize(p utils.Prompter, variant string) int {
+	maxSize := 64
+	if variant == crypto.HashBLAKE2s {
+		maxSize = 32
+	}
+	size := promptInt(p, fmt.Sprintf("Enter digest size in bytes (1-%d, default 32): ", maxSize), 1, maxSize)
+	if size == 0 {
+		return 32
+	}
+	return size
+}
+
 // GetPBKDFAlgorithm prompts user to select a PBKDF algorithm
-func GetPBKDFAlgorithm() string {
+func GetPBKDFAlgorithm(p utils.Prompter) string {
 	fmt.Println("\nSelect PBKDF Algorithm:")
 	fmt.Println("1. PBKDF2 (Password-Based Key Derivation Function 2)")
 	fmt.Println("2. Argon2id (Memory-Hard Function)")
-	fmt.Println("3. Scrypt (Memory-Hard Function)")
-	fmt.Println("4. Run Benchmark on All")
+	fmt.Println("3. Argon2i (Memory-Hard, Side-Channel Resistant)")
+	fmt.Println("4. Scrypt (Memory-Hard Function)")
+	fmt.Println("5. bcrypt (Adaptive Blowfish-Based Hash)")
+	fmt.Println("6. Run Benchmark on All")
+	fmt.Println("7. Verify a Password Against a Stored Value")
+	fmt.Println("8. Compare With a Fast Hash (SHA-256 vs Argon2id)")
 
-	choice := input.GetIntInput("Enter your choice (1-4): ", 1, 4)
+	choice := promptInt(p, "Enter your choice (1-8): ", 1, 8)
 
 	switch choice {
 	case 1:
@@ -272,9 +1053,17 @@ func GetPBKDFAlgorithm() string {
 	case 2:
 		return "argon2id"
 	case 3:
-		return "scrypt"
+		return "argon2i"
 	case 4:
+		return "scrypt"
+	case 5:
+		return "bcrypt"
+	case 6:
 		return "benchmark"
+	case 7:
+		return "verify"
+	case 8:
+		return "compare"
 	default:
 		fmt.Println("Invalid choice. Defaulting to Argon2id")
 		return "argon2id"
@@ -282,13 +1071,16 @@ func GetPBKDFAlgorithm() string {
 }
 
 // GetJWTAlgorithm prompts user to select a JWT algorithm
-func GetJWTAlgorithm() string {
+func GetJWTAlgorithm(p utils.Prompter) string {
 	fmt.Println("\nSelect JWT Algorithm:")
 	fmt.Println("1. HS256 (HMAC with SHA-256)")
 	fmt.Println("2. RS256 (RSA with SHA-256)")
-	fmt.Println("3. EdDSA (Ed25519)")
+	fmt.Println("3. PS256 (RSA-PSS with SHA-256)")
+	fmt.Println("4. EdDSA (Ed25519)")
+	fmt.Println("5. ES256 (ECDSA P-256 with SHA-256)")
+	fmt.Println("6. ES384 (ECDSA P-384 with SHA-384)")
 
-	choice := input.GetIntInput("Enter your choice (1-3): ", 1, 3)
+	choice := promptInt(p, "Enter your choice (1-6): ", 1, 6)
 
 	switch choice {
 	case 1:
@@ -296,7 +1088,13 @@ func GetJWTAlgorithm() string {
 	case 2:
 		return "RS256"
 	case 3:
+		return "PS256"
+	case 4:
 		return "EdDSA"
+	case 5:
+		return "ES256"
+	case 6:
+		return "ES384"
 	default:
 		fmt.Println("Invalid choice. Defaulting to HS256")
 		return "HS256"
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestExportTranscript(t *testing.T) {
+	t.Chdir(t.TempDir())
+	transcript := &crypto.KeyExchangeTranscript{Protocol: "X25519", Participants: []string{"Alice", "Bob"}}
+	export := func(input string) error {
+		menu := NewMenu(NewConsoleDisplay(), &ConsoleInput{
+			scanner: bufio.NewScanner(strings.NewReader(input)),
+			theme:   utils.DefaultTheme,
+		}, nil)
+		var err error
+		captureStdout(t, func() { err = menu.exportTranscript(transcript) })
+		return err
+	}
+	readFile := func(name string) string {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	// An empty path saves under the default name
+	if err := export("2\n\n"); err != nil {
+		t.Fatalf("exportTranscript() error = %v", err)
+	}
+	if !strings.HasPrefix(readFile("x25519_sequence.mmd"), "sequenceDiagram") {
+		t.Error("Expected a Mermaid diagram in x25519_sequence.mmd")
+	}
+
+	// An existing file is kept unless the user agrees to replace it
+	if err := os.WriteFile("x25519_sequence.mmd", []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := export("2\n\n\n"); err != nil {
+		t.Fatalf("exportTranscript() error = %v", err)
+	}
+	if got := readFile("x25519_sequence.mmd"); got != "notes" {
+		t.Errorf("Expected the existing file to be kept, got %q", got)
+	}
+	if err := export("2\nx25519_sequence.mmd\ny\n"); err != nil {
+		t.Fatalf("exportTranscript() error = %v", err)
+	}
+	if !strings.HasPrefix(readFile("x25519_sequence.mmd"), "sequenceDiagram") {
+		t.Error("Expected the file to be replaced after confirming")
+	}
+
+	// The user can pick another path
+	if err := export("3\nexchange.puml\n"); err != nil {
+		t.Fatalf("exportTranscript() error = %v", err)
+	}
+	if !strings.HasPrefix(readFile("exchange.puml"), "@startuml") {
+		t.Error("Expected a PlantUML diagram in exchange.puml")
+	}
+}
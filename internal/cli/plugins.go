@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/config"
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// externalProcessor runs an executable as a processor. The text is written to its stdin and
+// the operation is passed as the last argument; stdout is the result and each stderr line is
+// shown as a step.
+type externalProcessor struct {
+	name    string
+	command string
+	args    []string
+}
+
+// Process runs the plugin command
+func (p *externalProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	cmd := exec.Command(p.command, append(append([]string{}, p.args...), operation)...)
+	cmd.Stdin = strings.NewReader(text)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(stderr.String(), "\n"), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	steps := utils.DataSteps(lines)
+	if err != nil {
+		return "", steps, fmt.Errorf("plugin %s failed: %w", p.name, err)
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), steps, nil
+}
+
+// pluginCreator returns a creator for an external command
+func pluginCreator(name, command string, args []string) ProcessorCreator {
+	return func(_ *config.Config) (crypto.Processor, error) {
+		return &externalProcessor{name: name, command: command, args: args}, nil
+	}
+}
+
+// isPluginExecutable reports whether a directory entry can be run as a plugin
+func isPluginExecutable(info fs.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(info.Name()))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// LoadPlugins registers the commands listed in the plugin config, then every executable in
+// its plugin directory, and returns the labels added. A missing directory is not an error.
+func (f *CryptoProcessorFactory) LoadPlugins(cfg config.PluginsConfig) ([]string, error) {
+	var added []string
+	for _, plugin := range cfg.Commands {
+		if plugin.Name == "" || plugin.Command == "" {
+			return added, fmt.Errorf("plugin entries need a name and a command")
+		}
+		f.RegisterPlugin(plugin.Name, pluginCreator(plugin.Name, plugin.Command, plugin.Args))
+		added = append(added, plugin.Name)
+	}
+
+	if cfg.Dir == "" {
+		return added, nil
+	}
+	entries, err := os.ReadDir(cfg.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return added, nil
+	}
+	if err != nil {
+		return added, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !isPluginExecutable(info) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		f.RegisterPlugin(name, pluginCreator(name, filepath.Join(cfg.Dir, entry.Name()), nil))
+		added = append(added, name)
+	}
+	return added, nil
+}
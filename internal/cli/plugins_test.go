@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/config"
+)
+
+func TestLoadPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin script uses /bin/sh")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"operation: $1\" >&2\ntr a-z A-Z\n"
+	if err := os.WriteFile(filepath.Join(dir, "upper.sh"), []byte(script), 0700); err != nil {
+		t.Fatalf("Failed to write plugin: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.txt"), []byte("not a plugin"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	factory := NewCryptoProcessorFactory()
+	added, err := factory.LoadPlugins(config.PluginsConfig{
+		Dir: dir,
+		Commands: []config.PluginConfig{
+			{Name: "Echo", Command: "cat"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("LoadPlugins() error = %v", err)
+	}
+	if len(added) != 2 || added[0] != "Echo" || added[1] != "upper" {
+		t.Fatalf("Expected plugins [Echo upper], got %v", added)
+	}
+
+	entries := factory.MenuEntries()
+	last := entries[len(entries)-1]
+	if last.ID != pluginIDBase+1 || last.Label != "upper" {
+		t.Fatalf("Expected upper as plugin %d, got %d %q", pluginIDBase+1, last.ID, last.Label)
+	}
+
+	processor, err := factory.CreateProcessor(last.ID)
+	if err != nil {
+		t.Fatalf("CreateProcessor() error = %v", err)
+	}
+	result, steps, err := processor.Process("hello", "encrypt")
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if result != "HELLO" {
+		t.Errorf("Expected HELLO, got %q", result)
+	}
+	if len(steps) != 1 || steps[0].Text != "operation: encrypt" {
+		t.Errorf("Expected the stderr line as a step, got %v", steps)
+	}
+
+	display := NewConsoleDisplay()
+	display.SetMenuEntries(entries)
+	output := captureStdout(t, display.ShowMenu)
+	if !strings.Contains(output, "Plugins:") || !strings.Contains(output, "upper") {
+		t.Errorf("Expected the plugins in the menu, got:\n%s", output)
+	}
+}
+
+// captureStdout returns what f writes to stdout
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+	outputCh := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		outputCh <- string(data)
+	}()
+
+	f()
+	w.Close()
+	os.Stdout = oldStdout
+	return <-outputCh
+}
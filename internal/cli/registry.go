@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/abdorrahmani/cryptolens/internal/benchmark"
+)
+
+// MenuEntry is one line of the main menu. Processor entries have a Creator; built-in tools
+// such as the benchmark have an Action instead.
+type MenuEntry struct {
+	ID      int
+	Label   string
+	Color   string
+	Creator ProcessorCreator
+	Action  func(m *Menu) error
+	Exit    bool
+
+	// NoCompare explains why a processor entry is left out of the comparison mode
+	NoCompare string
+	// CompareVariants splits a processor entry into several comparison targets
+	CompareVariants func() []compareTarget
+}
+
+// MenuRegistry maps main menu IDs to their entries
+type MenuRegistry map[int]MenuEntry
+
+// mainMenu lists the built-in main menu in display order; IDs are assigned from 1. Adding a
+// processor only takes a line here, plus any extra prompts it needs in processChoice.
+var mainMenu = []MenuEntry{
+	{Label: "Base64 Encoding/Decoding", Color: "yellow", Creator: createBase64Processor},
+	{Label: "Caesar Cipher", Color: "yellow", Creator: createCaesarProcessor},
+	{Label: "AES Encryption/Decryption", Color: "yellow", Creator: createAESProcessor},
+	{Label: "Hashing (SHA-1/2/3, BLAKE2b/s, BLAKE3)", Color: "yellow", Creator: createHashProcessor, CompareVariants: hashCompareVariants},
+	{Label: "RSA Encryption/Decryption", Color: "yellow", Creator: createRSAProcessor},
+	{Label: "HMAC (Hash-based Message Authentication)", Color: "yellow", Creator: createHMACProcessor},
+	{Label: "PBKDF (Password-Based Key Derivation)", Color: "yellow", Creator: createPBKDFProcessor},
+	{Label: "Diffie-Hellman Key Exchange", Color: "yellow", Creator: createDHProcessor,
+		NoCompare: "a key exchange between two parties, not a text transformation"},
+	{Label: "X25519 Key Exchange", Color: "yellow", Creator: createX25519Processor,
+		NoCompare: "a key exchange between two parties, not a text transformation"},
+	{Label: "JWT (JSON Web Token)", Color: "yellow", Creator: createJWTProcessor},
+	{Label: "ChaCha20-Poly1305 Encryption", Color: "yellow", Creator: createChaCha20Poly1305Processor,
+		NoCompare: "prompts for a key and associated data while running"},
+	{Label: "Blowfish Encryption (Legacy)", Color: "yellow", Creator: createBlowfishProcessor},
+	{Label: "Triple DES Encryption (Legacy, Deprecated)", Color: "yellow", Creator: createTripleDESProcessor},
+	{Label: "RC4 Stream Cipher (Insecure, Educational Only)", Color: "yellow", Creator: createRC4Processor},
+	{Label: "Signature Verification Matrix", Color: "yellow", Creator: createSignatureMatrixProcessor,
+		NoCompare: "signs with every scheme itself and reports a matrix, not one output"},
+	{Label: "AES-GCM-SIV Encryption (Nonce-Misuse Resistant)", Color: "yellow", Creator: createAESGCMSIVProcessor},
+	{Label: "One-Time Pad (Perfect Secrecy)", Color: "yellow", Creator: createOneTimePadProcessor},
+	{Label: "Checksums (CRC32, Adler-32) - Not Cryptographic", Color: "yellow", Creator: createChecksumProcessor},
+	{Label: "Password Strength Estimator", Color: "yellow", Creator: createPasswordStrengthProcessor},
+	{Label: "Salted vs Unsalted Password Hashing", Color: "yellow", Creator: createSaltingProcessor},
+	{Label: "ROT13/ROT47", Color: "yellow", Creator: createROTProcessor},
+	{Label: "HKDF (Extract-and-Expand Key Derivation)", Color: "yellow", Creator: createHKDFProcessor},
+	{Label: "Merkle Tree and Inclusion Proofs", Color: "yellow", Creator: createMerkleTreeProcessor},
+	{Label: "Feistel Network (Toy Cipher, Insecure)", Color: "yellow", Creator: createFeistelProcessor},
+	{Label: "Avalanche Effect (One Bit Flip)", Color: "yellow", Creator: createAvalancheProcessor},
+	{Label: "Base-N Encoding (Base16, Base58, Base62, Base85)", Color: "yellow", Creator: createBaseNProcessor},
+	{Label: "TOTP One-Time Passwords (otpauth:// URI)", Color: "yellow", Creator: createTOTPProcessor,
+		NoCompare: "takes a Base32 secret and returns a provisioning URI, not a transformation of the text"},
+	{Label: "Symmetric Cipher Benchmark (AES vs ChaCha20)", Color: "yellow", Action: (*Menu).runSymmetricBenchmark},
+	{Label: "Unknown Blob Diagnostic", Color: "yellow", Action: (*Menu).runBlobDiagnostic},
+	{Label: "Key Challenge (Learning Game)", Color: "yellow", Action: (*Menu).runKeyChallenge},
+	{Label: "Attack Simulations", Color: "red", Action: (*Menu).handleAttackMenu},
+	{Label: "Reset Keys", Color: "yellow", Action: (*Menu).resetKeys},
+	{Label: "Compare Algorithms", Color: "yellow", Action: (*Menu).runCompare},
+	{Label: "Exit", Color: "red", Exit: true},
+}
+
+// filterMenuEntries returns the entries whose label contains every word of query. Case and
+// punctuation are ignored, so "chacha20poly" matches "ChaCha20-Poly1305".
+func filterMenuEntries(entries []MenuEntry, query string) []MenuEntry {
+	words := strings.Fields(query)
+	var matches []MenuEntry
+	for _, entry := range entries {
+		label := normalizeMenuText(entry.Label)
+		matched := len(words) > 0
+		for _, word := range words {
+			if !strings.Contains(label, normalizeMenuText(word)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// normalizeMenuText lower-cases s and drops everything but letters and digits
+func normalizeMenuText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// runSymmetricBenchmark runs the symmetric cipher benchmark and shows its report
+func (m *Menu) runSymmetricBenchmark() error {
+	result, steps, err := m.interruptible(benchmark.RunSymmetricBenchmarkContext)
+	if err != nil {
+		return err
+	}
+	m.display.ShowResult(result, steps)
+	return nil
+}
+
+// resetKeys resets the keys in the menu's keys directory
+func (m *Menu) resetKeys() error {
+	return m.ResetKeys(m.keysDir)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMenuEntries(t *testing.T) {
+	t.Chdir(t.TempDir())
+	factory := NewCryptoProcessorFactory()
+	entries := factory.MenuEntries()
+	if len(entries) != len(mainMenu) {
+		t.Fatalf("Expected %d entries, got %d", len(mainMenu), len(entries))
+	}
+
+	for i, entry := range entries {
+		if entry.ID != i+1 {
+			t.Errorf("Expected entry %q to have ID %d, got %d", entry.Label, i+1, entry.ID)
+		}
+		if entry.Creator == nil && entry.Action == nil && !entry.Exit {
+			t.Errorf("Entry %q has nothing to run", entry.Label)
+		}
+		if entry.Creator != nil {
+			if _, err := factory.CreateProcessor(entry.ID); err != nil {
+				t.Errorf("CreateProcessor(%d) error = %v", entry.ID, err)
+			}
+		} else if _, err := factory.CreateProcessor(entry.ID); err == nil {
+			t.Errorf("Expected CreateProcessor(%d) to fail for %q", entry.ID, entry.Label)
+		}
+	}
+
+	last := entries[len(entries)-1]
+	if !last.Exit {
+		t.Errorf("Expected Exit to be the last entry, got %q", last.Label)
+	}
+
+	output := captureStdout(t, NewConsoleDisplay().ShowMenu)
+	for _, entry := range entries {
+		if !strings.Contains(output, entry.Label) {
+			t.Errorf("Expected %q in the menu", entry.Label)
+		}
+	}
+	if !strings.Contains(output, "(1-34)") {
+		t.Errorf("Expected the prompt to end at the Exit ID, got:\n%s", output)
+	}
+}
+
+func TestFilterMenuEntries(t *testing.T) {
+	entries := NewCryptoProcessorFactory().MenuEntries()
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"chacha", []string{"ChaCha20-Poly1305 Encryption", "Symmetric Cipher Benchmark (AES vs ChaCha20)"}},
+		{"chacha20poly", []string{"ChaCha20-Poly1305 Encryption"}},
+		{"KEY exchange", []string{"Diffie-Hellman Key Exchange", "X25519 Key Exchange"}},
+		{"enigma", nil},
+	}
+	for _, tt := range tests {
+		matches := filterMenuEntries(entries, tt.query)
+		var got []string
+		for _, entry := range matches {
+			got = append(got, entry.Label)
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("filterMenuEntries(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+)
+
+// SessionLogEntry is one operation recorded in the session log. The raw input is never stored.
+// InputHMAC is keyed with a random key that only lives for the session, so equal inputs can be
+// matched within one session, but a logged password cannot be found with a lookup table.
+// Outputs may be plaintexts or derived keys, so they are only kept when the user opts in.
+type SessionLogEntry struct {
+	Time        time.Time `json:"time"`
+	Algorithm   string    `json:"algorithm"`
+	Operation   string    `json:"operation"`
+	InputLength int       `json:"inputLength"`
+	InputHMAC   string    `json:"inputHmac,omitempty"`
+	Output      string    `json:"output,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// SessionLog appends one JSON object per operation to a writer (JSON Lines)
+type SessionLog struct {
+	w              io.Writer
+	includeOutputs bool
+	key            []byte
+	now            func() time.Time
+}
+
+// NewSessionLog creates a session log writing to w; includeOutputs also records each result
+func NewSessionLog(w io.Writer, includeOutputs bool) *SessionLog {
+	key := make([]byte, sha256.Size)
+	rand.Read(key) // never fails; crypto/rand crashes the program instead
+	return &SessionLog{w: w, includeOutputs: includeOutputs, key: key, now: time.Now}
+}
+
+// OpenSessionLog opens path for appending, creating it readable only by the owner
+func OpenSessionLog(path string, includeOutputs bool) (*SessionLog, *os.File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open session log: %w", err)
+	}
+	return NewSessionLog(file, includeOutputs), file, nil
+}
+
+// Record writes an entry for one Process call; a nil log records nothing
+func (l *SessionLog) Record(processor crypto.Processor, operation, input, output string, processErr error) error {
+	if l == nil {
+		return nil
+	}
+	entry := SessionLogEntry{
+		Time:        l.now().UTC(),
+		Algorithm:   processorName(processor),
+		Operation:   operation,
+		InputLength: len(input),
+	}
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(input))
+	entry.InputHMAC = hex.EncodeToString(mac.Sum(nil))
+	if l.includeOutputs {
+		entry.Output = output
+	}
+	if processErr != nil {
+		entry.Error = processErr.Error()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode session log entry: %w", err)
+	}
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+	return nil
+}
+
+// ReadSessionLog parses a session log, skipping blank lines
+func ReadSessionLog(r io.Reader) ([]SessionLogEntry, error) {
+	var entries []SessionLogEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry SessionLogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("invalid session log entry on line %d: %w", n, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session log: %w", err)
+	}
+	return entries, nil
+}
+
+// processorName returns a short name for a processor's type, such as "AES" or "TimingAttack"
+func processorName(processor crypto.Processor) string {
+	name := fmt.Sprintf("%T", processor)
+	name = name[strings.LastIndex(name, ".")+1:]
+	return strings.TrimSuffix(name, "Processor")
+}
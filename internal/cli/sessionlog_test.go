@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+)
+
+func TestSessionLog(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewSessionLog(&buf, true)
+	log.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	if err := log.Record(crypto.NewCaesarProcessor(), crypto.OperationEncrypt, "secret", "vhfuhw", nil); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := log.Record(crypto.NewAESProcessor(), crypto.OperationDecrypt, "bad", "", errors.New("invalid input")); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if strings.Contains(buf.String(), `"secret"`) {
+		t.Errorf("The raw input must not be logged: %s", buf.String())
+	}
+
+	entries, err := ReadSessionLog(&buf)
+	if err != nil {
+		t.Fatalf("ReadSessionLog failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	first := entries[0]
+	if first.Algorithm != "Caesar" || first.Operation != crypto.OperationEncrypt || first.Output != "vhfuhw" ||
+		first.InputLength != 6 || len(first.InputHMAC) != 64 || !first.Time.Equal(log.now()) {
+		t.Errorf("Unexpected first entry %+v", first)
+	}
+	if entries[1].Algorithm != "AES" || entries[1].Error != "invalid input" {
+		t.Errorf("Unexpected second entry %+v", entries[1])
+	}
+}
+
+func TestSessionLog_Defaults(t *testing.T) {
+	// Outputs are left out unless requested, and the same input gets the same keyed digest
+	// within a session but a different one in the next session
+	var buf bytes.Buffer
+	log := NewSessionLog(&buf, false)
+	for i := 0; i < 2; i++ {
+		if err := log.Record(crypto.NewCaesarProcessor(), crypto.OperationEncrypt, "secret", "vhfuhw", nil); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	if err := NewSessionLog(&buf, false).Record(crypto.NewCaesarProcessor(), crypto.OperationEncrypt, "secret", "vhfuhw", nil); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	entries, err := ReadSessionLog(&buf)
+	if err != nil {
+		t.Fatalf("ReadSessionLog failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Output != "" || entry.InputLength != 6 {
+			t.Errorf("Expected no output by default, got %+v", entry)
+		}
+	}
+	if entries[0].InputHMAC != entries[1].InputHMAC {
+		t.Error("Expected equal inputs to match within a session")
+	}
+	if entries[0].InputHMAC == entries[2].InputHMAC {
+		t.Error("Expected a fresh HMAC key for each session")
+	}
+	// An unsalted SHA-256 of the input must not appear
+	if strings.Contains(buf.String(), "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b") {
+		t.Error("The session log must not contain a plain SHA-256 of the input")
+	}
+
+	// A nil log records nothing
+	var nilLog *SessionLog
+	if err := nilLog.Record(crypto.NewCaesarProcessor(), crypto.OperationEncrypt, "secret", "", nil); err != nil {
+		t.Errorf("Record on a nil log failed: %v", err)
+	}
+	if _, err := ReadSessionLog(strings.NewReader("{not json}\n")); err == nil {
+		t.Error("Expected an error for an invalid entry")
+	}
+}
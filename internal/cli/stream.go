@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/abdorrahmani/cryptolens/internal/config"
+	"github.com/abdorrahmani/cryptolens/internal/crypto"
+)
+
+// StreamFile encrypts or decrypts the file at inPath with chunked ChaCha20-Poly1305 and writes
+// the result to outPath, or to stdout when outPath is empty. A file output is written to a
+// temporary file first, so a stream that fails authentication leaves no partial plaintext.
+func StreamFile(cfg *config.Config, inPath, outPath, operation string) (string, error) {
+	processor, err := createChaCha20Poly1305Processor(cfg)
+	if err != nil {
+		return "", err
+	}
+	streamer, ok := processor.(crypto.StreamProcessor)
+	if !ok {
+		return "", fmt.Errorf("processor does not support streaming")
+	}
+
+	in, err := os.Open(inPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open input: %w", err)
+	}
+	defer in.Close()
+
+	var out io.Writer = os.Stdout
+	var tmp *os.File
+	if outPath != "" {
+		tmp, err = os.CreateTemp(filepath.Dir(outPath), ".cryptolens-stream-*")
+		if err != nil {
+			return "", fmt.Errorf("failed to create output: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		out = tmp
+	}
+
+	n, err := streamer.StreamProcess(in, out, operation)
+	if err != nil {
+		return "", err
+	}
+	if tmp == nil {
+		return fmt.Sprintf("Processed %d bytes", n), nil
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write output: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), outPath); err != nil {
+		return "", fmt.Errorf("failed to write output: %w", err)
+	}
+	return fmt.Sprintf("Processed %d bytes into %s", n, outPath), nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,6 +14,7 @@ import (
 type Provider interface {
 	GetAESConfig() AESConfig
 	GetChaCha20Poly1305Config() ChaCha20Poly1305Config
+	GetAESGCMSIVConfig() AESGCMSIVConfig
 	GetBase64Config() Base64Config
 	GetCaesarConfig() CaesarConfig
 	GetRSAConfig() RSAConfig
@@ -20,7 +23,13 @@ type Provider interface {
 	GetDHConfig() DHConfig
 	GetX25519Config() X25519Config
 	GetJWTConfig() JWTConfig
+	GetBlowfishConfig() BlowfishConfig
+	GetTripleDESConfig() TripleDESConfig
+	GetHashConfig() HashConfig
+	GetTOTPConfig() TOTPConfig
+	GetAttackConfig() AttackConfig
 	GetGeneralConfig() GeneralConfig
+	GetPluginsConfig() PluginsConfig
 	Save(path string) error
 }
 
@@ -28,14 +37,26 @@ type Provider interface {
 type AESConfig struct {
 	DefaultKeySize int    `yaml:"defaultKeySize"`
 	KeyFile        string `yaml:"keyFile"`
+	MaxKeyAgeDays  int    `yaml:"maxKeyAgeDays"`
+	OpenSSLCompat  bool   `yaml:"opensslCompat"` // Use the openssl enc Salted__ format with a passphrase
 }
 
 // ChaCha20Poly1305Config represents ChaCha20-Poly1305 specific configuration
 type ChaCha20Poly1305Config struct {
-	KeySize   int    `yaml:"keySize"`
-	KeyFile   string `yaml:"keyFile"`
-	NonceSize int    `yaml:"nonceSize"`
-	TagSize   int    `yaml:"tagSize"`
+	KeySize       int    `yaml:"keySize"`
+	KeyFile       string `yaml:"keyFile"`
+	NonceSize     int    `yaml:"nonceSize"`
+	TagSize       int    `yaml:"tagSize"`
+	MaxKeyAgeDays int    `yaml:"maxKeyAgeDays"`
+	ChunkSize     int    `yaml:"chunkSize"` // Plaintext bytes per chunk when streaming files
+	AAD           string `yaml:"aad"`       // Additional authenticated data; empty prompts on a terminal
+}
+
+// AESGCMSIVConfig represents AES-GCM-SIV specific configuration
+type AESGCMSIVConfig struct {
+	KeySize       int    `yaml:"keySize"`
+	KeyFile       string `yaml:"keyFile"`
+	MaxKeyAgeDays int    `yaml:"maxKeyAgeDays"`
 }
 
 // Base64Config represents Base64-specific configuration
@@ -45,7 +66,8 @@ type Base64Config struct {
 
 // CaesarConfig represents Caesar cipher-specific configuration
 type CaesarConfig struct {
-	DefaultShift int `yaml:"defaultShift"`
+	DefaultShift int    `yaml:"defaultShift"`
+	Alphabet     string `yaml:"alphabet"` // latin or unicode
 }
 
 // RSAConfig represents RSA-specific configuration
@@ -53,6 +75,9 @@ type RSAConfig struct {
 	KeySize        int    `yaml:"keySize"`
 	PublicKeyFile  string `yaml:"publicKeyFile"`
 	PrivateKeyFile string `yaml:"privateKeyFile"`
+	Padding        string `yaml:"padding"`
+	Mode           string `yaml:"mode"`
+	KeyFormat      string `yaml:"keyFormat"` // Format for generated keys: pkcs1 or pkcs8
 }
 
 // HMACConfig represents HMAC-specific configuration
@@ -60,6 +85,8 @@ type HMACConfig struct {
 	KeySize       int    `yaml:"keySize"`
 	KeyFile       string `yaml:"keyFile"`
 	HashAlgorithm string `yaml:"hashAlgorithm"`
+	DigestSize    int    `yaml:"digestSize"`
+	MaxKeyAgeDays int    `yaml:"maxKeyAgeDays"`
 }
 
 // PBKDFConfig represents PBKDF-specific configuration
@@ -69,6 +96,8 @@ type PBKDFConfig struct {
 	Memory              uint32   `yaml:"memory"`
 	Threads             uint8    `yaml:"threads"`
 	KeyLength           uint32   `yaml:"keyLength"`
+	Cost                int      `yaml:"cost"`
+	Salt                string   `yaml:"salt"`
 	AvailableAlgorithms []string `yaml:"availableAlgorithms"`
 }
 
@@ -76,6 +105,7 @@ type PBKDFConfig struct {
 type DHConfig struct {
 	KeySize          int    `yaml:"keySize"`
 	Generator        int    `yaml:"generator"`
+	Group            string `yaml:"group"`
 	PrimeFile        string `yaml:"primeFile"`
 	PrivateKeyFile   string `yaml:"privateKeyFile"`
 	PublicKeyFile    string `yaml:"publicKeyFile"`
@@ -98,18 +128,97 @@ type JWTConfig struct {
 	Ed25519PrivateKeyFile string   `yaml:"ed25519PrivateKeyFile"`
 	Ed25519PublicKeyFile  string   `yaml:"ed25519PublicKeyFile"`
 	AvailableAlgorithms   []string `yaml:"availableAlgorithms"`
+	Issuer                string   `yaml:"issuer"`
+	Audience              string   `yaml:"audience"`
+	Leeway                int      `yaml:"leeway"`
+	RequireNotBefore      bool     `yaml:"requireNotBefore"`
+	VerificationKeyFile   string   `yaml:"verificationKeyFile"`
+	VerificationKeyPEM    string   `yaml:"verificationKeyPEM"`
+}
+
+// BlowfishConfig represents Blowfish-specific configuration
+type BlowfishConfig struct {
+	KeySize int    `yaml:"keySize"`
+	KeyFile string `yaml:"keyFile"`
+}
+
+// TripleDESConfig represents Triple-DES specific configuration
+type TripleDESConfig struct {
+	KeyingOption int    `yaml:"keyingOption"`
+	KeyFile      string `yaml:"keyFile"`
+}
+
+// HashConfig represents settings for the general hashing processor
+type HashConfig struct {
+	Algorithm  string `yaml:"algorithm"`
+	DigestSize int    `yaml:"digestSize"`
+}
+
+// TOTPConfig represents settings for the TOTP processor and its otpauth:// URI
+type TOTPConfig struct {
+	Issuer    string `yaml:"issuer"`    // Service name shown by the authenticator app
+	Account   string `yaml:"account"`   // Account name, usually an email address
+	Algorithm string `yaml:"algorithm"` // HMAC hash: sha1, sha256, or sha512
+	Digits    int    `yaml:"digits"`
+	Period    int    `yaml:"period"` // Seconds each code is valid
+}
+
+// AttackConfig represents settings for the attack simulations
+type AttackConfig struct {
+	Scorer               string        `yaml:"scorer"`
+	BruteForceIterations int           `yaml:"bruteForceIterations"` // PBKDF2 iterations protecting the brute-force target
+	TimingIterations     int           `yaml:"timingIterations"`     // Measurements per guess in the timing attack
+	TimingDelayPerByte   time.Duration `yaml:"timingDelayPerByte"`   // Simulated work per matching byte, e.g. "1ms"
+	ECBKeySize           int           `yaml:"ecbKeySize"`
+	NonceReuseKeySize    int           `yaml:"nonceReuseKeySize"`
+	WordlistFile         string        `yaml:"wordlistFile"` // Brute-force dictionary, one password per line; empty uses the built-in list
 }
 
 // GeneralConfig represents general application settings
 type GeneralConfig struct {
-	LogLevel string `yaml:"logLevel"`
-	Debug    bool   `yaml:"debug"`
+	LogLevel   string `yaml:"logLevel"`
+	Debug      bool   `yaml:"debug"`
+	MaxWorkers int    `yaml:"maxWorkers"` // Goroutine cap for parallel work; 0 means one per CPU
+	Verbose    *bool  `yaml:"verbose"`    // Show each processor's step-by-step explanation; unset means true
+	// Encoding of binary results from AES, RSA, ChaCha20-Poly1305, and HMAC: hex, base64, or base64url
+	OutputEncoding string `yaml:"outputEncoding"`
+	// Pause long step-by-step output after each screenful; only applies when stdin and stdout are terminals
+	Pager bool `yaml:"pager"`
+	// JSON Lines file recording each operation; empty turns the session log off
+	SessionLog string `yaml:"sessionLog"`
+	// Also record each result in the session log; results can be decrypted plaintexts or derived keys
+	SessionLogOutputs bool `yaml:"sessionLogOutputs"`
+	// Stop benchmarks, attack simulations and comparisons after this long and show partial results; 0 means no limit
+	MaxRunDuration time.Duration `yaml:"maxRunDuration"`
+	// Draw each result as a terminal QR code as well; needs a build with -tags qr
+	QRCode bool `yaml:"qrCode"`
+	// Copy each result to the system clipboard; a notice is shown when no clipboard is available
+	Clipboard bool `yaml:"clipboard"`
+}
+
+// IsVerbose reports whether results are shown with their processing steps
+func (g GeneralConfig) IsVerbose() bool {
+	return g.Verbose == nil || *g.Verbose
+}
+
+// PluginConfig describes an external command added to the menu as a processor
+type PluginConfig struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+}
+
+// PluginsConfig lists the external processors to add to the menu
+type PluginsConfig struct {
+	Dir      string         `yaml:"dir"` // Every executable in this directory becomes a plugin
+	Commands []PluginConfig `yaml:"commands"`
 }
 
 // Config implements Provider interface
 type Config struct {
 	AES              AESConfig              `yaml:"aes"`
 	ChaCha20Poly1305 ChaCha20Poly1305Config `yaml:"chacha20poly1305"`
+	AESGCMSIV        AESGCMSIVConfig        `yaml:"aesgcmsiv"`
 	Base64           Base64Config           `yaml:"base64"`
 	Caesar           CaesarConfig           `yaml:"caesar"`
 	RSA              RSAConfig              `yaml:"rsa"`
@@ -118,7 +227,13 @@ type Config struct {
 	DH               DHConfig               `yaml:"dh"`
 	X25519           X25519Config           `yaml:"x25519"`
 	JWT              JWTConfig              `yaml:"jwt"`
+	Blowfish         BlowfishConfig         `yaml:"blowfish"`
+	TripleDES        TripleDESConfig        `yaml:"tripledes"`
+	Hash             HashConfig             `yaml:"hash"`
+	TOTP             TOTPConfig             `yaml:"totp"`
+	Attack           AttackConfig           `yaml:"attack"`
 	General          GeneralConfig          `yaml:"general"`
+	Plugins          PluginsConfig          `yaml:"plugins"`
 }
 
 // GetAESConfig returns the AES configuration
@@ -131,6 +246,11 @@ func (c *Config) GetChaCha20Poly1305Config() ChaCha20Poly1305Config {
 	return c.ChaCha20Poly1305
 }
 
+// GetAESGCMSIVConfig returns the AES-GCM-SIV configuration
+func (c *Config) GetAESGCMSIVConfig() AESGCMSIVConfig {
+	return c.AESGCMSIV
+}
+
 // GetBase64Config returns the Base64 configuration
 func (c *Config) GetBase64Config() Base64Config {
 	return c.Base64
@@ -171,6 +291,31 @@ func (c *Config) GetJWTConfig() JWTConfig {
 	return c.JWT
 }
 
+// GetBlowfishConfig returns the Blowfish configuration
+func (c *Config) GetBlowfishConfig() BlowfishConfig {
+	return c.Blowfish
+}
+
+// GetHashConfig returns the hashing configuration
+func (c *Config) GetHashConfig() HashConfig {
+	return c.Hash
+}
+
+// GetTOTPConfig returns the TOTP configuration
+func (c *Config) GetTOTPConfig() TOTPConfig {
+	return c.TOTP
+}
+
+// GetTripleDESConfig returns the Triple-DES configuration
+func (c *Config) GetTripleDESConfig() TripleDESConfig {
+	return c.TripleDES
+}
+
+// GetAttackConfig returns the attack simulation configuration
+func (c *Config) GetAttackConfig() AttackConfig {
+	return c.Attack
+}
+
 // GetGeneralConfig returns the general configuration
 func (c *Config) GetGeneralConfig() GeneralConfig {
 	return c.General
@@ -178,9 +323,9 @@ func (c *Config) GetGeneralConfig() GeneralConfig {
 
 // Save saves the configuration to the specified file
 func (c *Config) Save(path string) error {
-	data, err := yaml.Marshal(c)
+	data, err := c.Marshal()
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
 
 	if err := os.WriteFile(path, data, 0600); err != nil {
@@ -190,15 +335,62 @@ func (c *Config) Save(path string) error {
 	return nil
 }
 
+// GetPluginsConfig returns the plugin configuration
+func (c *Config) GetPluginsConfig() PluginsConfig {
+	return c.Plugins
+}
+
+// Marshal returns the configuration as YAML
+func (c *Config) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// DefaultConfigPath returns ~/.cryptolens/config.yaml
+func DefaultConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".cryptolens", "config.yaml"), nil
+}
+
+// InitConfig writes a default config file to configPath, or the default path when empty.
+// An existing file is only replaced when force is set. It returns the path written.
+func InitConfig(configPath string, force bool) (string, error) {
+	if configPath == "" {
+		defaultPath, err := DefaultConfigPath()
+		if err != nil {
+			return "", err
+		}
+		configPath = defaultPath
+	}
+	if _, err := os.Stat(configPath); err == nil && !force {
+		return "", fmt.Errorf("config file already exists: %s (use --force to overwrite)", configPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	config := createDefaultConfig()
+	if err := SaveConfig(configPath, portableKeyPaths(config, filepath.Dir(config.AES.KeyFile))); err != nil {
+		return "", err
+	}
+	return configPath, nil
+}
+
 // LoadConfig loads the configuration from the specified file
 func LoadConfig(configPath string) (*Config, error) {
 	// If no config path is provided, use default
 	if configPath == "" {
-		homeDir, err := os.UserHomeDir()
+		defaultPath, err := DefaultConfigPath()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get user home directory: %w", err)
+			return nil, err
 		}
-		configPath = filepath.Join(homeDir, ".cryptolens", "config.yaml")
+		configPath = defaultPath
 	}
 
 	// Create config directory if it doesn't exist
@@ -211,9 +403,19 @@ func LoadConfig(configPath string) (*Config, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		// Create default config
 		config := createDefaultConfig()
-		if err := SaveConfig(configPath, config); err != nil {
+		if err := SaveConfig(configPath, portableKeyPaths(config, filepath.Dir(config.AES.KeyFile))); err != nil {
 			return nil, fmt.Errorf("failed to create default config: %w", err)
 		}
+		// Overrides apply after saving so ephemeral values never reach the file
+		if err := applyEnvOverrides(config, os.LookupEnv); err != nil {
+			return nil, err
+		}
+		if err := setDefaults(config); err != nil {
+			return nil, err
+		}
+		if err := validate(config); err != nil {
+			return nil, err
+		}
 		return config, nil
 	}
 
@@ -229,24 +431,52 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// Overrides apply before defaulting, so an override of zero still gets the default
+	if err := applyEnvOverrides(&config, os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if err := setDefaults(&config); err != nil {
+		return nil, err
+	}
+	if err := validate(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// setDefaults fills in every value the config leaves unset and resolves the key paths
+// against the keys directory next to the executable
+func setDefaults(config *Config) error {
 	// Get the project root directory (where the executable is)
 	execPath, err := os.Executable()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get executable path: %w", err)
+		return fmt.Errorf("failed to get executable path: %w", err)
 	}
 	projectRoot := filepath.Dir(execPath)
 
 	// Create keys directory in project root
 	keysDir := filepath.Join(projectRoot, "keys")
 	if err := os.MkdirAll(keysDir, 0700); err != nil {
-		return nil, fmt.Errorf("failed to create keys directory: %w", err)
+		return fmt.Errorf("failed to create keys directory: %w", err)
 	}
 
-	// Update key paths to use project root
-	config.RSA.PublicKeyFile = filepath.Join(keysDir, "rsa_public.pem")
-	config.RSA.PrivateKeyFile = filepath.Join(keysDir, "rsa_private.pem")
-	config.AES.KeyFile = filepath.Join(keysDir, "aes_key.bin")
-	config.HMAC.KeyFile = filepath.Join(keysDir, "hmac_key.bin")
+	// Resolve key paths against the keys directory, keeping user-specified locations
+	config.RSA.PublicKeyFile = keyPath(keysDir, config.RSA.PublicKeyFile, "rsa_public.pem")
+	config.RSA.PrivateKeyFile = keyPath(keysDir, config.RSA.PrivateKeyFile, "rsa_private.pem")
+	if config.RSA.Padding == "" {
+		config.RSA.Padding = "oaep"
+	}
+	if config.RSA.Mode == "" {
+		config.RSA.Mode = "direct"
+	}
+	if config.RSA.KeyFormat == "" {
+		config.RSA.KeyFormat = "pkcs1"
+	}
+	if config.AES.DefaultKeySize == 0 {
+		config.AES.DefaultKeySize = 256
+	}
+	config.AES.KeyFile = keyPath(keysDir, config.AES.KeyFile, "aes_key.bin")
+	config.HMAC.KeyFile = keyPath(keysDir, config.HMAC.KeyFile, "hmac_key.bin")
 
 	// Ensure HMAC config has default values if not set
 	if config.HMAC.KeySize == 0 {
@@ -257,49 +487,201 @@ func LoadConfig(configPath string) (*Config, error) {
 	}
 
 	// Set DH defaults
-	config.DH.KeySize = 2048
-	config.DH.Generator = 2
-	config.DH.PrimeFile = filepath.Join(keysDir, "dh_prime.bin")
-	config.DH.PrivateKeyFile = filepath.Join(keysDir, "dh_private.bin")
-	config.DH.PublicKeyFile = filepath.Join(keysDir, "dh_public.bin")
-	config.DH.SharedSecretFile = filepath.Join(keysDir, "dh_shared.bin")
+	if config.DH.KeySize == 0 {
+		config.DH.KeySize = 2048
+	}
+	if config.DH.Generator == 0 {
+		config.DH.Generator = 2
+	}
+	if config.DH.Group == "" {
+		config.DH.Group = "modp2048"
+	}
+	config.DH.PrimeFile = keyPath(keysDir, config.DH.PrimeFile, "dh_prime.bin")
+	config.DH.PrivateKeyFile = keyPath(keysDir, config.DH.PrivateKeyFile, "dh_private.bin")
+	config.DH.PublicKeyFile = keyPath(keysDir, config.DH.PublicKeyFile, "dh_public.bin")
+	config.DH.SharedSecretFile = keyPath(keysDir, config.DH.SharedSecretFile, "dh_shared.bin")
 
 	// Set X25519 defaults
-	config.X25519.PrivateKeyFile = filepath.Join(keysDir, "x25519_private.bin")
-	config.X25519.PublicKeyFile = filepath.Join(keysDir, "x25519_public.bin")
-	config.X25519.SharedSecretFile = filepath.Join(keysDir, "x25519_shared.bin")
+	config.X25519.PrivateKeyFile = keyPath(keysDir, config.X25519.PrivateKeyFile, "x25519_private.bin")
+	config.X25519.PublicKeyFile = keyPath(keysDir, config.X25519.PublicKeyFile, "x25519_public.bin")
+	config.X25519.SharedSecretFile = keyPath(keysDir, config.X25519.SharedSecretFile, "x25519_shared.bin")
 
 	// Set JWT defaults
-	config.JWT.Algorithm = "HS256"
-	config.JWT.KeyFile = filepath.Join(keysDir, "jwt_key.bin")
-	config.JWT.RSAPrivateKeyFile = filepath.Join(keysDir, "jwt_rsa_private.pem")
-	config.JWT.RSAPublicKeyFile = filepath.Join(keysDir, "jwt_rsa_public.pem")
-	config.JWT.Ed25519PrivateKeyFile = filepath.Join(keysDir, "jwt_ed25519_private.bin")
-	config.JWT.Ed25519PublicKeyFile = filepath.Join(keysDir, "jwt_ed25519_public.bin")
-	config.JWT.AvailableAlgorithms = []string{"HS256", "RS256", "EdDSA"}
+	if config.JWT.Algorithm == "" {
+		config.JWT.Algorithm = "HS256"
+	}
+	config.JWT.KeyFile = keyPath(keysDir, config.JWT.KeyFile, "jwt_key.bin")
+	config.JWT.RSAPrivateKeyFile = keyPath(keysDir, config.JWT.RSAPrivateKeyFile, "jwt_rsa_private.pem")
+	config.JWT.RSAPublicKeyFile = keyPath(keysDir, config.JWT.RSAPublicKeyFile, "jwt_rsa_public.pem")
+	config.JWT.Ed25519PrivateKeyFile = keyPath(keysDir, config.JWT.Ed25519PrivateKeyFile, "jwt_ed25519_private.bin")
+	config.JWT.Ed25519PublicKeyFile = keyPath(keysDir, config.JWT.Ed25519PublicKeyFile, "jwt_ed25519_public.bin")
+	if len(config.JWT.AvailableAlgorithms) == 0 {
+		config.JWT.AvailableAlgorithms = []string{"HS256", "RS256", "PS256", "EdDSA", "ES256", "ES384"}
+	}
 
 	// Set ChaCha20-Poly1305 defaults
-	config.ChaCha20Poly1305.KeySize = 256
-	config.ChaCha20Poly1305.KeyFile = filepath.Join(keysDir, "chacha20poly1305_key.bin")
-	config.ChaCha20Poly1305.NonceSize = 12
-	config.ChaCha20Poly1305.TagSize = 16
+	if config.ChaCha20Poly1305.KeySize == 0 {
+		config.ChaCha20Poly1305.KeySize = 256
+	}
+	config.ChaCha20Poly1305.KeyFile = keyPath(keysDir, config.ChaCha20Poly1305.KeyFile, "chacha20poly1305_key.bin")
+	if config.ChaCha20Poly1305.NonceSize == 0 {
+		config.ChaCha20Poly1305.NonceSize = 12
+	}
+	if config.ChaCha20Poly1305.TagSize == 0 {
+		config.ChaCha20Poly1305.TagSize = 16
+	}
+	if config.ChaCha20Poly1305.ChunkSize == 0 {
+		config.ChaCha20Poly1305.ChunkSize = 65536
+	}
+
+	// Set AES-GCM-SIV defaults
+	if config.AESGCMSIV.KeySize == 0 {
+		config.AESGCMSIV.KeySize = 256
+	}
+	config.AESGCMSIV.KeyFile = keyPath(keysDir, config.AESGCMSIV.KeyFile, "aes_gcm_siv_key.bin")
 
 	// Set Caesar defaults
-	config.Caesar.DefaultShift = 3
+	if config.Caesar.DefaultShift == 0 {
+		config.Caesar.DefaultShift = 3
+	}
+	if config.Caesar.Alphabet == "" {
+		config.Caesar.Alphabet = "latin"
+	}
+
+	// Set Blowfish defaults if not set
+	if config.Blowfish.KeySize == 0 {
+		config.Blowfish.KeySize = 128
+	}
+	config.Blowfish.KeyFile = keyPath(keysDir, config.Blowfish.KeyFile, "blowfish_key.bin")
+
+	// Set Triple-DES defaults if not set
+	if config.TripleDES.KeyingOption == 0 {
+		config.TripleDES.KeyingOption = 3
+	}
+	config.TripleDES.KeyFile = keyPath(keysDir, config.TripleDES.KeyFile, fmt.Sprintf("3des_%dkey.bin", config.TripleDES.KeyingOption))
+
+	// Set hash defaults if not set
+	if config.Hash.Algorithm == "" {
+		config.Hash.Algorithm = "sha256"
+	}
+	if config.Hash.DigestSize == 0 {
+		config.Hash.DigestSize = 32
+	}
+
+	// Set TOTP defaults if not set
+	if config.TOTP.Issuer == "" {
+		config.TOTP.Issuer = "CryptoLens"
+	}
+	if config.TOTP.Account == "" {
+		config.TOTP.Account = "user@example.com"
+	}
+	if config.TOTP.Algorithm == "" {
+		config.TOTP.Algorithm = "sha1"
+	}
+	if config.TOTP.Digits == 0 {
+		config.TOTP.Digits = 6
+	}
+	if config.TOTP.Period == 0 {
+		config.TOTP.Period = 30
+	}
+
+	// Set attack defaults if not set
+	if config.Attack.Scorer == "" {
+		config.Attack.Scorer = "chisquared"
+	}
+	if config.Attack.BruteForceIterations == 0 {
+		config.Attack.BruteForceIterations = 100
+	}
+	if config.Attack.TimingIterations == 0 {
+		config.Attack.TimingIterations = 5
+	}
+	if config.Attack.TimingDelayPerByte == 0 {
+		config.Attack.TimingDelayPerByte = time.Millisecond
+	}
+	if config.Attack.ECBKeySize == 0 {
+		config.Attack.ECBKeySize = config.AES.DefaultKeySize
+	}
+	if config.Attack.ECBKeySize == 0 {
+		config.Attack.ECBKeySize = 256
+	}
+	if config.Attack.NonceReuseKeySize == 0 {
+		config.Attack.NonceReuseKeySize = 256
+	}
 
 	// Set PBKDF defaults
-	config.PBKDF.Algorithm = "argon2id"
-	config.PBKDF.Iterations = 100000
-	config.PBKDF.Memory = 65536
-	config.PBKDF.Threads = 4
-	config.PBKDF.KeyLength = 32
-	config.PBKDF.AvailableAlgorithms = []string{"pbkdf2", "argon2id", "scrypt"}
+	if config.PBKDF.Algorithm == "" {
+		config.PBKDF.Algorithm = "argon2id"
+	}
+	// A zero iteration count is left for the processor, which picks the per-algorithm default
+	if config.PBKDF.Memory == 0 {
+		config.PBKDF.Memory = 65536
+	}
+	if config.PBKDF.Threads == 0 {
+		config.PBKDF.Threads = 4
+	}
+	if config.PBKDF.KeyLength == 0 {
+		config.PBKDF.KeyLength = 32
+	}
+	if config.PBKDF.Cost == 0 {
+		config.PBKDF.Cost = 10
+	}
+	if len(config.PBKDF.AvailableAlgorithms) == 0 {
+		config.PBKDF.AvailableAlgorithms = []string{"pbkdf2", "argon2id", "argon2i", "scrypt", "bcrypt"}
+	}
 
 	// Set General defaults
-	config.General.LogLevel = "info"
-	config.General.Debug = false
+	if config.General.LogLevel == "" {
+		config.General.LogLevel = "info"
+	}
+	if config.General.Verbose == nil {
+		verbose := true
+		config.General.Verbose = &verbose
+	}
+	if config.General.OutputEncoding == "" {
+		config.General.OutputEncoding = "base64"
+	}
 
-	return &config, nil
+	return nil
+}
+
+// validate rejects values the processors would refuse later, so a bad file or override
+// fails at startup
+func validate(config *Config) error {
+	switch config.AES.DefaultKeySize {
+	case 128, 192, 256:
+	default:
+		return fmt.Errorf("invalid aes.defaultKeySize: %d (must be 128, 192, or 256)", config.AES.DefaultKeySize)
+	}
+	return nil
+}
+
+// keyPath resolves a configured key file: empty uses defaultName in keysDir, a bare file
+// name is placed in keysDir, and any other path is used as given
+func keyPath(keysDir, configured, defaultName string) string {
+	if configured == "" {
+		return filepath.Join(keysDir, defaultName)
+	}
+	if filepath.Base(configured) == configured {
+		return filepath.Join(keysDir, configured)
+	}
+	return configured
+}
+
+// portableKeyPaths returns a copy of config with the key files inside keysDir reduced to
+// bare names, so a saved config keeps working when the binary moves
+func portableKeyPaths(config *Config, keysDir string) *Config {
+	portable := *config
+	sections := reflect.ValueOf(&portable).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Field(j)
+			if field.Kind() == reflect.String && field.String() != "" && filepath.Dir(field.String()) == keysDir {
+				field.SetString(filepath.Base(field.String()))
+			}
+		}
+	}
+	return &portable
 }
 
 // SaveConfig saves the configuration to the specified file
@@ -338,17 +720,26 @@ func createDefaultConfig() *Config {
 	config.ChaCha20Poly1305.KeyFile = filepath.Join(keysDir, "chacha20poly1305_key.bin")
 	config.ChaCha20Poly1305.NonceSize = 12
 	config.ChaCha20Poly1305.TagSize = 16
+	config.ChaCha20Poly1305.ChunkSize = 65536
+
+	// Set AES-GCM-SIV defaults
+	config.AESGCMSIV.KeySize = 256
+	config.AESGCMSIV.KeyFile = filepath.Join(keysDir, "aes_gcm_siv_key.bin")
 
 	// Set Base64 defaults
 	config.Base64.PaddingChar = "="
 
 	// Set Caesar defaults
 	config.Caesar.DefaultShift = 3
+	config.Caesar.Alphabet = "latin"
 
 	// Set RSA defaults
 	config.RSA.KeySize = 2048
 	config.RSA.PublicKeyFile = filepath.Join(keysDir, "rsa_public.pem")
 	config.RSA.PrivateKeyFile = filepath.Join(keysDir, "rsa_private.pem")
+	config.RSA.Padding = "oaep"
+	config.RSA.Mode = "direct"
+	config.RSA.KeyFormat = "pkcs1"
 
 	// Set HMAC defaults
 	config.HMAC.KeySize = 256
@@ -357,15 +748,17 @@ func createDefaultConfig() *Config {
 
 	// Set PBKDF defaults
 	config.PBKDF.Algorithm = "argon2id"
-	config.PBKDF.Iterations = 100000
+	config.PBKDF.Iterations = 3
 	config.PBKDF.Memory = 65536
 	config.PBKDF.Threads = 4
 	config.PBKDF.KeyLength = 32
-	config.PBKDF.AvailableAlgorithms = []string{"pbkdf2", "argon2id", "scrypt"}
+	config.PBKDF.Cost = 10
+	config.PBKDF.AvailableAlgorithms = []string{"pbkdf2", "argon2id", "argon2i", "scrypt", "bcrypt"}
 
 	// Set DH defaults
 	config.DH.KeySize = 2048
 	config.DH.Generator = 2
+	config.DH.Group = "modp2048"
 	config.DH.PrimeFile = filepath.Join(keysDir, "dh_prime.bin")
 	config.DH.PrivateKeyFile = filepath.Join(keysDir, "dh_private.bin")
 	config.DH.PublicKeyFile = filepath.Join(keysDir, "dh_public.bin")
@@ -383,11 +776,42 @@ func createDefaultConfig() *Config {
 	config.JWT.RSAPublicKeyFile = filepath.Join(keysDir, "jwt_rsa_public.pem")
 	config.JWT.Ed25519PrivateKeyFile = filepath.Join(keysDir, "jwt_ed25519_private.bin")
 	config.JWT.Ed25519PublicKeyFile = filepath.Join(keysDir, "jwt_ed25519_public.bin")
-	config.JWT.AvailableAlgorithms = []string{"HS256", "RS256", "EdDSA"}
+	config.JWT.AvailableAlgorithms = []string{"HS256", "RS256", "PS256", "EdDSA", "ES256", "ES384"}
+
+	// Set Blowfish defaults
+	config.Blowfish.KeySize = 128
+	config.Blowfish.KeyFile = filepath.Join(keysDir, "blowfish_key.bin")
+
+	// Set Triple-DES defaults
+	config.TripleDES.KeyingOption = 3
+	config.TripleDES.KeyFile = filepath.Join(keysDir, "3des_3key.bin")
+
+	// Set hash defaults
+	config.Hash.Algorithm = "sha256"
+	config.Hash.DigestSize = 32
+
+	// Set TOTP defaults
+	config.TOTP.Issuer = "CryptoLens"
+	config.TOTP.Account = "user@example.com"
+	config.TOTP.Algorithm = "sha1"
+	config.TOTP.Digits = 6
+	config.TOTP.Period = 30
+
+	// Set attack defaults
+	config.Attack.Scorer = "chisquared"
+	config.Attack.BruteForceIterations = 100
+	config.Attack.TimingIterations = 5
+	config.Attack.TimingDelayPerByte = time.Millisecond
+	config.Attack.ECBKeySize = 256
+	config.Attack.NonceReuseKeySize = 256
 
 	// Set General defaults
 	config.General.LogLevel = "info"
 	config.General.Debug = false
+	verbose := true
+	config.General.Verbose = &verbose
+	config.General.OutputEncoding = "base64"
+	config.General.Pager = true
 
 	return config
 }
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -136,8 +138,8 @@ func TestConfigProvider(t *testing.T) {
 
 	// Test GetPBKDFConfig
 	pbkdfConfig := config.GetPBKDFConfig()
-	if pbkdfConfig.Iterations != 100000 {
-		t.Errorf("Expected PBKDF iterations 100000, got %d", pbkdfConfig.Iterations)
+	if pbkdfConfig.Iterations != 3 {
+		t.Errorf("Expected PBKDF iterations 3, got %d", pbkdfConfig.Iterations)
 	}
 	if pbkdfConfig.Memory != 65536 {
 		t.Errorf("Expected PBKDF memory 65536, got %d", pbkdfConfig.Memory)
@@ -148,8 +150,8 @@ func TestConfigProvider(t *testing.T) {
 	if pbkdfConfig.KeyLength != 32 {
 		t.Errorf("Expected PBKDF key length 32, got %d", pbkdfConfig.KeyLength)
 	}
-	if len(pbkdfConfig.AvailableAlgorithms) != 3 {
-		t.Errorf("Expected 3 PBKDF algorithms, got %d", len(pbkdfConfig.AvailableAlgorithms))
+	if len(pbkdfConfig.AvailableAlgorithms) != 5 {
+		t.Errorf("Expected 5 PBKDF algorithms, got %d", len(pbkdfConfig.AvailableAlgorithms))
 	}
 
 	// Test GetDHConfig
@@ -175,8 +177,8 @@ func TestConfigProvider(t *testing.T) {
 	if jwtConfig.Algorithm != "HS256" {
 		t.Errorf("Expected JWT algorithm HS256, got %s", jwtConfig.Algorithm)
 	}
-	if len(jwtConfig.AvailableAlgorithms) != 3 {
-		t.Errorf("Expected 3 JWT algorithms, got %d", len(jwtConfig.AvailableAlgorithms))
+	if len(jwtConfig.AvailableAlgorithms) != 6 {
+		t.Errorf("Expected 6 JWT algorithms, got %d", len(jwtConfig.AvailableAlgorithms))
 	}
 
 	// Test GetGeneralConfig
@@ -236,8 +238,8 @@ func TestCreateDefaultConfig(t *testing.T) {
 	if config.PBKDF.Algorithm != "argon2id" {
 		t.Errorf("Expected PBKDF algorithm argon2id, got %s", config.PBKDF.Algorithm)
 	}
-	if config.PBKDF.Iterations != 100000 {
-		t.Errorf("Expected PBKDF iterations 100000, got %d", config.PBKDF.Iterations)
+	if config.PBKDF.Iterations != 3 {
+		t.Errorf("Expected PBKDF iterations 3, got %d", config.PBKDF.Iterations)
 	}
 	if config.DH.KeySize != 2048 {
 		t.Errorf("Expected DH key size 2048, got %d", config.DH.KeySize)
@@ -245,13 +247,197 @@ func TestCreateDefaultConfig(t *testing.T) {
 	if config.DH.Generator != 2 {
 		t.Errorf("Expected DH generator 2, got %d", config.DH.Generator)
 	}
+	if config.DH.Group != "modp2048" {
+		t.Errorf("Expected DH group modp2048, got %s", config.DH.Group)
+	}
 	if config.JWT.Algorithm != "HS256" {
 		t.Errorf("Expected JWT algorithm HS256, got %s", config.JWT.Algorithm)
 	}
+	if config.Attack.BruteForceIterations != 100 {
+		t.Errorf("Expected brute-force iterations 100, got %d", config.Attack.BruteForceIterations)
+	}
+	if config.Attack.TimingDelayPerByte != time.Millisecond {
+		t.Errorf("Expected timing delay per byte 1ms, got %v", config.Attack.TimingDelayPerByte)
+	}
 	if config.General.LogLevel != "info" {
 		t.Errorf("Expected log level info, got %s", config.General.LogLevel)
 	}
+	if !config.General.IsVerbose() {
+		t.Error("Expected verbose output by default")
+	}
 	if config.General.Debug {
 		t.Error("Expected debug mode to be false")
 	}
+	if config.General.MaxRunDuration != 0 {
+		t.Errorf("Expected no run time limit by default, got %v", config.General.MaxRunDuration)
+	}
+}
+
+func TestLoadConfigAttackSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	data := []byte("attack:\n  bruteForceIterations: 50\n  timingIterations: 2\n  timingDelayPerByte: 250us\n  ecbKeySize: 128\ngeneral:\n  maxRunDuration: 2m\n")
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	attack := config.GetAttackConfig()
+	if attack.BruteForceIterations != 50 {
+		t.Errorf("Expected brute-force iterations 50, got %d", attack.BruteForceIterations)
+	}
+	if attack.TimingIterations != 2 {
+		t.Errorf("Expected timing iterations 2, got %d", attack.TimingIterations)
+	}
+	if attack.TimingDelayPerByte != 250*time.Microsecond {
+		t.Errorf("Expected timing delay per byte 250µs, got %v", attack.TimingDelayPerByte)
+	}
+	if attack.ECBKeySize != 128 {
+		t.Errorf("Expected ECB key size 128, got %d", attack.ECBKeySize)
+	}
+	if attack.NonceReuseKeySize != 256 {
+		t.Errorf("Expected default nonce reuse key size 256, got %d", attack.NonceReuseKeySize)
+	}
+	if got := config.GetGeneralConfig().MaxRunDuration; got != 2*time.Minute {
+		t.Errorf("Expected max run duration 2m, got %v", got)
+	}
+}
+
+func TestLoadConfigKeepsZeroPBKDFIterations(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("pbkdf:\n  algorithm: argon2id\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	// Zero reaches the processor, which picks the Argon2 or PBKDF2 default itself
+	if got := config.GetPBKDFConfig().Iterations; got != 0 {
+		t.Errorf("Expected PBKDF iterations to stay 0, got %d", got)
+	}
+}
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("aes:\n  defaultKeySize: 256\njwt:\n  algorithm: HS256\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv("CRYPTOLENS_AES_KEYSIZE", "128")
+	t.Setenv("CRYPTOLENS_JWT_ALGORITHM", "RS256")
+	t.Setenv("CRYPTOLENS_AES_OPENSSLCOMPAT", "true")
+	t.Setenv("CRYPTOLENS_ATTACK_TIMINGDELAYPERBYTE", "2ms")
+	t.Setenv("CRYPTOLENS_PBKDF_AVAILABLEALGORITHMS", "pbkdf2, scrypt")
+	t.Setenv("CRYPTOLENS_GENERAL_VERBOSE", "false")
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.General.IsVerbose() {
+		t.Error("Expected verbose output to be disabled")
+	}
+	if config.AES.DefaultKeySize != 128 {
+		t.Errorf("Expected AES key size 128, got %d", config.AES.DefaultKeySize)
+	}
+	if config.JWT.Algorithm != "RS256" {
+		t.Errorf("Expected JWT algorithm RS256, got %s", config.JWT.Algorithm)
+	}
+	if !config.AES.OpenSSLCompat {
+		t.Error("Expected OpenSSL compatibility to be enabled")
+	}
+	if config.Attack.TimingDelayPerByte != 2*time.Millisecond {
+		t.Errorf("Expected timing delay 2ms, got %v", config.Attack.TimingDelayPerByte)
+	}
+	if got := config.PBKDF.AvailableAlgorithms; len(got) != 2 || got[0] != "pbkdf2" || got[1] != "scrypt" {
+		t.Errorf("Expected [pbkdf2 scrypt], got %v", got)
+	}
+
+	// The canonical name wins over the alias
+	t.Setenv("CRYPTOLENS_AES_DEFAULTKEYSIZE", "192")
+	if config, err = LoadConfig(configPath); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.AES.DefaultKeySize != 192 {
+		t.Errorf("Expected AES key size 192, got %d", config.AES.DefaultKeySize)
+	}
+
+	// Zero gets the default and invalid sizes are rejected
+	t.Setenv("CRYPTOLENS_AES_DEFAULTKEYSIZE", "0")
+	if config, err = LoadConfig(configPath); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.AES.DefaultKeySize != 256 {
+		t.Errorf("Expected the default AES key size 256, got %d", config.AES.DefaultKeySize)
+	}
+	t.Setenv("CRYPTOLENS_AES_DEFAULTKEYSIZE", "17")
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected an error for an invalid AES key size")
+	}
+	t.Setenv("CRYPTOLENS_AES_DEFAULTKEYSIZE", "192")
+
+	// Struct lists have no string form and are left to the file
+	t.Setenv("CRYPTOLENS_PLUGINS_COMMANDS", "rot13")
+	if _, err := LoadConfig(configPath); err != nil {
+		t.Errorf("Expected CRYPTOLENS_PLUGINS_COMMANDS to be ignored, got %v", err)
+	}
+
+	t.Setenv("CRYPTOLENS_HMAC_KEYSIZE", "large")
+	if _, err := LoadConfig(configPath); err == nil {
+		t.Error("Expected an error for a non-numeric override")
+	}
+}
+
+func TestLoadConfigKeepsUserKeyPaths(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	aesKey := filepath.Join(tempDir, "custom", "aes.bin")
+	data := []byte("aes:\n  keyFile: " + aesKey + "\ndh:\n  keySize: 3072\n  primeFile: my_prime.bin\n")
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.AES.KeyFile != aesKey {
+		t.Errorf("Expected AES key file %s, got %s", aesKey, config.AES.KeyFile)
+	}
+	keysDir := filepath.Dir(config.RSA.PrivateKeyFile)
+	if filepath.Base(config.RSA.PrivateKeyFile) != "rsa_private.pem" {
+		t.Errorf("Expected the default RSA private key file, got %s", config.RSA.PrivateKeyFile)
+	}
+	if want := filepath.Join(keysDir, "my_prime.bin"); config.DH.PrimeFile != want {
+		t.Errorf("Expected a bare file name to resolve to %s, got %s", want, config.DH.PrimeFile)
+	}
+	if config.DH.KeySize != 3072 {
+		t.Errorf("Expected DH key size 3072, got %d", config.DH.KeySize)
+	}
+}
+
+func TestLoadConfigSavesPortableKeyPaths(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if !filepath.IsAbs(config.AES.KeyFile) {
+		t.Errorf("Expected an absolute AES key path, got %s", config.AES.KeyFile)
+	}
+
+	saved, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if !strings.Contains(string(saved), "keyFile: aes_key.bin") {
+		t.Errorf("Expected the saved config to use bare key file names, got:\n%s", saved)
+	}
 }
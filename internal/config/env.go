@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envPrefix starts every environment variable that overrides a config value. The full name
+// is the prefix, the section's YAML key and the field's YAML key, upper-cased and joined with
+// underscores: CRYPTOLENS_JWT_ALGORITHM sets jwt.algorithm. Lists of structs, such as
+// plugins.commands, have no single-string form and can only be set in the file.
+const envPrefix = "CRYPTOLENS_"
+
+// envAliases maps shorter variable names onto the canonical ones; the canonical name wins
+var envAliases = map[string]string{
+	"CRYPTOLENS_AES_DEFAULTKEYSIZE": "CRYPTOLENS_AES_KEYSIZE",
+}
+
+// durationType is checked before the int kind, since time.Duration is an int64
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// envName returns the variable that overrides the field with the given YAML keys
+func envName(section, field string) string {
+	return envPrefix + strings.ToUpper(section) + "_" + strings.ToUpper(field)
+}
+
+// yamlKey returns the YAML key of a struct field
+func yamlKey(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("yaml"), ",")[0]
+}
+
+// applyEnvOverrides sets config values from CRYPTOLENS_* variables, read through lookup
+func applyEnvOverrides(config *Config, lookup func(string) (string, bool)) error {
+	configValue := reflect.ValueOf(config).Elem()
+	configType := configValue.Type()
+	for i := 0; i < configType.NumField(); i++ {
+		section := configType.Field(i)
+		sectionValue := configValue.Field(i)
+		for j := 0; j < section.Type.NumField(); j++ {
+			field := section.Type.Field(j)
+			if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() != reflect.String {
+				continue
+			}
+			name := envName(yamlKey(section), yamlKey(field))
+			raw, ok := lookup(name)
+			if !ok {
+				if alias, hasAlias := envAliases[name]; hasAlias {
+					raw, ok = lookup(alias)
+				}
+			}
+			if !ok {
+				continue
+			}
+			if err := setFromEnv(sectionValue.Field(j), raw); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setFromEnv parses raw into the field according to its type
+func setFromEnv(field reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Ptr:
+		value := reflect.New(field.Type().Elem())
+		if err := setFromEnv(value.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(value)
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", field.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -3,9 +3,8 @@ package crypto
 import (
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/rand"
-	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
@@ -13,8 +12,12 @@ import (
 
 type AESProcessor struct {
 	BaseConfigurableProcessor
-	keyManager KeyManager
-	keySize    int
+	keyManager     KeyManager
+	keySize        int
+	opensslCompat  bool   // Use the openssl enc "Salted__" format with a passphrase
+	passphrase     string // Passphrase for OpenSSL-compatible mode
+	outputEncoding string // Encoding of encrypted results; decryption accepts any
+	rand           io.Reader
 }
 
 func NewAESProcessor() *AESProcessor {
@@ -44,6 +47,30 @@ func (p *AESProcessor) Configure(config map[string]interface{}) error {
 		}
 	}
 
+	if random, ok := config["rand"].(io.Reader); ok {
+		p.rand = random
+	}
+	if compat, ok := config["opensslCompat"].(bool); ok {
+		p.opensslCompat = compat
+	}
+	if passphrase, ok := config["passphrase"].(string); ok {
+		p.passphrase = passphrase
+	}
+	if encoding, ok := config["outputEncoding"].(string); ok && encoding != "" {
+		if err := utils.ValidateEncoding(encoding); err != nil {
+			return err
+		}
+		p.outputEncoding = encoding
+	}
+
+	// Keep the current key unless key settings were given
+	_, hasKeySize := config["keySize"]
+	_, hasKeyFile := config["keyFile"]
+	_, hasMaxKeyAge := config["maxKeyAgeDays"]
+	if p.keyManager != nil && !hasKeySize && !hasKeyFile && !hasMaxKeyAge {
+		return nil
+	}
+
 	// Configure key file if provided
 	keyFile := "keys/aes_key.bin"
 	if kf, ok := config["keyFile"].(string); ok {
@@ -51,7 +78,11 @@ func (p *AESProcessor) Configure(config map[string]interface{}) error {
 	}
 
 	// Initialize key manager
-	p.keyManager = NewFileKeyManager(p.keySize, keyFile)
+	keyManager := NewFileKeyManager(p.keySize, keyFile)
+	if days, ok := config["maxKeyAgeDays"].(int); ok {
+		keyManager.SetMaxKeyAge(days)
+	}
+	p.keyManager = keyManager
 	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
 		return fmt.Errorf("failed to load/generate key: %w", err)
 	}
@@ -59,7 +90,7 @@ func (p *AESProcessor) Configure(config map[string]interface{}) error {
 	return nil
 }
 
-func (p *AESProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *AESProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 
 	// Check for empty input
@@ -73,10 +104,14 @@ func (p *AESProcessor) Process(text string, operation string) (string, []string,
 	}
 
 	// Add introduction
-	v.AddStep("AES Encryption Process")
-	v.AddStep("=============================")
+	v.AddHeader("AES Encryption Process")
 	v.AddNote("AES (Advanced Encryption Standard) is a symmetric encryption algorithm")
 	v.AddNote(fmt.Sprintf("Using AES-%d in CBC mode with PKCS7 padding", p.keySize))
+	if p.opensslCompat {
+		v.AddSeparator()
+		return p.processOpenSSL(v, text, operation)
+	}
+	addKeyRotationNote(v, p.keyManager)
 	v.AddSeparator()
 
 	// Show key information
@@ -90,7 +125,7 @@ func (p *AESProcessor) Process(text string, operation string) (string, []string,
 	if operation == OperationDecrypt {
 		// Add decryption steps
 		v.AddStep("Decryption Process:")
-		v.AddStep("1. Base64 decode the input")
+		v.AddStep("1. Decode the input (hex, Base64, or Base64URL)")
 		v.AddStep("2. Extract IV from the beginning")
 		v.AddStep("3. Use AES-CBC to decrypt")
 		v.AddStep("4. Remove PKCS7 padding")
@@ -98,14 +133,17 @@ func (p *AESProcessor) Process(text string, operation string) (string, []string,
 		v.AddSeparator()
 
 		// Show input
-		v.AddTextStep("Encrypted Input (Base64)", text)
+		v.AddTextStep("Encrypted Input", text)
 		v.AddArrow()
 
-		// Decode from base64
-		data, err := base64.StdEncoding.DecodeString(text)
+		// An IV and at least one block, in any encoding that gives whole blocks
+		data, encoding, err := utils.DecodeAny(text, func(data []byte) bool {
+			return len(data) >= 2*aes.BlockSize && len(data)%aes.BlockSize == 0
+		})
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+			return "", nil, fmt.Errorf("invalid ciphertext: %w", err)
 		}
+		v.AddStep(fmt.Sprintf("Detected input encoding: %s", utils.EncodingName(encoding)))
 		v.AddHexStep("Decoded Data", data)
 		v.AddArrow()
 
@@ -119,26 +157,14 @@ func (p *AESProcessor) Process(text string, operation string) (string, []string,
 		v.AddHexStep("Ciphertext", ciphertext)
 		v.AddArrow()
 
-		// Create cipher block
-		block, err := aes.NewCipher(p.keyManager.GetKey())
+		// Decrypt and unpad
+		unpadded, err := p.Decrypt(data)
 		if err != nil {
-			return "", nil, fmt.Errorf("failed to create cipher: %v", err)
+			return "", nil, err
 		}
-		v.AddStep("Created AES cipher block")
-		v.AddArrow()
-
-		// Decrypt
-		mode := cipher.NewCBCDecrypter(block, iv)
-		plaintext := make([]byte, len(ciphertext))
-		mode.CryptBlocks(plaintext, ciphertext)
-		v.AddHexStep("Decrypted Data (with padding)", plaintext)
+		v.AddStep("Created AES cipher block and decrypted with AES-CBC")
+		v.AddStep(fmt.Sprintf("Removed %d bytes of PKCS7 padding", len(ciphertext)-len(unpadded)))
 		v.AddArrow()
-
-		// Unpad
-		unpadded, err := p.unpad(plaintext)
-		if err != nil {
-			return "", nil, fmt.Errorf("failed to unpad: %w", err)
-		}
 		v.AddTextStep("Decrypted Text", string(unpadded))
 
 		// Add security notes
@@ -159,51 +185,35 @@ func (p *AESProcessor) Process(text string, operation string) (string, []string,
 	v.AddStep("3. Add PKCS7 padding")
 	v.AddStep("4. Use AES-CBC to encrypt")
 	v.AddStep("5. Combine IV and ciphertext")
-	v.AddStep("6. Base64 encode the result")
+	v.AddStep(fmt.Sprintf("6. %s encode the result", utils.EncodingName(p.outputEncoding)))
 	v.AddSeparator()
 
 	// Show input
 	v.AddTextStep("Input Text", text)
 	v.AddArrow()
 
-	// Create initialization vector
-	iv := make([]byte, aes.BlockSize)
-	if _, err := rand.Read(iv); err != nil {
-		return "", nil, fmt.Errorf("failed to generate IV: %v", err)
-	}
-	v.AddHexStep("Generated IV", iv)
-	v.AddArrow()
-
-	// Create cipher block
-	block, err := aes.NewCipher(p.keyManager.GetKey())
+	// Encrypt: the result is the random IV followed by the ciphertext
+	result, err := p.Encrypt([]byte(text))
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to create cipher: %v", err)
+		return "", nil, err
 	}
+	v.AddHexStep("Generated IV", result[:aes.BlockSize])
+	v.AddArrow()
 	v.AddStep("Created AES cipher block")
 	v.AddArrow()
 
 	// Pad the input
-	paddedText := p.pad([]byte(text))
+	paddedText := addPaddingSteps(v, []byte(text), aes.BlockSize)
 	v.AddHexStep("Padded Input", paddedText)
 	v.AddArrow()
 
-	// Encrypt
-	ciphertext := make([]byte, len(paddedText))
-	mode := cipher.NewCBCEncrypter(block, iv)
-	mode.CryptBlocks(ciphertext, paddedText)
-	v.AddHexStep("Encrypted Data", ciphertext)
+	v.AddHexStep("Encrypted Data", result[aes.BlockSize:])
 	v.AddArrow()
-
-	// Combine IV and ciphertext
-	result := make([]byte, len(iv)+len(ciphertext))
-	copy(result, iv)
-	copy(result[len(iv):], ciphertext)
 	v.AddHexStep("Combined IV and Ciphertext", result)
 	v.AddArrow()
 
-	// Base64 encode the result
-	encoded := base64.StdEncoding.EncodeToString(result)
-	v.AddTextStep("Base64 Encoded Result", encoded)
+	encoded := utils.Encode(result, p.outputEncoding)
+	v.AddTextStep(fmt.Sprintf("%s Encoded Result", utils.EncodingName(p.outputEncoding)), encoded)
 
 	// Add security notes
 	v.AddSeparator()
@@ -229,14 +239,72 @@ func (p *AESProcessor) Process(text string, operation string) (string, []string,
 	return encoded, v.GetSteps(), nil
 }
 
-func (p *AESProcessor) pad(data []byte) []byte {
-	padding := aes.BlockSize - (len(data) % aes.BlockSize)
-	padtext := make([]byte, len(data)+padding)
-	copy(padtext, data)
-	for i := len(data); i < len(padtext); i++ {
-		padtext[i] = byte(padding)
+// Encrypt encrypts plaintext with AES-CBC and PKCS7 padding under the managed key and returns
+// IV || ciphertext, or the openssl enc "Salted__" format in OpenSSL-compatible mode
+func (p *AESProcessor) Encrypt(plaintext []byte) ([]byte, error) {
+	if p.opensslCompat {
+		if p.passphrase == "" {
+			return nil, fmt.Errorf("no passphrase configured for OpenSSL-compatible mode")
+		}
+		salt := make([]byte, opensslSaltSize)
+		if _, err := io.ReadFull(randomSource(p.rand), salt); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+		return opensslEncrypt(p.passphrase, plaintext, p.keySize, salt)
+	}
+	if p.keyManager == nil {
+		return nil, fmt.Errorf("processor is not configured")
+	}
+
+	block, err := aes.NewCipher(p.keyManager.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	result := make([]byte, aes.BlockSize, aes.BlockSize+len(plaintext)+aes.BlockSize)
+	if _, err := io.ReadFull(randomSource(p.rand), result); err != nil {
+		return nil, fmt.Errorf("failed to generate IV: %w", err)
+	}
+	padded := p.pad(plaintext)
+	cipher.NewCBCEncrypter(block, result).CryptBlocks(padded, padded)
+	return append(result, padded...), nil
+}
+
+// Decrypt reverses Encrypt
+func (p *AESProcessor) Decrypt(data []byte) ([]byte, error) {
+	if p.opensslCompat {
+		if p.passphrase == "" {
+			return nil, fmt.Errorf("no passphrase configured for OpenSSL-compatible mode")
+		}
+		return opensslDecrypt(p.passphrase, data, p.keySize)
+	}
+	if p.keyManager == nil {
+		return nil, fmt.Errorf("processor is not configured")
+	}
+
+	if len(data) < aes.BlockSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	iv := data[:aes.BlockSize]
+	ciphertext := data[aes.BlockSize:]
+	if len(ciphertext)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("ciphertext is not a multiple of the block size")
+	}
+
+	block, err := aes.NewCipher(p.keyManager.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+	unpadded, err := p.unpad(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpad: %w", err)
 	}
-	return padtext
+	return unpadded, nil
+}
+
+func (p *AESProcessor) pad(data []byte) []byte {
+	return pkcs7Pad(data, aes.BlockSize)
 }
 
 func (p *AESProcessor) unpad(data []byte) ([]byte, error) {
@@ -249,3 +317,41 @@ func (p *AESProcessor) unpad(data []byte) ([]byte, error) {
 	}
 	return data[:len(data)-padding], nil
 }
+
+// OpenSSLCompat reports whether the processor uses the openssl enc format and needs a passphrase
+func (p *AESProcessor) OpenSSLCompat() bool {
+	return p.opensslCompat
+}
+
+// KeyFile returns the key file currently in use
+func (p *AESProcessor) KeyFile() string {
+	return keyFileOf(p.keyManager)
+}
+
+// UseKeyFile switches to an existing key file, such as an archived key
+func (p *AESProcessor) UseKeyFile(path string) error {
+	keyManager, err := OpenFileKeyManager(p.keySize, path)
+	if err != nil {
+		return err
+	}
+	p.keyManager = keyManager
+	return nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *AESProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "keySize", Description: "Key size in bits (128, 192, or 256)", Value: p.keySize},
+		{Name: "keyFile", Description: "File the key is stored in", Value: keyFileOf(p.keyManager)},
+		{Name: "opensslCompat", Description: "Use the openssl enc Salted__ format with a passphrase", Value: p.opensslCompat},
+	}
+}
+
+// AuditSources lists the randomness sources and libraries used for AES
+func (p *AESProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		keyManagerRandomness,
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Random IV for each encryption"},
+		{Kind: AuditStandardLibrary, Package: "crypto/aes, crypto/cipher", Purpose: "AES block cipher in CBC mode"},
+	}
+}
@@ -1,8 +1,12 @@
 package crypto
 
 import (
+	"crypto/aes"
 	"encoding/base64"
+	"path/filepath"
 	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 func TestNewAESProcessor(t *testing.T) {
@@ -109,6 +113,74 @@ func TestAESProcessor_Process(t *testing.T) {
 	}
 }
 
+func TestAESProcessor_Cipher(t *testing.T) {
+	processor := NewAESProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize": 128,
+		"keyFile": filepath.Join(t.TempDir(), "aes_key.bin"),
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+
+	for _, plaintext := range []string{"", "Hello, World!", "exactly sixteen!"} {
+		sealed, err := processor.Encrypt([]byte(plaintext))
+		if err != nil {
+			t.Fatalf("Encrypt(%q) error = %v", plaintext, err)
+		}
+		if want := aes.BlockSize + (len(plaintext)/aes.BlockSize+1)*aes.BlockSize; len(sealed) != want {
+			t.Errorf("Encrypt(%q) = %d bytes, want IV || padded ciphertext of %d", plaintext, len(sealed), want)
+		}
+		decrypted, err := processor.Decrypt(sealed)
+		if err != nil || string(decrypted) != plaintext {
+			t.Errorf("Decrypt() = %q, %v, want %q", decrypted, err, plaintext)
+		}
+	}
+
+	// Process and the byte API share one format
+	sealed, _ := processor.Encrypt([]byte("interop"))
+	if decrypted, _, err := processor.Process(base64.StdEncoding.EncodeToString(sealed), OperationDecrypt); err != nil || decrypted != "interop" {
+		t.Errorf("Process(decrypt) = %q, %v", decrypted, err)
+	}
+
+	if _, err := processor.Decrypt(make([]byte, aes.BlockSize+5)); err == nil {
+		t.Error("Expected an error for a partial block")
+	}
+}
+
+func TestAESProcessor_OutputEncoding(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "aes_key.bin")
+	for _, encoding := range utils.Encodings() {
+		processor := NewAESProcessor()
+		if err := processor.Configure(map[string]interface{}{
+			"keyFile":        keyFile,
+			"outputEncoding": encoding,
+		}); err != nil {
+			t.Fatalf("Failed to configure processor: %v", err)
+		}
+		encrypted, _, err := processor.Process("encode me", OperationEncrypt)
+		if err != nil {
+			t.Fatalf("%s: encryption failed: %v", encoding, err)
+		}
+		if _, detected, err := utils.DecodeAny(encrypted, nil); err != nil || detected != encoding {
+			t.Errorf("%s: result %q detected as %s, %v", encoding, encrypted, detected, err)
+		}
+
+		// Decryption accepts every encoding, whichever one is configured
+		decryptor := NewAESProcessor()
+		if err := decryptor.Configure(map[string]interface{}{"keyFile": keyFile}); err != nil {
+			t.Fatalf("Failed to configure processor: %v", err)
+		}
+		decrypted, _, err := decryptor.Process(encrypted, OperationDecrypt)
+		if err != nil || decrypted != "encode me" {
+			t.Errorf("%s: decryption = %q, %v", encoding, decrypted, err)
+		}
+	}
+
+	if err := NewAESProcessor().Configure(map[string]interface{}{"outputEncoding": "base32"}); err == nil {
+		t.Error("Expected an error for an unsupported output encoding")
+	}
+}
+
 func TestAESProcessor_Padding(t *testing.T) {
 	processor := NewAESProcessor()
 
@@ -0,0 +1,135 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+)
+
+func TestPOLYVAL(t *testing.T) {
+	// RFC 8452 Appendix A
+	h, _ := hex.DecodeString("25629347589242761d31f826ba4b757b")
+	x, _ := hex.DecodeString("4f4f95668c83dfb6401762bb2d01a262d1a24ddd2721d006bbe45f20d3c9f362")
+	got := polyval(h, x)
+	if hex.EncodeToString(got[:]) != "f7a3b47b846119fae5b7866cf5e5b77e" {
+		t.Errorf("POLYVAL = %x", got)
+	}
+}
+
+func TestGCMSIV_RFCVectors(t *testing.T) {
+	// RFC 8452 Appendix C.1 and C.2
+	tests := []struct {
+		key       string
+		plaintext string
+		want      string
+	}{
+		{"01000000000000000000000000000000", "", "dc20e2d83f25705bb49e439eca56de25"},
+		{"01000000000000000000000000000000", "0100000000000000", "b5d839330ac7b786578782fff6013b815b287c22493a364c"},
+		{"0100000000000000000000000000000000000000000000000000000000000000", "", "07f5f4169bbf55a8400cd47ea6fd400f"},
+		{"0100000000000000000000000000000000000000000000000000000000000000", "0100000000000000", "c2ef328e5c71c83b843122130f7364b761e0b97427e3df28"},
+	}
+	nonce, _ := hex.DecodeString("030000000000000000000000")
+	for _, tt := range tests {
+		key, _ := hex.DecodeString(tt.key)
+		plaintext, _ := hex.DecodeString(tt.plaintext)
+		aead, err := newGCMSIV(key)
+		if err != nil {
+			t.Fatalf("newGCMSIV() error = %v", err)
+		}
+		sealed := aead.Seal(nil, nonce, plaintext, nil)
+		if hex.EncodeToString(sealed) != tt.want {
+			t.Errorf("Seal(%s) = %x, want %s", tt.plaintext, sealed, tt.want)
+		}
+		opened, err := aead.Open(nil, nonce, sealed, nil)
+		if err != nil || hex.EncodeToString(opened) != tt.plaintext {
+			t.Errorf("Open() = %x, %v", opened, err)
+		}
+		sealed[0] ^= 1
+		if _, err := aead.Open(nil, nonce, sealed, nil); err == nil {
+			t.Error("Expected Open to reject a modified ciphertext")
+		}
+	}
+}
+
+func TestGCMSIV_LengthLimits(t *testing.T) {
+	if err := checkGCMSIVLengths(1<<36, 1<<36); err != nil {
+		t.Errorf("Expected 2^36 bytes to be accepted, got %v", err)
+	}
+	if err := checkGCMSIVLengths(1<<36+1, 0); err == nil {
+		t.Error("Expected an error for plaintext over 2^36 bytes")
+	}
+	if err := checkGCMSIVLengths(0, 1<<36+1); err == nil {
+		t.Error("Expected an error for additional data over 2^36 bytes")
+	}
+}
+
+func TestAESGCMSIVProcessor_Process(t *testing.T) {
+	for _, keySize := range []int{128, 256} {
+		processor := NewAESGCMSIVProcessor()
+		if err := processor.Configure(map[string]interface{}{
+			"keySize": keySize,
+			"keyFile": filepath.Join(t.TempDir(), "aes_gcm_siv_key.bin"),
+		}); err != nil {
+			t.Fatalf("Failed to configure processor: %v", err)
+		}
+
+		plaintext := "Attack at dawn"
+		encrypted, steps, err := processor.Process(plaintext, OperationEncrypt)
+		if err != nil {
+			t.Fatalf("Encryption failed: %v", err)
+		}
+		if len(steps) == 0 {
+			t.Error("Encryption returned no steps")
+		}
+		data, _ := base64.StdEncoding.DecodeString(encrypted)
+		if len(data) != gcmSIVNonceSize+len(plaintext)+gcmSIVTagSize {
+			t.Errorf("Expected nonce || ciphertext || tag, got %d bytes", len(data))
+		}
+
+		decrypted, _, err := processor.Process(encrypted, OperationDecrypt)
+		if err != nil {
+			t.Fatalf("Decryption failed: %v", err)
+		}
+		if decrypted != plaintext {
+			t.Errorf("Decryption result = %v, want %v", decrypted, plaintext)
+		}
+
+		data[len(data)-1] ^= 1
+		if _, _, err := processor.Process(base64.StdEncoding.EncodeToString(data), OperationDecrypt); err == nil {
+			t.Error("Expected decryption to fail for a modified tag")
+		}
+	}
+
+	if err := NewAESGCMSIVProcessor().Configure(map[string]interface{}{"keySize": 192}); err == nil {
+		t.Error("Expected an error for a 192-bit key")
+	}
+}
+
+func TestAESGCMSIVProcessor_Cipher(t *testing.T) {
+	var c Cipher = NewAESGCMSIVProcessor()
+	if _, err := c.Encrypt([]byte("x")); err == nil {
+		t.Error("Expected an error before Configure")
+	}
+
+	processor := NewAESGCMSIVProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keyFile": filepath.Join(t.TempDir(), "aes_gcm_siv_key.bin"),
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	sealed, err := processor.Encrypt([]byte("Attack at dawn"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// Process reads what Encrypt writes
+	decrypted, _, err := processor.Process(base64.StdEncoding.EncodeToString(sealed), OperationDecrypt)
+	if err != nil || decrypted != "Attack at dawn" {
+		t.Errorf("Process(decrypt) = %q, %v", decrypted, err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := processor.Decrypt(sealed); err == nil {
+		t.Error("Expected Decrypt to reject a modified tag")
+	}
+}
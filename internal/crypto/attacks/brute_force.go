@@ -1,34 +1,63 @@
 package attacks
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 	"golang.org/x/crypto/pbkdf2"
 )
 
+// progressInterval is how often the live brute-force progress display refreshes
+const progressInterval = 100 * time.Millisecond
+
 // BruteForceProcessor implements the brute force attack simulation
 type BruteForceProcessor struct {
 	*BaseProcessor
-	config *AttackConfig
+	config          *AttackConfig
+	wordlistFile    string // One password per line; empty uses the built-in list
+	progressTracker ProgressTracker
 }
 
 // NewBruteForceProcessor creates a new brute force attack processor
 func NewBruteForceProcessor() *BruteForceProcessor {
 	return &BruteForceProcessor{
-		BaseProcessor: NewBaseProcessor(),
-		config:        NewAttackConfig(),
+		BaseProcessor:   NewBaseProcessor(),
+		config:          NewAttackConfig(),
+		progressTracker: NewRateProgressTracker("attempts"),
 	}
 }
 
 // Configure configures the brute force processor
 func (p *BruteForceProcessor) Configure(config map[string]interface{}) error {
 	if iterations, ok := config["iterations"].(int); ok {
+		if iterations <= 0 {
+			return fmt.Errorf("invalid iterations: %d (must be positive)", iterations)
+		}
 		p.config.Iterations = iterations
 	}
+	if maxWorkers, ok := config["maxWorkers"].(int); ok {
+		if maxWorkers < 0 {
+			return fmt.Errorf("invalid max workers: %d (must be 0 for one per CPU, or more)", maxWorkers)
+		}
+		p.config.MaxWorkers = maxWorkers
+	}
+	if wordlistFile, ok := config["wordlistFile"].(string); ok {
+		if wordlistFile != "" {
+			if _, err := os.Stat(wordlistFile); err != nil {
+				return fmt.Errorf("failed to open wordlist: %w", err)
+			}
+		}
+		p.wordlistFile = wordlistFile
+	}
 
 	// Generate a random salt
 	p.config.Salt = make([]byte, 16)
@@ -40,7 +69,12 @@ func (p *BruteForceProcessor) Configure(config map[string]interface{}) error {
 }
 
 // Process demonstrates the brute force attack on a weak PBKDF key
-func (p *BruteForceProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *BruteForceProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	return p.ProcessContext(context.Background(), text, operation)
+}
+
+// ProcessContext is Process, stopping the dictionary scan once ctx is done
+func (p *BruteForceProcessor) ProcessContext(ctx context.Context, text string, operation string) (string, []utils.Step, error) {
 	p.addIntroduction()
 
 	// Generate target key
@@ -49,20 +83,29 @@ func (p *BruteForceProcessor) Process(text string, operation string) (string, []
 
 	// Start attack
 	startTime := time.Now()
-	attempts, found, foundPassword, foundKey := p.performAttack(targetKey)
+	attempts, found, foundPassword, foundKey, err := p.performAttack(ctx, targetKey)
+	if err != nil {
+		return "", nil, err
+	}
 	duration := time.Since(startTime)
 
 	// Show results
 	p.addResults(attempts, duration, found, foundPassword, foundKey)
+	timedOut := !found && errors.Is(ctx.Err(), context.DeadlineExceeded)
+	if timedOut {
+		p.AddNote(fmt.Sprintf("⏱ Time limit reached after %d attempts; the rest of the dictionary was not tried", attempts))
+	}
 	p.addSecurityImplications()
 	p.addComparisonWithSecureParams(duration)
 
+	if timedOut {
+		return fmt.Sprintf("Attack stopped at the time limit after %.2f seconds", duration.Seconds()), p.GetSteps(), nil
+	}
 	return fmt.Sprintf("Attack completed in %.2f seconds", duration.Seconds()), p.GetSteps(), nil
 }
 
 func (p *BruteForceProcessor) addIntroduction() {
-	p.AddStep("🔒 Brute Force Attack on Weak PBKDF")
-	p.AddStep("================================")
+	p.AddHeader("🔒 Brute Force Attack on Weak PBKDF")
 	p.AddNote("This simulation demonstrates how weak key derivation parameters")
 	p.AddNote("can make passwords vulnerable to brute force attacks")
 	p.AddNote("")
@@ -86,41 +129,116 @@ func (p *BruteForceProcessor) addTargetKeyInfo(text, targetKey string) {
 	p.AddArrow()
 }
 
-func (p *BruteForceProcessor) performAttack(targetKey string) (int, bool, string, string) {
-	commonPasswords := CommonPasswords()
+func (p *BruteForceProcessor) performAttack(ctx context.Context, targetKey string) (int, bool, string, string, error) {
 	p.addAttackDetails()
 
-	var attempts int
-	var found bool
+	var attempts atomic.Int64
 	var foundPassword string
-	var foundKey string
 
-	for _, password := range commonPasswords {
-		attempts++
-		derivedKey := pbkdf2.Key([]byte(password), p.config.Salt, p.config.Iterations, 32, sha256.New)
-		derivedKeyBase64 := base64.StdEncoding.EncodeToString(derivedKey)
+	// A streamed wordlist has no known length, so only the built-in list gets a bar and ETA
+	total := 0
+	if p.wordlistFile == "" {
+		total = len(CommonPasswords())
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.reportProgress(&attempts, total, done)
+	}()
+
+	err := p.forEachBatch(func(batch []string) bool {
+		foundIndex := atomic.Int64{}
+		foundIndex.Store(-1)
+
+		// Candidates are split across a bounded pool of workers; the first match stops the rest
+		utils.RunWorkers(len(batch), p.config.MaxWorkers, func(i int) bool {
+			if ctx.Err() != nil {
+				return true
+			}
+			attempts.Add(1)
+			derivedKey := pbkdf2.Key([]byte(batch[i]), p.config.Salt, p.config.Iterations, 32, sha256.New)
+			if base64.StdEncoding.EncodeToString(derivedKey) != targetKey {
+				return false
+			}
+			foundIndex.Store(int64(i))
+			return true
+		})
 
-		if attempts%5 == 0 {
-			p.AddStep(fmt.Sprintf("Trying password %d/%d: %s", attempts, len(commonPasswords), password))
+		if i := foundIndex.Load(); i >= 0 {
+			foundPassword = batch[i]
+			return true
 		}
+		return ctx.Err() != nil
+	})
+	close(done)
+	wg.Wait()
+	if err != nil {
+		return 0, false, "", "", err
+	}
+	if foundPassword == "" && ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return 0, false, "", "", fmt.Errorf("brute force stopped after %d attempts: %w", attempts.Load(), ctx.Err())
+	}
+
+	if foundPassword != "" {
+		return int(attempts.Load()), true, foundPassword, targetKey, nil
+	}
+	return int(attempts.Load()), false, "", "", nil
+}
+
+// forEachBatch feeds the dictionary to fn in batches, streaming the wordlist file when one is set
+func (p *BruteForceProcessor) forEachBatch(fn func(batch []string) (stop bool)) error {
+	if p.wordlistFile == "" {
+		fn(CommonPasswords())
+		return nil
+	}
 
-		if derivedKeyBase64 == targetKey {
-			found = true
-			foundPassword = password
-			foundKey = derivedKeyBase64
-			break
+	file, err := os.Open(p.wordlistFile)
+	if err != nil {
+		return fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	defer file.Close()
+	return streamWordlist(file, wordlistBatchSize, fn)
+}
+
+// reportProgress refreshes the live progress display from the attempt counter until done is closed
+func (p *BruteForceProcessor) reportProgress(attempts *atomic.Int64, total int, done <-chan struct{}) {
+	start := time.Now()
+	update := func() {
+		current := int(attempts.Load())
+		var eta time.Duration
+		if current > 0 && total > current {
+			eta = time.Since(start) / time.Duration(current) * time.Duration(total-current)
 		}
+		p.progressTracker.UpdateProgress(current, total, eta)
 	}
 
-	return attempts, found, foundPassword, foundKey
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			update()
+			p.progressTracker.Complete()
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
 }
 
 func (p *BruteForceProcessor) addAttackDetails() {
 	p.AddStep("Attack Details:")
-	p.AddStep("1. Using a dictionary of common passwords")
+	if p.wordlistFile != "" {
+		p.AddStep(fmt.Sprintf("1. Streaming candidates from wordlist %s", p.wordlistFile))
+	} else {
+		p.AddStep("1. Using a dictionary of common passwords")
+	}
 	p.AddStep("2. Testing each password with the same salt")
 	p.AddStep("3. Comparing derived keys")
 	p.AddStep(fmt.Sprintf("4. Only %d iterations makes this very fast", p.config.Iterations))
+	p.AddStep(fmt.Sprintf("5. Candidates are tested in parallel on %d worker(s)", utils.WorkerCount(p.config.MaxWorkers)))
 	p.AddArrow()
 }
 
@@ -142,7 +260,7 @@ func (p *BruteForceProcessor) addResults(attempts int, duration time.Duration, f
 
 func (p *BruteForceProcessor) addSecurityImplications() {
 	p.AddSeparator()
-	p.AddStep("🔒 Security Implications:")
+	p.AddHeader("🔒 Security Implications:")
 	p.AddStep("1. Weak key derivation parameters make brute force attacks feasible")
 	p.AddStep("2. Common passwords are easily cracked")
 	p.AddStep("3. Low iteration counts allow for rapid password testing")
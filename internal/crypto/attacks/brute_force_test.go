@@ -1,8 +1,16 @@
 package attacks
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 func TestBruteForceProcessor_Configure(t *testing.T) {
@@ -98,7 +106,7 @@ func TestBruteForceProcessor_Process(t *testing.T) {
 			// Check if the result contains "Password found!" or "Password not found"
 			found := false
 			for _, step := range steps {
-				if step == "✅ Password found!" {
+				if step.Text == "✅ Password found!" {
 					found = true
 					break
 				}
@@ -132,3 +140,161 @@ func TestBruteForceProcessor_Performance(t *testing.T) {
 		t.Errorf("Attack took too long: %v", duration)
 	}
 }
+
+func TestBruteForceProcessor_MaxWorkers(t *testing.T) {
+	p := NewBruteForceProcessor()
+	if err := p.Configure(map[string]interface{}{"maxWorkers": -1}); err == nil {
+		t.Error("expected error for negative maxWorkers")
+	}
+
+	p = NewBruteForceProcessor()
+	if err := p.Configure(map[string]interface{}{"iterations": 100, "maxWorkers": 1}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	_, steps, err := p.Process("admin123", "attack")
+	if err != nil {
+		t.Fatalf("BruteForceProcessor.Process() error = %v", err)
+	}
+	found := false
+	for _, step := range steps {
+		if step.Text == "✅ Password found!" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected password to be found with a single worker")
+	}
+}
+
+func TestStreamWordlist(t *testing.T) {
+	input := "alpha\r\nbravo\n\ncharlie\ndelta\necho\n"
+	var batches [][]string
+	if err := streamWordlist(strings.NewReader(input), 2, func(batch []string) bool {
+		batches = append(batches, batch)
+		return false
+	}); err != nil {
+		t.Fatalf("streamWordlist() error = %v", err)
+	}
+	want := [][]string{{"alpha", "bravo"}, {"charlie", "delta"}, {"echo"}}
+	if fmt.Sprint(batches) != fmt.Sprint(want) {
+		t.Errorf("streamWordlist() batches = %v, want %v", batches, want)
+	}
+
+	// Stopping early must not read further batches
+	calls := 0
+	if err := streamWordlist(strings.NewReader(input), 2, func(batch []string) bool {
+		calls++
+		return true
+	}); err != nil {
+		t.Fatalf("streamWordlist() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("streamWordlist() called fn %d times after stop, want 1", calls)
+	}
+}
+
+func TestBruteForceProcessor_WordlistFile(t *testing.T) {
+	p := NewBruteForceProcessor()
+	if err := p.Configure(map[string]interface{}{"wordlistFile": filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Error("expected error for a missing wordlist")
+	}
+
+	// Put the target past the first batch so streaming has to continue
+	var lines []string
+	for i := 0; i < wordlistBatchSize+10; i++ {
+		lines = append(lines, fmt.Sprintf("candidate%d", i))
+	}
+	lines = append(lines, "correct horse battery staple")
+	path := filepath.Join(t.TempDir(), "wordlist.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		t.Fatalf("Failed to write wordlist: %v", err)
+	}
+
+	p = NewBruteForceProcessor()
+	if err := p.Configure(map[string]interface{}{"iterations": 1, "wordlistFile": path}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	_, steps, err := p.Process("correct horse battery staple", "attack")
+	if err != nil {
+		t.Fatalf("BruteForceProcessor.Process() error = %v", err)
+	}
+	joined := strings.Join(utils.StepTexts(steps), "\n")
+	if !strings.Contains(joined, "✅ Password found!") {
+		t.Error("expected password from the wordlist to be found")
+	}
+	if !strings.Contains(joined, "Streaming candidates from wordlist") {
+		t.Error("expected steps to mention the wordlist")
+	}
+}
+
+// recordingTracker captures progress updates for tests
+type recordingTracker struct {
+	updates   [][2]int
+	completed bool
+}
+
+func (r *recordingTracker) UpdateProgress(current, total int, _ time.Duration) {
+	r.updates = append(r.updates, [2]int{current, total})
+}
+
+func (r *recordingTracker) Complete() {
+	r.completed = true
+}
+
+func TestBruteForceProcessor_Progress(t *testing.T) {
+	p := NewBruteForceProcessor()
+	tracker := &recordingTracker{}
+	p.progressTracker = tracker
+	if err := p.Configure(map[string]interface{}{"iterations": 100}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+
+	if _, _, err := p.Process("xK9#mP2$vL5", "attack"); err != nil {
+		t.Fatalf("BruteForceProcessor.Process() error = %v", err)
+	}
+	if !tracker.completed {
+		t.Error("expected progress tracker to be completed")
+	}
+	if len(tracker.updates) == 0 {
+		t.Fatal("expected at least one progress update")
+	}
+	last := tracker.updates[len(tracker.updates)-1]
+	total := len(CommonPasswords())
+	if last != [2]int{total, total} {
+		t.Errorf("final progress = %d/%d, want %d/%d", last[0], last[1], total, total)
+	}
+}
+
+func TestBruteForceProcessor_Cancelled(t *testing.T) {
+	processor := NewBruteForceProcessor()
+	if err := processor.Configure(map[string]interface{}{}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := processor.ProcessContext(ctx, "xK9#mP2$vL5", "attack")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestBruteForceProcessor_TimeLimit(t *testing.T) {
+	processor := NewBruteForceProcessor()
+	if err := processor.Configure(map[string]interface{}{}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+
+	result, steps, err := processor.ProcessContext(ctx, "xK9#mP2$vL5", "attack")
+	if err != nil {
+		t.Fatalf("Expected partial results at the time limit, got %v", err)
+	}
+	if !strings.HasPrefix(result, "Attack stopped at the time limit") {
+		t.Errorf("Unexpected result %q", result)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Time limit reached") {
+		t.Error("Expected a note about the time limit")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 // ECBProcessor implements the ECB mode attack simulation
@@ -43,7 +45,7 @@ func (p *ECBProcessor) Configure(config map[string]interface{}) error {
 }
 
 // Process demonstrates the ECB mode pattern leakage
-func (p *ECBProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *ECBProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	p.addIntroduction()
 
 	// Create cipher block
@@ -69,8 +71,7 @@ func (p *ECBProcessor) Process(text string, operation string) (string, []string,
 }
 
 func (p *ECBProcessor) addIntroduction() {
-	p.AddStep("🔒 ECB Mode Pattern Leakage Demonstration")
-	p.AddStep("=====================================")
+	p.AddHeader("🔒 ECB Mode Pattern Leakage Demonstration")
 	p.AddNote("ECB (Electronic Codebook) mode encrypts each block independently")
 	p.AddNote("This leads to pattern leakage when the same plaintext blocks are encrypted")
 	p.AddSeparator()
@@ -80,6 +81,12 @@ func (p *ECBProcessor) addInputInfo(text string, paddedText []byte) {
 	p.AddTextStep("Input Text", text)
 	p.AddArrow()
 	p.AddHexStep("Padded Input", paddedText)
+	padding := len(paddedText) - len(text)
+	if padding == aes.BlockSize {
+		p.AddStep(fmt.Sprintf("Input is block-aligned: a full extra block of %d PKCS7 padding bytes (0x%02x each) was appended", padding, padding))
+	} else {
+		p.AddStep(fmt.Sprintf("PKCS7 added %d padding byte(s) of value 0x%02x to fill the final block", padding, padding))
+	}
 	p.AddArrow()
 
 	// Show block structure
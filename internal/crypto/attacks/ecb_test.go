@@ -127,7 +127,7 @@ func TestECBProcessor_Process(t *testing.T) {
 				if tt.name == "repeating text" {
 					patternFound := false
 					for _, step := range steps {
-						if step == "• Ciphertext pattern found in blocks: [0 1]" {
+						if step.Text == "• Ciphertext pattern found in blocks: [0 1]" {
 							patternFound = true
 							break
 						}
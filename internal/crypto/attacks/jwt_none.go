@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 // JWTNoneProcessor implements the JWT None Algorithm attack simulation
@@ -28,7 +30,7 @@ func (p *JWTNoneProcessor) Configure(config map[string]interface{}) error {
 }
 
 // Process demonstrates the JWT None Algorithm attack
-func (p *JWTNoneProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *JWTNoneProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	p.addIntroduction()
 
 	// Parse the input as a JWT token or create a sample one
@@ -69,8 +71,7 @@ func (p *JWTNoneProcessor) Process(text string, operation string) (string, []str
 }
 
 func (p *JWTNoneProcessor) addIntroduction() {
-	p.AddStep("🔓 JWT None Algorithm Attack Demonstration")
-	p.AddStep("=====================================")
+	p.AddHeader("🔓 JWT None Algorithm Attack Demonstration")
 	p.AddNote("The 'none' algorithm in JWT allows tokens without signature verification")
 	p.AddNote("Attackers can modify the algorithm header to bypass signature checks")
 	p.AddNote("This attack exploits improper JWT library implementations")
@@ -77,10 +77,10 @@ func TestJWTNoneProcessor_Process_WithSampleToken(t *testing.T) {
 	foundIntroduction := false
 	foundSecurityImplications := false
 	for _, step := range steps {
-		if strings.Contains(step, "JWT None Algorithm Attack Demonstration") {
+		if strings.Contains(step.Text, "JWT None Algorithm Attack Demonstration") {
 			foundIntroduction = true
 		}
-		if strings.Contains(step, "Security Implications") {
+		if strings.Contains(step.Text, "Security Implications") {
 			foundSecurityImplications = true
 		}
 	}
@@ -245,9 +245,9 @@ func TestJWTNoneProcessor_Integration(t *testing.T) {
 	// Verify we have educational content
 	hasEducationalContent := false
 	for _, step := range steps {
-		if strings.Contains(step, "Security Implications") ||
-			strings.Contains(step, "Best Practices") ||
-			strings.Contains(step, "Implementation Fixes") {
+		if strings.Contains(step.Text, "Security Implications") ||
+			strings.Contains(step.Text, "Best Practices") ||
+			strings.Contains(step.Text, "Implementation Fixes") {
 			hasEducationalContent = true
 			break
 		}
@@ -0,0 +1,200 @@
+package attacks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// defaultSeparationKeySize is the RSA modulus size used by the key separation demo
+const defaultSeparationKeySize = 2048
+
+// rsaKeyRole is an RSA key together with the X.509 KeyUsage bits it is allowed
+type rsaKeyRole struct {
+	key   *rsa.PrivateKey
+	usage x509.KeyUsage
+}
+
+// KeySeparationProcessor demonstrates why one RSA key must not both sign and decrypt
+type KeySeparationProcessor struct {
+	*BaseProcessor
+	config       *AttackConfig
+	separateKeys bool
+	dualUse      bool // Set by Process when the signing key can also decrypt
+}
+
+// NewKeySeparationProcessor creates a new RSA key separation processor
+func NewKeySeparationProcessor() *KeySeparationProcessor {
+	config := NewAttackConfig()
+	config.KeySize = defaultSeparationKeySize
+	return &KeySeparationProcessor{
+		BaseProcessor: NewBaseProcessor(),
+		config:        config,
+	}
+}
+
+// Configure configures the key size and whether the server uses separate keys
+func (p *KeySeparationProcessor) Configure(config map[string]interface{}) error {
+	if keySize, ok := config["keySize"].(int); ok {
+		switch keySize {
+		case 1024, 2048, 3072:
+			p.config.KeySize = keySize
+		default:
+			return fmt.Errorf("invalid key size: %d (must be 1024, 2048, or 3072)", keySize)
+		}
+	}
+	if separate, ok := config["separateKeys"].(bool); ok {
+		p.separateKeys = separate
+	}
+	return nil
+}
+
+// DualUse reports whether the last run used one key for both signing and encryption
+func (p *KeySeparationProcessor) DualUse() bool {
+	return p.dualUse
+}
+
+// Process encrypts the text to the server and recovers it through the server's signing service
+func (p *KeySeparationProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	p.addIntroduction()
+
+	if len(text) == 0 || len(text) >= p.config.KeySize/8 {
+		return "", nil, fmt.Errorf("invalid input: message must be 1 to %d bytes for a %d-bit key", p.config.KeySize/8-1, p.config.KeySize)
+	}
+	message := new(big.Int).SetBytes([]byte(text))
+
+	encryption, signing, err := p.serverKeys()
+	if err != nil {
+		return "", nil, err
+	}
+	p.dualUse = encryption.key == signing.key
+	p.addKeyUsage(encryption, signing)
+
+	// A client encrypts a secret to the server with textbook RSA: c = m^e mod n
+	pub := &encryption.key.PublicKey
+	ciphertext := new(big.Int).Exp(message, big.NewInt(int64(pub.E)), pub.N)
+	p.AddStep("Victim Message:")
+	p.AddStep(fmt.Sprintf("  Client encrypts %q to the server's encryption key", text))
+	p.AddHexStep("  Ciphertext c = m^e mod n", ciphertext.Bytes())
+	p.AddSeparator()
+
+	recovered, err := p.crossProtocolAttack(ciphertext, pub, signing)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result := ""
+	if recovered == text {
+		result = recovered
+		p.AddStep(fmt.Sprintf("❌ Attacker recovered the secret: %q", recovered))
+		p.AddStep("   The signing service decrypted the message without knowing it")
+	} else {
+		p.AddStep("✅ The \"signature\" is unrelated to the secret: the attack failed")
+		p.AddStep("   The signing key cannot undo encryption under a different key")
+	}
+	p.AddSeparator()
+
+	p.addSecurityImplications()
+	return result, p.GetSteps(), nil
+}
+
+func (p *KeySeparationProcessor) addIntroduction() {
+	p.AddHeader("🔑 RSA Key Separation: Signing vs Encryption")
+	p.AddNote("RSA signing (s = m^d) and RSA decryption (m = c^d) are the same operation")
+	p.AddNote("A server that signs with its decryption key is a decryption oracle")
+	p.AddSeparator()
+}
+
+// serverKeys returns the server's encryption and signing keys, which are the same key unless separated
+func (p *KeySeparationProcessor) serverKeys() (rsaKeyRole, rsaKeyRole, error) {
+	key, err := rsa.GenerateKey(rand.Reader, p.config.KeySize)
+	if err != nil {
+		return rsaKeyRole{}, rsaKeyRole{}, fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+	if !p.separateKeys {
+		role := rsaKeyRole{key: key, usage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment}
+		return role, role, nil
+	}
+
+	signingKey, err := rsa.GenerateKey(rand.Reader, p.config.KeySize)
+	if err != nil {
+		return rsaKeyRole{}, rsaKeyRole{}, fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+	return rsaKeyRole{key: key, usage: x509.KeyUsageKeyEncipherment},
+		rsaKeyRole{key: signingKey, usage: x509.KeyUsageDigitalSignature}, nil
+}
+
+// addKeyUsage shows each key's X.509 KeyUsage and flags a key allowed to do both jobs
+func (p *KeySeparationProcessor) addKeyUsage(encryption, signing rsaKeyRole) {
+	p.AddStep("Server Keys (X.509 KeyUsage):")
+	if p.dualUse {
+		p.AddStep(fmt.Sprintf("  Shared key (%d-bit): %s", p.config.KeySize, keyUsageNames(encryption.usage)))
+		p.AddStep("⚠️ RISK: one key is used for both digitalSignature and keyEncipherment")
+	} else {
+		p.AddStep(fmt.Sprintf("  Encryption key (%d-bit): %s", p.config.KeySize, keyUsageNames(encryption.usage)))
+		p.AddStep(fmt.Sprintf("  Signing key    (%d-bit): %s", p.config.KeySize, keyUsageNames(signing.usage)))
+		p.AddStep("✅ Each key has a single purpose")
+	}
+	p.AddSeparator()
+}
+
+// crossProtocolAttack submits a blinded ciphertext to the signing service and unblinds the "signature"
+func (p *KeySeparationProcessor) crossProtocolAttack(ciphertext *big.Int, pub *rsa.PublicKey, signing rsaKeyRole) (string, error) {
+	p.AddStep("Cross-Protocol Attack:")
+	p.AddStep("1. Capture the ciphertext c sent to the server")
+	p.AddStep("2. Blind it: c' = c · r^e mod n, so the signer does not recognise it")
+	p.AddStep("3. Ask the server to sign c' as if it were a document hash")
+	p.AddStep("4. Unblind: s' · r^-1 = (m·r) · r^-1 = m")
+	p.AddSeparator()
+
+	r, err := rand.Int(rand.Reader, pub.N)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate blinding factor: %w", err)
+	}
+	rInv := new(big.Int).ModInverse(r, pub.N)
+	if rInv == nil {
+		return "", fmt.Errorf("failed to invert blinding factor")
+	}
+	blinded := new(big.Int).Exp(r, big.NewInt(int64(pub.E)), pub.N)
+	blinded.Mul(blinded, ciphertext).Mod(blinded, pub.N)
+	p.AddHexStep("Blinded Request c'", blinded.Bytes())
+
+	// The server's raw signing operation: s = x^d mod n with its signing key
+	signKey := signing.key
+	signature := new(big.Int).Exp(blinded, signKey.D, signKey.N)
+	p.AddHexStep("Server \"Signature\" s'", signature.Bytes())
+
+	unblinded := signature.Mul(signature, rInv).Mod(signature, pub.N)
+	p.AddArrow()
+	p.AddHexStep("Unblinded Result", unblinded.Bytes())
+	return string(unblinded.Bytes()), nil
+}
+
+func (p *KeySeparationProcessor) addSecurityImplications() {
+	p.AddStep("⚠️ Security Implications:")
+	p.AddStep("1. Any signing interface becomes a decryption oracle for a dual-use key")
+	p.AddStep("2. Padding (OAEP, PSS) makes the exploit harder but does not make key reuse safe")
+	p.AddStep("3. A key compromise or legal key escrow for one purpose exposes the other")
+
+	p.AddStep("✅ Best Practices:")
+	p.AddStep("1. Use separate key pairs for signing and encryption")
+	p.AddStep("2. Set X.509 KeyUsage to digitalSignature OR keyEncipherment, never both")
+	p.AddStep("3. Use OAEP for encryption and PSS for signatures, never textbook RSA")
+}
+
+// keyUsageNames lists the X.509 KeyUsage bits relevant to the demo
+func keyUsageNames(usage x509.KeyUsage) string {
+	var names []string
+	if usage&x509.KeyUsageDigitalSignature != 0 {
+		names = append(names, "digitalSignature")
+	}
+	if usage&x509.KeyUsageKeyEncipherment != 0 {
+		names = append(names, "keyEncipherment")
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,61 @@
+package attacks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestKeySeparationProcessor_DualUseKey(t *testing.T) {
+	processor := NewKeySeparationProcessor()
+	if err := processor.Configure(map[string]interface{}{"keySize": 1024}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("wire 5000 to acct 42", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if !processor.DualUse() {
+		t.Error("Expected the default scenario to use one key for both purposes")
+	}
+	if result != "wire 5000 to acct 42" {
+		t.Errorf("Expected the signing service to reveal the secret, got %q", result)
+	}
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	if !strings.Contains(output, "RISK: one key is used for both digitalSignature and keyEncipherment") {
+		t.Error("Expected the dual-use key to be flagged as a risk")
+	}
+}
+
+func TestKeySeparationProcessor_SeparateKeys(t *testing.T) {
+	processor := NewKeySeparationProcessor()
+	if err := processor.Configure(map[string]interface{}{"keySize": 1024, "separateKeys": true}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("secret", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if processor.DualUse() {
+		t.Error("Expected separate keys")
+	}
+	if result != "" {
+		t.Errorf("Expected the attack to fail with separate keys, got %q", result)
+	}
+	if strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "RISK") {
+		t.Error("Did not expect a risk flag with separate keys")
+	}
+}
+
+func TestKeySeparationProcessor_InvalidInput(t *testing.T) {
+	processor := NewKeySeparationProcessor()
+	if err := processor.Configure(map[string]interface{}{"keySize": 512}); err == nil {
+		t.Error("Expected an error for an unsupported key size")
+	}
+	if _, _, err := processor.Process("", "attack"); err == nil {
+		t.Error("Expected an error for an empty message")
+	}
+}
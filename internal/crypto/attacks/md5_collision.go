@@ -0,0 +1,143 @@
+package attacks
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// md5CollisionA and md5CollisionB are the 128-byte colliding messages published by
+// Wang, Feng, Lai and Yu (2004). They differ in six bytes but share one MD5 digest.
+var (
+	md5CollisionA = mustDecodeHex("" +
+		"d131dd02c5e6eec4693d9a0698aff95c2fcab58712467eab4004583eb8fb7f89" +
+		"55ad340609f4b30283e488832571415a085125e8f7cdc99fd91dbdf280373c5b" +
+		"d8823e3156348f5bae6dacd436c919c6dd53e2b487da03fd02396306d248cda0" +
+		"e99f33420f577ee8ce54b67080a80d1ec69821bcb6a8839396f9652b6ff72a70")
+	md5CollisionB = mustDecodeHex("" +
+		"d131dd02c5e6eec4693d9a0698aff95c2fcab50712467eab4004583eb8fb7f89" +
+		"55ad340609f4b30283e4888325f1415a085125e8f7cdc99fd91dbd7280373c5b" +
+		"d8823e3156348f5bae6dacd436c919c6dd53e23487da03fd02396306d248cda0" +
+		"e99f33420f577ee8ce54b67080280d1ec69821bcb6a8839396f965ab6ff72a70")
+)
+
+func mustDecodeHex(s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// MD5CollisionProcessor demonstrates two different inputs with the same MD5 digest
+type MD5CollisionProcessor struct {
+	*BaseProcessor
+	config *AttackConfig
+}
+
+// NewMD5CollisionProcessor creates a new MD5 collision processor
+func NewMD5CollisionProcessor() *MD5CollisionProcessor {
+	return &MD5CollisionProcessor{
+		BaseProcessor: NewBaseProcessor(),
+		config:        NewAttackConfig(),
+	}
+}
+
+// Configure configures the MD5 collision processor
+func (p *MD5CollisionProcessor) Configure(config map[string]interface{}) error {
+	return nil
+}
+
+// Process shows the classic collision pair and that appending the text keeps them colliding
+func (p *MD5CollisionProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	p.addIntroduction()
+
+	p.AddHexStep("Message A (128 bytes)", md5CollisionA)
+	p.AddHexStep("Message B (128 bytes)", md5CollisionB)
+	p.addDifferences()
+
+	p.AddStep("Digests Side by Side:")
+	p.compareDigests(md5CollisionA, md5CollisionB)
+	p.AddSeparator()
+
+	// MD5 processes input block by block, so equal internal state stays equal under a shared suffix
+	suffixA := append(append([]byte{}, md5CollisionA...), text...)
+	suffixB := append(append([]byte{}, md5CollisionB...), text...)
+	p.AddStep(fmt.Sprintf("Appending your text %q to both messages:", text))
+	collides := p.compareDigests(suffixA, suffixB)
+	p.AddNote("Merkle–Damgård hashes carry the colliding state forward, so any shared suffix still collides")
+	p.AddSeparator()
+
+	if !collides {
+		return "", nil, fmt.Errorf("collision pair did not collide")
+	}
+
+	p.addChosenPrefix()
+	p.addSecurityImplications()
+
+	sum := md5.Sum(suffixA)
+	return hex.EncodeToString(sum[:]), p.GetSteps(), nil
+}
+
+func (p *MD5CollisionProcessor) addIntroduction() {
+	p.AddHeader("💥 MD5 Collision Demonstration")
+	p.AddNote("A collision is two different inputs with the same hash")
+	p.AddNote("MD5 collisions can be found in seconds on a laptop since 2004-2006")
+	p.AddSeparator()
+}
+
+// addDifferences lists the byte positions where the two messages differ
+func (p *MD5CollisionProcessor) addDifferences() {
+	p.AddStep("Differing Bytes:")
+	for i := range md5CollisionA {
+		if md5CollisionA[i] != md5CollisionB[i] {
+			p.AddStep(fmt.Sprintf("  offset %3d: %02x vs %02x", i, md5CollisionA[i], md5CollisionB[i]))
+		}
+	}
+	p.AddSeparator()
+}
+
+// compareDigests shows MD5 and SHA-256 of both inputs and reports whether MD5 collides
+func (p *MD5CollisionProcessor) compareDigests(a, b []byte) bool {
+	md5A, md5B := md5.Sum(a), md5.Sum(b)
+	shaA, shaB := sha256.Sum256(a), sha256.Sum256(b)
+
+	p.AddStep(fmt.Sprintf("  MD5(A)     = %x", md5A))
+	p.AddStep(fmt.Sprintf("  MD5(B)     = %x", md5B))
+	collides := !bytes.Equal(a, b) && md5A == md5B
+	if collides {
+		p.AddStep("  ❌ Inputs differ but MD5 digests are identical")
+	}
+	p.AddStep(fmt.Sprintf("  SHA-256(A) = %x", shaA))
+	p.AddStep(fmt.Sprintf("  SHA-256(B) = %x", shaB))
+	if shaA != shaB {
+		p.AddStep("  ✅ SHA-256 still tells them apart")
+	}
+	return collides
+}
+
+func (p *MD5CollisionProcessor) addChosenPrefix() {
+	p.AddStep("🎯 Identical-Prefix vs Chosen-Prefix Collisions:")
+	p.AddStep("  Identical-prefix (shown above): both files share a prefix, then differ in collision blocks")
+	p.AddStep("  Chosen-prefix: the attacker picks two different prefixes (e.g. two certificates)")
+	p.AddStep("  and computes near-collision blocks that bring both MD5 states together")
+	p.AddStep("  Chosen-prefix MD5 collisions take hours; for SHA-1 they were shown in 2020 (SHA-1 is a Shambles)")
+	p.AddSeparator()
+}
+
+func (p *MD5CollisionProcessor) addSecurityImplications() {
+	p.AddStep("⚠️ Real-World Impact:")
+	p.AddStep("1. 2008: a rogue CA certificate was forged from an MD5-signed certificate")
+	p.AddStep("2. 2012: Flame malware used a chosen-prefix MD5 collision to fake a Microsoft")
+	p.AddStep("   code-signing certificate and spread through Windows Update")
+	p.AddStep("3. Two documents with one signature: sign the harmless one, swap in the other")
+
+	p.AddStep("✅ Best Practices:")
+	p.AddStep("1. Never use MD5 (or SHA-1) for signatures, certificates, or integrity")
+	p.AddStep("2. Use SHA-256, SHA-3, or BLAKE2")
+	p.AddStep("3. MD5 is only acceptable as a non-security checksum")
+}
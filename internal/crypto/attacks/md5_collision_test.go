@@ -0,0 +1,43 @@
+package attacks
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestMD5CollisionPair(t *testing.T) {
+	if bytes.Equal(md5CollisionA, md5CollisionB) {
+		t.Fatal("Expected the collision messages to differ")
+	}
+	if md5.Sum(md5CollisionA) != md5.Sum(md5CollisionB) {
+		t.Error("Expected the collision messages to share an MD5 digest")
+	}
+}
+
+func TestMD5CollisionProcessor_Process(t *testing.T) {
+	processor := NewMD5CollisionProcessor()
+	if err := processor.Configure(nil); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("pay $1", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	sum := md5.Sum(append(append([]byte{}, md5CollisionB...), "pay $1"...))
+	if result != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected the suffixed messages to keep colliding, got %s", result)
+	}
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{"Inputs differ but MD5 digests are identical", "Flame"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected steps to contain %q", want)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 type NonceReuseProcessor struct {
 	*BaseProcessor
 	config *AttackConfig
+	input  utils.Prompter
 }
 
 // NewNonceReuseProcessor creates a new nonce reuse attack processor
@@ -33,6 +34,10 @@ func (p *NonceReuseProcessor) Configure(config map[string]interface{}) error {
 		p.config.KeySize = keySize
 	}
 
+	if input, ok := config["input"].(utils.Prompter); ok {
+		p.input = input
+	}
+
 	// Generate a random key
 	p.config.Key = make([]byte, p.config.KeySize/8)
 	if _, err := rand.Read(p.config.Key); err != nil {
@@ -43,7 +48,7 @@ func (p *NonceReuseProcessor) Configure(config map[string]interface{}) error {
 }
 
 // Process demonstrates the nonce reuse vulnerability in AEAD ciphers
-func (p *NonceReuseProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *NonceReuseProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	p.addIntroduction()
 
 	// Get second message
@@ -76,8 +81,7 @@ func (p *NonceReuseProcessor) Process(text string, operation string) (string, []
 }
 
 func (p *NonceReuseProcessor) addIntroduction() {
-	p.AddStep("🔒 Nonce Reuse in AEAD Ciphers")
-	p.AddStep("============================")
+	p.AddHeader("🔒 Nonce Reuse in AEAD Ciphers")
 	p.AddNote("AEAD (Authenticated Encryption with Associated Data) ciphers")
 	p.AddNote("require unique nonces for each encryption operation")
 	p.AddNote("Reusing a nonce with the same key completely breaks security")
@@ -85,13 +89,8 @@ func (p *NonceReuseProcessor) addIntroduction() {
 }
 
 func (p *NonceReuseProcessor) getSecondMessage() string {
-	p.AddStep("Step 1: Message Collection")
-	p.AddStep("----------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter a second message to encrypt with the same nonce: ", "brightGreen"))
-	var secondMessage string
-	if _, err := fmt.Scanln(&secondMessage); err != nil {
-		secondMessage = "This is a different message encrypted with the same nonce!"
-	}
+	p.AddSubheader("Step 1: Message Collection")
+	secondMessage := utils.PromptLine(p.input, "Enter a second message to encrypt with the same nonce: ")
 	if secondMessage == "" {
 		secondMessage = "This is a different message encrypted with the same nonce!"
 	}
@@ -115,8 +114,7 @@ func (p *NonceReuseProcessor) addInputInfo(text, secondMessage string) {
 }
 
 func (p *NonceReuseProcessor) initializeCipher() (cipher.AEAD, []byte, error) {
-	p.AddStep("Step 2: Cipher Initialization")
-	p.AddStep("---------------------------")
+	p.AddSubheader("Step 2: Cipher Initialization")
 	aead, err := chacha20poly1305.New(p.config.Key)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create cipher: %w", err)
@@ -124,8 +122,7 @@ func (p *NonceReuseProcessor) initializeCipher() (cipher.AEAD, []byte, error) {
 	p.AddStep("✅ Cipher initialized successfully")
 	p.AddArrow()
 
-	p.AddStep("Step 3: Nonce Generation")
-	p.AddStep("---------------------")
+	p.AddSubheader("Step 3: Nonce Generation")
 	nonce := make([]byte, aead.NonceSize())
 	if _, err := rand.Read(nonce); err != nil {
 		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
@@ -139,8 +136,7 @@ func (p *NonceReuseProcessor) initializeCipher() (cipher.AEAD, []byte, error) {
 }
 
 func (p *NonceReuseProcessor) encryptMessages(aead cipher.AEAD, nonce []byte, text, secondMessage string) ([]byte, []byte) {
-	p.AddStep("Step 4: Encryption")
-	p.AddStep("----------------")
+	p.AddSubheader("Step 4: Encryption")
 	ciphertext1 := aead.Seal(nil, nonce, []byte(text), nil)
 	ciphertext2 := aead.Seal(nil, nonce, []byte(secondMessage), nil)
 
@@ -153,8 +149,7 @@ func (p *NonceReuseProcessor) encryptMessages(aead cipher.AEAD, nonce []byte, te
 }
 
 func (p *NonceReuseProcessor) demonstrateAttack(text, secondMessage string, ciphertext1, ciphertext2 []byte) {
-	p.AddStep("Step 5: Nonce Reuse Attack")
-	p.AddStep("----------------------")
+	p.AddSubheader("Step 5: Nonce Reuse Attack")
 	p.AddStep("When the same nonce is used with the same key:")
 	p.AddStep("1. The keystream is identical for both messages")
 	p.AddStep("2. XORing the ciphertexts reveals the XOR of the plaintexts")
@@ -177,8 +172,7 @@ func (p *NonceReuseProcessor) demonstrateAttack(text, secondMessage string, ciph
 }
 
 func (p *NonceReuseProcessor) addTechnicalExplanation() {
-	p.AddStep("🧠 Why This Works:")
-	p.AddStep("================")
+	p.AddHeader("🧠 Why This Works:")
 	p.AddStep("AEAD = Stream cipher + MAC")
 	p.AddStep("If nonce is reused:")
 	p.AddStep("• Same key + nonce → same keystream (KS)")
@@ -194,8 +188,7 @@ func (p *NonceReuseProcessor) addTechnicalExplanation() {
 }
 
 func (p *NonceReuseProcessor) addSecurityImplications() {
-	p.AddStep("🔒 Security Implications")
-	p.AddStep("======================")
+	p.AddHeader("🔒 Security Implications")
 	p.AddStep("1. Nonce reuse in AEAD ciphers is catastrophic")
 	p.AddStep("2. The same nonce with the same key produces identical keystream")
 	p.AddStep("3. This allows attackers to:")
@@ -205,13 +198,15 @@ func (p *NonceReuseProcessor) addSecurityImplications() {
 	p.AddStep("4. Authentication tags become meaningless")
 	p.AddStep("5. The entire security model collapses")
 
-	p.AddStep("✅ Best Practices")
-	p.AddStep("===============")
+	p.AddHeader("✅ Best Practices")
 	p.AddStep("1. Never reuse nonces with the same key")
 	p.AddStep("2. Use a cryptographically secure random number generator")
 	p.AddStep("3. Consider using a counter-based nonce generation")
 	p.AddStep("4. Implement proper nonce management in your application")
 	p.AddStep("5. Use unique nonces for each encryption operation")
+	p.AddStep("6. When unique nonces cannot be guaranteed, use a nonce-misuse-resistant AEAD such as")
+	p.AddStep("   AES-GCM-SIV (RFC 8452, see \"AES-GCM-SIV Encryption\" in the main menu): a reused nonce")
+	p.AddStep("   only reveals whether two messages are identical")
 }
 
 // xorBytes performs XOR operation on two byte slices
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"strings"
 	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 func TestNonceReuseProcessor_Configure(t *testing.T) {
@@ -128,6 +130,27 @@ func TestNonceReuseProcessor_Process(t *testing.T) {
 	}
 }
 
+// lineInput answers every prompt with the same line
+type lineInput string
+
+func (l lineInput) Choose(title string, options []string) (int, error) { return 1, nil }
+func (l lineInput) Prompt(prompt string) (string, error)               { return string(l), nil }
+
+func TestNonceReuseProcessor_PromptedSecondMessage(t *testing.T) {
+	p := NewNonceReuseProcessor()
+	if err := p.Configure(map[string]interface{}{"keySize": 256, "input": lineInput("Attack at dusk")}); err != nil {
+		t.Fatalf("failed to configure processor: %v", err)
+	}
+
+	_, steps, err := p.Process("Attack at dawn", "encrypt")
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Attack at dusk") {
+		t.Error("expected the prompted second message in the steps")
+	}
+}
+
 func TestNonceReuseProcessor_XorBytes(t *testing.T) {
 	tests := []struct {
 		name        string
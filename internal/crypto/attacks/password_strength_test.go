@@ -0,0 +1,75 @@
+package attacks
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestEstimatePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		pool     int
+		rating   string
+		common   bool
+	}{
+		{"abcdefg", 26, "Weak", false},
+		{"Tr0ub4dor&3", 95, "Strong", false},
+		{"correct horse battery staple", 59, "Very Strong", false},
+		{"password", 26, "Very Weak", true},
+		{"PASSWORD123!", 69, "Very Weak", true},
+		{"пароль", 100, "Reasonable", false},
+	}
+	for _, tt := range tests {
+		estimate := EstimatePassword(tt.password)
+		if estimate.PoolSize != tt.pool {
+			t.Errorf("%q: expected pool size %d, got %d", tt.password, tt.pool, estimate.PoolSize)
+		}
+		if estimate.Rating != tt.rating {
+			t.Errorf("%q: expected rating %q, got %q (%.1f bits)", tt.password, tt.rating, estimate.Rating, estimate.Effective)
+		}
+		if (estimate.CommonRank > 0) != tt.common {
+			t.Errorf("%q: expected common = %v, got rank %d", tt.password, tt.common, estimate.CommonRank)
+		}
+		want := float64(len([]rune(tt.password))) * math.Log2(float64(tt.pool))
+		if math.Abs(estimate.Entropy-want) > 1e-9 {
+			t.Errorf("%q: expected %.2f bits, got %.2f", tt.password, want, estimate.Entropy)
+		}
+	}
+}
+
+func TestPasswordStrengthProcessor_Process(t *testing.T) {
+	processor := NewPasswordStrengthProcessor()
+	if err := processor.Configure(map[string]interface{}{"iterations": 1000}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("password", "")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if !strings.HasPrefix(result, "Very Weak") {
+		t.Errorf("Expected a Very Weak rating, got %q", result)
+	}
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{"Found in the common password list", "1000 iterations", "bcrypt"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected steps to contain %q", want)
+		}
+	}
+
+	// Each call starts a fresh visualization
+	_, again, _ := processor.Process("password", "")
+	if len(again) != len(steps) {
+		t.Errorf("Expected %d steps on the second call, got %d", len(steps), len(again))
+	}
+
+	if _, _, err := processor.Process("", ""); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+	if err := processor.Configure(map[string]interface{}{"iterations": 0}); err == nil {
+		t.Error("Expected an error for zero iterations")
+	}
+}
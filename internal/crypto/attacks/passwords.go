@@ -1,5 +1,44 @@
 package attacks
 
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// wordlistBatchSize is the number of wordlist lines held in memory at once
+const wordlistBatchSize = 4096
+
+// streamWordlist reads one password per line and calls fn with successive batches
+// until fn returns true or the input ends. Blank lines are skipped.
+func streamWordlist(r io.Reader, batchSize int, fn func(batch []string) (stop bool)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	batch := make([]string, 0, batchSize)
+	for scanner.Scan() {
+		password := strings.TrimRight(scanner.Text(), "\r")
+		if password == "" {
+			continue
+		}
+		batch = append(batch, password)
+		if len(batch) == batchSize {
+			if fn(batch) {
+				return nil
+			}
+			batch = make([]string, 0, batchSize)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read wordlist: %w", err)
+	}
+	if len(batch) > 0 {
+		fn(batch)
+	}
+	return nil
+}
+
 // CommonPasswords returns a list of common passwords to test
 func CommonPasswords() []string {
 	return []string{
@@ -0,0 +1,225 @@
+package attacks
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// defaultIVGuesses are the attacker's candidate plaintexts besides the real secret
+var defaultIVGuesses = []string{"attack at dawn", "attack at dusk", "retreat at noon"}
+
+// cbcOracle encrypts chosen plaintexts with AES-CBC under a fixed key, as a server would
+type cbcOracle struct {
+	block       cipher.Block
+	nextIV      []byte
+	predictable bool // When true each IV is the last ciphertext block of the previous message
+}
+
+// newCBCOracle creates an oracle with a random key and first IV
+func newCBCOracle(predictable bool) (*cbcOracle, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	o := &cbcOracle{block: block, predictable: predictable, nextIV: make([]byte, aes.BlockSize)}
+	if _, err := rand.Read(o.nextIV); err != nil {
+		return nil, fmt.Errorf("failed to generate IV: %w", err)
+	}
+	return o, nil
+}
+
+// Encrypt pads and encrypts the plaintext, returning the IV used and the ciphertext
+func (o *cbcOracle) Encrypt(plaintext []byte) ([]byte, []byte, error) {
+	iv := o.nextIV
+	padded := pkcs7Pad(plaintext, aes.BlockSize)
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(o.block, iv).CryptBlocks(ciphertext, padded)
+
+	if o.predictable {
+		o.nextIV = ciphertext[len(ciphertext)-aes.BlockSize:]
+	} else {
+		o.nextIV = make([]byte, aes.BlockSize)
+		if _, err := rand.Read(o.nextIV); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate IV: %w", err)
+		}
+	}
+	return iv, ciphertext, nil
+}
+
+// PredictIV returns the IV an attacker expects for the next message
+func (o *cbcOracle) PredictIV(lastCiphertext []byte) []byte {
+	return lastCiphertext[len(lastCiphertext)-aes.BlockSize:]
+}
+
+// testGuess submits a chosen plaintext that cancels the predicted IV and reports whether
+// the oracle's ciphertext reproduces the victim's, which confirms the guess. The oracle's
+// ciphertext is returned so the next IV can be predicted from it.
+func testGuess(oracle *cbcOracle, victimIV, victimCiphertext, lastCiphertext []byte, guess string) (bool, []byte, error) {
+	chosen := pkcs7Pad([]byte(guess), aes.BlockSize)
+	nextIV := oracle.PredictIV(lastCiphertext)
+	for i := 0; i < aes.BlockSize; i++ {
+		chosen[i] ^= nextIV[i] ^ victimIV[i]
+	}
+
+	_, ciphertext, err := oracle.Encrypt(chosen)
+	if err != nil {
+		return false, nil, err
+	}
+	// The oracle appends its own padding block, so only compare the victim's length
+	match := len(ciphertext) >= len(victimCiphertext) &&
+		bytes.Equal(ciphertext[:len(victimCiphertext)], victimCiphertext)
+	return match, ciphertext, nil
+}
+
+// PredictableIVProcessor demonstrates a chosen-plaintext attack on CBC with predictable IVs
+type PredictableIVProcessor struct {
+	*BaseProcessor
+	config  *AttackConfig
+	guesses []string
+}
+
+// NewPredictableIVProcessor creates a new predictable IV attack processor
+func NewPredictableIVProcessor() *PredictableIVProcessor {
+	return &PredictableIVProcessor{
+		BaseProcessor: NewBaseProcessor(),
+		config:        NewAttackConfig(),
+		guesses:       defaultIVGuesses,
+	}
+}
+
+// Configure configures the predictable IV processor
+func (p *PredictableIVProcessor) Configure(config map[string]interface{}) error {
+	if guesses, ok := config["guesses"].([]string); ok {
+		if len(guesses) == 0 {
+			return fmt.Errorf("invalid guesses: at least one guess is required")
+		}
+		p.guesses = guesses
+	}
+	return nil
+}
+
+// Process encrypts the text as the victim's secret and tries to confirm it from a list of guesses
+func (p *PredictableIVProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	p.addIntroduction()
+	guesses := p.candidateList(text)
+
+	p.AddStep("Scenario:")
+	p.AddStep("  The victim's secret is one of a few likely messages")
+	p.AddStep("  The attacker can have the server encrypt chosen plaintexts (e.g. via injected JavaScript)")
+	for i, guess := range guesses {
+		p.AddStep(fmt.Sprintf("  Guess %d: %q", i+1, guess))
+	}
+	p.AddSeparator()
+
+	p.AddStep("🔗 CBC with chained (predictable) IVs, as in SSL 3.0 / TLS 1.0:")
+	confirmed, err := p.runAttack(text, guesses, true)
+	if err != nil {
+		return "", nil, err
+	}
+	p.AddSeparator()
+
+	p.AddStep("🎲 CBC with a fresh random IV per message:")
+	if _, err := p.runAttack(text, guesses, false); err != nil {
+		return "", nil, err
+	}
+	p.AddSeparator()
+
+	p.addDistinction()
+	p.addSecurityImplications()
+
+	return confirmed, p.GetSteps(), nil
+}
+
+func (p *PredictableIVProcessor) addIntroduction() {
+	p.AddHeader("🔮 Predictable CBC IV Demonstration (BEAST-style)")
+	p.AddNote("CBC encrypts the first block as E(K, IV ⊕ P1)")
+	p.AddNote("If the attacker knows the next IV, they can choose P1 to cancel it")
+	p.AddSeparator()
+}
+
+// candidateList returns the guesses with the real secret included exactly once
+func (p *PredictableIVProcessor) candidateList(secret string) []string {
+	guesses := []string{}
+	seen := map[string]bool{}
+	for _, guess := range append(append([]string{}, p.guesses...), secret) {
+		if !seen[guess] {
+			seen[guess] = true
+			guesses = append(guesses, guess)
+		}
+	}
+	return guesses
+}
+
+// runAttack lets the victim encrypt the secret and tests each guess with one chosen plaintext
+func (p *PredictableIVProcessor) runAttack(secret string, guesses []string, predictable bool) (string, error) {
+	oracle, err := newCBCOracle(predictable)
+	if err != nil {
+		return "", err
+	}
+	victimIV, victimCiphertext, err := oracle.Encrypt([]byte(secret))
+	if err != nil {
+		return "", err
+	}
+	p.AddHexStep("  Victim IV", victimIV)
+	p.AddTextStep("  Victim Ciphertext (Base64)", base64.StdEncoding.EncodeToString(victimCiphertext))
+
+	last := victimCiphertext
+	confirmed := ""
+	for _, guess := range guesses {
+		// With random IVs the attacker still predicts from the last ciphertext, and is wrong
+		p.AddHexStep(fmt.Sprintf("  Predicted IV for guess %q", guess), oracle.PredictIV(last))
+		match, ciphertext, err := testGuess(oracle, victimIV, victimCiphertext, last, guess)
+		if err != nil {
+			return "", err
+		}
+		last = ciphertext
+		if match {
+			confirmed = guess
+			p.AddStep(fmt.Sprintf("  ✅ %q: ciphertext matches, the secret is confirmed", guess))
+		} else {
+			p.AddStep(fmt.Sprintf("  ❌ %q: no match", guess))
+		}
+	}
+	if confirmed == "" {
+		p.AddStep("  No guess could be confirmed: the IV could not be cancelled out")
+	}
+	return confirmed, nil
+}
+
+func (p *PredictableIVProcessor) addDistinction() {
+	p.AddStep("📘 Unpredictable vs Merely Unique:")
+	p.AddStep("  CBC: the IV is XORed into the plaintext before the block cipher")
+	p.AddStep("       → it must be unpredictable, or chosen plaintexts can cancel it")
+	p.AddStep("       The chained IVs above never repeat, yet the attack still works")
+	p.AddStep("  CTR/GCM: the nonce only selects a keystream E(K, nonce‖counter)")
+	p.AddStep("       → plaintext never enters the block cipher, so knowing the nonce gives nothing")
+	p.AddStep("       → it must be unique; a counter is fine, but a repeat leaks P1 ⊕ P2")
+	p.AddSeparator()
+}
+
+func (p *PredictableIVProcessor) addSecurityImplications() {
+	p.AddStep("⚠️ Security Implications:")
+	p.AddStep("1. Predictable CBC IVs allow confirming guessed plaintext (cookies, PINs, yes/no answers)")
+	p.AddStep("2. BEAST (2011) exploited chained IVs in TLS 1.0 to recover HTTP cookies")
+
+	p.AddStep("✅ Best Practices:")
+	p.AddStep("1. Generate each CBC IV with crypto/rand, never from previous ciphertext or a counter")
+	p.AddStep("2. Prefer AEAD modes (AES-GCM, ChaCha20-Poly1305) with unique nonces")
+	p.AddStep("3. Use TLS 1.2+ which sends an explicit random IV per record")
+}
+
+// pkcs7Pad pads data to a multiple of blockSize
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - len(data)%blockSize
+	return append(append([]byte{}, data...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
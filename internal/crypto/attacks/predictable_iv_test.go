@@ -0,0 +1,67 @@
+package attacks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestTestGuess_PredictableIVDistinguishesChosenPlaintexts(t *testing.T) {
+	for _, predictable := range []bool{true, false} {
+		oracle, err := newCBCOracle(predictable)
+		if err != nil {
+			t.Fatalf("newCBCOracle failed: %v", err)
+		}
+		victimIV, victimCiphertext, err := oracle.Encrypt([]byte("transfer $100 to bob"))
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+
+		wrong, last, err := testGuess(oracle, victimIV, victimCiphertext, victimCiphertext, "transfer $900 to eve")
+		if err != nil {
+			t.Fatalf("testGuess failed: %v", err)
+		}
+		right, _, err := testGuess(oracle, victimIV, victimCiphertext, last, "transfer $100 to bob")
+		if err != nil {
+			t.Fatalf("testGuess failed: %v", err)
+		}
+
+		if wrong {
+			t.Errorf("predictable=%v: wrong guess was confirmed", predictable)
+		}
+		// Only a predictable IV lets the attacker confirm the right guess
+		if right != predictable {
+			t.Errorf("predictable=%v: right guess confirmed=%v", predictable, right)
+		}
+	}
+}
+
+func TestPredictableIVProcessor_Process(t *testing.T) {
+	processor := NewPredictableIVProcessor()
+	if err := processor.Configure(map[string]interface{}{"guesses": []string{"yes", "no"}}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("no", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result != "no" {
+		t.Errorf("Expected the secret %q to be confirmed, got %q", "no", result)
+	}
+
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{"Unpredictable vs Merely Unique", "No guess could be confirmed"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected steps to contain %q", want)
+		}
+	}
+}
+
+func TestPredictableIVProcessor_Configure_Invalid(t *testing.T) {
+	processor := NewPredictableIVProcessor()
+	if err := processor.Configure(map[string]interface{}{"guesses": []string{}}); err == nil {
+		t.Error("Expected an error for an empty guess list")
+	}
+}
@@ -0,0 +1,72 @@
+package attacks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// progressBarWidth is the widest progress bar drawn; larger totals are scaled down
+const progressBarWidth = 40
+
+// ConsoleProgressTracker implements progress tracking in the console
+type ConsoleProgressTracker struct {
+	unit     string // What is being counted, e.g. "bytes"
+	showRate bool   // Also print units per second
+	start    time.Time
+}
+
+// NewConsoleProgressTracker creates a new console progress tracker
+func NewConsoleProgressTracker() *ConsoleProgressTracker {
+	return &ConsoleProgressTracker{unit: "bytes"}
+}
+
+// NewRateProgressTracker creates a console progress tracker that also shows the rate per second
+func NewRateProgressTracker(unit string) *ConsoleProgressTracker {
+	return &ConsoleProgressTracker{unit: unit, showRate: true}
+}
+
+// UpdateProgress updates the progress display. A total of zero means the total is unknown.
+func (t *ConsoleProgressTracker) UpdateProgress(current, total int, eta time.Duration) {
+	if t.start.IsZero() {
+		t.start = time.Now()
+	}
+
+	rate := ""
+	if t.showRate {
+		if elapsed := time.Since(t.start).Seconds(); elapsed > 0 {
+			rate = fmt.Sprintf(" - %.0f %s/s", float64(current)/elapsed, t.unit)
+		}
+	}
+
+	if total <= 0 {
+		fmt.Printf("\rProgress: %d %s%s", current, t.unit, rate)
+		return
+	}
+
+	filled, width := current, total
+	if total > progressBarWidth {
+		filled, width = current*progressBarWidth/total, progressBarWidth
+	}
+	progressBar := fmt.Sprintf("[%s%s]",
+		strings.Repeat("█", filled),
+		strings.Repeat("░", width-filled))
+
+	etaStr := utils.FormatDuration(eta)
+	if etaStr == "" {
+		etaStr = "calculating..."
+	}
+
+	fmt.Printf("\rProgress: %-40s %d/%d %s - ETA: %-10s%s",
+		progressBar,
+		current, total, t.unit,
+		etaStr, rate)
+}
+
+// Complete marks the progress as complete
+func (t *ConsoleProgressTracker) Complete() {
+	fmt.Println()
+	t.start = time.Time{}
+}
@@ -0,0 +1,65 @@
+package attacks
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestSaltingProcessor_Process(t *testing.T) {
+	processor := NewSaltingProcessor()
+	result, steps, err := processor.Process("password", "")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	lines := strings.Split(result, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected an unsalted and two salted lines, got %q", result)
+	}
+	unsalted := sha256.Sum256([]byte("password"))
+	if lines[0] != "Unsalted: "+hex.EncodeToString(unsalted[:]) {
+		t.Errorf("Unexpected unsalted line %q", lines[0])
+	}
+
+	// Each salted record must verify, and the two must differ
+	var digests []string
+	for _, line := range lines[1:] {
+		salt, digest, ok := strings.Cut(line[strings.Index(line, ": ")+2:], "$")
+		if !ok {
+			t.Fatalf("Expected salt$digest, got %q", line)
+		}
+		saltBytes, err := hex.DecodeString(salt)
+		if err != nil || len(saltBytes) != saltSize {
+			t.Fatalf("Invalid salt in %q", line)
+		}
+		if hex.EncodeToString(saltedHash(saltBytes, "password")) != digest {
+			t.Errorf("Digest in %q does not match its salt", line)
+		}
+		digests = append(digests, digest)
+	}
+	if digests[0] == digests[1] {
+		t.Error("Expected different salted digests for the same password")
+	}
+
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), `One lookup cracks it: the digest belongs to "password"`) {
+		t.Error("Expected the lookup table to crack the unsalted hash")
+	}
+}
+
+func TestSaltingProcessor_Errors(t *testing.T) {
+	processor := NewSaltingProcessor()
+	if _, _, err := processor.Process("", ""); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+	if err := processor.Configure(map[string]interface{}{"rand": bytes.NewReader(nil)}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if _, _, err := processor.Process("password", ""); err == nil {
+		t.Error("Expected an error when the salt cannot be generated")
+	}
+}
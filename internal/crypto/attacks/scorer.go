@@ -0,0 +1,129 @@
+package attacks
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Available English-likelihood scorers
+const (
+	ScorerChiSquared = "chisquared"
+	ScorerBigram     = "bigram"
+	ScorerDictionary = "dictionary"
+)
+
+// Scorer rates how closely a candidate decryption resembles English (lower is closer)
+type Scorer interface {
+	// Name returns a human-readable name for the scoring strategy
+	Name() string
+	// Score rates the candidate plaintext
+	Score(text string) float64
+}
+
+// NewScorer returns the named scorer
+func NewScorer(name string) (Scorer, error) {
+	switch name {
+	case ScorerChiSquared:
+		return chiSquaredScorer{}, nil
+	case ScorerBigram:
+		return bigramScorer{}, nil
+	case ScorerDictionary:
+		return dictionaryScorer{}, nil
+	default:
+		return nil, fmt.Errorf("invalid scorer: %s (must be chisquared, bigram, or dictionary)", name)
+	}
+}
+
+// chiSquaredScorer compares single-letter frequencies with English
+type chiSquaredScorer struct{}
+
+func (chiSquaredScorer) Name() string { return "letter-frequency χ²" }
+
+func (chiSquaredScorer) Score(text string) float64 {
+	return chiSquared(onlyLetters(text))
+}
+
+// englishBigrams holds the percentage frequency of the most common English letter pairs
+var englishBigrams = map[string]float64{
+	"TH": 3.56, "HE": 3.07, "IN": 2.43, "ER": 2.05, "AN": 1.99, "RE": 1.85, "ON": 1.76,
+	"AT": 1.49, "EN": 1.45, "ND": 1.35, "TI": 1.34, "ES": 1.34, "OR": 1.28, "TE": 1.20,
+	"OF": 1.17, "ED": 1.17, "IS": 1.13, "IT": 1.12, "AL": 1.09, "AR": 1.07, "ST": 1.05,
+	"TO": 1.04, "NT": 1.04, "NG": 0.95, "SE": 0.93, "HA": 0.93, "AS": 0.87, "OU": 0.87,
+	"IO": 0.83, "LE": 0.83, "VE": 0.83, "CO": 0.79, "ME": 0.79, "DE": 0.76, "HI": 0.76,
+	"RI": 0.73, "RO": 0.73, "IC": 0.70, "NE": 0.69, "EA": 0.69, "RA": 0.69, "CE": 0.65,
+	"LI": 0.62, "CH": 0.60, "LL": 0.58, "BE": 0.58, "MA": 0.57, "SI": 0.55, "OM": 0.55,
+	"UR": 0.54,
+}
+
+// bigramScorer rates adjacent letter pairs within words by their English log-probability
+type bigramScorer struct{}
+
+func (bigramScorer) Name() string { return "bigram log-likelihood" }
+
+func (bigramScorer) Score(text string) float64 {
+	upper := strings.ToUpper(text)
+	total, pairs := 0.0, 0
+	for i := 0; i+1 < len(upper); i++ {
+		a, b := upper[i], upper[i+1]
+		if a < 'A' || a > 'Z' || b < 'A' || b > 'Z' {
+			continue
+		}
+		total -= math.Log(bigramProbability(a, b))
+		pairs++
+	}
+	if pairs == 0 {
+		return math.MaxFloat64
+	}
+	return total / float64(pairs)
+}
+
+// bigramProbability returns the English probability of a letter pair. Pairs outside the
+// common table fall back to half the product of their letter frequencies.
+func bigramProbability(a, b byte) float64 {
+	if pct, ok := englishBigrams[string([]byte{a, b})]; ok {
+		return pct / 100
+	}
+	return englishFrequencies[a-'A'] * englishFrequencies[b-'A'] / 2
+}
+
+// commonWords lists frequent English words used by the dictionary scorer
+var commonWords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`the of and to in is it you that he was for on are with as his they
+		be at one have this from or had by not but some what there we can out other were all your when
+		up use word how said an each she which do their time if will way about many then them would
+		write like so these her long make thing see him two has look more day could go come did my
+		no most who over know than call first people may down side been now find any new work part
+		take get place made live where after back little only round man year came show every good me
+		give our under name very through just form much great think say help low line before turn
+		cause same mean differ move right boy old too does tell set three want air well also play
+		small end put home read hand large spell add even land here must big high such why ask men
+		went light kind off need house picture try us again animal point mother world near build self
+		earth father head stand own page should country found answer school grow study still learn`) {
+		commonWords[strings.ToUpper(word)] = true
+	}
+}
+
+// dictionaryScorer counts how much of the candidate is made of common English words
+type dictionaryScorer struct{}
+
+func (dictionaryScorer) Name() string { return "dictionary word count" }
+
+func (dictionaryScorer) Score(text string) float64 {
+	words := strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool {
+		return r < 'A' || r > 'Z'
+	})
+	if len(words) == 0 {
+		return 0
+	}
+	matched := 0
+	for _, word := range words {
+		if commonWords[word] {
+			matched++
+		}
+	}
+	// Negate the matched fraction so that, like the other scorers, lower is better
+	return -float64(matched) / float64(len(words))
+}
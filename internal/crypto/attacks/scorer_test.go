@@ -0,0 +1,84 @@
+package attacks
+
+import (
+	"strings"
+	"testing"
+)
+
+// reverseText reverses text, keeping its letter counts but breaking its letter pairs
+func reverseText(text string) string {
+	b := []byte(text)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+func TestNewScorer(t *testing.T) {
+	for _, name := range []string{ScorerChiSquared, ScorerBigram, ScorerDictionary} {
+		if _, err := NewScorer(name); err != nil {
+			t.Errorf("NewScorer(%q) error = %v", name, err)
+		}
+	}
+	if _, err := NewScorer("trigram"); err == nil {
+		t.Error("Expected error for unknown scorer")
+	}
+}
+
+func TestBigramScorer_BeatsMonogramOnShortText(t *testing.T) {
+	samples := []string{
+		"meet me at the usual place",
+		"the quick brown fox",
+		"attack at dawn",
+		"send more money",
+		"it was the best of times",
+		"hold the line",
+		"retreat to the river",
+		"this is the end",
+	}
+
+	wins := func(scorer Scorer) int {
+		count := 0
+		for _, sample := range samples {
+			if scorer.Score(sample) < scorer.Score(reverseText(sample)) {
+				count++
+			}
+		}
+		return count
+	}
+
+	bigramWins := wins(bigramScorer{})
+	monogramWins := wins(chiSquaredScorer{})
+	if bigramWins != len(samples) {
+		t.Errorf("bigram scorer ranked English first in %d of %d samples", bigramWins, len(samples))
+	}
+	if bigramWins <= monogramWins {
+		t.Errorf("bigram scorer (%d wins) should beat the monogram scorer (%d wins)", bigramWins, monogramWins)
+	}
+}
+
+func TestDictionaryScorer(t *testing.T) {
+	scorer := dictionaryScorer{}
+	if scorer.Score("the man and the dog") >= scorer.Score("qzx vvk wpl") {
+		t.Error("dictionary scorer should prefer common English words")
+	}
+}
+
+func TestFrequencyAnalysisProcessor_Scorers(t *testing.T) {
+	ciphertext := shiftText(frequencySampleText, 26-9)
+	for _, name := range []string{ScorerChiSquared, ScorerBigram, ScorerDictionary} {
+		t.Run(name, func(t *testing.T) {
+			p := NewFrequencyAnalysisProcessor()
+			if err := p.Configure(map[string]interface{}{"cipher": "caesar", "scorer": name}); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			result, _, err := p.Process(ciphertext, "")
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if !strings.Contains(result, "shift 9") {
+				t.Errorf("expected shift 9, got %q", result)
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package attacks
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -19,13 +21,17 @@ type TimingAttackProcessor struct {
 	visualizer      AttackVisualizer
 	progressTracker ProgressTracker
 	config          *TimingAttackConfig
+
+	// constantTimeSimulator re-runs the attack against a constant-time verifier when enabled
+	constantTimeSimulator AttackSimulator
 }
 
 // TimingAttackConfig holds configuration for timing attacks
 type TimingAttackConfig struct {
-	KeySize      int
-	Iterations   int
-	DelayPerByte time.Duration
+	KeySize         int
+	Iterations      int
+	DelayPerByte    time.Duration
+	UseConstantTime bool // Also attack a verifier using subtle.ConstantTimeCompare
 }
 
 // NewTimingAttackProcessor creates a new timing attack processor
@@ -50,11 +56,39 @@ func (p *TimingAttackProcessor) Configure(config map[string]interface{}) error {
 	}
 
 	if iterations, ok := config["iterations"].(int); ok {
+		if iterations <= 0 {
+			return fmt.Errorf("invalid iterations: %d (must be positive)", iterations)
+		}
 		p.config.Iterations = iterations
 	}
 
+	if delay, ok := config["delayPerByte"].(time.Duration); ok {
+		if delay < 0 {
+			return fmt.Errorf("invalid delay per byte: %v (must not be negative)", delay)
+		}
+		p.config.DelayPerByte = delay
+	}
+
+	if useConstantTime, ok := config["useConstantTime"].(bool); ok {
+		p.config.UseConstantTime = useConstantTime
+	}
+
 	// Initialize components
-	p.simulator = NewTimingAttackSimulator(p.config)
+	simulator := NewTimingAttackSimulator(p.config)
+	p.simulator = simulator
+	p.constantTimeSimulator = nil
+	if p.config.UseConstantTime {
+		// Attack the same key so the two runs differ only in the verifier
+		key := make([]byte, p.config.KeySize/8)
+		if _, err := rand.Read(key); err != nil {
+			return fmt.Errorf("failed to generate key: %w", err)
+		}
+		simulator.key = key
+		constantTime := NewTimingAttackSimulator(p.config)
+		constantTime.key = key
+		constantTime.verify = constantTimeCompare
+		p.constantTimeSimulator = constantTime
+	}
 	p.visualizer = NewTimingAttackVisualizer()
 	p.progressTracker = NewConsoleProgressTracker()
 
@@ -62,18 +96,24 @@ func (p *TimingAttackProcessor) Configure(config map[string]interface{}) error {
 }
 
 // Process demonstrates the timing attack on HMAC comparison
-func (p *TimingAttackProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *TimingAttackProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	return p.ProcessContext(context.Background(), text, operation)
+}
+
+// ProcessContext is Process, stopping between guesses once ctx is done. When ctx hits its
+// deadline the bytes guessed so far are shown instead of returning an error.
+func (p *TimingAttackProcessor) ProcessContext(ctx context.Context, text string, operation string) (string, []utils.Step, error) {
 	// Add introduction
-	p.AddStep("🔒 Timing Attack on HMAC Comparison")
-	p.AddStep("================================")
+	p.AddHeader("🔒 Timing Attack on HMAC Comparison")
 	p.AddNote("Timing attacks exploit variations in execution time")
 	p.AddNote("In HMAC verification, byte-by-byte comparison can leak information")
 	p.AddNote("This simulation demonstrates why constant-time comparison is crucial")
 	p.AddSeparator()
 
 	// Run the attack simulation
-	result, err := p.simulator.Simulate(text)
-	if err != nil {
+	result, err := p.simulator.SimulateContext(ctx, text)
+	timedOut := errors.Is(err, context.DeadlineExceeded)
+	if err != nil && !timedOut {
 		return "", nil, err
 	}
 
@@ -81,6 +121,25 @@ func (p *TimingAttackProcessor) Process(text string, operation string) (string,
 	steps := p.visualizer.VisualizeAttack(result)
 	p.AddSteps(steps)
 
+	if timedOut {
+		p.AddSeparator()
+		p.AddNote(fmt.Sprintf("⏱ Time limit reached after %d of %d bytes; the remaining bytes were not guessed",
+			len(result.GuessedValue), len(result.CorrectValue)))
+		p.AddSteps(p.visualizer.VisualizeSecurityNotes())
+		return fmt.Sprintf("Attack stopped at the time limit after %s", utils.FormatDuration(result.Duration)), p.GetSteps(), nil
+	}
+
+	// Re-run the same attack against the constant-time verifier
+	if p.constantTimeSimulator != nil {
+		fmt.Println("\nRe-running the attack against subtle.ConstantTimeCompare...")
+		constantTimeResult, err := p.constantTimeSimulator.SimulateContext(ctx, text)
+		if err != nil {
+			return "", nil, err
+		}
+		p.addComparison(result, constantTimeResult)
+		result.Duration += constantTimeResult.Duration
+	}
+
 	// Add security notes
 	securityNotes := p.visualizer.VisualizeSecurityNotes()
 	p.AddSteps(securityNotes)
@@ -88,7 +147,23 @@ func (p *TimingAttackProcessor) Process(text string, operation string) (string,
 	// Get all steps once
 	allSteps := p.GetSteps()
 
-	return fmt.Sprintf("Attack completed in %.2fs", result.Duration.Seconds()), allSteps, nil
+	return fmt.Sprintf("Attack completed in %s", utils.FormatDuration(result.Duration)), allSteps, nil
+}
+
+// addComparison shows the accuracy of both runs side by side
+func (p *TimingAttackProcessor) addComparison(vulnerable, constantTime *AttackResult) {
+	total := len(vulnerable.CorrectValue)
+	p.AddSeparator()
+	p.AddStep("⚖️ Vulnerable vs Constant-Time Verifier:")
+	p.AddStep(fmt.Sprintf("  %-22s %-16s %s", "", "Byte-by-byte", "ConstantTimeCompare"))
+	p.AddStep(fmt.Sprintf("  %-22s %-16s %s", "Correct bytes",
+		fmt.Sprintf("%d/%d", vulnerable.Statistics.CorrectGuesses, total),
+		fmt.Sprintf("%d/%d", constantTime.Statistics.CorrectGuesses, total)))
+	p.AddStep(fmt.Sprintf("  %-22s %-16s %s", "Accuracy",
+		fmt.Sprintf("%.1f%%", vulnerable.Statistics.Accuracy),
+		fmt.Sprintf("%.1f%%", constantTime.Statistics.Accuracy)))
+	p.AddStep(fmt.Sprintf("  %-22s %.1f%% (1 in 256 per byte)", "Random guessing", 100.0/256))
+	p.AddNote("With the early exit removed, every guess takes the same time and the attacker is left guessing")
 }
 
 // TimingAttackSimulator implements the timing attack simulation logic
@@ -96,18 +171,28 @@ type TimingAttackSimulator struct {
 	config          *TimingAttackConfig
 	key             []byte
 	progressTracker ProgressTracker
+	verify          func(a, b []byte) bool // The server's MAC comparison under attack
 }
 
 // NewTimingAttackSimulator creates a new timing attack simulator
 func NewTimingAttackSimulator(config *TimingAttackConfig) *TimingAttackSimulator {
-	return &TimingAttackSimulator{
+	s := &TimingAttackSimulator{
 		config:          config,
 		progressTracker: NewConsoleProgressTracker(),
 	}
+	s.verify = func(a, b []byte) bool {
+		return compare(a, b, config.DelayPerByte)
+	}
+	return s
 }
 
 // Simulate runs the timing attack simulation
 func (s *TimingAttackSimulator) Simulate(input string) (*AttackResult, error) {
+	return s.SimulateContext(context.Background(), input)
+}
+
+// SimulateContext runs the timing attack simulation, stopping between guesses once ctx is done
+func (s *TimingAttackSimulator) SimulateContext(ctx context.Context, input string) (*AttackResult, error) {
 	// Generate key if not exists
 	if s.key == nil {
 		s.key = make([]byte, s.config.KeySize/8)
@@ -131,14 +216,14 @@ func (s *TimingAttackSimulator) Simulate(input string) (*AttackResult, error) {
 	totalBytes := len(correctHMAC)
 	totalGuesses := totalBytes * 256 // 256 possible values per byte
 	fmt.Printf("\nTotal work: %d bytes × 256 guesses = %d comparisons\n", totalBytes, totalGuesses)
-	fmt.Printf("Estimated time: %.1f seconds\n\n", float64(totalGuesses)*0.001*float64(s.config.Iterations))
+	fmt.Printf("Estimated time: %s\n\n", utils.FormatDuration(time.Duration(totalGuesses*s.config.Iterations)*s.config.DelayPerByte))
 
 	// Print initial progress line
 	fmt.Print("Progress: [", strings.Repeat("░", totalBytes), "] 0/", totalBytes, " bytes - ETA: calculating...")
 
 	// Run the attack
 	startTime := time.Now()
-	guessedHMAC, stats := s.runAttack(correctHMAC)
+	guessedHMAC, stats, completed := s.runAttack(ctx, correctHMAC)
 	result.Duration = time.Since(startTime)
 	result.GuessedValue = guessedHMAC
 	result.Statistics = stats
@@ -147,11 +232,14 @@ func (s *TimingAttackSimulator) Simulate(input string) (*AttackResult, error) {
 	// Complete progress tracking
 	s.progressTracker.Complete()
 
+	if completed < totalBytes {
+		return result, fmt.Errorf("timing attack stopped after %d of %d bytes: %w", completed, totalBytes, ctx.Err())
+	}
 	return result, nil
 }
 
-// runAttack performs the actual timing attack
-func (s *TimingAttackSimulator) runAttack(correctHMAC []byte) ([]byte, *AttackStatistics) {
+// runAttack performs the actual timing attack, returning how many bytes were guessed before ctx was done
+func (s *TimingAttackSimulator) runAttack(ctx context.Context, correctHMAC []byte) ([]byte, *AttackStatistics, int) {
 	guessedHMAC := make([]byte, len(correctHMAC))
 	stats := &AttackStatistics{
 		ByteTimings: make([]ByteTiming, len(correctHMAC)),
@@ -177,6 +265,11 @@ func (s *TimingAttackSimulator) runAttack(correctHMAC []byte) ([]byte, *AttackSt
 		var bestTime time.Duration
 
 		for b := 0; b < 256; b++ {
+			if ctx.Err() != nil {
+				stats.ByteTimings = stats.ByteTimings[:i]
+				s.calculateStatistics(stats)
+				return guessedHMAC[:i], stats, i
+			}
 			guessedHMAC[i] = byte(b)
 			byteTime := s.measureByteTime(guessedHMAC, correctHMAC)
 
@@ -205,7 +298,7 @@ func (s *TimingAttackSimulator) runAttack(correctHMAC []byte) ([]byte, *AttackSt
 
 	// Calculate statistics
 	s.calculateStatistics(stats)
-	return guessedHMAC, stats
+	return guessedHMAC, stats, len(correctHMAC)
 }
 
 // measureByteTime measures the time taken for a byte comparison
@@ -213,7 +306,7 @@ func (s *TimingAttackSimulator) measureByteTime(guessed, correct []byte) time.Du
 	var totalTime time.Duration
 	for i := 0; i < s.config.Iterations; i++ {
 		start := time.Now()
-		compare(guessed, correct)
+		s.verify(guessed, correct)
 		totalTime += time.Since(start)
 	}
 	return totalTime / time.Duration(s.config.Iterations)
@@ -222,6 +315,9 @@ func (s *TimingAttackSimulator) measureByteTime(guessed, correct []byte) time.Du
 // calculateStatistics computes attack statistics
 func (s *TimingAttackSimulator) calculateStatistics(stats *AttackStatistics) {
 	totalBytes := len(stats.ByteTimings)
+	if totalBytes == 0 {
+		return
+	}
 	stats.Accuracy = float64(stats.CorrectGuesses) / float64(totalBytes) * 100
 
 	var totalCorrectTime, totalIncorrectTime time.Duration
@@ -242,7 +338,7 @@ func (s *TimingAttackSimulator) calculateStatistics(stats *AttackStatistics) {
 }
 
 // compare implements a vulnerable byte-by-byte comparison
-func compare(a, b []byte) bool {
+func compare(a, b []byte, delay time.Duration) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -250,11 +346,16 @@ func compare(a, b []byte) bool {
 		if a[i] != b[i] {
 			return false
 		}
-		time.Sleep(time.Millisecond) // Simulated delay
+		time.Sleep(delay) // Simulated per-byte work
 	}
 	return true
 }
 
+// constantTimeCompare is the safe verifier: its running time does not depend on where the inputs differ
+func constantTimeCompare(a, b []byte) bool {
+	return utils.ConstantTimeCompare(a, b)
+}
+
 // TimingAttackVisualizer implements visualization for timing attacks
 type TimingAttackVisualizer struct {
 	*BaseProcessor
@@ -268,13 +369,13 @@ func NewTimingAttackVisualizer() *TimingAttackVisualizer {
 }
 
 // VisualizeAttack visualizes the attack results
-func (v *TimingAttackVisualizer) VisualizeAttack(result *AttackResult) []string {
+func (v *TimingAttackVisualizer) VisualizeAttack(result *AttackResult) []utils.Step {
 	// Clear any existing steps
 	v.visualizer = utils.NewVisualizer()
 
 	// Add attack results
 	v.AddTextStep("Attack Results:", "")
-	v.AddStep(fmt.Sprintf("Total attack time: %.2fs", result.Duration.Seconds()))
+	v.AddStep(fmt.Sprintf("Total attack time: %s", utils.FormatDuration(result.Duration)))
 	v.AddStep(fmt.Sprintf("Guessed HMAC: %s", hex.EncodeToString(result.GuessedValue)))
 	v.AddStep(fmt.Sprintf("Correct HMAC: %s", hex.EncodeToString(result.CorrectValue)))
 
@@ -290,13 +391,12 @@ func (v *TimingAttackVisualizer) VisualizeAttack(result *AttackResult) []string
 	v.AddStep(fmt.Sprintf("✔️ Correct guesses: %d", result.Statistics.CorrectGuesses))
 	v.AddStep(fmt.Sprintf("❌ Incorrect guesses: %d", result.Statistics.IncorrectGuesses))
 	v.AddStep(fmt.Sprintf("📊 Accuracy: %.1f%%", result.Statistics.Accuracy))
-	v.AddStep(fmt.Sprintf("⏱️ Average time per byte (correct): %.1fms", float64(result.Statistics.AvgCorrectTime.Microseconds())/1000.0))
-	v.AddStep(fmt.Sprintf("⏱️ Average time per byte (wrong): %.1fms", float64(result.Statistics.AvgIncorrectTime.Microseconds())/1000.0))
+	v.AddStep(fmt.Sprintf("⏱️ Average time per byte (correct): %s", utils.FormatDuration(result.Statistics.AvgCorrectTime)))
+	v.AddStep(fmt.Sprintf("⏱️ Average time per byte (wrong): %s", utils.FormatDuration(result.Statistics.AvgIncorrectTime)))
 
 	// Add timing visualization
 	v.AddSeparator()
-	v.AddStep("Timing Visualization:")
-	v.AddStep("===================")
+	v.AddHeader("Timing Visualization:")
 
 	// Find max time for scaling
 	var maxTime time.Duration
@@ -307,17 +407,18 @@ func (v *TimingAttackVisualizer) VisualizeAttack(result *AttackResult) []string
 	}
 
 	// Show timing graph
+	maxChars := utils.BarChartWidth(22)
 	for _, bt := range result.Statistics.ByteTimings {
-		barLength := int(float64(bt.Duration) / float64(maxTime) * 50)
+		barLength := int(float64(bt.Duration) / float64(maxTime) * float64(maxChars))
 		bar := strings.Repeat("█", barLength)
 		status := "❌"
 		if bt.IsCorrect {
 			status = "✔️"
 		}
 
-		v.AddStep(fmt.Sprintf("Byte %2d: %6.2fms %s %s",
+		v.AddStep(fmt.Sprintf("Byte %2d: %8s %s %s",
 			bt.ByteNumber,
-			float64(bt.Duration.Microseconds())/1000.0,
+			utils.FormatDuration(bt.Duration),
 			bar,
 			status))
 	}
@@ -326,12 +427,12 @@ func (v *TimingAttackVisualizer) VisualizeAttack(result *AttackResult) []string
 }
 
 // VisualizeSecurityNotes visualizes security-related notes
-func (v *TimingAttackVisualizer) VisualizeSecurityNotes() []string {
+func (v *TimingAttackVisualizer) VisualizeSecurityNotes() []utils.Step {
 	// Clear any existing steps
 	v.visualizer = utils.NewVisualizer()
 
 	v.AddSeparator()
-	v.AddStep("🔒 Security Implications:")
+	v.AddHeader("🔒 Security Implications:")
 	v.AddStep("1. Timing attacks can reveal secret information")
 	v.AddStep("2. Byte-by-byte comparison is vulnerable")
 	v.AddStep("3. Execution time variations leak information")
@@ -354,34 +455,3 @@ func (v *TimingAttackVisualizer) VisualizeSecurityNotes() []string {
 
 	return v.GetSteps()
 }
-
-// ConsoleProgressTracker implements progress tracking in the console
-type ConsoleProgressTracker struct {
-}
-
-// NewConsoleProgressTracker creates a new console progress tracker
-func NewConsoleProgressTracker() *ConsoleProgressTracker {
-	return &ConsoleProgressTracker{}
-}
-
-// UpdateProgress updates the progress display
-func (t *ConsoleProgressTracker) UpdateProgress(current, total int, eta time.Duration) {
-	progressBar := fmt.Sprintf("[%s%s]",
-		strings.Repeat("█", current),
-		strings.Repeat("░", total-current))
-
-	etaStr := utils.FormatDuration(eta)
-	if etaStr == "" {
-		etaStr = "calculating..."
-	}
-
-	fmt.Printf("\rProgress: %-40s %d/%d bytes - ETA: %-10s",
-		progressBar,
-		current, total,
-		etaStr)
-}
-
-// Complete marks the progress as complete
-func (t *ConsoleProgressTracker) Complete() {
-	fmt.Println()
-}
@@ -0,0 +1,101 @@
+package attacks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestConstantTimeCompare(t *testing.T) {
+	a := []byte("0123456789abcdef")
+	if !constantTimeCompare(a, []byte("0123456789abcdef")) {
+		t.Error("Expected equal inputs to match")
+	}
+	if constantTimeCompare(a, []byte("0123456789abcdeX")) {
+		t.Error("Expected different inputs not to match")
+	}
+	if constantTimeCompare(a, a[:8]) {
+		t.Error("Expected inputs of different length not to match")
+	}
+}
+
+func TestTimingAttackProcessor_UseConstantTime(t *testing.T) {
+	processor := NewTimingAttackProcessor()
+	if err := processor.Configure(map[string]interface{}{"keySize": 256}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if processor.constantTimeSimulator != nil {
+		t.Error("Expected no constant-time run by default")
+	}
+
+	if err := processor.Configure(map[string]interface{}{"useConstantTime": true}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	vulnerable := processor.simulator.(*TimingAttackSimulator)
+	constantTime, ok := processor.constantTimeSimulator.(*TimingAttackSimulator)
+	if !ok {
+		t.Fatal("Expected a constant-time simulator when useConstantTime is set")
+	}
+	if !bytes.Equal(vulnerable.key, constantTime.key) || len(constantTime.key) != 32 {
+		t.Error("Expected both runs to attack the same 256-bit key")
+	}
+}
+
+func TestTimingAttackProcessor_ConfigureValidation(t *testing.T) {
+	processor := NewTimingAttackProcessor()
+	if err := processor.Configure(map[string]interface{}{"iterations": 0}); err == nil {
+		t.Error("Expected error for zero iterations")
+	}
+	if err := processor.Configure(map[string]interface{}{"delayPerByte": -time.Millisecond}); err == nil {
+		t.Error("Expected error for negative delay")
+	}
+	if err := processor.Configure(map[string]interface{}{"iterations": 2, "delayPerByte": time.Microsecond}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if processor.config.Iterations != 2 || processor.config.DelayPerByte != time.Microsecond {
+		t.Errorf("Expected iterations 2 and delay 1µs, got %d and %v", processor.config.Iterations, processor.config.DelayPerByte)
+	}
+}
+
+func TestTimingAttackSimulator_Cancelled(t *testing.T) {
+	processor := NewTimingAttackProcessor()
+	if err := processor.Configure(map[string]interface{}{"iterations": 2, "delayPerByte": time.Microsecond}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := processor.simulator.SimulateContext(ctx, "message")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if result == nil || len(result.GuessedValue) != 0 {
+		t.Errorf("Expected an empty partial result, got %+v", result)
+	}
+}
+
+func TestTimingAttackProcessor_TimeLimit(t *testing.T) {
+	processor := NewTimingAttackProcessor()
+	if err := processor.Configure(map[string]interface{}{"iterations": 1, "delayPerByte": time.Millisecond}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	// An expired deadline stops the attack before the first byte
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+
+	result, steps, err := processor.ProcessContext(ctx, "message", "attack")
+	if err != nil {
+		t.Fatalf("Expected partial results at the time limit, got %v", err)
+	}
+	if !strings.HasPrefix(result, "Attack stopped at the time limit") {
+		t.Errorf("Unexpected result %q", result)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Time limit reached") {
+		t.Error("Expected a note about the time limit")
+	}
+}
@@ -1,6 +1,7 @@
 package attacks
 
 import (
+	"context"
 	"time"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
@@ -9,7 +10,7 @@ import (
 // AttackProcessor defines the interface for all attack simulations
 type AttackProcessor interface {
 	Configure(config map[string]interface{}) error
-	Process(text string, operation string) (string, []string, error)
+	Process(text string, operation string) (string, []utils.Step, error)
 }
 
 // BaseProcessor provides common functionality for all attack processors
@@ -29,6 +30,21 @@ func (p *BaseProcessor) AddStep(step string) {
 	p.visualizer.AddStep(step)
 }
 
+// AddHeader adds a section title to the visualization
+func (p *BaseProcessor) AddHeader(title string) {
+	p.visualizer.AddHeader(title)
+}
+
+// AddSubheader adds the title of a step or subsection to the visualization
+func (p *BaseProcessor) AddSubheader(title string) {
+	p.visualizer.AddSubheader(title)
+}
+
+// AddDiagram adds an ASCII diagram to the visualization
+func (p *BaseProcessor) AddDiagram(diagram string) {
+	p.visualizer.AddDiagram(diagram)
+}
+
 // AddNote adds a note to the visualization
 func (p *BaseProcessor) AddNote(note string) {
 	p.visualizer.AddNote(note)
@@ -55,15 +71,13 @@ func (p *BaseProcessor) AddArrow() {
 }
 
 // GetSteps returns the visualization steps
-func (p *BaseProcessor) GetSteps() []string {
+func (p *BaseProcessor) GetSteps() []utils.Step {
 	return p.visualizer.GetSteps()
 }
 
-// AddSteps adds multiple steps to the visualization
-func (p *BaseProcessor) AddSteps(steps []string) {
-	for _, step := range steps {
-		p.AddStep(step)
-	}
+// AddSteps adds multiple steps to the visualization, keeping their types
+func (p *BaseProcessor) AddSteps(steps []utils.Step) {
+	p.visualizer.AddSteps(steps)
 }
 
 // AttackConfig holds common configuration for attacks
@@ -72,6 +86,7 @@ type AttackConfig struct {
 	Iterations int
 	Salt       []byte
 	Key        []byte
+	MaxWorkers int // Zero means one worker per CPU
 }
 
 // NewAttackConfig creates a new attack configuration with default values
@@ -111,6 +126,8 @@ type ByteTiming struct {
 // AttackSimulator defines the interface for attack simulations
 type AttackSimulator interface {
 	Simulate(input string) (*AttackResult, error)
+	// SimulateContext is Simulate, stopping early with an error wrapping ctx.Err() once ctx is done
+	SimulateContext(ctx context.Context, input string) (*AttackResult, error)
 }
 
 // ProgressTracker handles progress reporting during attacks
@@ -121,6 +138,6 @@ type ProgressTracker interface {
 
 // AttackVisualizer handles visualization of attack results
 type AttackVisualizer interface {
-	VisualizeAttack(result *AttackResult) []string
-	VisualizeSecurityNotes() []string
+	VisualizeAttack(result *AttackResult) []utils.Step
+	VisualizeSecurityNotes() []utils.Step
 }
@@ -0,0 +1,205 @@
+package attacks
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// defaultSeedWindow is how many seconds back the attacker searches for the seed
+const defaultSeedWindow = 3600
+
+// WeakRNGProcessor demonstrates recovering a key generated from a time-seeded math/rand
+type WeakRNGProcessor struct {
+	*BaseProcessor
+	config     *AttackConfig
+	seedWindow int64
+	seed       int64 // Victim seed; zero means a time within the window
+}
+
+// NewWeakRNGProcessor creates a new weak RNG attack processor
+func NewWeakRNGProcessor() *WeakRNGProcessor {
+	return &WeakRNGProcessor{
+		BaseProcessor: NewBaseProcessor(),
+		config:        NewAttackConfig(),
+		seedWindow:    defaultSeedWindow,
+	}
+}
+
+// Configure configures the weak RNG processor
+func (p *WeakRNGProcessor) Configure(config map[string]interface{}) error {
+	if keySize, ok := config["keySize"].(int); ok {
+		switch keySize {
+		case 128, 192, 256:
+			p.config.KeySize = keySize
+		default:
+			return fmt.Errorf("invalid key size: %d (must be 128, 192, or 256)", keySize)
+		}
+	}
+	if window, ok := config["seedWindow"].(int); ok {
+		if window <= 0 {
+			return fmt.Errorf("invalid seed window: %d (must be positive)", window)
+		}
+		p.seedWindow = int64(window)
+	}
+	if seed, ok := config["seed"].(int64); ok {
+		p.seed = seed
+	}
+	return nil
+}
+
+// Process encrypts the text under a weakly generated key and recovers that key by replaying seeds
+func (p *WeakRNGProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	p.addIntroduction()
+
+	now := time.Now().Unix()
+	seed := p.seed
+	if seed == 0 {
+		// The victim generated its key at some point in the last seedWindow seconds
+		offset, err := rand.Int(rand.Reader, big.NewInt(p.seedWindow))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to pick key generation time: %w", err)
+		}
+		seed = now - offset.Int64()
+	}
+
+	// Victim side
+	key := weakKey(seed, p.config.KeySize/8)
+	ciphertext, err := sealWithKey(key, []byte(text))
+	if err != nil {
+		return "", nil, err
+	}
+	p.AddStep("Victim Key Generation:")
+	p.AddStep("  rand.Seed(time.Now().Unix()) followed by rand.Read(key)")
+	p.AddStep(fmt.Sprintf("  Generated at %s", time.Unix(seed, 0).UTC().Format(time.RFC3339)))
+	p.AddHexStep("Target Key (secret)", key)
+	p.AddTextStep("Encrypted Message (AES-GCM, Base64)", base64.StdEncoding.EncodeToString(ciphertext))
+	p.AddSeparator()
+
+	// Attacker side
+	recovered, recoveredSeed, tried, elapsed := p.recoverKey(ciphertext, now)
+	p.AddStep("Attack Process:")
+	p.AddStep(fmt.Sprintf("1. Assume the key was made in the last %d seconds", p.seedWindow))
+	p.AddStep("2. For each candidate second, seed math/rand and regenerate the key")
+	p.AddStep("3. Accept the key whose AES-GCM tag verifies on the captured ciphertext")
+	p.AddSeparator()
+
+	if recovered == nil {
+		p.AddStep(fmt.Sprintf("Seed not found after %d candidates (generated outside the search window)", tried))
+		p.addComparison()
+		return "", p.GetSteps(), nil
+	}
+
+	p.AddStep(fmt.Sprintf("Seed recovered: %d (after %d candidates in %v)", recoveredSeed, tried, elapsed.Round(time.Millisecond)))
+	p.AddHexStep("Recovered Key", recovered)
+	p.AddArrow()
+	if bytes.Equal(recovered, key) {
+		p.AddStep("✅ Recovered key matches the target key byte for byte")
+	}
+	plaintext, err := openWithKey(recovered, ciphertext)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decrypt with recovered key: %w", err)
+	}
+	p.AddTextStep("Decrypted Message", string(plaintext))
+	p.AddSeparator()
+
+	p.addComparison()
+	p.addSecurityImplications()
+
+	return string(plaintext), p.GetSteps(), nil
+}
+
+func (p *WeakRNGProcessor) addIntroduction() {
+	p.AddHeader("🎲 Weak RNG Key Generation Demonstration")
+	p.AddNote("math/rand is a deterministic PRNG: the same seed always yields the same output")
+	p.AddNote("Seeding it with the current time leaves only a few thousand possible keys")
+	p.AddSeparator()
+}
+
+// recoverKey replays every seed in the window, newest first, until one decrypts the ciphertext
+func (p *WeakRNGProcessor) recoverKey(ciphertext []byte, now int64) ([]byte, int64, int, time.Duration) {
+	start := time.Now()
+	tried := 0
+	for seed := now; seed > now-p.seedWindow; seed-- {
+		tried++
+		candidate := weakKey(seed, p.config.KeySize/8)
+		if _, err := openWithKey(candidate, ciphertext); err == nil {
+			return candidate, seed, tried, time.Since(start)
+		}
+	}
+	return nil, 0, tried, time.Since(start)
+}
+
+func (p *WeakRNGProcessor) addComparison() {
+	p.AddStep("math/rand vs crypto/rand:")
+	p.AddStep(fmt.Sprintf("  math/rand seeded by time: ~2^%.0f candidate keys (one per second in the window)",
+		math.Log2(float64(p.seedWindow))))
+	p.AddStep(fmt.Sprintf("  crypto/rand:              2^%d candidate keys, with no seed to replay", p.config.KeySize))
+	p.AddStep("  crypto/rand reads from the operating system's CSPRNG, which is unpredictable")
+	p.AddSeparator()
+}
+
+func (p *WeakRNGProcessor) addSecurityImplications() {
+	p.AddStep("⚠️ Security Implications:")
+	p.AddStep("1. Key length means nothing if the key comes from a small seed space")
+	p.AddStep("2. Timestamps, PIDs, and counters are guessable seeds")
+	p.AddStep("3. Real incidents: Debian OpenSSL (2008), predictable wallet and session keys")
+
+	p.AddStep("✅ Best Practices:")
+	p.AddStep("1. Always use crypto/rand for keys, nonces, IVs, salts, and tokens")
+	p.AddStep("2. Never seed or reuse a general-purpose PRNG for secrets")
+	p.AddStep("3. Derive subkeys with a KDF (HKDF) from a random master key")
+}
+
+// weakKey generates a key the insecure way: math/rand seeded with a timestamp
+func weakKey(seed int64, size int) []byte {
+	key := make([]byte, size)
+	mathrand.New(mathrand.NewSource(seed)).Read(key)
+	return key
+}
+
+// sealWithKey encrypts data with AES-GCM, prefixing the nonce
+func sealWithKey(key, data []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// openWithKey decrypts data produced by sealWithKey
+func openWithKey(key, data []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,68 @@
+package attacks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestWeakRNGProcessor_RecoversKey(t *testing.T) {
+	processor := NewWeakRNGProcessor()
+	if err := processor.Configure(map[string]interface{}{"keySize": 128, "seedWindow": 600}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("launch codes", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result != "launch codes" {
+		t.Errorf("Expected recovered plaintext %q, got %q", "launch codes", result)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Recovered key matches the target key") {
+		t.Error("Expected steps to confirm the recovered key matches")
+	}
+}
+
+func TestWeakRNGProcessor_SeedOutsideWindow(t *testing.T) {
+	processor := NewWeakRNGProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"seedWindow": 10,
+		"seed":       time.Now().Add(-time.Hour).Unix(),
+	}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("secret", "attack")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result != "" {
+		t.Errorf("Expected no recovery outside the window, got %q", result)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Seed not found") {
+		t.Error("Expected steps to report the seed was not found")
+	}
+}
+
+func TestWeakKeyIsDeterministic(t *testing.T) {
+	if !bytes.Equal(weakKey(42, 32), weakKey(42, 32)) {
+		t.Error("Expected the same seed to produce the same key")
+	}
+	if bytes.Equal(weakKey(42, 32), weakKey(43, 32)) {
+		t.Error("Expected different seeds to produce different keys")
+	}
+}
+
+func TestWeakRNGProcessor_Configure_Invalid(t *testing.T) {
+	processor := NewWeakRNGProcessor()
+	if err := processor.Configure(map[string]interface{}{"keySize": 100}); err == nil {
+		t.Error("Expected an error for an invalid key size")
+	}
+	if err := processor.Configure(map[string]interface{}{"seedWindow": 0}); err == nil {
+		t.Error("Expected an error for a zero seed window")
+	}
+}
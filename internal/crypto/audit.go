@@ -0,0 +1,86 @@
+package crypto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Kinds of sources reported by a dependency audit
+const (
+	AuditRandomness      = "randomness"
+	AuditStandardLibrary = "standard library"
+	AuditExternalLibrary = "external library"
+	AuditInRepository    = "in-repository"
+)
+
+// AuditSource is a randomness source or library a processor relies on
+type AuditSource struct {
+	Kind    string
+	Package string
+	Purpose string
+}
+
+// AuditableProcessor is implemented by processors that can report their dependencies
+type AuditableProcessor interface {
+	// AuditSources lists the randomness sources and libraries used by the current configuration
+	AuditSources() []AuditSource
+}
+
+// keyManagerRandomness is the randomness source behind FileKeyManager key generation
+var keyManagerRandomness = AuditSource{
+	Kind:    AuditRandomness,
+	Package: "crypto/rand",
+	Purpose: "Symmetric key generation (FileKeyManager)",
+}
+
+// AuditReport lists where a processor's randomness and primitives come from
+func AuditReport(processor Processor) (string, []utils.Step, error) {
+	auditable, ok := processor.(AuditableProcessor)
+	if !ok {
+		return "", nil, fmt.Errorf("processor does not support dependency audits")
+	}
+	sources := auditable.AuditSources()
+
+	v := utils.NewVisualizer()
+	v.AddHeader("Dependency Audit")
+	v.AddNote("Lists every randomness source and library used by this operation")
+	v.AddSeparator()
+
+	for _, kind := range []string{AuditRandomness, AuditStandardLibrary, AuditExternalLibrary, AuditInRepository} {
+		var matching []AuditSource
+		for _, source := range sources {
+			if source.Kind == kind {
+				matching = append(matching, source)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		switch kind {
+		case AuditRandomness:
+			v.AddStep("Randomness Sources:")
+		case AuditStandardLibrary:
+			v.AddStep("Go Standard Library Primitives:")
+		case AuditExternalLibrary:
+			v.AddStep("External Libraries:")
+		case AuditInRepository:
+			v.AddStep("⚠️ In-Repository Implementations (not independently audited):")
+		}
+		for _, source := range matching {
+			v.AddStep(fmt.Sprintf("  • %s — %s", source.Package, source.Purpose))
+		}
+		v.AddSeparator()
+	}
+
+	v.AddNote("Prefer audited, widely reviewed libraries over hand-rolled primitives")
+	v.AddNote("crypto/rand reads from the operating system CSPRNG and never needs manual seeding")
+
+	packages := make([]string, 0, len(sources))
+	for _, source := range sources {
+		packages = append(packages, source.Package)
+	}
+	return fmt.Sprintf("Sources: %s", strings.Join(packages, ", ")), v.GetSteps(), nil
+}
@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestAvalancheProcessor_Process(t *testing.T) {
+	for _, target := range []string{HashSHA256, HashSHA3256, HashBLAKE3, AvalancheAES} {
+		processor := NewAvalancheProcessor()
+		if err := processor.Configure(map[string]interface{}{"target": target, "bit": 5}); err != nil {
+			t.Fatalf("Configure(%s) error = %v", target, err)
+		}
+		result, steps, err := processor.Process("avalanche", OperationEncrypt)
+		if err != nil {
+			t.Fatalf("Process(%s) error = %v", target, err)
+		}
+
+		var changed, total int
+		var percent float64
+		if _, err := fmt.Sscanf(result, "Flipping 1 input bit changed %d of %d output bits (%f%%)", &changed, &total, &percent); err != nil {
+			t.Fatalf("%s: unexpected result %q", target, result)
+		}
+		// A single sample varies, but far fewer than a quarter or more than three quarters is a bug
+		if changed < total/4 || changed > total*3/4 {
+			t.Errorf("%s: changed %d of %d bits, want roughly half", target, changed, total)
+		}
+
+		output := strings.Join(utils.StepTexts(steps), "\n")
+		for _, want := range []string{"Step 1: Flip input bit 5 (byte 0, bit 5 from the left)", "^^", "█"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("%s: expected steps to contain %q", target, want)
+			}
+		}
+	}
+}
+
+func TestAvalancheProcessor_Errors(t *testing.T) {
+	processor := NewAvalancheProcessor()
+	if err := processor.Configure(map[string]interface{}{"target": "md5"}); err == nil {
+		t.Error("Expected an error for an unsupported target")
+	}
+	if err := processor.Configure(map[string]interface{}{"bit": -1}); err == nil {
+		t.Error("Expected an error for a negative bit")
+	}
+	if err := processor.Configure(map[string]interface{}{"bit": 16}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if _, _, err := processor.Process("ab", OperationEncrypt); err == nil {
+		t.Error("Expected an error for a bit past the end of the input")
+	}
+	if _, _, err := processor.Process("abc", OperationDecrypt); err == nil {
+		t.Error("Expected an error for decryption")
+	}
+}
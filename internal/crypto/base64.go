@@ -20,7 +20,7 @@ func (p *Base64Processor) Configure(config map[string]interface{}) error {
 	return p.BaseConfigurableProcessor.Configure(config)
 }
 
-func (p *Base64Processor) Process(text string, operation string) (string, []string, error) {
+func (p *Base64Processor) Process(text string, operation string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 
 	// Validate operation type
@@ -29,8 +29,7 @@ func (p *Base64Processor) Process(text string, operation string) (string, []stri
 	}
 
 	// Add introduction
-	v.AddStep("Base64 Encoding/Decoding Process")
-	v.AddStep("=============================")
+	v.AddHeader("Base64 Encoding/Decoding Process")
 	v.AddNote("Base64 is a binary-to-text encoding scheme")
 	v.AddNote("It represents binary data in an ASCII string format")
 	v.AddSeparator()
@@ -0,0 +1,285 @@
+package crypto
+
+import (
+	"encoding/ascii85"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Radixes supported by the BaseNProcessor
+const (
+	Base16 = "base16"
+	Base58 = "base58"
+	Base62 = "base62"
+	Base85 = "base85"
+)
+
+// Alphabets for the positional encodings. Base58 is Bitcoin's, which drops 0, O, I, and l
+// so that addresses cannot be misread.
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// baseNMaxSteps is how many conversion steps are shown before the rest are summarized
+const baseNMaxSteps = 8
+
+// BaseNProcessor converts bytes to and from base16, base58, base62, or base85 (Ascii85)
+type BaseNProcessor struct {
+	BaseConfigurableProcessor
+	base string
+}
+
+// NewBaseNProcessor creates a new base-N processor using base58
+func NewBaseNProcessor() *BaseNProcessor {
+	return &BaseNProcessor{
+		base: Base58,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *BaseNProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if base, ok := config["base"].(string); ok && base != "" {
+		switch base {
+		case Base16, Base58, Base62, Base85:
+			p.base = base
+		default:
+			return fmt.Errorf("unsupported base: %s (must be one of: base16, base58, base62, base85)", base)
+		}
+	}
+
+	return nil
+}
+
+// radix returns the number of symbols of the configured base
+func (p *BaseNProcessor) radix() int {
+	switch p.base {
+	case Base16:
+		return 16
+	case Base62:
+		return 62
+	case Base85:
+		return 85
+	default:
+		return 58
+	}
+}
+
+// Process implements the Processor interface; encrypt encodes and decrypt decodes
+func (p *BaseNProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	if operation != OperationEncrypt && operation != OperationDecrypt {
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	radix := p.radix()
+	v := utils.NewVisualizer()
+	v.AddHeader(fmt.Sprintf("Base%d Encoding/Decoding Process", radix))
+	v.AddNote(fmt.Sprintf("Base%d writes bytes with %d symbols, about %.2f characters per byte",
+		radix, radix, 8/math.Log2(float64(radix))))
+	switch p.base {
+	case Base16:
+		v.AddNote("Base16 (hex) splits every byte into two 4-bit nibbles")
+	case Base58:
+		v.AddNote("Base58 is used for Bitcoin addresses and IPFS hashes; its alphabet has no 0, O, I, or l")
+	case Base62:
+		v.AddNote("Base62 uses only letters and digits, so it is safe in URLs and identifiers")
+	case Base85:
+		v.AddNote("Base85 (Ascii85, used by PDF and PostScript) turns every 4 bytes into 5 characters")
+	}
+	v.AddSeparator()
+
+	var result string
+	var err error
+	switch p.base {
+	case Base16:
+		result, err = p.processBase16(v, text, operation)
+	case Base85:
+		result, err = p.processBase85(v, text, operation)
+	default:
+		alphabet := base58Alphabet
+		if p.base == Base62 {
+			alphabet = base62Alphabet
+		}
+		result, err = p.processPositional(v, text, operation, alphabet)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	v.AddSeparator()
+	v.AddNote("Encoding is not encryption: anyone can decode the result")
+	return result, v.GetSteps(), nil
+}
+
+// processBase16 shows each byte splitting into two hex digits
+func (p *BaseNProcessor) processBase16(v *utils.Visualizer, text, operation string) (string, error) {
+	if operation == OperationDecrypt {
+		data, err := hex.DecodeString(strings.TrimSpace(text))
+		if err != nil {
+			return "", fmt.Errorf("invalid base16 string: %w", err)
+		}
+		v.AddTextStep("Base16 Input", text)
+		v.AddBinaryStep("Decoded Binary", data)
+		v.AddTextStep("Decoded Text", string(data))
+		return string(data), nil
+	}
+
+	data := []byte(text)
+	v.AddTextStep("Input Text", text)
+	v.AddStep("Each byte = high nibble + low nibble:")
+	for i, b := range data {
+		if i == baseNMaxSteps {
+			v.AddStep(fmt.Sprintf("  ... %d more bytes", len(data)-i))
+			break
+		}
+		v.AddStep(fmt.Sprintf("  %08b → %04b %04b → %x %x", b, b>>4, b&0x0f, b>>4, b&0x0f))
+	}
+	encoded := hex.EncodeToString(data)
+	v.AddTextStep("Base16 Encoded Result", encoded)
+	return encoded, nil
+}
+
+// processBase85 shows each 4-byte group as a 32-bit number written in five base-85 digits
+func (p *BaseNProcessor) processBase85(v *utils.Visualizer, text, operation string) (string, error) {
+	if operation == OperationDecrypt {
+		src := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(text), "<~"), "~>")
+		data := make([]byte, 4*len(src))
+		n, _, err := ascii85.Decode(data, []byte(src), true)
+		if err != nil {
+			return "", fmt.Errorf("invalid base85 string: %w", err)
+		}
+		data = data[:n]
+		v.AddTextStep("Base85 Input", text)
+		v.AddStep("Every 5 characters (value - 33) are base-85 digits of one 32-bit group; 'z' is 4 zero bytes")
+		v.AddBinaryStep("Decoded Binary", data)
+		v.AddTextStep("Decoded Text", string(data))
+		return string(data), nil
+	}
+
+	data := []byte(text)
+	v.AddTextStep("Input Text", text)
+	v.AddStep("Each 4-byte group as a 32-bit number, written in base 85 (digit + 33 = character):")
+	for i := 0; i < len(data); i += 4 {
+		if i/4 == baseNMaxSteps {
+			v.AddStep(fmt.Sprintf("  ... %d more groups", (len(data)-i+3)/4))
+			break
+		}
+		group := make([]byte, 4)
+		n := copy(group, data[i:])
+		value := binary.BigEndian.Uint32(group)
+		digits := make([]uint32, 5)
+		for j, rest := 4, value; j >= 0; j, rest = j-1, rest/85 {
+			digits[j] = rest % 85
+		}
+		chars := make([]byte, len(digits))
+		for j, d := range digits {
+			chars[j] = byte(d) + 33
+		}
+		note := ""
+		if n < 4 {
+			// A short final group is zero-padded, then only n+1 characters are kept
+			chars = chars[:n+1]
+			note = fmt.Sprintf("  (zero-padded, keep %d chars)", n+1)
+		}
+		v.AddStep(fmt.Sprintf("  %x → %10d → %v → %q%s", data[i:i+n], value, digits, chars, note))
+	}
+
+	encoded := make([]byte, ascii85.MaxEncodedLen(len(data)))
+	encoded = encoded[:ascii85.Encode(encoded, data)]
+	v.AddTextStep("Base85 Encoded Result", string(encoded))
+	v.AddNote("Groups of four zero bytes are shortened to 'z'")
+	return string(encoded), nil
+}
+
+// processPositional converts the whole input as one big number by repeated division by
+// the radix. Leading zero bytes have no numeric value, so each becomes a leading zero symbol.
+func (p *BaseNProcessor) processPositional(v *utils.Visualizer, text, operation, alphabet string) (string, error) {
+	radix := big.NewInt(int64(len(alphabet)))
+	zero := alphabet[0]
+
+	if operation == OperationDecrypt {
+		input := strings.TrimSpace(text)
+		v.AddTextStep(fmt.Sprintf("Base%d Input", len(alphabet)), input)
+		v.AddStep("Each symbol: value = value × radix + digit")
+		value := new(big.Int)
+		leadingZeros := 0
+		for i, char := range input {
+			digit := strings.IndexRune(alphabet, char)
+			if digit < 0 {
+				return "", fmt.Errorf("invalid base%d string: %q is not in the alphabet", len(alphabet), char)
+			}
+			if i == leadingZeros && byte(char) == zero {
+				leadingZeros++
+			}
+			value.Mul(value, radix)
+			value.Add(value, big.NewInt(int64(digit)))
+			if i < baseNMaxSteps {
+				v.AddStep(fmt.Sprintf("  '%c' = %2d → %s", char, digit, value.String()))
+			} else if i == baseNMaxSteps {
+				v.AddStep(fmt.Sprintf("  ... %d more symbols", len(input)-i))
+			}
+		}
+		data := append(make([]byte, leadingZeros), value.Bytes()...)
+		if leadingZeros > 0 {
+			v.AddStep(fmt.Sprintf("%d leading '%c' symbols → %d leading zero bytes", leadingZeros, zero, leadingZeros))
+		}
+		v.AddHexStep("Decoded Bytes", data)
+		v.AddTextStep("Decoded Text", string(data))
+		return string(data), nil
+	}
+
+	data := []byte(text)
+	v.AddTextStep("Input Text", text)
+	v.AddHexStep("Input Bytes", data)
+	value := new(big.Int).SetBytes(data)
+	v.AddStep(fmt.Sprintf("As one big-endian number: %s", value.String()))
+	v.AddStep(fmt.Sprintf("Repeatedly divide by %d; each remainder is the next digit from the right:", len(alphabet)))
+
+	var digits []byte
+	remainder := new(big.Int)
+	for step := 0; value.Sign() > 0; step++ {
+		quotient := new(big.Int)
+		quotient.DivMod(value, radix, remainder)
+		symbol := alphabet[remainder.Int64()]
+		if step < baseNMaxSteps {
+			v.AddStep(fmt.Sprintf("  %s ÷ %d = %s remainder %2d → '%c'", value.String(), len(alphabet), quotient.String(), remainder.Int64(), symbol))
+		} else if step == baseNMaxSteps {
+			v.AddStep("  ... (continues until the quotient is 0)")
+		}
+		digits = append(digits, symbol)
+		value = quotient
+	}
+	leadingZeros := 0
+	for leadingZeros < len(data) && data[leadingZeros] == 0 {
+		digits = append(digits, zero)
+		leadingZeros++
+	}
+	if leadingZeros > 0 {
+		v.AddStep(fmt.Sprintf("%d leading zero bytes → %d leading '%c' symbols", leadingZeros, leadingZeros, zero))
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+
+	encoded := string(digits)
+	v.AddTextStep(fmt.Sprintf("Base%d Encoded Result", len(alphabet)), encoded)
+	v.AddNote("Whole-number conversion costs O(n²) time, so it suits short values such as keys and hashes")
+	return encoded, nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *BaseNProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "base", Description: "base16, base58, base62, or base85", Value: p.base},
+	}
+}
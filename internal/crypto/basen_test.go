@@ -0,0 +1,90 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestBaseNProcessor_Vectors(t *testing.T) {
+	tests := []struct {
+		base  string
+		input string
+		want  string
+	}{
+		{Base16, "Hi!", "486921"},
+		{Base58, "Hello World!", "2NEpo7TZRRrLZSi2U"},
+		{Base58, "\x00\x00\x00\x28\x7f\xb4\xcd", "111233QC4"},
+		{Base62, "\xff", "47"},
+		{Base62, "\x00\x01", "01"},
+		{Base85, "Man ", "9jqo^"},
+		{Base85, "\x00\x00\x00\x00", "z"},
+	}
+	for _, tt := range tests {
+		processor := NewBaseNProcessor()
+		if err := processor.Configure(map[string]interface{}{"base": tt.base}); err != nil {
+			t.Fatalf("Configure(%s) error = %v", tt.base, err)
+		}
+		encoded, _, err := processor.Process(tt.input, OperationEncrypt)
+		if err != nil {
+			t.Fatalf("%s encode error = %v", tt.base, err)
+		}
+		if encoded != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.base, tt.input, encoded, tt.want)
+		}
+		decoded, _, err := processor.Process(encoded, OperationDecrypt)
+		if err != nil {
+			t.Fatalf("%s decode error = %v", tt.base, err)
+		}
+		if decoded != tt.input {
+			t.Errorf("%s round trip = %q, want %q", tt.base, decoded, tt.input)
+		}
+	}
+}
+
+func TestBaseNProcessor_RoundTrip(t *testing.T) {
+	input := "The quick brown fox jumps over the lazy dog"
+	for _, base := range []string{Base16, Base58, Base62, Base85} {
+		processor := NewBaseNProcessor()
+		if err := processor.Configure(map[string]interface{}{"base": base}); err != nil {
+			t.Fatalf("Configure(%s) error = %v", base, err)
+		}
+		encoded, steps, err := processor.Process(input, OperationEncrypt)
+		if err != nil {
+			t.Fatalf("%s encode error = %v", base, err)
+		}
+		if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "  ...") {
+			t.Errorf("%s: expected the conversion steps to be summarized for a long input", base)
+		}
+		decoded, _, err := processor.Process(encoded, OperationDecrypt)
+		if err != nil {
+			t.Fatalf("%s decode error = %v", base, err)
+		}
+		if decoded != input {
+			t.Errorf("%s round trip = %q, want %q", base, decoded, input)
+		}
+	}
+}
+
+func TestBaseNProcessor_Errors(t *testing.T) {
+	processor := NewBaseNProcessor()
+	if err := processor.Configure(map[string]interface{}{"base": "base36"}); err == nil {
+		t.Error("Expected an error for an unsupported base")
+	}
+	// 0, O, I, and l are not in the Base58 alphabet
+	if _, _, err := processor.Process("0OIl", OperationDecrypt); err == nil {
+		t.Error("Expected an error for symbols outside the Base58 alphabet")
+	}
+	for base, input := range map[string]string{Base16: "xyz", Base85: "abc{"} {
+		if err := processor.Configure(map[string]interface{}{"base": base}); err != nil {
+			t.Fatalf("Configure(%s) error = %v", base, err)
+		}
+		if _, _, err := processor.Process(input, OperationDecrypt); err == nil {
+			t.Errorf("%s: expected an error for %q", base, input)
+		}
+	}
+	if _, _, err := processor.Process("test", "invalid"); err == nil {
+		t.Error("Expected an error for an invalid operation")
+	}
+}
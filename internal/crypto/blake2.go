@@ -0,0 +1,179 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math/bits"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+)
+
+// BLAKE2 variants with a configurable digest size
+const (
+	HashBLAKE2b = "blake2b"
+	HashBLAKE2s = "blake2s"
+)
+
+// newBLAKE2 returns a BLAKE2 hash of the given variant with a custom digest size in bytes.
+// BLAKE2b supports 1-64 bytes and BLAKE2s supports 1-32 bytes, as defined in RFC 7693.
+func newBLAKE2(variant string, size int, key []byte) (hash.Hash, error) {
+	switch variant {
+	case HashBLAKE2b:
+		if size < 1 || size > blake2b.Size {
+			return nil, fmt.Errorf("invalid BLAKE2b digest size: %d (must be 1-%d bytes)", size, blake2b.Size)
+		}
+		return blake2b.New(size, key)
+	case HashBLAKE2s:
+		if size < 1 || size > blake2s.Size {
+			return nil, fmt.Errorf("invalid BLAKE2s digest size: %d (must be 1-%d bytes)", size, blake2s.Size)
+		}
+		if len(key) > blake2s.Size {
+			return nil, fmt.Errorf("invalid BLAKE2s key size: %d (must be at most %d bytes)", len(key), blake2s.Size)
+		}
+		if size == blake2s.Size {
+			return blake2s.New256(key)
+		}
+		// x/crypto/blake2s only exposes 128 and 256-bit digests, so other
+		// sizes use the RFC 7693 parameter block directly
+		return newBLAKE2sDigest(size, key), nil
+	default:
+		return nil, fmt.Errorf("unsupported BLAKE2 variant: %s", variant)
+	}
+}
+
+// blake2sIV is the BLAKE2s initialization vector (the SHA-256 IV)
+var blake2sIV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// blake2sSigma is the BLAKE2s message word permutation schedule
+var blake2sSigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// blake2sDigest is a BLAKE2s hash with an arbitrary digest size.
+//
+// The digest length is part of the BLAKE2s parameter block that seeds the state, so a
+// shorter digest is not a truncated BLAKE2s-256 output. golang.org/x/crypto/blake2s only
+// builds that parameter block for 16 and 32-byte digests (and New128 requires a key), so
+// the other sizes from RFC 7693 need this small reimplementation of the compression
+// function. It is checked against the RFC 7693 Appendix E self-test in blake2_test.go.
+type blake2sDigest struct {
+	h      [8]uint32
+	t      uint64
+	buf    [blake2s.BlockSize]byte
+	offset int
+	size   int
+	key    []byte
+}
+
+// newBLAKE2sDigest creates a BLAKE2s digest producing size bytes
+func newBLAKE2sDigest(size int, key []byte) *blake2sDigest {
+	d := &blake2sDigest{size: size, key: append([]byte(nil), key...)}
+	d.Reset()
+	return d
+}
+
+func (d *blake2sDigest) Size() int { return d.size }
+
+func (d *blake2sDigest) BlockSize() int { return blake2s.BlockSize }
+
+func (d *blake2sDigest) Reset() {
+	d.h = blake2sIV
+	d.h[0] ^= 0x01010000 ^ uint32(len(d.key))<<8 ^ uint32(d.size)
+	d.t = 0
+	d.offset = 0
+	d.buf = [blake2s.BlockSize]byte{}
+	if len(d.key) > 0 {
+		// A keyed hash starts with the key padded to a full block
+		copy(d.buf[:], d.key)
+		d.offset = blake2s.BlockSize
+	}
+}
+
+func (d *blake2sDigest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// Only compress a full buffer once more input arrives, since the
+		// last block must be compressed with the final flag set
+		if d.offset == blake2s.BlockSize {
+			d.t += blake2s.BlockSize
+			d.compress(false)
+			d.offset = 0
+		}
+		copied := copy(d.buf[d.offset:], p)
+		d.offset += copied
+		p = p[copied:]
+	}
+	return n, nil
+}
+
+func (d *blake2sDigest) Sum(b []byte) []byte {
+	final := *d
+	for i := final.offset; i < blake2s.BlockSize; i++ {
+		final.buf[i] = 0
+	}
+	final.t += uint64(final.offset)
+	final.compress(true)
+
+	var out [blake2s.Size]byte
+	for i, word := range final.h {
+		binary.LittleEndian.PutUint32(out[i*4:], word)
+	}
+	return append(b, out[:d.size]...)
+}
+
+// compress runs the BLAKE2s compression function on the buffered block
+func (d *blake2sDigest) compress(last bool) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(d.buf[i*4:])
+	}
+
+	var v [16]uint32
+	copy(v[:8], d.h[:])
+	copy(v[8:], blake2sIV[:])
+	v[12] ^= uint32(d.t)
+	v[13] ^= uint32(d.t >> 32)
+	if last {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, e int, x, y uint32) {
+		v[a] += v[b] + x
+		v[e] = bits.RotateLeft32(v[e]^v[a], -16)
+		v[c] += v[e]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -12)
+		v[a] += v[b] + y
+		v[e] = bits.RotateLeft32(v[e]^v[a], -8)
+		v[c] += v[e]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -7)
+	}
+
+	for _, s := range blake2sSigma {
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
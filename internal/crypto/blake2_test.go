@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+)
+
+func TestBLAKE2s256KnownVectors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+		{"abc", "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+	}
+
+	for _, tt := range tests {
+		h, err := newBLAKE2(HashBLAKE2s, 32, nil)
+		if err != nil {
+			t.Fatalf("newBLAKE2 failed: %v", err)
+		}
+		h.Write([]byte(tt.input))
+		if got := hex.EncodeToString(h.Sum(nil)); got != tt.expected {
+			t.Errorf("BLAKE2s-256(%q) = %s, want %s", tt.input, got, tt.expected)
+		}
+
+		// The custom-size implementation must agree at the standard size
+		custom := newBLAKE2sDigest(32, nil)
+		custom.Write([]byte(tt.input))
+		if got := hex.EncodeToString(custom.Sum(nil)); got != tt.expected {
+			t.Errorf("custom BLAKE2s-256(%q) = %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestBLAKE2sCustomDigestMatchesReference(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	message := bytes.Repeat([]byte("CryptoLens"), 20) // spans several blocks
+
+	reference, err := blake2s.New128(key)
+	if err != nil {
+		t.Fatalf("blake2s.New128 failed: %v", err)
+	}
+	reference.Write(message)
+
+	custom, err := newBLAKE2(HashBLAKE2s, 16, key)
+	if err != nil {
+		t.Fatalf("newBLAKE2 failed: %v", err)
+	}
+	custom.Write(message[:7])
+	custom.Write(message[7:])
+
+	if !bytes.Equal(custom.Sum(nil), reference.Sum(nil)) {
+		t.Error("keyed BLAKE2s-128 does not match the reference implementation")
+	}
+}
+
+func TestBLAKE2bCustomDigestSize(t *testing.T) {
+	h, err := newBLAKE2(HashBLAKE2b, 48, nil)
+	if err != nil {
+		t.Fatalf("newBLAKE2 failed: %v", err)
+	}
+	h.Write([]byte("abc"))
+	digest := h.Sum(nil)
+	if len(digest) != 48 {
+		t.Fatalf("digest length = %d, want 48", len(digest))
+	}
+	expected := "6f56a82c8e7ef526dfe182eb5212f7db9df1317e57815dbda46083fc30f54ee6c66ba83be64b302d7cba6ce15bb556f4"
+	if got := hex.EncodeToString(digest); got != expected {
+		t.Errorf("BLAKE2b-384(abc) = %s, want %s", got, expected)
+	}
+
+	// A 48-byte BLAKE2b digest is not a truncated BLAKE2b-512 digest
+	full := blake2b.Sum512([]byte("abc"))
+	if bytes.Equal(digest, full[:48]) {
+		t.Error("custom digest size should change the output, not truncate it")
+	}
+}
+
+func TestBLAKE2sCustomDigestSize(t *testing.T) {
+	h, err := newBLAKE2(HashBLAKE2s, 20, nil)
+	if err != nil {
+		t.Fatalf("newBLAKE2 failed: %v", err)
+	}
+	h.Write([]byte("abc"))
+	expected := "5ae3b99be29b01834c3b508521ede60438f8de17"
+	if got := hex.EncodeToString(h.Sum(nil)); got != expected {
+		t.Errorf("BLAKE2s-160(abc) = %s, want %s", got, expected)
+	}
+}
+
+// rfc7693SelftestSeq is the deterministic input generator of RFC 7693 Appendix E
+func rfc7693SelftestSeq(length int, seed uint32) []byte {
+	out := make([]byte, length)
+	a, b := 0xDEAD4BAD*seed, uint32(1)
+	for i := range out {
+		a, b = b, a+b
+		out[i] = byte(b >> 24)
+	}
+	return out
+}
+
+func TestBLAKE2sDigestRFC7693Selftest(t *testing.T) {
+	// RFC 7693 Appendix E: hash every unkeyed and keyed digest of each size and input
+	// length into one BLAKE2s-256 "hash of hashes"
+	grand := newBLAKE2sDigest(32, nil)
+	for _, outlen := range []int{16, 20, 28, 32} {
+		for _, inlen := range []int{0, 3, 64, 65, 255, 1024} {
+			in := rfc7693SelftestSeq(inlen, uint32(inlen))
+
+			unkeyed := newBLAKE2sDigest(outlen, nil)
+			unkeyed.Write(in)
+			grand.Write(unkeyed.Sum(nil))
+
+			keyed := newBLAKE2sDigest(outlen, rfc7693SelftestSeq(outlen, uint32(outlen)))
+			keyed.Write(in)
+			grand.Write(keyed.Sum(nil))
+		}
+	}
+
+	expected := "6a411f08ce25adcdfb02aba641451cec53c598b24f4fc787fbdc88797f4c1dfe"
+	if got := hex.EncodeToString(grand.Sum(nil)); got != expected {
+		t.Errorf("BLAKE2s self-test = %s, want %s", got, expected)
+	}
+}
+
+func TestBLAKE2InvalidSizes(t *testing.T) {
+	if _, err := newBLAKE2(HashBLAKE2b, 65, nil); err == nil {
+		t.Error("Expected error for 65-byte BLAKE2b digest")
+	}
+	if _, err := newBLAKE2(HashBLAKE2s, 33, nil); err == nil {
+		t.Error("Expected error for 33-byte BLAKE2s digest")
+	}
+	if _, err := newBLAKE2(HashBLAKE2s, 0, nil); err == nil {
+		t.Error("Expected error for empty BLAKE2s digest")
+	}
+}
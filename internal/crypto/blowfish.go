@@ -0,0 +1,218 @@
+package crypto
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/blowfish"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// BlowfishProcessor implements Blowfish encryption in CBC mode for legacy interop
+type BlowfishProcessor struct {
+	BaseConfigurableProcessor
+	keyManager KeyManager
+	keySize    int
+}
+
+// NewBlowfishProcessor creates a new Blowfish processor
+func NewBlowfishProcessor() *BlowfishProcessor {
+	return &BlowfishProcessor{
+		keySize: 128, // Common default for legacy systems
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *BlowfishProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	// Ensure keys directory exists
+	if err := os.MkdirAll("keys", 0700); err != nil {
+		return fmt.Errorf("failed to create keys directory: %w", err)
+	}
+
+	// Configure key size if provided
+	if keySize, ok := config["keySize"].(int); ok && keySize != 0 {
+		if keySize < 32 || keySize > 448 || keySize%8 != 0 {
+			return fmt.Errorf("invalid key size: %d (must be a multiple of 8 between 32 and 448)", keySize)
+		}
+		p.keySize = keySize
+	}
+
+	// Configure key file if provided
+	keyFile := "keys/blowfish_key.bin"
+	if kf, ok := config["keyFile"].(string); ok && kf != "" {
+		keyFile = kf
+	}
+
+	// Initialize key manager
+	p.keyManager = NewFileKeyManager(p.keySize, keyFile)
+	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
+		return fmt.Errorf("failed to load/generate key: %w", err)
+	}
+
+	return nil
+}
+
+// Process implements the Processor interface
+func (p *BlowfishProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	// Check for empty input
+	if text == "" {
+		return "", nil, fmt.Errorf("empty input")
+	}
+
+	// Validate operation type
+	if operation != OperationEncrypt && operation != OperationDecrypt {
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	// Add introduction
+	v.AddHeader("Blowfish Encryption Process")
+	v.AddNote("Blowfish is a symmetric block cipher designed by Bruce Schneier in 1993")
+	v.AddNote(fmt.Sprintf("Using Blowfish with a %d-bit key in CBC mode with PKCS7 padding", p.keySize))
+	v.AddStep("⚠️ Legacy algorithm: supported only for interoperability with older systems")
+	v.AddSeparator()
+
+	// Show key information
+	v.AddStep("Key Information:")
+	v.AddStep(fmt.Sprintf("Key Size: %d bits", p.keySize))
+	v.AddStep(fmt.Sprintf("Block Size: %d bits", blowfish.BlockSize*8))
+	v.AddStep("Mode: CBC (Cipher Block Chaining)")
+	v.AddStep("Padding: PKCS7")
+	v.AddSeparator()
+
+	block, err := blowfish.NewCipher(p.keyManager.GetKey())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	var result string
+	if operation == OperationDecrypt {
+		v.AddStep("Decryption Process:")
+		v.AddStep("1. Base64 decode the input")
+		v.AddStep("2. Extract IV from the beginning")
+		v.AddStep("3. Use Blowfish-CBC to decrypt")
+		v.AddStep("4. Remove PKCS7 padding")
+		v.AddStep("5. Convert result to text")
+		v.AddSeparator()
+
+		v.AddTextStep("Encrypted Input (Base64)", text)
+		v.AddArrow()
+
+		data, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+		}
+		v.AddHexStep("Decoded Data", data)
+		v.AddArrow()
+
+		if len(data) < 2*blowfish.BlockSize || len(data)%blowfish.BlockSize != 0 {
+			return "", nil, fmt.Errorf("ciphertext too short or not a multiple of the block size")
+		}
+		iv := data[:blowfish.BlockSize]
+		ciphertext := data[blowfish.BlockSize:]
+		v.AddHexStep("Initialization Vector (IV)", iv)
+		v.AddHexStep("Ciphertext", ciphertext)
+		v.AddArrow()
+
+		plaintext := make([]byte, len(ciphertext))
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+		v.AddHexStep("Decrypted Data (with padding)", plaintext)
+		v.AddArrow()
+
+		unpadded, err := pkcs7Unpad(plaintext, blowfish.BlockSize)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to unpad: %w", err)
+		}
+		v.AddTextStep("Decrypted Text", string(unpadded))
+		result = string(unpadded)
+	} else {
+		v.AddStep("Encryption Process:")
+		v.AddStep("1. Convert text to bytes")
+		v.AddStep("2. Generate random IV")
+		v.AddStep("3. Add PKCS7 padding")
+		v.AddStep("4. Use Blowfish-CBC to encrypt")
+		v.AddStep("5. Combine IV and ciphertext")
+		v.AddStep("6. Base64 encode the result")
+		v.AddSeparator()
+
+		v.AddTextStep("Input Text", text)
+		v.AddArrow()
+
+		iv := make([]byte, blowfish.BlockSize)
+		if _, err := rand.Read(iv); err != nil {
+			return "", nil, fmt.Errorf("failed to generate IV: %w", err)
+		}
+		v.AddHexStep("Generated IV", iv)
+		v.AddArrow()
+
+		paddedText := addPaddingSteps(v, []byte(text), blowfish.BlockSize)
+		v.AddHexStep("Padded Input", paddedText)
+		v.AddArrow()
+
+		ciphertext := make([]byte, len(paddedText))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, paddedText)
+		v.AddHexStep("Encrypted Data", ciphertext)
+		v.AddArrow()
+
+		combined := make([]byte, len(iv)+len(ciphertext))
+		copy(combined, iv)
+		copy(combined[len(iv):], ciphertext)
+		v.AddHexStep("Combined IV and Ciphertext", combined)
+		v.AddArrow()
+
+		result = base64.StdEncoding.EncodeToString(combined)
+		v.AddTextStep("Base64 Encoded Result", result)
+	}
+
+	// Add legacy warnings
+	v.AddSeparator()
+	v.AddStep("⚠️ Security Warning:")
+	v.AddNote("1. Blowfish uses a 64-bit block, so ciphertext blocks start colliding after about 2^32 blocks (32 GB)")
+	v.AddNote("2. The Sweet32 birthday attack (CVE-2016-2183) exploits these collisions to recover plaintext")
+	v.AddNote("3. Rekey long before 2^32 blocks, or better, migrate to AES-GCM or ChaCha20-Poly1305")
+	v.AddNote("4. Blowfish's slow key schedule also makes it a poor fit for frequent rekeying")
+
+	return result, v.GetSteps(), nil
+}
+
+// KeyFile returns the key file currently in use
+func (p *BlowfishProcessor) KeyFile() string {
+	return keyFileOf(p.keyManager)
+}
+
+// UseKeyFile switches to an existing key file, such as an archived key
+func (p *BlowfishProcessor) UseKeyFile(path string) error {
+	keyManager, err := OpenFileKeyManager(p.keySize, path)
+	if err != nil {
+		return err
+	}
+	p.keyManager = keyManager
+	return nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *BlowfishProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "keySize", Description: "Key size in bits (32-448, multiple of 8)", Value: p.keySize},
+		{Name: "keyFile", Description: "File the key is stored in", Value: keyFileOf(p.keyManager)},
+	}
+}
+
+// AuditSources lists the randomness sources and libraries used for Blowfish
+func (p *BlowfishProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		keyManagerRandomness,
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Random IV for each encryption"},
+		{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/blowfish", Purpose: "Blowfish block cipher"},
+		{Kind: AuditStandardLibrary, Package: "crypto/cipher", Purpose: "CBC mode"},
+	}
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+func TestBlowfishProcessor_Configure(t *testing.T) {
+	processor := NewBlowfishProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize": 512,
+		"keyFile": "keys/test_blowfish_key.bin",
+	}); err == nil {
+		t.Error("Expected error for invalid key size, got nil")
+	}
+
+	if err := processor.Configure(map[string]interface{}{
+		"keySize": 256,
+		"keyFile": "keys/test_blowfish_key.bin",
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	if processor.keySize != 256 {
+		t.Errorf("keySize = %v, want 256", processor.keySize)
+	}
+}
+
+func TestBlowfishProcessor_Process(t *testing.T) {
+	processor := NewBlowfishProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keyFile": "keys/test_blowfish_key.bin",
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+
+	plaintext := "Hello, legacy world!"
+	result, steps, err := processor.Process(plaintext, OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	if len(steps) == 0 {
+		t.Error("Encryption returned no steps")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(result)
+	if err != nil {
+		t.Fatalf("Encryption result is not valid base64: %v", err)
+	}
+	if len(data)%blowfish.BlockSize != 0 || len(data) < 2*blowfish.BlockSize {
+		t.Errorf("Unexpected ciphertext length %d", len(data))
+	}
+
+	decrypted, _, err := processor.Process(result, OperationDecrypt)
+	if err != nil {
+		t.Fatalf("Decryption failed: %v", err)
+	}
+	if decrypted != plaintext {
+		t.Errorf("Decryption result = %v, want %v", decrypted, plaintext)
+	}
+}
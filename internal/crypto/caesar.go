@@ -2,18 +2,49 @@ package crypto
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
+// caesarAutoShift is the shift value that decrypts with every shift instead of a known one
+const caesarAutoShift = "auto"
+
+// Caesar alphabets: Latin shifts only A–Z; Unicode also shifts Greek and Cyrillic letters
+const (
+	CaesarAlphabetLatin   = "latin"
+	CaesarAlphabetUnicode = "unicode"
+)
+
+// caesarAlphabet is one script's letters in order, in upper and lower case
+type caesarAlphabet struct {
+	name  string
+	upper []rune
+	lower []rune
+}
+
+var (
+	latinAlphabet = caesarAlphabet{"Latin", []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ"), []rune("abcdefghijklmnopqrstuvwxyz")}
+
+	// extendedAlphabets are shifted in Unicode mode; final sigma (ς) has no uppercase and is left alone
+	extendedAlphabets = []caesarAlphabet{
+		{"Greek", []rune("ΑΒΓΔΕΖΗΘΙΚΛΜΝΞΟΠΡΣΤΥΦΧΨΩ"), []rune("αβγδεζηθικλμνξοπρστυφχψω")},
+		{"Cyrillic", []rune("АБВГДЕЁЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯ"), []rune("абвгдеёжзийклмнопрстуфхцчшщъыьэюя")},
+	}
+)
+
 type CaesarProcessor struct {
 	BaseConfigurableProcessor
-	shift int
+	shift     int
+	autoShift bool
+	alphabet  string
 }
 
 func NewCaesarProcessor() *CaesarProcessor {
 	return &CaesarProcessor{
-		shift: 3, // Default shift
+		shift:    3, // Default shift
+		alphabet: CaesarAlphabetLatin,
 	}
 }
 
@@ -23,25 +54,52 @@ func (p *CaesarProcessor) Configure(config map[string]interface{}) error {
 		return err
 	}
 
-	// Configure shift if provided
-	if shift, ok := config["shift"].(int); ok {
+	// Configure shift if provided: a number, or "auto" to try all shifts on decryption
+	switch shift := config["shift"].(type) {
+	case int:
 		p.shift = shift
+		p.autoShift = false
+	case string:
+		if shift == caesarAutoShift {
+			p.autoShift = true
+			break
+		}
+		n, err := strconv.Atoi(shift)
+		if err != nil {
+			return fmt.Errorf("invalid shift: %s (must be a number or %q)", shift, caesarAutoShift)
+		}
+		p.shift = n
+		p.autoShift = false
+	}
+
+	if alphabet, ok := config["alphabet"].(string); ok && alphabet != "" {
+		switch alphabet {
+		case CaesarAlphabetLatin, CaesarAlphabetUnicode:
+			p.alphabet = alphabet
+		default:
+			return fmt.Errorf("invalid alphabet: %s (must be %s or %s)", alphabet, CaesarAlphabetLatin, CaesarAlphabetUnicode)
+		}
 	}
 
 	return nil
 }
 
-func (p *CaesarProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *CaesarProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 
 	// Validate operation type
 	if operation != OperationEncrypt && operation != OperationDecrypt {
 		return "", nil, fmt.Errorf("invalid operation: %s", operation)
 	}
+	if p.autoShift {
+		if operation != OperationDecrypt {
+			return "", nil, fmt.Errorf("shift %q only applies to decryption", caesarAutoShift)
+		}
+		return p.allShifts(text)
+	}
 
 	// Add introduction
-	v.AddStep("Caesar Cipher Process")
-	v.AddStep("=============================")
+	v.AddHeader("Caesar Cipher Process")
 	v.AddNote("Caesar cipher is a substitution cipher")
 	v.AddNote(fmt.Sprintf("Using shift value of %d", p.shift))
 	v.AddSeparator()
@@ -50,50 +108,53 @@ func (p *CaesarProcessor) Process(text string, operation string) (string, []stri
 	v.AddStep("Alphabet:")
 	v.AddStep("A B C D E F G H I J K L M N O P Q R S T U V W X Y Z")
 	v.AddStep("0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25")
+	if p.alphabet == CaesarAlphabetUnicode {
+		for _, alphabet := range extendedAlphabets {
+			v.AddStep(fmt.Sprintf("%s (%d letters): %s", alphabet.name, len(alphabet.upper), string(alphabet.upper)))
+		}
+	}
 	v.AddSeparator()
 
-	// Process each character
-	result := make([]rune, len(text))
-	for i, char := range text {
-		if (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') {
-			var base rune
-			if char >= 'a' && char <= 'z' {
-				base = 'a'
-			} else {
-				base = 'A'
-			}
-			pos := int(char - base)
-			if operation == OperationDecrypt {
-				pos = (pos - p.shift + 26) % 26
-			} else {
-				pos = (pos + p.shift) % 26
-			}
-			result[i] = rune(int(base) + pos)
-
-			// Show character transformation
-			v.AddStep(fmt.Sprintf("Character '%c':", char))
-			v.AddStep(fmt.Sprintf("  Position: %d", int(char-base)))
-			if operation == OperationDecrypt {
-				v.AddStep(fmt.Sprintf("  Shift: -%d", p.shift))
-				v.AddStep(fmt.Sprintf("  New Position: (%d - %d + 26) %% 26 = %d", int(char-base), p.shift, pos))
-			} else {
-				v.AddStep(fmt.Sprintf("  Shift: +%d", p.shift))
-				v.AddStep(fmt.Sprintf("  New Position: (%d + %d) %% 26 = %d", int(char-base), p.shift, pos))
-			}
-			v.AddStep(fmt.Sprintf("  Result: '%c'", result[i]))
-			v.AddArrow()
-		} else {
-			result[i] = char
+	// Process each character (rune by rune, so multi-byte characters stay intact)
+	var result strings.Builder
+	for _, char := range text {
+		letters, pos, ok := p.findLetter(char)
+		if !ok {
+			result.WriteRune(char)
 			v.AddStep(fmt.Sprintf("Non-alphabetic character '%c' - unchanged", char))
 			v.AddArrow()
+			continue
+		}
+
+		n := len(letters)
+		shift := ((p.shift % n) + n) % n
+		var newPos int
+		if operation == OperationDecrypt {
+			newPos = (pos - shift + n) % n
+		} else {
+			newPos = (pos + shift) % n
+		}
+		result.WriteRune(letters[newPos])
+
+		// Show character transformation
+		v.AddStep(fmt.Sprintf("Character '%c':", char))
+		v.AddStep(fmt.Sprintf("  Position: %d", pos))
+		if operation == OperationDecrypt {
+			v.AddStep(fmt.Sprintf("  Shift: -%d", shift))
+			v.AddStep(fmt.Sprintf("  New Position: (%d - %d + %d) %% %d = %d", pos, shift, n, n, newPos))
+		} else {
+			v.AddStep(fmt.Sprintf("  Shift: +%d", shift))
+			v.AddStep(fmt.Sprintf("  New Position: (%d + %d) %% %d = %d", pos, shift, n, newPos))
 		}
+		v.AddStep(fmt.Sprintf("  Result: '%c'", letters[newPos]))
+		v.AddArrow()
 	}
 
 	// Show the result
 	if operation == OperationDecrypt {
-		v.AddTextStep("Decrypted Text", string(result))
+		v.AddTextStep("Decrypted Text", result.String())
 	} else {
-		v.AddTextStep("Encrypted Text", string(result))
+		v.AddTextStep("Encrypted Text", result.String())
 	}
 
 	// Add how it works
@@ -102,6 +163,9 @@ func (p *CaesarProcessor) Process(text string, operation string) (string, []stri
 	v.AddStep("1. Each letter is shifted by a fixed number of positions")
 	v.AddStep("2. The shift wraps around the alphabet (Z → A)")
 	v.AddStep("3. Non-alphabetic characters remain unchanged")
+	if p.alphabet == CaesarAlphabetUnicode {
+		v.AddStep("   Greek and Cyrillic letters shift within their own alphabet")
+	}
 	v.AddStep("4. The same shift value is used for all letters")
 	v.AddNote("Caesar cipher is a simple substitution cipher - it's not secure for real-world use")
 
@@ -113,5 +177,82 @@ func (p *CaesarProcessor) Process(text string, operation string) (string, []stri
 	v.AddNote("3. No key management - same shift for all messages")
 	v.AddNote("4. Can be broken by brute force (trying all 25 shifts)")
 
-	return string(result), v.GetSteps(), nil
+	return result.String(), v.GetSteps(), nil
+}
+
+// allShifts decrypts text with each of the 26 shifts so the plaintext can be picked out by eye
+func (p *CaesarProcessor) allShifts(text string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	v.AddHeader("Caesar Cipher Brute Force")
+	v.AddNote("The shift is unknown, so every one of the 26 possible shifts is tried")
+	v.AddNote("Only one candidate should read as plain language")
+	v.AddSeparator()
+	v.AddTextStep("Ciphertext", text)
+	v.AddSeparator()
+
+	candidates := make([]string, 26)
+	for shift := range candidates {
+		candidate := p.shiftText(text, -shift)
+		candidates[shift] = fmt.Sprintf("Shift %2d: %s", shift, candidate)
+		v.AddStep(fmt.Sprintf("Shift %2d (%c → A): %s", shift, rune('A'+shift), candidate))
+	}
+
+	v.AddSeparator()
+	v.AddStep("How the Brute Force Works:")
+	v.AddStep("1. A Caesar key is one of only 26 shifts (shift 0 leaves the text unchanged)")
+	v.AddStep("2. Decrypting with every shift is instant, even by hand")
+	v.AddStep("3. The right shift is the one whose output is readable")
+	v.AddNote("Frequency analysis (Attack Simulations) picks the right shift automatically")
+
+	return strings.Join(candidates, "\n"), v.GetSteps(), nil
+}
+
+// findLetter returns the alphabet (in the letter's case) containing char and its position there
+func (p *CaesarProcessor) findLetter(char rune) ([]rune, int, bool) {
+	alphabets := []caesarAlphabet{latinAlphabet}
+	if p.alphabet == CaesarAlphabetUnicode {
+		alphabets = append(alphabets, extendedAlphabets...)
+	}
+	for _, alphabet := range alphabets {
+		for _, letters := range [][]rune{alphabet.upper, alphabet.lower} {
+			for i, letter := range letters {
+				if letter == char {
+					return letters, i, true
+				}
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// shiftText shifts the letters in text by shift positions, keeping case and other characters
+func (p *CaesarProcessor) shiftText(text string, shift int) string {
+	var b strings.Builder
+	for _, char := range text {
+		letters, pos, ok := p.findLetter(char)
+		if !ok {
+			b.WriteRune(char)
+			continue
+		}
+		n := len(letters)
+		b.WriteRune(letters[((pos+shift)%n+n)%n])
+	}
+	return b.String()
+}
+
+// Parameters returns the processor's effective settings
+func (p *CaesarProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "shift", Description: "Number of positions to shift each letter, or \"auto\" to try all", Value: p.shiftValue()},
+		{Name: "alphabet", Description: "Letters to shift: latin (A–Z) or unicode (also Greek and Cyrillic)", Value: p.alphabet},
+	}
+}
+
+// shiftValue returns the configured shift, or "auto" when every shift is tried
+func (p *CaesarProcessor) shiftValue() interface{} {
+	if p.autoShift {
+		return caesarAutoShift
+	}
+	return p.shift
 }
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -273,3 +274,111 @@ func TestCaesarProcessor_Process_NonAlphabetic(t *testing.T) {
 		t.Errorf("Non-alphabetic characters were modified: got %v, want %v", decrypted, input)
 	}
 }
+
+func TestCaesarProcessor_Process_AllShifts(t *testing.T) {
+	processor := NewCaesarProcessor()
+	if err := processor.Configure(map[string]interface{}{"shift": "auto"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	result, steps, err := processor.Process("Khoor, Zruog!", OperationDecrypt)
+	if err != nil {
+		t.Fatalf("Decryption failed: %v", err)
+	}
+	lines := strings.Split(result, "\n")
+	if len(lines) != 26 {
+		t.Fatalf("Expected 26 candidates, got %d", len(lines))
+	}
+	if lines[0] != "Shift  0: Khoor, Zruog!" {
+		t.Errorf("Unexpected shift 0 candidate %q", lines[0])
+	}
+	if lines[3] != "Shift  3: Hello, World!" {
+		t.Errorf("Unexpected shift 3 candidate %q", lines[3])
+	}
+	if len(steps) == 0 {
+		t.Error("Expected visualization steps")
+	}
+
+	if _, _, err := processor.Process("Hello", OperationEncrypt); err == nil {
+		t.Error("Expected an error when encrypting with an automatic shift")
+	}
+}
+
+func TestCaesarProcessor_Configure_StringShift(t *testing.T) {
+	processor := NewCaesarProcessor()
+	if err := processor.Configure(map[string]interface{}{"shift": "auto"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := processor.Configure(map[string]interface{}{"shift": "7"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if processor.autoShift || processor.shift != 7 {
+		t.Errorf("Expected a fixed shift of 7, got shift %d (auto %v)", processor.shift, processor.autoShift)
+	}
+	if err := processor.Configure(map[string]interface{}{"shift": "seven"}); err == nil {
+		t.Error("Expected an error for a non-numeric shift")
+	}
+}
+
+func TestCaesarProcessor_Process_Unicode(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		input    string
+		want     string
+	}{
+		{
+			name:     "latin keeps multi-byte characters intact",
+			alphabet: CaesarAlphabetLatin,
+			input:    "Café Привет",
+			want:     "Fdié Привет",
+		},
+		{
+			name:     "unicode shifts Cyrillic",
+			alphabet: CaesarAlphabetUnicode,
+			input:    "Привет, мир!",
+			want:     "Тулезх, плу!",
+		},
+		{
+			name:     "unicode wraps Greek",
+			alphabet: CaesarAlphabetUnicode,
+			input:    "Ωψ",
+			want:     "Γβ",
+		},
+		{
+			name:     "accented letters pass through",
+			alphabet: CaesarAlphabetUnicode,
+			input:    "Crème brûlée",
+			want:     "Fuèph euûoéh",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewCaesarProcessor()
+			if err := processor.Configure(map[string]interface{}{"alphabet": tt.alphabet}); err != nil {
+				t.Fatalf("Failed to configure processor: %v", err)
+			}
+
+			encrypted, _, err := processor.Process(tt.input, OperationEncrypt)
+			if err != nil {
+				t.Fatalf("Encryption failed: %v", err)
+			}
+			if encrypted != tt.want {
+				t.Errorf("Encrypted = %q, want %q", encrypted, tt.want)
+			}
+
+			decrypted, _, err := processor.Process(encrypted, OperationDecrypt)
+			if err != nil {
+				t.Fatalf("Decryption failed: %v", err)
+			}
+			if decrypted != tt.input {
+				t.Errorf("Round trip failed: got %q, want %q", decrypted, tt.input)
+			}
+		})
+	}
+
+	if err := NewCaesarProcessor().Configure(map[string]interface{}{"alphabet": "klingon"}); err == nil {
+		t.Error("Expected an error for an unknown alphabet")
+	}
+}
@@ -1,13 +1,10 @@
 package crypto
 
 import (
-	"bufio"
-	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 	"time"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
@@ -21,6 +18,25 @@ type ChaCha20Poly1305Processor struct {
 	keySize    int
 	nonceSize  int
 	tagSize    int
+
+	// Plaintext bytes per chunk for StreamProcess
+	streamChunkSize int
+
+	// Additional authenticated data; when not configured it is prompted for on a terminal
+	aad           string
+	aadConfigured bool
+
+	// Random-nonce usage tracking for the birthday bound
+	nonceCounter       *nonceCounter
+	sessionEncryptions uint64
+
+	// Asks for custom keys, nonces, and tampering; without one the defaults are used
+	input utils.Prompter
+
+	// Encoding of encrypted results; decryption accepts any
+	outputEncoding string
+
+	rand io.Reader
 }
 
 // NewChaCha20Poly1305Processor creates a new ChaCha20-Poly1305 processor
@@ -38,16 +54,24 @@ func (p *ChaCha20Poly1305Processor) Configure(config map[string]interface{}) err
 		return err
 	}
 
-	// Configure key file if provided
+	if random, ok := config["rand"].(io.Reader); ok {
+		p.rand = random
+	}
+
+	if input, ok := config["input"].(utils.Prompter); ok {
+		p.input = input
+	}
+
+	// Configure key file if provided; later calls without one keep the current key
 	keyFile := "keys/chacha20poly1305_key.bin"
-	if kf, ok := config["keyFile"].(string); ok {
+	kf, hasKeyFile := config["keyFile"].(string)
+	if hasKeyFile {
 		keyFile = kf
 	}
-
-	// Initialize key manager
-	p.keyManager = NewFileKeyManager(256, keyFile) // ChaCha20-Poly1305 uses 256-bit keys
-	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
-		return fmt.Errorf("failed to load/generate key: %w", err)
+	if hasKeyFile || p.keyManager == nil {
+		if err := p.configureKey(keyFile, config); err != nil {
+			return err
+		}
 	}
 
 	// Configure key size if provided
@@ -66,6 +90,27 @@ func (p *ChaCha20Poly1305Processor) Configure(config map[string]interface{}) err
 		p.nonceSize = nonceSize
 	}
 
+	// Configure additional authenticated data if provided
+	if aad, ok := config["aad"].(string); ok {
+		p.aad = aad
+		p.aadConfigured = true
+	}
+
+	if encoding, ok := config["outputEncoding"].(string); ok && encoding != "" {
+		if err := utils.ValidateEncoding(encoding); err != nil {
+			return err
+		}
+		p.outputEncoding = encoding
+	}
+
+	// Configure the streaming chunk size if provided
+	if chunkSize, ok := config["chunkSize"].(int); ok && chunkSize != 0 {
+		if chunkSize < 0 || chunkSize > maxStreamChunkSize {
+			return fmt.Errorf("invalid chunk size: %d (must be between 1 and %d bytes)", chunkSize, maxStreamChunkSize)
+		}
+		p.streamChunkSize = chunkSize
+	}
+
 	// Configure tag size if provided
 	if tagSize, ok := config["tagSize"].(int); ok {
 		if tagSize != 16 {
@@ -77,21 +122,47 @@ func (p *ChaCha20Poly1305Processor) Configure(config map[string]interface{}) err
 	return nil
 }
 
+// configureKey loads or generates the key in keyFile and opens its nonce counter
+func (p *ChaCha20Poly1305Processor) configureKey(keyFile string, config map[string]interface{}) error {
+	// Initialize key manager
+	keyManager := NewFileKeyManager(256, keyFile) // ChaCha20-Poly1305 uses 256-bit keys
+	if days, ok := config["maxKeyAgeDays"].(int); ok {
+		keyManager.SetMaxKeyAge(days)
+	}
+	p.keyManager = keyManager
+	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
+		return fmt.Errorf("failed to load/generate key: %w", err)
+	}
+
+	// Track random-nonce encryptions next to the key file
+	nonceCountFile := keyFile + nonceCountSuffix
+	if nf, ok := config["nonceCountFile"].(string); ok && nf != "" {
+		nonceCountFile = nf
+	}
+	p.nonceCounter = newNonceCounter(nonceCountFile)
+	if keyManager.Rotated() {
+		// A fresh key starts a fresh nonce budget
+		if err := p.nonceCounter.Reset(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Process implements the Processor interface
-func (p *ChaCha20Poly1305Processor) Process(text string, operation string) (string, []string, error) {
+func (p *ChaCha20Poly1305Processor) Process(text string, operation string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 
 	// Add introduction
-	v.AddStep("🔐 ChaCha20-Poly1305 Process")
-	v.AddStep("=============================")
+	v.AddHeader("🔐 ChaCha20-Poly1305 Process")
 	v.AddStep("ChaCha20-Poly1305 is an authenticated encryption algorithm")
 	v.AddStep("It combines the ChaCha20 stream cipher with the Poly1305 MAC")
 	v.AddStep("Provides both confidentiality and authenticity")
+	addKeyRotationNote(v, p.keyManager)
 	v.AddSeparator()
 
 	// Add length explanations
-	v.AddStep("📏 Key Lengths")
-	v.AddStep("=============")
+	v.AddHeader("📏 Key Lengths")
 	v.AddStep("Key: 32 bytes (256 bits)")
 	v.AddStep("• Used for both ChaCha20 encryption and Poly1305 MAC")
 	v.AddStep("• Must be kept secret and secure")
@@ -112,41 +183,40 @@ func (p *ChaCha20Poly1305Processor) Process(text string, operation string) (stri
 
 	// Add ASCII Diagram
 	v.AddStep("Encryption Flow:")
-	v.AddStep("┌─────────────┐")
-	v.AddStep("│  Plaintext  │")
-	v.AddStep("└──────┬──────┘")
-	v.AddStep("       │")
+	v.AddDiagram("┌─────────────┐")
+	v.AddDiagram("│  Plaintext  │")
+	v.AddDiagram("└──────┬──────┘")
+	v.AddDiagram("       │")
 	v.AddStep("       v")
-	v.AddStep("┌─────────────┐")
-	v.AddStep("│    Key      │")
-	v.AddStep("└──────┬──────┘")
-	v.AddStep("       │")
+	v.AddDiagram("┌─────────────┐")
+	v.AddDiagram("│    Key      │")
+	v.AddDiagram("└──────┬──────┘")
+	v.AddDiagram("       │")
 	v.AddStep("       v")
-	v.AddStep("┌─────────────┐")
-	v.AddStep("│    Nonce    │")
-	v.AddStep("└──────┬──────┘")
-	v.AddStep("       │")
+	v.AddDiagram("┌─────────────┐")
+	v.AddDiagram("│    Nonce    │")
+	v.AddDiagram("└──────┬──────┘")
+	v.AddDiagram("       │")
 	v.AddStep("       v")
-	v.AddStep("┌─────────────┐")
-	v.AddStep("│ ChaCha20    │")
-	v.AddStep("│ Encryption  │")
-	v.AddStep("└──────┬──────┘")
-	v.AddStep("       │")
+	v.AddDiagram("┌─────────────┐")
+	v.AddDiagram("│ ChaCha20    │")
+	v.AddDiagram("│ Encryption  │")
+	v.AddDiagram("└──────┬──────┘")
+	v.AddDiagram("       │")
 	v.AddStep("       v")
-	v.AddStep("┌─────────────┐")
-	v.AddStep("│ Poly1305    │")
-	v.AddStep("│    MAC      │")
-	v.AddStep("└──────┬──────┘")
-	v.AddStep("       │")
+	v.AddDiagram("┌─────────────┐")
+	v.AddDiagram("│ Poly1305    │")
+	v.AddDiagram("│    MAC      │")
+	v.AddDiagram("└──────┬──────┘")
+	v.AddDiagram("       │")
 	v.AddStep("       v")
-	v.AddStep("┌─────────────┐")
-	v.AddStep("│ Ciphertext  │")
-	v.AddStep("└─────────────┘")
+	v.AddDiagram("┌─────────────┐")
+	v.AddDiagram("│ Ciphertext  │")
+	v.AddDiagram("└─────────────┘")
 	v.AddSeparator()
 
 	// Add Tutorial Section
-	v.AddStep("📚 Tutorial: Understanding ChaCha20-Poly1305")
-	v.AddStep("=========================================")
+	v.AddHeader("📚 Tutorial: Understanding ChaCha20-Poly1305")
 	v.AddStep("1. ChaCha20 Stream Cipher:")
 	v.AddStep("   • Fast and secure stream cipher")
 	v.AddStep("   • Resistant to timing attacks")
@@ -176,8 +246,7 @@ func (p *ChaCha20Poly1305Processor) Process(text string, operation string) (stri
 	v.AddSeparator()
 
 	// Add Tampering Test Section
-	v.AddStep("Tampering Test")
-	v.AddStep("================")
+	v.AddHeader("Tampering Test")
 	v.AddStep("This section demonstrates how ChaCha20-Poly1305 detects tampering")
 	v.AddStep("You can test the authenticity feature by:")
 	v.AddStep("1. Encrypting a message")
@@ -205,35 +274,20 @@ func (p *ChaCha20Poly1305Processor) Process(text string, operation string) (stri
 	return p.decrypt(text, v)
 }
 
-func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (string, []string, error) {
+func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (string, []utils.Step, error) {
 	// Show original text
-	v.AddStep("Step 1: Input Processing")
-	v.AddStep("----------------------")
+	v.AddSubheader("Step 1: Input Processing")
 	v.AddTextStep("Original Text", text)
 	v.AddArrow()
 
 	// Ask for key input preference
-	v.AddStep("Step 2: Key Management")
-	v.AddStep("---------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Key Management:", "brightCyan"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("1. Use existing key", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("2. Enter custom key (32 bytes in hex)", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter your choice (1-2): ", "brightGreen"))
-
+	v.AddSubheader("Step 2: Key Management")
 	var key []byte
 	var err error
-	choice := ""
-	if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-		choice = strings.TrimSpace(input)
-	}
+	choice := p.chooseKey()
 
-	if choice == "2" {
-		fmt.Printf("%s", utils.DefaultTheme.Format("Enter 32-byte key in hex format: ", "brightGreen"))
-		keyHex := ""
-		if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-			keyHex = strings.TrimSpace(input)
-		}
-		key, err = hex.DecodeString(keyHex)
+	if choice == 2 {
+		key, err = hex.DecodeString(utils.PromptLine(p.input, "Enter 32-byte key in hex format: "))
 		if err != nil || len(key) != 32 {
 			return "", nil, fmt.Errorf("invalid key: must be 32 bytes in hex format")
 		}
@@ -248,8 +302,7 @@ func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (s
 	v.AddArrow()
 
 	// Create ChaCha20-Poly1305 cipher
-	v.AddStep("Step 3: Cipher Initialization")
-	v.AddStep("---------------------------")
+	v.AddSubheader("Step 3: Cipher Initialization")
 	aead, err := chacha20poly1305.New(key)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to create cipher: %w", err)
@@ -258,26 +311,14 @@ func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (s
 	v.AddArrow()
 
 	// Ask for nonce input preference
-	v.AddStep("Step 4: Nonce Management")
-	v.AddStep("----------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Nonce Management:", "brightCyan"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("1. Generate random nonce", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("2. Enter custom nonce (12 bytes in hex)", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter your choice (1-2): ", "brightGreen"))
-
+	v.AddSubheader("Step 4: Nonce Management")
 	var nonce []byte
-	choice = ""
-	if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-		choice = strings.TrimSpace(input)
-	}
+	choice = utils.PromptChoice(p.input, "Nonce Management:",
+		"Generate random nonce",
+		"Enter custom nonce (12 bytes in hex)")
 
-	if choice == "2" {
-		fmt.Printf("%s", utils.DefaultTheme.Format("Enter 12-byte nonce in hex format: ", "brightGreen"))
-		nonceHex := ""
-		if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-			nonceHex = strings.TrimSpace(input)
-		}
-		nonce, err = hex.DecodeString(nonceHex)
+	if choice == 2 {
+		nonce, err = hex.DecodeString(utils.PromptLine(p.input, "Enter 12-byte nonce in hex format: "))
 		if err != nil || len(nonce) != 12 {
 			return "", nil, fmt.Errorf("invalid nonce: must be 12 bytes in hex format")
 		}
@@ -287,24 +328,20 @@ func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (s
 		v.AddStep("⚠️ WARNING: Each encryption should use a unique nonce")
 	} else {
 		nonce = make([]byte, p.nonceSize)
-		if _, err := rand.Read(nonce); err != nil {
+		if _, err := io.ReadFull(randomSource(p.rand), nonce); err != nil {
 			return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
 		}
 		v.AddStep("Using randomly generated nonce")
 		v.AddStep("✅ The nonce is cryptographically secure and unique")
+		p.addNonceUsage(v)
 	}
 
 	v.AddHexStep("Nonce", nonce)
 	v.AddArrow()
 
-	// Get AAD from user
-	v.AddStep("Step 5: Additional Authenticated Data (AAD)")
-	v.AddStep("----------------------------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter Additional Authenticated Data (AAD) or press Enter to skip: ", "brightGreen bold"))
-	aad := ""
-	if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-		aad = strings.TrimSpace(input)
-	}
+	// Get AAD from the configuration or the user
+	v.AddSubheader("Step 5: Additional Authenticated Data (AAD)")
+	aad := p.associatedData()
 
 	if aad != "" {
 		v.AddTextStep("Additional Authenticated Data (AAD)", aad)
@@ -315,8 +352,7 @@ func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (s
 	}
 
 	// Measure execution time
-	v.AddStep("Step 6: Encryption Process")
-	v.AddStep("------------------------")
+	v.AddSubheader("Step 6: Encryption Process")
 	startTime := time.Now()
 	ciphertext := aead.Seal(nil, nonce, []byte(text), []byte(aad))
 	executionTime := time.Since(startTime)
@@ -344,15 +380,14 @@ func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (s
 	result := append(nonce, ciphertext...)
 
 	// Show final result in different formats
-	v.AddStep("Step 7: Final Result")
-	v.AddStep("------------------")
+	v.AddSubheader("Step 7: Final Result")
 	v.AddTextStep("Final Result (Hex)", hex.EncodeToString(result))
 	v.AddTextStep("Final Result (Base64)", base64.StdEncoding.EncodeToString(result))
+	v.AddStep(fmt.Sprintf("Returned as %s", utils.EncodingName(p.outputEncoding)))
 
 	// Add security notes
 	v.AddSeparator()
-	v.AddStep("🔒 Security Considerations")
-	v.AddStep("========================")
+	v.AddHeader("🔒 Security Considerations")
 	v.AddStep("1. ChaCha20-Poly1305 provides both confidentiality and authenticity")
 	v.AddStep("2. The key must be kept secret and secure")
 	v.AddStep("3. The nonce must be unique for each encryption")
@@ -362,32 +397,79 @@ func (p *ChaCha20Poly1305Processor) encrypt(text string, v *utils.Visualizer) (s
 		v.AddStep("6. AAD provides additional authentication for associated metadata")
 		v.AddStep("7. Any change to AAD will cause decryption to fail")
 	}
-	if choice == "2" {
+	if choice == 2 {
 		v.AddStep("8. Using custom key - ensure it's kept secure")
 		v.AddStep("9. Using custom nonce - ensure it's never reused")
 	}
 
-	return base64.StdEncoding.EncodeToString(result), v.GetSteps(), nil
+	return utils.Encode(result, p.outputEncoding), v.GetSteps(), nil
+}
+
+// chooseKey asks whether to use the managed key or a custom one
+func (p *ChaCha20Poly1305Processor) chooseKey() int {
+	return utils.PromptChoice(p.input, "Key Management:",
+		"Use existing key",
+		"Enter custom key (32 bytes in hex)")
+}
+
+// stdinIsTerminal reports whether the AAD prompt can be shown; tests replace it
+var stdinIsTerminal = utils.StdinIsTerminal
+
+// associatedData returns the configured AAD. Without one it prompts when stdin is a terminal,
+// and uses no AAD otherwise, so piped and scripted runs never block on the prompt.
+func (p *ChaCha20Poly1305Processor) associatedData() string {
+	if p.aadConfigured || p.input == nil || !stdinIsTerminal() {
+		return p.aad
+	}
+	return utils.PromptLine(p.input, "Enter Additional Authenticated Data (AAD) or press Enter to skip: ")
+}
+
+// addNonceUsage records a random-nonce encryption and warns as the birthday bound nears
+func (p *ChaCha20Poly1305Processor) addNonceUsage(v *utils.Visualizer) {
+	if p.nonceCounter == nil {
+		return
+	}
+	p.sessionEncryptions++
+	total, err := p.nonceCounter.Increment()
+	if err != nil {
+		v.AddNote(fmt.Sprintf("Could not persist nonce count: %v", err))
+	}
+
+	v.AddStep(fmt.Sprintf("Random-nonce encryptions this session: %d", p.sessionEncryptions))
+	v.AddStep(fmt.Sprintf("Random-nonce encryptions under this key (approx.): %d of a safe limit of 2^32", total))
+
+	switch {
+	case total >= randomNonceSafeLimit:
+		v.AddStep("⚠️ WARNING: This key has exceeded the safe limit for random 96-bit nonces")
+		v.AddStep("⚠️ A nonce collision is now a realistic risk - rotate the key immediately")
+	case total >= randomNonceWarnAtLimit:
+		v.AddStep("⚠️ WARNING: Approaching the birthday bound for random 96-bit nonces")
+	default:
+		return
+	}
+	v.AddNote("Switch to XChaCha20-Poly1305 (192-bit random nonces) or use a counter-based nonce")
+	v.AddNote("Rotating the key resets the nonce budget")
 }
 
-func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (string, []string, error) {
+func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (string, []utils.Step, error) {
 	// Decode input
-	v.AddStep("Step 1: Input Processing")
-	v.AddStep("----------------------")
-	decoded, err := base64.StdEncoding.DecodeString(text)
+	v.AddSubheader("Step 1: Input Processing")
+	decoded, encoding, err := utils.DecodeAny(text, func(data []byte) bool {
+		return len(data) >= p.nonceSize+p.tagSize
+	})
 	if err != nil {
-		v.AddStep("❌ Error: Invalid base64 input")
+		v.AddStep("❌ Error: Input is not Base64, Base64URL, or hex")
 		return "", v.GetSteps(), fmt.Errorf("failed to decode input: %w", err)
 	}
+	v.AddStep(fmt.Sprintf("Detected input encoding: %s", utils.EncodingName(encoding)))
 
 	// Show input
-	v.AddTextStep("Input (Base64)", text)
+	v.AddTextStep("Input", text)
 	v.AddArrow()
 
 	// Extract nonce and ciphertext
-	v.AddStep("Step 2: Data Extraction")
-	v.AddStep("---------------------")
-	if len(decoded) < p.nonceSize {
+	v.AddSubheader("Step 2: Data Extraction")
+	if len(decoded) < p.nonceSize+p.tagSize {
 		v.AddStep("❌ Error: Input too short")
 		return "", v.GetSteps(), fmt.Errorf("input too short")
 	}
@@ -407,24 +489,16 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	v.AddArrow()
 
 	// Interactive Tampering Test
-	v.AddStep("Step 3: Tampering Test")
-	v.AddStep("---------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Do you want to simulate tampering?", "brightCyan"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("1. No tampering", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("2. Flip a bit in ciphertext", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("3. Corrupt the tag", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter your choice (1-3): ", "brightGreen"))
-
-	choice := ""
-	if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-		choice = strings.TrimSpace(input)
-	}
+	v.AddSubheader("Step 3: Tampering Test")
+	choice := utils.PromptChoice(p.input, "Do you want to simulate tampering?",
+		"No tampering",
+		"Flip a bit in ciphertext",
+		"Corrupt the tag")
 
 	// Show simulation details
-	v.AddStep("Simulation Details:")
-	v.AddStep("-----------------")
+	v.AddSubheader("Simulation Details:")
 	switch choice {
-	case "2":
+	case 2:
 		// Flip a random bit in the ciphertext
 		if len(actualCiphertext) > 0 {
 			byteIndex := 0
@@ -440,11 +514,10 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 			v.AddStep("Modified Ciphertext (hex): " + hex.EncodeToString([]byte{actualCiphertext[byteIndex]}))
 			// Reconstruct the ciphertext with the modified data
 			ciphertext = append(actualCiphertext, tag...)
-			v.AddStep("Modified Data:")
-			v.AddStep("-------------")
+			v.AddSubheader("Modified Data:")
 			v.AddHexStep("Modified Ciphertext", ciphertext)
 		}
-	case "3":
+	case 3:
 		// Corrupt the tag
 		if len(tag) > 0 {
 			originalTag := make([]byte, len(tag))
@@ -459,8 +532,7 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 			v.AddStep("Modified Tag (hex): " + hex.EncodeToString(tag[:1]))
 			// Reconstruct the ciphertext with the modified tag
 			ciphertext = append(actualCiphertext, tag...)
-			v.AddStep("Modified Data:")
-			v.AddStep("-------------")
+			v.AddSubheader("Modified Data:")
 			v.AddHexStep("Modified Ciphertext", ciphertext)
 		}
 	default:
@@ -469,26 +541,12 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	v.AddSeparator()
 
 	// Ask for key input preference
-	v.AddStep("Step 4: Key Management")
-	v.AddStep("---------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Key Management:", "brightCyan"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("1. Use existing key", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("2. Enter custom key (32 bytes in hex)", "yellow"))
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter your choice (1-2): ", "brightGreen"))
-
+	v.AddSubheader("Step 4: Key Management")
 	var key []byte
-	choice = ""
-	if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-		choice = strings.TrimSpace(input)
-	}
+	choice = p.chooseKey()
 
-	if choice == "2" {
-		fmt.Printf("%s", utils.DefaultTheme.Format("Enter 32-byte key in hex format: ", "brightGreen"))
-		keyHex := ""
-		if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-			keyHex = strings.TrimSpace(input)
-		}
-		key, err = hex.DecodeString(keyHex)
+	if choice == 2 {
+		key, err = hex.DecodeString(utils.PromptLine(p.input, "Enter 32-byte key in hex format: "))
 		if err != nil || len(key) != 32 {
 			v.AddStep("❌ Error: Invalid key format or length")
 			return "", v.GetSteps(), fmt.Errorf("invalid key: must be 32 bytes in hex format")
@@ -503,14 +561,9 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	v.AddHexStep("Decryption Key", key)
 	v.AddArrow()
 
-	// Get AAD from user
-	v.AddStep("Step 5: Additional Authenticated Data (AAD)")
-	v.AddStep("----------------------------------------")
-	fmt.Printf("\n%s", utils.DefaultTheme.Format("Enter Additional Authenticated Data (AAD) or press Enter to skip: ", "brightGreen"))
-	aad := ""
-	if input, err := bufio.NewReader(os.Stdin).ReadString('\n'); err == nil {
-		aad = strings.TrimSpace(input)
-	}
+	// Get AAD from the configuration or the user
+	v.AddSubheader("Step 5: Additional Authenticated Data (AAD)")
+	aad := p.associatedData()
 
 	if aad != "" {
 		v.AddTextStep("Additional Authenticated Data (AAD)", aad)
@@ -521,8 +574,7 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	}
 
 	// Create ChaCha20-Poly1305 cipher
-	v.AddStep("Step 6: Cipher Initialization")
-	v.AddStep("---------------------------")
+	v.AddSubheader("Step 6: Cipher Initialization")
 	aead, err := chacha20poly1305.New(key)
 	if err != nil {
 		v.AddStep("❌ Error: Failed to create cipher")
@@ -532,8 +584,7 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	v.AddArrow()
 
 	// Measure execution time
-	v.AddStep("Step 7: Decryption Process")
-	v.AddStep("------------------------")
+	v.AddSubheader("Step 7: Decryption Process")
 	startTime := time.Now()
 	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(aad))
 	executionTime := time.Since(startTime)
@@ -548,15 +599,14 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	v.AddStep(fmt.Sprintf("Decryption time: %s", timeStr))
 
 	if err != nil {
-		v.AddStep("❌ Authentication Failed!")
-		v.AddStep("=========================")
+		v.AddHeader("❌ Authentication Failed!")
 		v.AddStep("The decryption failed because:")
-		if choice == "2" {
+		if choice == 2 {
 			v.AddStep("• A bit was flipped in the ciphertext")
 			v.AddStep("• This demonstrates how ChaCha20-Poly1305 detects tampering")
 			v.AddStep("• The authentication tag verification failed")
 			v.AddStep("• This is expected behavior for tampered data")
-		} else if choice == "3" {
+		} else if choice == 3 {
 			v.AddStep("• The authentication tag was corrupted")
 			v.AddStep("• This shows how the MAC protects message integrity")
 			v.AddStep("• The tag verification failed")
@@ -577,14 +627,12 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 	}
 
 	// Show decrypted text
-	v.AddStep("Step 8: Final Result")
-	v.AddStep("------------------")
+	v.AddSubheader("Step 8: Final Result")
 	v.AddTextStep("Decrypted Text", string(plaintext))
 
 	// Add security notes
 	v.AddSeparator()
-	v.AddStep("🔒 Security Considerations")
-	v.AddStep("========================")
+	v.AddHeader("🔒 Security Considerations")
 	v.AddStep("1. The authentication tag is verified during decryption")
 	v.AddStep("2. If the tag is invalid, decryption fails")
 	v.AddStep("3. This ensures both confidentiality and authenticity")
@@ -594,10 +642,76 @@ func (p *ChaCha20Poly1305Processor) decrypt(text string, v *utils.Visualizer) (s
 		v.AddStep("6. AAD provides additional authentication for associated metadata")
 		v.AddStep("7. Any change to AAD will cause decryption to fail")
 	}
-	if choice == "2" {
+	if choice == 2 {
 		v.AddStep("8. Using custom key - ensure it's kept secure")
 		v.AddStep("9. Using custom nonce - ensure it's never reused")
 	}
 
 	return string(plaintext), v.GetSteps(), nil
 }
+
+// Encrypt seals plaintext under the managed key with a random nonce and the configured AAD
+// and returns nonce || ciphertext || tag, the same format Process produces
+func (p *ChaCha20Poly1305Processor) Encrypt(plaintext []byte) ([]byte, error) {
+	if p.keyManager == nil {
+		return nil, fmt.Errorf("processor is not configured")
+	}
+	aead, err := chacha20poly1305.New(p.keyManager.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(randomSource(p.rand), nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	if p.nonceCounter != nil {
+		p.sessionEncryptions++
+		if _, err := p.nonceCounter.Increment(); err != nil {
+			return nil, err
+		}
+	}
+	return aead.Seal(nonce, nonce, plaintext, []byte(p.aad)), nil
+}
+
+// Decrypt opens nonce || ciphertext || tag under the managed key and the configured AAD
+func (p *ChaCha20Poly1305Processor) Decrypt(data []byte) ([]byte, error) {
+	if p.keyManager == nil {
+		return nil, fmt.Errorf("processor is not configured")
+	}
+	aead, err := chacha20poly1305.New(p.keyManager.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	if len(data) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("input too short")
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(p.aad))
+	if err != nil {
+		return nil, fmt.Errorf("message authentication failed: %w", err)
+	}
+	return plaintext, nil
+}
+
+// KeyFile returns the key file currently in use
+func (p *ChaCha20Poly1305Processor) KeyFile() string {
+	return keyFileOf(p.keyManager)
+}
+
+// UseKeyFile switches to an existing key file, such as an archived key
+func (p *ChaCha20Poly1305Processor) UseKeyFile(path string) error {
+	keyManager, err := OpenFileKeyManager(256, path)
+	if err != nil {
+		return err
+	}
+	p.keyManager = keyManager
+	return nil
+}
+
+// AuditSources lists the randomness sources and libraries used for ChaCha20-Poly1305
+func (p *ChaCha20Poly1305Processor) AuditSources() []AuditSource {
+	return []AuditSource{
+		keyManagerRandomness,
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Random nonces (unless a counter nonce is configured)"},
+		{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/chacha20poly1305", Purpose: "ChaCha20 stream cipher with Poly1305 authentication"},
+	}
+}
@@ -0,0 +1,171 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Streaming format (the STREAM construction): a header, then chunks sealed with
+// nonce = prefix (7 bytes) || chunk counter (4 bytes, big-endian) || last-chunk flag (1 byte).
+// The header, followed by any configured AAD, is the AAD of every chunk, so the chunk size
+// and prefix cannot be altered.
+const (
+	streamMagic            = "CLS1"
+	streamNoncePrefixSize  = 7
+	streamHeaderSize       = len(streamMagic) + 4 + streamNoncePrefixSize
+	defaultStreamChunkSize = 64 * 1024
+	maxStreamChunkSize     = 16 * 1024 * 1024
+)
+
+// errStreamTruncated is returned when a stream ends without its final chunk
+var errStreamTruncated = errors.New("stream truncated: the final chunk is missing")
+
+// streamNonce builds the nonce for one chunk
+func streamNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[streamNoncePrefixSize:], counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// readChunk fills buf from r and reports whether it is the last chunk, peeking one byte
+// ahead so a stream that ends on a chunk boundary still marks its final chunk
+func readChunk(r *bufio.Reader, buf []byte) (int, bool, error) {
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, true, nil
+	}
+	if err != nil {
+		return n, false, err
+	}
+	if _, err := r.Peek(1); err == io.EOF {
+		return n, true, nil
+	} else if err != nil {
+		return n, false, err
+	}
+	return n, false, nil
+}
+
+// StreamProcess encrypts or decrypts in to out in fixed-size chunks, so input of any size can
+// be processed in constant memory. It returns the number of plaintext bytes processed.
+// Decryption fails if chunks were reordered, modified, dropped, or the stream was truncated;
+// chunks before the failure have already been written to out.
+func (p *ChaCha20Poly1305Processor) StreamProcess(in io.Reader, out io.Writer, operation string) (int64, error) {
+	if p.keyManager == nil {
+		return 0, fmt.Errorf("processor is not configured")
+	}
+	aead, err := chacha20poly1305.New(p.keyManager.GetKey())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	switch operation {
+	case OperationEncrypt:
+		return p.streamEncrypt(aead, bufio.NewReader(in), out)
+	case OperationDecrypt:
+		return p.streamDecrypt(aead, bufio.NewReader(in), out)
+	default:
+		return 0, fmt.Errorf("invalid operation: %s", operation)
+	}
+}
+
+func (p *ChaCha20Poly1305Processor) streamEncrypt(aead cipher.AEAD, in *bufio.Reader, out io.Writer) (int64, error) {
+	chunkSize := p.streamChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+
+	header := make([]byte, 0, streamHeaderSize)
+	header = append(header, streamMagic...)
+	header = binary.BigEndian.AppendUint32(header, uint32(chunkSize))
+	prefix := make([]byte, streamNoncePrefixSize)
+	if _, err := io.ReadFull(randomSource(p.rand), prefix); err != nil {
+		return 0, fmt.Errorf("failed to generate nonce prefix: %w", err)
+	}
+	header = append(header, prefix...)
+	if _, err := out.Write(header); err != nil {
+		return 0, fmt.Errorf("failed to write stream header: %w", err)
+	}
+	ad := append(header, p.aad...)
+
+	buf := make([]byte, chunkSize)
+	sealed := make([]byte, 0, chunkSize+aead.Overhead())
+	var total int64
+	for counter := uint64(0); ; counter++ {
+		if counter > math.MaxUint32 {
+			return total, fmt.Errorf("input too large: more than 2^32 chunks")
+		}
+		n, last, err := readChunk(in, buf)
+		if err != nil {
+			return total, fmt.Errorf("failed to read input: %w", err)
+		}
+		sealed = aead.Seal(sealed[:0], streamNonce(prefix, uint32(counter), last), buf[:n], ad)
+		if _, err := out.Write(sealed); err != nil {
+			return total, fmt.Errorf("failed to write chunk %d: %w", counter, err)
+		}
+		total += int64(n)
+		if last {
+			return total, nil
+		}
+	}
+}
+
+func (p *ChaCha20Poly1305Processor) streamDecrypt(aead cipher.AEAD, in *bufio.Reader, out io.Writer) (int64, error) {
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(in, header); err != nil {
+		return 0, fmt.Errorf("failed to read stream header: %w", err)
+	}
+	if !bytes.Equal(header[:len(streamMagic)], []byte(streamMagic)) {
+		return 0, fmt.Errorf("not a CryptoLens stream: bad magic")
+	}
+	chunkSize := int(binary.BigEndian.Uint32(header[len(streamMagic):]))
+	if chunkSize < 1 || chunkSize > maxStreamChunkSize {
+		return 0, fmt.Errorf("invalid stream chunk size: %d", chunkSize)
+	}
+	prefix := header[len(streamMagic)+4:]
+	ad := append(header, p.aad...)
+
+	buf := make([]byte, chunkSize+aead.Overhead())
+	plaintext := make([]byte, 0, chunkSize)
+	var total int64
+	for counter := uint64(0); ; counter++ {
+		if counter > math.MaxUint32 {
+			return total, fmt.Errorf("stream too large: more than 2^32 chunks")
+		}
+		n, last, err := readChunk(in, buf)
+		if err != nil {
+			return total, fmt.Errorf("failed to read input: %w", err)
+		}
+		if n < aead.Overhead() {
+			return total, errStreamTruncated
+		}
+		plaintext, err = aead.Open(plaintext[:0], streamNonce(prefix, uint32(counter), last), buf[:n], ad)
+		if err != nil {
+			if last {
+				// The last chunk we saw was not sealed as final: the stream was cut short
+				if _, errNotLast := aead.Open(nil, streamNonce(prefix, uint32(counter), false), buf[:n], ad); errNotLast == nil {
+					return total, errStreamTruncated
+				}
+			}
+			return total, fmt.Errorf("chunk %d failed authentication: %w", counter, err)
+		}
+		if _, err := out.Write(plaintext); err != nil {
+			return total, fmt.Errorf("failed to write chunk %d: %w", counter, err)
+		}
+		total += int64(len(plaintext))
+		if last {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func newStreamTestProcessor(t *testing.T, chunkSize int) *ChaCha20Poly1305Processor {
+	t.Helper()
+	dir := t.TempDir()
+	processor := NewChaCha20Poly1305Processor()
+	if err := processor.Configure(map[string]interface{}{
+		"keyFile":   filepath.Join(dir, "chacha20poly1305_key.bin"),
+		"chunkSize": chunkSize,
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	return processor
+}
+
+func TestChaCha20Poly1305Processor_StreamRoundTrip(t *testing.T) {
+	const chunkSize = 16
+	processor := newStreamTestProcessor(t, chunkSize)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, 3 * chunkSize, 3*chunkSize + 5} {
+		plaintext := bytes.Repeat([]byte{0xAB}, size)
+		var sealed bytes.Buffer
+		n, err := processor.StreamProcess(bytes.NewReader(plaintext), &sealed, OperationEncrypt)
+		if err != nil || n != int64(size) {
+			t.Fatalf("size %d: StreamProcess(encrypt) = %d, %v", size, n, err)
+		}
+		chunks := max(1, (size+chunkSize-1)/chunkSize)
+		if want := streamHeaderSize + size + chunks*16; sealed.Len() != want {
+			t.Errorf("size %d: expected %d sealed bytes, got %d", size, want, sealed.Len())
+		}
+
+		var opened bytes.Buffer
+		n, err = processor.StreamProcess(bytes.NewReader(sealed.Bytes()), &opened, OperationDecrypt)
+		if err != nil || n != int64(size) {
+			t.Fatalf("size %d: StreamProcess(decrypt) = %d, %v", size, n, err)
+		}
+		if !bytes.Equal(opened.Bytes(), plaintext) {
+			t.Errorf("size %d: round trip mismatch", size)
+		}
+	}
+}
+
+func TestChaCha20Poly1305Processor_StreamDetectsTampering(t *testing.T) {
+	const chunkSize = 16
+	processor := newStreamTestProcessor(t, chunkSize)
+
+	var sealed bytes.Buffer
+	if _, err := processor.StreamProcess(bytes.NewReader(make([]byte, 3*chunkSize+5)), &sealed, OperationEncrypt); err != nil {
+		t.Fatalf("StreamProcess(encrypt) error = %v", err)
+	}
+	data := sealed.Bytes()
+	sealedChunk := chunkSize + 16
+	chunk := func(i int) []byte {
+		start := streamHeaderSize + i*sealedChunk
+		return data[start:min(start+sealedChunk, len(data))]
+	}
+	join := func(parts ...[]byte) []byte { return bytes.Join(parts, nil) }
+	header := data[:streamHeaderSize]
+
+	modifiedHeader := append([]byte(nil), data...)
+	modifiedHeader[streamHeaderSize-1] ^= 1
+	flipped := append([]byte(nil), data...)
+	flipped[len(flipped)-1] ^= 1
+
+	tests := []struct {
+		name      string
+		data      []byte
+		truncated bool
+	}{
+		{"truncated at a chunk boundary", join(header, chunk(0), chunk(1)), true},
+		{"header only", header, true},
+		{"chunks reordered", join(header, chunk(1), chunk(0), chunk(2), chunk(3)), false},
+		{"chunk dropped", join(header, chunk(0), chunk(2), chunk(3)), false},
+		{"trailing data", join(data, []byte{0}), false},
+		{"header modified", modifiedHeader, false},
+		{"tag modified", flipped, false},
+	}
+	for _, tt := range tests {
+		_, err := processor.StreamProcess(bytes.NewReader(tt.data), &bytes.Buffer{}, OperationDecrypt)
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		if tt.truncated && !errors.Is(err, errStreamTruncated) {
+			t.Errorf("%s: expected a truncation error, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestChaCha20Poly1305Processor_StreamAAD(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "chacha20poly1305_key.bin")
+	configure := func(aad string) *ChaCha20Poly1305Processor {
+		processor := NewChaCha20Poly1305Processor()
+		if err := processor.Configure(map[string]interface{}{"keyFile": keyFile, "aad": aad}); err != nil {
+			t.Fatalf("Failed to configure processor: %v", err)
+		}
+		return processor
+	}
+
+	var sealed bytes.Buffer
+	if _, err := configure("backup-2024").StreamProcess(bytes.NewReader([]byte("data")), &sealed, OperationEncrypt); err != nil {
+		t.Fatalf("StreamProcess(encrypt) error = %v", err)
+	}
+	if _, err := configure("backup-2025").StreamProcess(bytes.NewReader(sealed.Bytes()), &bytes.Buffer{}, OperationDecrypt); err == nil {
+		t.Error("Expected decryption with different AAD to fail")
+	}
+	if _, err := configure("backup-2024").StreamProcess(bytes.NewReader(sealed.Bytes()), &bytes.Buffer{}, OperationDecrypt); err != nil {
+		t.Errorf("StreamProcess(decrypt) error = %v", err)
+	}
+}
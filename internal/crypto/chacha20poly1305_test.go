@@ -2,32 +2,41 @@ package crypto
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
-// mockStdin creates a temporary stdin with predefined inputs
-func mockStdin(inputs ...string) func() {
-	oldStdin := os.Stdin
-	r, w, _ := os.Pipe()
-	os.Stdin = r
-
-	// Write all inputs with newlines
-	for _, input := range inputs {
-		if _, err := w.Write([]byte(input + "\n")); err != nil {
-			// If we can't write to the pipe, we should restore stdin and panic
-			os.Stdin = oldStdin
-			panic(fmt.Sprintf("failed to write to mock stdin: %v", err))
-		}
+// scriptedInput answers prompts from a fixed list of responses
+type scriptedInput struct {
+	responses []string
+}
+
+func (s *scriptedInput) next() (string, error) {
+	if len(s.responses) == 0 {
+		return "", io.EOF
 	}
-	w.Close()
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
 
-	return func() {
-		os.Stdin = oldStdin
+func (s *scriptedInput) Choose(title string, options []string) (int, error) {
+	response, err := s.next()
+	if err != nil {
+		return 0, err
 	}
+	return strconv.Atoi(response)
+}
+
+func (s *scriptedInput) Prompt(prompt string) (string, error) {
+	return s.next()
 }
 
 func TestChaCha20Poly1305Processor(t *testing.T) {
@@ -124,8 +133,8 @@ func TestChaCha20Poly1305Processor(t *testing.T) {
 	})
 
 	t.Run("Invalid Base64 Input", func(t *testing.T) {
-		// Test invalid base64
-		invalidInput := "not-base64-encoded"
+		// Not valid in any accepted encoding ("not-base64-encoded" is valid Base64URL)
+		invalidInput := "not base64 encoded!"
 
 		// Attempt decryption
 		_, steps, err := processor.Process(invalidInput, OperationDecrypt)
@@ -135,17 +144,6 @@ func TestChaCha20Poly1305Processor(t *testing.T) {
 	})
 
 	t.Run("Tampered Ciphertext", func(t *testing.T) {
-		// Mock stdin for interactive prompts
-		restore := mockStdin(
-			"1", // Use existing key
-			"1", // Generate random nonce
-			"",  // No AAD
-			"2", // Flip a bit in ciphertext
-			"1", // Use existing key for decryption
-			"",  // No AAD for decryption
-		)
-		defer restore()
-
 		// Encrypt a message
 		plaintext := "Test message"
 		ciphertext, _, err := processor.Process(plaintext, OperationEncrypt)
@@ -169,17 +167,6 @@ func TestChaCha20Poly1305Processor(t *testing.T) {
 	})
 
 	t.Run("Tampered Tag", func(t *testing.T) {
-		// Mock stdin for interactive prompts
-		restore := mockStdin(
-			"1", // Use existing key
-			"1", // Generate random nonce
-			"",  // No AAD
-			"3", // Corrupt the tag
-			"1", // Use existing key for decryption
-			"",  // No AAD for decryption
-		)
-		defer restore()
-
 		// Encrypt a message
 		plaintext := "Test message"
 		ciphertext, _, err := processor.Process(plaintext, OperationEncrypt)
@@ -254,6 +241,16 @@ func TestChaCha20Poly1305Processor(t *testing.T) {
 		require.NotEmpty(t, steps)
 	})
 
+	t.Run("AAD Mismatch", func(t *testing.T) {
+		require.NoError(t, processor.Configure(map[string]interface{}{"aad": "header-v1"}))
+		ciphertext, _, err := processor.Process("Message with AAD", OperationEncrypt)
+		require.NoError(t, err)
+
+		require.NoError(t, processor.Configure(map[string]interface{}{"aad": "header-v2"}))
+		_, _, err = processor.Process(ciphertext, OperationDecrypt)
+		require.Error(t, err)
+	})
+
 	t.Run("Multiple Operations", func(t *testing.T) {
 		// Test multiple encryption/decryption operations
 		messages := []string{
@@ -277,3 +274,118 @@ func TestChaCha20Poly1305Processor(t *testing.T) {
 		}
 	})
 }
+
+func TestChaCha20Poly1305Processor_NonceBudgetWarning(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := dir + "/chacha_key.bin"
+	processor := NewChaCha20Poly1305Processor()
+	require.NoError(t, processor.Configure(map[string]interface{}{
+		"keyFile": keyFile,
+	}))
+
+	// A fresh key stays quiet
+	_, steps, err := processor.Process("hello", OperationEncrypt)
+	require.NoError(t, err)
+	for _, step := range steps {
+		require.NotContains(t, step.Text, "birthday bound")
+	}
+
+	// Simulate a key that has already been used for billions of encryptions
+	require.NoError(t, os.WriteFile(keyFile+nonceCountSuffix, []byte(fmt.Sprint(randomNonceWarnAtLimit)), 0600))
+
+	_, steps, err = processor.Process("hello", OperationEncrypt)
+	require.NoError(t, err)
+	found := false
+	for _, step := range steps {
+		if strings.Contains(step.Text, "Approaching the birthday bound") {
+			found = true
+		}
+	}
+	require.True(t, found, "expected nonce budget warning")
+	require.Equal(t, randomNonceWarnAtLimit+1, processor.nonceCounter.Count())
+	require.Equal(t, uint64(2), processor.sessionEncryptions)
+}
+
+func TestChaCha20Poly1305Processor_AADPromptOnlyOnTerminal(t *testing.T) {
+	oldIsTerminal := stdinIsTerminal
+	defer func() { stdinIsTerminal = oldIsTerminal }()
+
+	processor := NewChaCha20Poly1305Processor()
+	require.NoError(t, processor.Configure(map[string]interface{}{
+		"keyFile": t.TempDir() + "/chacha_key.bin",
+	}))
+
+	// Without an input handler nothing is prompted
+	stdinIsTerminal = func() bool { return true }
+	require.Equal(t, "", processor.associatedData())
+
+	// Piped stdin is never read for AAD
+	stdinIsTerminal = func() bool { return false }
+	require.NoError(t, processor.Configure(map[string]interface{}{"input": &scriptedInput{responses: []string{"typed-aad"}}}))
+	require.Equal(t, "", processor.associatedData())
+
+	// A terminal is prompted
+	stdinIsTerminal = func() bool { return true }
+	require.Equal(t, "typed-aad", processor.associatedData())
+
+	// Configured AAD wins over the prompt
+	require.NoError(t, processor.Configure(map[string]interface{}{"aad": "configured"}))
+	require.Equal(t, "configured", processor.associatedData())
+}
+
+func TestChaCha20Poly1305Processor_PromptedChoices(t *testing.T) {
+	processor := NewChaCha20Poly1305Processor()
+	require.NoError(t, processor.Configure(map[string]interface{}{
+		"keyFile": t.TempDir() + "/chacha_key.bin",
+	}))
+	key := strings.Repeat("ab", 32)
+	nonce := strings.Repeat("01", 12)
+
+	// A custom key and nonce come from the input handler
+	require.NoError(t, processor.Configure(map[string]interface{}{
+		"input": &scriptedInput{responses: []string{"2", key, "2", nonce}},
+	}))
+	ciphertext, _, err := processor.Process("hello", OperationEncrypt)
+	require.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, nonce, hex.EncodeToString(decoded[:12]))
+
+	// Simulated tampering fails authentication; no tampering with the same key succeeds
+	for _, tamper := range []string{"2", "3"} {
+		processor.input = &scriptedInput{responses: []string{tamper, "2", key}}
+		_, _, err = processor.Process(ciphertext, OperationDecrypt)
+		require.ErrorContains(t, err, "message authentication failed")
+	}
+	processor.input = &scriptedInput{responses: []string{"1", "2", key}}
+	plaintext, _, err := processor.Process(ciphertext, OperationDecrypt)
+	require.NoError(t, err)
+	require.Equal(t, "hello", plaintext)
+
+	// An invalid custom key is rejected
+	processor.input = &scriptedInput{responses: []string{"2", "not-hex"}}
+	_, _, err = processor.Process("hello", OperationEncrypt)
+	require.ErrorContains(t, err, "invalid key")
+}
+
+func TestChaCha20Poly1305Processor_Cipher(t *testing.T) {
+	processor := NewChaCha20Poly1305Processor()
+	require.NoError(t, processor.Configure(map[string]interface{}{
+		"keyFile": t.TempDir() + "/chacha_key.bin",
+		"aad":     "v1",
+	}))
+
+	sealed, err := processor.Encrypt([]byte("hello"))
+	require.NoError(t, err)
+	require.Len(t, sealed, 12+len("hello")+16)
+	require.Equal(t, uint64(1), processor.nonceCounter.Count())
+
+	// Process reads what Encrypt writes
+	decrypted, _, err := processor.Process(base64.StdEncoding.EncodeToString(sealed), OperationDecrypt)
+	require.NoError(t, err)
+	require.Equal(t, "hello", decrypted)
+
+	require.NoError(t, processor.Configure(map[string]interface{}{"aad": "v2"}))
+	_, err = processor.Decrypt(sealed)
+	require.ErrorContains(t, err, "message authentication failed")
+}
@@ -0,0 +1,224 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ciphers available in the key challenge
+const (
+	ChallengeAES      = "aes"
+	ChallengeBlowfish = "blowfish"
+	ChallengeRC4      = "rc4"
+)
+
+// challengeKeySize is the key size in bits used for every challenge cipher
+const challengeKeySize = 128
+
+// challengeMessages are the secret messages a challenge may encrypt
+var challengeMessages = []string{
+	"attack at dawn",
+	"the eagle has landed",
+	"meet me by the old oak tree",
+	"the password is swordfish",
+	"keys matter more than ciphers",
+}
+
+// memoryKeyManager holds a throwaway key in memory without touching the key directory
+type memoryKeyManager struct {
+	key []byte
+}
+
+// LoadOrGenerateKey implements KeyManager; the key is fixed at construction
+func (m *memoryKeyManager) LoadOrGenerateKey() error {
+	if len(m.key) == 0 {
+		return fmt.Errorf("no key set")
+	}
+	return nil
+}
+
+// GetKey returns the key
+func (m *memoryKeyManager) GetKey() []byte {
+	return m.key
+}
+
+// SetKey replaces the key
+func (m *memoryKeyManager) SetKey(key []byte) error {
+	m.key = key
+	return nil
+}
+
+// ChallengeGrade is the outcome of one attempt at a key challenge
+type ChallengeGrade struct {
+	Correct   bool
+	Plaintext string
+	Hint      string
+}
+
+// KeyChallenge encrypts a random message under a random key and grades attempts to name that key
+type KeyChallenge struct {
+	cipher     string
+	key        []byte
+	message    string
+	ciphertext string
+	candidates []string
+	attempts   int
+}
+
+// NewKeyChallenge creates a challenge for the named cipher with the real key hidden among decoys
+func NewKeyChallenge(cipher string, decoys int) (*KeyChallenge, error) {
+	if _, err := newChallengeProcessor(cipher, make([]byte, challengeKeySize/8)); err != nil {
+		return nil, err
+	}
+	if decoys < 0 {
+		return nil, fmt.Errorf("invalid decoy count: %d", decoys)
+	}
+
+	key, err := randomChallengeKey()
+	if err != nil {
+		return nil, err
+	}
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(challengeMessages))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pick message: %w", err)
+	}
+
+	c := &KeyChallenge{
+		cipher:  cipher,
+		key:     key,
+		message: challengeMessages[index.Int64()],
+	}
+
+	processor, _ := newChallengeProcessor(cipher, key)
+	c.ciphertext, _, err = processor.Process(c.message, OperationEncrypt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt challenge: %w", err)
+	}
+
+	c.candidates = []string{hex.EncodeToString(key)}
+	for i := 0; i < decoys; i++ {
+		decoy, err := randomChallengeKey()
+		if err != nil {
+			return nil, err
+		}
+		c.candidates = append(c.candidates, hex.EncodeToString(decoy))
+	}
+	// Shuffle so the real key's position gives nothing away
+	for i := len(c.candidates) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to shuffle candidates: %w", err)
+		}
+		c.candidates[i], c.candidates[j.Int64()] = c.candidates[j.Int64()], c.candidates[i]
+	}
+
+	return c, nil
+}
+
+// randomChallengeKey generates a fresh challenge key
+func randomChallengeKey() ([]byte, error) {
+	key := make([]byte, challengeKeySize/8)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// newChallengeProcessor returns the symmetric processor for a challenge cipher using an in-memory key
+func newChallengeProcessor(cipher string, key []byte) (Processor, error) {
+	switch cipher {
+	case ChallengeAES:
+		return newKeyedProcessor(BlobAESCBC, key)
+	case ChallengeBlowfish:
+		return newKeyedProcessor(BlobBlowfish, key)
+	case ChallengeRC4:
+		return newKeyedProcessor(BlobRC4, key)
+	default:
+		return nil, fmt.Errorf("unsupported challenge cipher: %s (must be aes, blowfish, or rc4)", cipher)
+	}
+}
+
+// challengeCipherName returns a display name for a challenge cipher
+func challengeCipherName(cipher string) string {
+	switch cipher {
+	case ChallengeAES:
+		return fmt.Sprintf("AES-%d-CBC", challengeKeySize)
+	case ChallengeBlowfish:
+		return "Blowfish-CBC"
+	default:
+		return "RC4"
+	}
+}
+
+// CipherName returns a display name for the challenge cipher
+func (c *KeyChallenge) CipherName() string {
+	return challengeCipherName(c.cipher)
+}
+
+// Ciphertext returns the Base64 ciphertext to be decrypted
+func (c *KeyChallenge) Ciphertext() string {
+	return c.ciphertext
+}
+
+// Candidates returns the hex-encoded candidate keys, one of which is correct
+func (c *KeyChallenge) Candidates() []string {
+	return c.candidates
+}
+
+// Attempts returns how many attempts have been graded
+func (c *KeyChallenge) Attempts() int {
+	return c.attempts
+}
+
+// Grade decrypts the ciphertext with a hex-encoded key and explains why a wrong key failed
+func (c *KeyChallenge) Grade(keyHex string) ChallengeGrade {
+	c.attempts++
+	keyHex = strings.TrimSpace(keyHex)
+
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return ChallengeGrade{Hint: "Keys are hex encoded: use only the digits 0-9 and letters a-f, two per byte"}
+	}
+	if len(key) != len(c.key) {
+		return ChallengeGrade{Hint: fmt.Sprintf("%s here uses a %d-byte key (%d hex digits), but you entered %d bytes",
+			c.CipherName(), len(c.key), len(c.key)*2, len(key))}
+	}
+
+	processor, err := newChallengeProcessor(c.cipher, key)
+	if err != nil {
+		return ChallengeGrade{Hint: err.Error()}
+	}
+	plaintext, _, err := processor.Process(c.ciphertext, OperationDecrypt)
+	if err == nil && plaintext == c.message {
+		return ChallengeGrade{Correct: true, Plaintext: plaintext}
+	}
+
+	var hint string
+	switch {
+	case err != nil:
+		hint = fmt.Sprintf("Decryption failed (%v). With CBC and PKCS7 a wrong key almost always leaves invalid padding, so the error itself gives the wrong key away", err)
+	case !utf8.ValidString(plaintext):
+		hint = "The key decrypted to random bytes. A stream cipher like RC4 never rejects a key, so you can only spot a wrong key by looking at the output"
+	default:
+		hint = "The output is not the secret message, so this is not the key"
+	}
+	return ChallengeGrade{Plaintext: plaintext, Hint: hint + c.prefixHint()}
+}
+
+// prefixHint reveals one more byte of the real key after each failed attempt
+func (c *KeyChallenge) prefixHint() string {
+	n := c.attempts
+	if n > len(c.key)/2 {
+		n = len(c.key) / 2
+	}
+	return fmt.Sprintf(". Hint: the key starts with %s", hex.EncodeToString(c.key[:n]))
+}
+
+// Reveal returns the hex-encoded key and the secret message
+func (c *KeyChallenge) Reveal() (string, string) {
+	return hex.EncodeToString(c.key), c.message
+}
@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyChallenge_Grade(t *testing.T) {
+	for _, cipher := range []string{ChallengeAES, ChallengeBlowfish, ChallengeRC4} {
+		t.Run(cipher, func(t *testing.T) {
+			challenge, err := NewKeyChallenge(cipher, 3)
+			require.NoError(t, err)
+			assert.Len(t, challenge.Candidates(), 4)
+			assert.Contains(t, challenge.Candidates(), hex.EncodeToString(challenge.key))
+
+			// A wrong key of the right length fails and reveals part of the real key
+			wrong := make([]byte, len(challenge.key))
+			copy(wrong, challenge.key)
+			wrong[0] ^= 0xff
+			grade := challenge.Grade(hex.EncodeToString(wrong))
+			assert.False(t, grade.Correct)
+			assert.Contains(t, grade.Hint, "Hint: the key starts with "+hex.EncodeToString(challenge.key[:1]))
+
+			// The correct key recovers the secret message
+			grade = challenge.Grade(hex.EncodeToString(challenge.key))
+			assert.True(t, grade.Correct)
+			assert.Equal(t, challenge.message, grade.Plaintext)
+			assert.Equal(t, 2, challenge.Attempts())
+		})
+	}
+}
+
+func TestKeyChallenge_MalformedKeys(t *testing.T) {
+	challenge, err := NewKeyChallenge(ChallengeAES, 0)
+	require.NoError(t, err)
+
+	grade := challenge.Grade("not hex")
+	assert.False(t, grade.Correct)
+	assert.Contains(t, grade.Hint, "hex encoded")
+
+	grade = challenge.Grade("00112233")
+	assert.False(t, grade.Correct)
+	assert.Contains(t, grade.Hint, "16-byte key (32 hex digits)")
+
+	_, err = NewKeyChallenge("des", 0)
+	assert.Error(t, err)
+}
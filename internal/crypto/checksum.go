@@ -0,0 +1,185 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Checksum algorithms supported by the ChecksumProcessor
+const (
+	ChecksumCRC32   = "crc32"
+	ChecksumCRC32C  = "crc32c"
+	ChecksumAdler32 = "adler32"
+)
+
+// ChecksumAlgorithms returns the algorithms ChecksumProcessor supports, in display order
+func ChecksumAlgorithms() []string {
+	return []string{ChecksumCRC32, ChecksumCRC32C, ChecksumAdler32}
+}
+
+// ChecksumProcessor computes non-cryptographic checksums and shows why they cannot protect integrity
+type ChecksumProcessor struct {
+	BaseConfigurableProcessor
+	algorithm string
+}
+
+// NewChecksumProcessor creates a new checksum processor
+func NewChecksumProcessor() *ChecksumProcessor {
+	return &ChecksumProcessor{
+		algorithm: ChecksumCRC32,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *ChecksumProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if algorithm, ok := config["algorithm"].(string); ok && algorithm != "" {
+		if _, err := newChecksum(algorithm); err != nil {
+			return err
+		}
+		p.algorithm = algorithm
+	}
+
+	return nil
+}
+
+// newChecksum returns a fresh checksum for algorithm
+func newChecksum(algorithm string) (hash.Hash32, error) {
+	switch algorithm {
+	case ChecksumCRC32:
+		return crc32.NewIEEE(), nil
+	case ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case ChecksumAdler32:
+		return adler32.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm: %s", algorithm)
+	}
+}
+
+// checksumDisplayName returns the conventional name of a checksum algorithm
+func checksumDisplayName(algorithm string) string {
+	switch algorithm {
+	case ChecksumCRC32C:
+		return "CRC-32C (Castagnoli)"
+	case ChecksumAdler32:
+		return "Adler-32"
+	default:
+		return "CRC-32 (IEEE)"
+	}
+}
+
+// Process implements the Processor interface; checksums have no decrypt, so the operation is ignored
+func (p *ChecksumProcessor) Process(text string, _ string) (string, []utils.Step, error) {
+	h, err := newChecksum(p.algorithm)
+	if err != nil {
+		return "", nil, err
+	}
+	data := []byte(text)
+	h.Write(data)
+	sum := h.Sum32()
+	name := checksumDisplayName(p.algorithm)
+
+	v := utils.NewVisualizer()
+	v.AddHeader(fmt.Sprintf("🧮 %s Checksum", name))
+	v.AddStep("⚠️ NOT FOR SECURITY: a checksum detects accidental errors, not deliberate tampering")
+	v.AddNote("Anyone can recompute a checksum after changing the data: there is no key")
+	v.AddNote("Checksums are also easy to forge: the data can be changed while keeping the same value")
+	v.AddSeparator()
+
+	v.AddSubheader("Step 1: Input")
+	v.AddTextStep("Input Text", text)
+	v.AddHexStep("Input Bytes", data)
+	v.AddArrow()
+
+	v.AddSubheader("Step 2: Checksum")
+	if p.algorithm == ChecksumAdler32 {
+		v.AddStep("A = 1 + sum of all bytes, B = sum of every intermediate A, both mod 65521")
+		v.AddStep("Checksum = B << 16 | A")
+	} else {
+		v.AddStep("The message is treated as a polynomial over GF(2) and divided by a fixed generator polynomial")
+		v.AddStep("The 32-bit remainder is the checksum; every step is XOR, so the result is linear in the input")
+	}
+	result := fmt.Sprintf("%08x", sum)
+	v.AddTextStep(fmt.Sprintf("%s (hex)", name), result)
+	v.AddStep("Only 32 bits: even random data matches a given value once in about 4 billion tries")
+	v.AddSeparator()
+
+	p.addForgeryDemo(v, data)
+
+	v.AddSeparator()
+	v.AddHeader("🔑 Checksum vs Hash vs HMAC")
+	v.AddStep("• Checksum (CRC, Adler): catches transmission errors; forgeable by anyone")
+	v.AddStep("• Cryptographic hash (SHA-256): collisions are infeasible, but anyone can still")
+	v.AddStep("  recompute the hash of modified data, so it only helps if the hash itself is trusted")
+	v.AddStep("• HMAC: needs the secret key to compute, so an attacker cannot produce a valid tag")
+	v.AddNote("Use HMAC or an AEAD such as AES-GCM to protect data against an adversary")
+
+	return result, v.GetSteps(), nil
+}
+
+// addForgeryDemo appends four chosen bytes to a modified message so its CRC-32 matches the original
+func (p *ChecksumProcessor) addForgeryDemo(v *utils.Visualizer, data []byte) {
+	v.AddHeader("🚨 Forging a Matching Checksum")
+	table := crc32.IEEETable
+	if p.algorithm == ChecksumCRC32C {
+		table = crc32.MakeTable(crc32.Castagnoli)
+	}
+	if p.algorithm == ChecksumAdler32 {
+		v.AddStep("Adler-32 sums are just as easy to steer: raising one byte by d and lowering the next")
+		v.AddStep("by d keeps A the same and raises B by d; the opposite swap elsewhere cancels it out")
+		v.AddStep("The CRC-32 of the same input can be forged with four appended bytes:")
+	}
+
+	forged := append([]byte("PAY $1,000,000 TO MALLORY. "), 0, 0, 0, 0)
+	target := crc32.Checksum(data, table)
+	patch := forgeCRC32(table, forged[:len(forged)-4], target)
+	copy(forged[len(forged)-4:], patch[:])
+
+	v.AddTextStep("Forged Message", string(forged[:len(forged)-4]))
+	v.AddHexStep("Appended Bytes (computed by running CRC-32 backwards)", patch[:])
+	v.AddStep(fmt.Sprintf("Original CRC: %08x", target))
+	v.AddStep(fmt.Sprintf("Forged CRC:   %08x", crc32.Checksum(forged, table)))
+	if crc32.Checksum(forged, table) == target {
+		v.AddStep("❌ Same checksum, completely different message: the check passes")
+	}
+	v.AddNote("This takes microseconds. Finding a SHA-256 second preimage would take about 2^256 work")
+}
+
+// forgeCRC32 returns the four bytes that, appended to prefix, make its CRC-32 equal target.
+// Feeding four bytes into the CRC register is the same as XORing them into it and feeding four
+// zero bytes, and each zero-byte step can be reversed because every table entry has a unique top byte.
+func forgeCRC32(table *crc32.Table, prefix []byte, target uint32) [4]byte {
+	var topByte [256]byte
+	for i, entry := range table {
+		topByte[entry>>24] = byte(i)
+	}
+
+	register := ^target
+	for range 4 {
+		i := topByte[register>>24]
+		register = (register^table[i])<<8 | uint32(i)
+	}
+
+	// The register after the prefix, XORed with the bytes, must equal the register found above
+	afterPrefix := ^crc32.Checksum(prefix, table)
+	var patch [4]byte
+	binary.LittleEndian.PutUint32(patch[:], register^afterPrefix)
+	return patch
+}
+
+// AuditSources implements the AuditableProcessor interface
+func (p *ChecksumProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		{Kind: AuditStandardLibrary, Package: "hash/crc32, hash/adler32", Purpose: "Non-cryptographic checksums"},
+		{Kind: AuditInRepository, Package: "internal/crypto/checksum.go", Purpose: "CRC-32 forgery for the demonstration"},
+	}
+}
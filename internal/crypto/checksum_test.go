@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestChecksumProcessor_Process(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		want      string
+	}{
+		// Check values for "123456789"
+		{ChecksumCRC32, "cbf43926"},
+		{ChecksumCRC32C, "e3069283"},
+		{ChecksumAdler32, "091e01de"},
+	}
+	for _, tt := range tests {
+		processor := NewChecksumProcessor()
+		if err := processor.Configure(map[string]interface{}{"algorithm": tt.algorithm}); err != nil {
+			t.Fatalf("Configure(%s) error = %v", tt.algorithm, err)
+		}
+		result, steps, err := processor.Process("123456789", OperationEncrypt)
+		if err != nil {
+			t.Fatalf("Process(%s) error = %v", tt.algorithm, err)
+		}
+		if result != tt.want {
+			t.Errorf("%s = %s, want %s", tt.algorithm, result, tt.want)
+		}
+		if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "NOT FOR SECURITY") {
+			t.Errorf("%s: expected the security warning", tt.algorithm)
+		}
+	}
+
+	if err := NewChecksumProcessor().Configure(map[string]interface{}{"algorithm": "md5"}); err == nil {
+		t.Error("Expected an error for an unsupported algorithm")
+	}
+}
+
+func TestForgeCRC32(t *testing.T) {
+	for _, table := range []*crc32.Table{crc32.IEEETable, crc32.MakeTable(crc32.Castagnoli)} {
+		for _, target := range []uint32{0, 0xcbf43926, 0xffffffff} {
+			prefix := []byte("forged message")
+			patch := forgeCRC32(table, prefix, target)
+			if got := crc32.Checksum(append(prefix, patch[:]...), table); got != target {
+				t.Errorf("forged CRC = %08x, want %08x", got, target)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"context"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// ProcessContext runs processor under ctx. A ContextProcessor stops early once ctx is done;
+// any other processor is not started when ctx is already done, but otherwise runs to completion.
+func ProcessContext(ctx context.Context, processor Processor, text string, operation string) (string, []utils.Step, error) {
+	if cp, ok := processor.(ContextProcessor); ok {
+		return cp.ProcessContext(ctx, text, operation)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	return processor.Process(text, operation)
+}
+
+// runContext runs fn, returning early with ctx.Err() once ctx is done. Library calls such as
+// a key derivation cannot be stopped part way, so an interrupted fn finishes in the background
+// and its result is discarded.
+func runContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	type outcome struct {
+		value T
+		err   error
+	}
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := fn()
+		done <- outcome{value, err}
+	}()
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// slowProcessor records which entry point ran
+type slowProcessor struct {
+	withContext bool
+}
+
+func (p *slowProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	return text, nil, nil
+}
+
+func (p *slowProcessor) ProcessContext(ctx context.Context, text string, operation string) (string, []utils.Step, error) {
+	p.withContext = true
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	return text, nil, nil
+}
+
+func TestProcessContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	contextAware := &slowProcessor{}
+	if _, _, err := ProcessContext(cancelled, contextAware, "hello", OperationEncrypt); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !contextAware.withContext {
+		t.Error("Expected ProcessContext to be used for a ContextProcessor")
+	}
+
+	plain := NewBase64Processor()
+	if _, _, err := ProcessContext(cancelled, plain, "hello", OperationEncrypt); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled context to skip a plain processor, got %v", err)
+	}
+	result, _, err := ProcessContext(context.Background(), plain, "hello", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("ProcessContext() error = %v", err)
+	}
+	if result != "aGVsbG8=" {
+		t.Errorf("Expected aGVsbG8=, got %q", result)
+	}
+}
+
+func TestRunContextReturnsOnceDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+
+	_, err := runContext(ctx, func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded while fn is still running, got %v", err)
+	}
+
+	value, err := runContext(context.Background(), func() (int, error) { return 42, nil })
+	if err != nil || value != 42 {
+		t.Errorf("runContext() = %d, %v; want 42, nil", value, err)
+	}
+}
+
+func TestSlowProcessorsHonourContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, algorithm := range []string{PBKDFAlgorithmPBKDF2, PBKDFAlgorithmArgon2id, PBKDFAlgorithmScrypt, PBKDFAlgorithmBcrypt} {
+		processor := NewPBKDFProcessor()
+		if err := processor.Configure(map[string]interface{}{"algorithm": algorithm, "keyFile": "keys/test_pbkdf_key.bin"}); err != nil {
+			t.Fatalf("%s: Configure() error = %v", algorithm, err)
+		}
+		if _, _, err := ProcessContext(cancelled, processor, "password", OperationEncrypt); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", algorithm, err)
+		}
+	}
+
+	// A missing RSA key pair is generated by the first operation, under its context
+	dir := t.TempDir()
+	privateFile := filepath.Join(dir, "private.pem")
+	processor := NewRSAProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize":        1024,
+		"publicKeyFile":  filepath.Join(dir, "public.pem"),
+		"privateKeyFile": privateFile,
+	}); err != nil {
+		t.Fatalf("RSA: Configure() error = %v", err)
+	}
+	if _, _, err := ProcessContext(cancelled, processor, "hello", OperationEncrypt); !errors.Is(err, context.Canceled) {
+		t.Errorf("RSA: expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(privateFile); !os.IsNotExist(err) {
+		t.Errorf("RSA: expected no key file after an interrupted generation, got %v", err)
+	}
+}
@@ -2,8 +2,6 @@ package crypto
 
 import (
 	"crypto"
-	"crypto/aes"
-	"crypto/cipher"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -11,33 +9,59 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"sync"
 	"time"
 
-	"golang.org/x/crypto/hkdf"
-
 	"github.com/abdorrahmani/cryptolens/internal/utils"
 	"golang.org/x/crypto/curve25519"
 )
 
+// dhAuthKeySize is the RSA modulus size used to authenticate the DH public keys
+const dhAuthKeySize = 2048
+
+// dhSessionCache keeps the DH primes and Alice's and Bob's RSA authentication keys for the
+// rest of the session, so repeated demonstrations skip the expensive generation
+var dhSessionCache = struct {
+	sync.Mutex
+	primes    map[string]*big.Int
+	aliceAuth *rsa.PrivateKey
+	bobAuth   *rsa.PrivateKey
+}{primes: make(map[string]*big.Int)}
+
+// resetDHSessionCache forgets the cached primes and authentication keys
+func resetDHSessionCache() {
+	dhSessionCache.Lock()
+	defer dhSessionCache.Unlock()
+	dhSessionCache.primes = make(map[string]*big.Int)
+	dhSessionCache.aliceAuth, dhSessionCache.bobAuth = nil, nil
+}
+
 // DHProcessor implements the Processor interface for Diffie-Hellman key exchange
 type DHProcessor struct {
 	keySize    int
 	generator  *big.Int
 	prime      *big.Int
-	keyManager KeyManager
+	group      string // Named group; empty generates a safe prime of keySize bits
+	primeFile  string
+	transcript *KeyExchangeTranscript
+	rand       io.Reader
 }
 
 // NewDHProcessor creates a new Diffie-Hellman processor
 func NewDHProcessor() *DHProcessor {
 	return &DHProcessor{
-		keySize:    2048,
-		generator:  big.NewInt(2),
-		keyManager: NewFileKeyManager(2048, "keys/dh_prime.bin"),
+		keySize:   2048,
+		generator: big.NewInt(2),
+		primeFile: "keys/dh_prime.bin",
 	}
 }
 
 // Configure configures the DH processor with the given settings
 func (p *DHProcessor) Configure(config map[string]interface{}) error {
+	if random, ok := config["rand"].(io.Reader); ok {
+		p.rand = random
+	}
+
 	if keySize, ok := config["keySize"].(int); ok {
 		p.keySize = keySize
 	} else if _, ok := config["keySize"].(string); ok {
@@ -51,23 +75,107 @@ func (p *DHProcessor) Configure(config map[string]interface{}) error {
 	}
 
 	if primeFile, ok := config["primeFile"].(string); ok {
-		// Create a new key manager with the specified file
-		p.keyManager = NewFileKeyManager(p.keySize, primeFile)
+		p.primeFile = primeFile
+	}
+
+	// A named group fixes the prime and generator, overriding keySize and generator
+	if name, ok := config["group"].(string); ok && name != "" {
+		if name == DHGroupGenerated {
+			p.group = ""
+			return nil
+		}
+		group, err := lookupDHGroup(name)
+		if err != nil {
+			return err
+		}
+		p.group = group.name
+		p.keySize = group.prime.BitLen()
+		p.generator = group.generator
 	}
 	return nil
 }
 
-// loadOrGeneratePrime loads or generates a prime number
+// Transcript returns the messages exchanged during the last demonstration
+func (p *DHProcessor) Transcript() *KeyExchangeTranscript {
+	return p.transcript
+}
+
+// loadOrGeneratePrime loads the safe prime from the prime file, generating and saving a new
+// one when the file is missing or does not hold a safe prime of the configured size
 func (p *DHProcessor) loadOrGeneratePrime() (*big.Int, error) {
-	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
+	prime, ok, err := loadSafePrime(p.primeFile, p.keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/generate prime: %w", err)
+	}
+	if ok {
+		return prime, nil
+	}
+
+	prime, err = generateSafePrime(randomSource(p.rand), p.keySize)
+	if err != nil {
 		return nil, fmt.Errorf("failed to load/generate prime: %w", err)
 	}
-	return new(big.Int).SetBytes(p.keyManager.GetKey()), nil
+	if err := saveSafePrime(p.primeFile, prime); err != nil {
+		return nil, fmt.Errorf("failed to load/generate prime: %w", err)
+	}
+	return prime, nil
+}
+
+// sessionPrime returns the prime from an earlier run in this session, or loads it;
+// the bool reports whether it came from the cache
+func (p *DHProcessor) sessionPrime() (*big.Int, bool, error) {
+	cacheKey := fmt.Sprintf("%s:%d", p.primeFile, p.keySize)
+	dhSessionCache.Lock()
+	defer dhSessionCache.Unlock()
+	if prime, ok := dhSessionCache.primes[cacheKey]; ok {
+		return prime, true, nil
+	}
+
+	prime, err := p.loadOrGeneratePrime()
+	if err != nil {
+		return nil, false, err
+	}
+	dhSessionCache.primes[cacheKey] = prime
+	return prime, false, nil
+}
+
+// authKeys returns Alice's and Bob's RSA authentication keys, generated once per session.
+// An injected randomness source bypasses the cache so runs stay reproducible.
+func (p *DHProcessor) authKeys() (*rsa.PrivateKey, *rsa.PrivateKey, bool, error) {
+	if p.rand != nil {
+		alice, bob, err := generateDHAuthKeys(p.rand)
+		return alice, bob, false, err
+	}
+
+	dhSessionCache.Lock()
+	defer dhSessionCache.Unlock()
+	if dhSessionCache.aliceAuth != nil {
+		return dhSessionCache.aliceAuth, dhSessionCache.bobAuth, true, nil
+	}
+	alice, bob, err := generateDHAuthKeys(rand.Reader)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	dhSessionCache.aliceAuth, dhSessionCache.bobAuth = alice, bob
+	return alice, bob, false, nil
+}
+
+// generateDHAuthKeys generates fresh RSA key pairs for Alice and Bob
+func generateDHAuthKeys(random io.Reader) (*rsa.PrivateKey, *rsa.PrivateKey, error) {
+	alice, err := rsa.GenerateKey(random, dhAuthKeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate Alice's RSA key: %w", err)
+	}
+	bob, err := rsa.GenerateKey(random, dhAuthKeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate Bob's RSA key: %w", err)
+	}
+	return alice, bob, nil
 }
 
 // generatePrivateKey generates a private key
 func (p *DHProcessor) generatePrivateKey() (*big.Int, error) {
-	private, err := rand.Int(rand.Reader, p.prime)
+	private, err := rand.Int(randomSource(p.rand), p.prime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate private key: %w", err)
 	}
@@ -75,37 +183,63 @@ func (p *DHProcessor) generatePrivateKey() (*big.Int, error) {
 }
 
 // Process implements the Processor interface for Diffie-Hellman
-func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
+func (p *DHProcessor) Process(text string, _ string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 	startTime := time.Now()
 
 	// Introduction
-	v.AddStep("Diffie-Hellman Key Exchange")
-	v.AddStep("=============================")
+	v.AddHeader("Diffie-Hellman Key Exchange")
 	v.AddNote("Diffie-Hellman is a method of securely exchanging cryptographic keys")
 	v.AddNote("It allows two parties to establish a shared secret over an insecure channel")
 	v.AddNote("The security is based on the difficulty of the discrete logarithm problem")
 	v.AddSeparator()
 
 	// Step 1: Generate or load prime number
-	v.AddStep("Step 1: Prime Number Setup")
-	v.AddStep("------------------------")
-	prime, err := p.loadOrGeneratePrime()
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to setup prime: %w", err)
+	v.AddSubheader("Step 1: Prime Number Setup")
+	var prime *big.Int
+	if p.group != "" {
+		group := dhGroups[p.group]
+		prime = group.prime
+		v.AddStep(fmt.Sprintf("Named Group: %s (%s)", group.name, group.standard))
+		v.AddNote(fmt.Sprintf("Fixed, published parameters used by %s", group.usedBy))
+		v.AddNote("Everyone can share one vetted group; only the private exponents need to be secret")
+	} else {
+		var primeCached bool
+		var err error
+		prime, primeCached, err = p.sessionPrime()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to setup prime: %w", err)
+		}
+		v.AddStep("Group: freshly generated safe prime")
+		if primeCached {
+			v.AddNote("Reusing the prime from an earlier run in this session")
+		}
 	}
 	p.prime = prime
+	order, err := generatorOrder(p.generator, prime)
+	if err != nil {
+		return "", nil, err
+	}
 
 	// Show parameters
 	v.AddStep("Parameters:")
 	v.AddStep(fmt.Sprintf("Prime (p): %s", p.prime.Text(16)))
 	v.AddStep(fmt.Sprintf("Generator (g): %s", p.generator.Text(16)))
 	v.AddStep(fmt.Sprintf("Key Size: %d bits", p.keySize))
+	v.AddStep("Safe Prime Check:")
+	v.AddStep("  p is prime ✓")
+	v.AddStep(fmt.Sprintf("  q = (p-1)/2 is prime ✓ (%d bits)", new(big.Int).Rsh(prime, 1).BitLen()))
+	if order.Cmp(new(big.Int).Rsh(prime, 1)) == 0 {
+		v.AddStep("  g^q mod p = 1, so g generates the prime-order subgroup of size q ✓")
+	} else {
+		v.AddStep("  g^q mod p ≠ 1, so g generates the full group of order p-1 = 2q")
+		v.AddNote("A full-group generator leaks the low bit of the private key through the public key")
+	}
+	v.AddNote("With a safe prime the only small subgroup has order 2, so small-subgroup attacks learn at most one bit")
 	v.AddSeparator()
 
 	// Step 2: Generate private keys
-	v.AddStep("Step 2: Private Key Generation")
-	v.AddStep("----------------------------")
+	v.AddSubheader("Step 2: Private Key Generation")
 	alicePrivate, err := p.generatePrivateKey()
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to generate Alice's private key: %w", err)
@@ -119,27 +253,24 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	v.AddArrow()
 
 	// Step 3: Calculate public keys
-	v.AddStep("Step 3: Public Key Calculation")
-	v.AddStep("----------------------------")
+	v.AddSubheader("Step 3: Public Key Calculation")
 	alicePublic := new(big.Int).Exp(p.generator, alicePrivate, prime)
 	bobPublic := new(big.Int).Exp(p.generator, bobPrivate, prime)
 	v.AddStep(fmt.Sprintf("Alice's Public Key: %s", alicePublic.Text(16)))
 	v.AddStep(fmt.Sprintf("Bob's Public Key: %s", bobPublic.Text(16)))
+	transcript := newKeyExchangeTranscript("Diffie-Hellman")
+	transcript.Send("Alice", "Bob", fmt.Sprintf("Public key A = g^a mod p (%s)", shortHex(alicePublic.Bytes())))
+	transcript.Send("Bob", "Alice", fmt.Sprintf("Public key B = g^b mod p (%s)", shortHex(bobPublic.Bytes())))
 	v.AddArrow()
 
 	// Step 4: Key Authentication (Preventing MITM)
-	v.AddStep("Step 4: Key Authentication")
-	v.AddStep("-------------------------")
+	v.AddSubheader("Step 4: Key Authentication")
 	v.AddNote("To prevent MITM attacks, we'll authenticate the public keys using RSA signatures")
 
-	// Generate RSA key pairs for Alice and Bob
-	aliceRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate Alice's RSA key: %w", err)
-	}
-	bobRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	// Generate RSA key pairs for Alice and Bob, or reuse the ones from this session
+	aliceRSAKey, bobRSAKey, authCached, err := p.authKeys()
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate Bob's RSA key: %w", err)
+		return "", nil, err
 	}
 
 	// Sign public keys with RSA private keys
@@ -150,22 +281,28 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	aliceHash := sha256.Sum256(alicePublicBytes)
 	bobHash := sha256.Sum256(bobPublicBytes)
 
-	aliceSignature, err := rsa.SignPKCS1v15(rand.Reader, aliceRSAKey, crypto.SHA256, aliceHash[:])
+	aliceSignature, err := rsa.SignPKCS1v15(randomSource(p.rand), aliceRSAKey, crypto.SHA256, aliceHash[:])
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to sign Alice's public key: %w", err)
 	}
 
-	bobSignature, err := rsa.SignPKCS1v15(rand.Reader, bobRSAKey, crypto.SHA256, bobHash[:])
+	bobSignature, err := rsa.SignPKCS1v15(randomSource(p.rand), bobRSAKey, crypto.SHA256, bobHash[:])
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to sign Bob's public key: %w", err)
 	}
 
-	v.AddStep("RSA Key Pairs Generated:")
+	if authCached {
+		v.AddStep("RSA Key Pairs (reused from earlier in this session):")
+	} else {
+		v.AddStep("RSA Key Pairs Generated:")
+	}
 	v.AddStep(fmt.Sprintf("Alice's RSA Public Key: %x", aliceRSAKey.PublicKey.N.Bytes()[:16]))
 	v.AddStep(fmt.Sprintf("Bob's RSA Public Key: %x", bobRSAKey.PublicKey.N.Bytes()[:16]))
 	v.AddStep("Signatures Created:")
 	v.AddStep(fmt.Sprintf("Alice's Signature: %x", aliceSignature[:16]))
 	v.AddStep(fmt.Sprintf("Bob's Signature: %x", bobSignature[:16]))
+	transcript.Send("Alice", "Bob", fmt.Sprintf("RSA signature over A (%s)", shortHex(aliceSignature)))
+	transcript.Send("Bob", "Alice", fmt.Sprintf("RSA signature over B (%s)", shortHex(bobSignature)))
 
 	// Verify signatures
 	err = rsa.VerifyPKCS1v15(&aliceRSAKey.PublicKey, crypto.SHA256, aliceHash[:], aliceSignature)
@@ -183,8 +320,7 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	v.AddArrow()
 
 	// Step 5: Calculate shared secrets
-	v.AddStep("Step 5: Shared Secret Calculation")
-	v.AddStep("-------------------------------")
+	v.AddSubheader("Step 5: Shared Secret Calculation")
 	aliceShared := new(big.Int).Exp(bobPublic, alicePrivate, prime)
 	bobShared := new(big.Int).Exp(alicePublic, bobPrivate, prime)
 	v.AddStep(fmt.Sprintf("Alice's Shared Secret: %s", aliceShared.Text(16)))
@@ -192,8 +328,7 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	v.AddArrow()
 
 	// Step 6: Verify shared secrets match
-	v.AddStep("Step 6: Shared Secret Verification")
-	v.AddStep("--------------------------------")
+	v.AddSubheader("Step 6: Shared Secret Verification")
 	if aliceShared.Cmp(bobShared) == 0 {
 		v.AddStep("✅ Shared secrets match!")
 	} else {
@@ -202,66 +337,45 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	v.AddSeparator()
 
 	// Step 7: Key Derivation Function (KDF)
-	v.AddStep("Step 7: Key Derivation")
-	v.AddStep("---------------------")
-	// Use HKDF to derive a secure key from the shared secret
-	hkdf := hkdf.New(sha256.New, aliceShared.Bytes(), []byte("CryptoLens-DH-KDF"), []byte("CryptoLens-DH-Info"))
-	derivedKey := make([]byte, 32)
-	if _, err := io.ReadFull(hkdf, derivedKey); err != nil {
-		return "", nil, fmt.Errorf("failed to derive key: %w", err)
-	}
-	v.AddStep(fmt.Sprintf("Derived key (using HKDF): %x", derivedKey))
+	v.AddSubheader("Step 7: Key Derivation")
+	// Use HKDF to derive a separate key for each direction from the shared secret
+	keys, err := deriveSessionKeys(aliceShared.Bytes(), []byte("CryptoLens-DH-KDF"))
+	if err != nil {
+		return "", nil, err
+	}
+	v.AddStep(fmt.Sprintf("Alice → Bob key (HKDF info %q): %x", SubkeyClientKey, keys.clientKey))
+	v.AddStep(fmt.Sprintf("Bob → Alice key (HKDF info %q): %x", SubkeyServerKey, keys.serverKey))
+	v.AddNote("Each purpose gets its own subkey, so one key is never reused for two jobs")
 	v.AddSeparator()
 
 	// Step 8: Demonstrate AES Encryption with Shared Secret
-	v.AddStep("Step 8: Using Shared Secret for AES Encryption")
-	v.AddStep("-------------------------------------------")
+	v.AddSubheader("Step 8: Using Shared Secret for AES Encryption")
 	v.AddNote("Now we'll demonstrate how the shared secret can be used for symmetric encryption")
 
-	// Create a sample message
-	sampleMessage := "Hello, this is a secret message!"
+	// Use the user's message, or a sample one if none was given
+	sampleMessage := keyExchangeMessage(text)
 	v.AddStep(fmt.Sprintf("Original Message: %s", sampleMessage))
 
-	// Create AES cipher
-	block, err := aes.NewCipher(derivedKey)
+	// Encrypt the message with AES-GCM under the derived key
+	ciphertext, err := encryptWithDerivedKeyFrom(randomSource(p.rand), keys.clientKey, sampleMessage)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to create AES cipher: %w", err)
+		return "", nil, err
 	}
-
-	// Create GCM mode
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to create GCM mode: %w", err)
-	}
-
-	// Generate nonce
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
-	}
-
-	// Encrypt the message
-	ciphertext := gcm.Seal(nonce, nonce, []byte(sampleMessage), nil)
 	v.AddStep(fmt.Sprintf("Encrypted Message (Base64): %s", base64.StdEncoding.EncodeToString(ciphertext)))
 
 	// Decrypt the message
-	nonceSize := gcm.NonceSize()
-	if len(ciphertext) < nonceSize {
-		return "", nil, fmt.Errorf("ciphertext too short")
-	}
-
-	nonce, ciphertext = ciphertext[:nonceSize], ciphertext[nonceSize:]
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := decryptWithDerivedKey(keys.clientKey, ciphertext)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to decrypt: %w", err)
+		return "", nil, err
 	}
 
-	v.AddStep(fmt.Sprintf("Decrypted Message: %s", string(plaintext)))
+	v.AddStep(fmt.Sprintf("Decrypted Message: %s", plaintext))
 	v.AddArrow()
+	transcript.Send("Alice", "Bob", fmt.Sprintf("AES-GCM ciphertext under HKDF key (%s)", shortHex(ciphertext)))
+	p.transcript = transcript
 
 	// Performance Comparison
-	v.AddStep("⚡ Performance Comparison")
-	v.AddStep("=======================")
+	v.AddHeader("⚡ Performance Comparison")
 	dhDuration := time.Since(startTime)
 	v.AddStep(fmt.Sprintf("Classic DH Execution Time: %v", dhDuration))
 
@@ -269,10 +383,10 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	x25519Start := time.Now()
 	alicePrivateX := make([]byte, 32)
 	bobPrivateX := make([]byte, 32)
-	if _, err := rand.Read(alicePrivateX); err != nil {
+	if _, err := io.ReadFull(randomSource(p.rand), alicePrivateX); err != nil {
 		return "", nil, fmt.Errorf("failed to generate Alice's private key: %w", err)
 	}
-	if _, err := rand.Read(bobPrivateX); err != nil {
+	if _, err := io.ReadFull(randomSource(p.rand), bobPrivateX); err != nil {
 		return "", nil, fmt.Errorf("failed to generate Bob's private key: %w", err)
 	}
 	alicePrivateX[0] &= 248
@@ -300,8 +414,7 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	v.AddSeparator()
 
 	// Security Considerations
-	v.AddStep("🔒 Security Considerations")
-	v.AddStep("========================")
+	v.AddHeader("🔒 Security Considerations")
 	v.AddStep("1. Man-in-the-Middle (MITM) Attack Prevention:")
 	v.AddStep("   • RSA signatures authenticate public keys")
 	v.AddStep("   • Prevents attackers from substituting their own keys")
@@ -339,3 +452,14 @@ func (p *DHProcessor) Process(_ string, _ string) (string, []string, error) {
 	result := "Successfully demonstrated authenticated Diffie-Hellman key exchange and AES encryption"
 	return result, v.GetSteps(), nil
 }
+
+// AuditSources lists the randomness sources and libraries used by the Diffie-Hellman demo
+func (p *DHProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Safe prime generation (generated group only), DH private exponents, RSA signing keys, and the AES-GCM nonce"},
+		{Kind: AuditStandardLibrary, Package: "math/big", Purpose: "Modular exponentiation in the DH group"},
+		{Kind: AuditStandardLibrary, Package: "crypto/rsa, crypto/sha256", Purpose: "Signing and verifying the exchanged public values"},
+		{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/hkdf", Purpose: "Deriving per-direction session keys from the shared secret"},
+		{Kind: AuditStandardLibrary, Package: "crypto/aes, crypto/cipher", Purpose: "AES-GCM encryption of the message with the derived key"},
+	}
+}
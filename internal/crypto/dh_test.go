@@ -1,8 +1,11 @@
 package crypto
 
 import (
+	"crypto/rand"
 	"math/big"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -48,6 +51,12 @@ func TestDHProcessor_Configure(t *testing.T) {
 
 func TestDHProcessor_Process(t *testing.T) {
 	processor := NewDHProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize":   512,
+		"primeFile": filepath.Join(t.TempDir(), "test_prime.bin"),
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
 	_, steps, err := processor.Process("", "")
 	if err != nil {
 		t.Fatalf("Process failed: %v", err)
@@ -67,7 +76,7 @@ func TestDHProcessor_Process(t *testing.T) {
 	for _, expectedStep := range expectedSteps {
 		found := false
 		for _, step := range steps {
-			if step == expectedStep {
+			if step.Text == expectedStep {
 				found = true
 				break
 			}
@@ -85,6 +94,7 @@ func TestDHProcessor_GeneratePrivateKey(t *testing.T) {
 
 	processor := NewDHProcessor()
 	config := map[string]interface{}{
+		"keySize":   512,
 		"primeFile": testPrimeFile,
 	}
 	if err := processor.Configure(config); err != nil {
@@ -121,6 +131,7 @@ func TestDHProcessor_KeyExchange(t *testing.T) {
 
 	processor := NewDHProcessor()
 	config := map[string]interface{}{
+		"keySize":   512,
 		"primeFile": testPrimeFile,
 	}
 	if err := processor.Configure(config); err != nil {
@@ -182,3 +193,177 @@ func TestDHProcessor_InvalidConfig(t *testing.T) {
 		t.Error("Expected error with invalid generator")
 	}
 }
+
+func TestDHProcessor_ReusesSessionKeys(t *testing.T) {
+	processor := NewDHProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize":   512,
+		"primeFile": filepath.Join(t.TempDir(), "test_prime.bin"),
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+
+	if _, _, err := processor.Process("", ""); err != nil {
+		t.Fatalf("First Process failed: %v", err)
+	}
+	_, steps, err := processor.Process("", "")
+	if err != nil {
+		t.Fatalf("Second Process failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Reusing the prime from an earlier run in this session",
+		"RSA Key Pairs (reused from earlier in this session):",
+	} {
+		found := false
+		for _, step := range steps {
+			if strings.Contains(step.Text, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected step not found on second run: %s", want)
+		}
+	}
+}
+
+func TestGenerateSafePrime(t *testing.T) {
+	prime, err := generateSafePrime(rand.Reader, 128)
+	if err != nil {
+		t.Fatalf("generateSafePrime failed: %v", err)
+	}
+	if prime.BitLen() != 128 {
+		t.Errorf("Expected a 128-bit prime, got %d bits", prime.BitLen())
+	}
+	if !isSafePrime(prime) {
+		t.Errorf("Expected (p-1)/2 to be prime for p = %s", prime.Text(16))
+	}
+	if isSafePrime(big.NewInt(13)) {
+		t.Error("13 is prime but (13-1)/2 = 6 is not, so it is not a safe prime")
+	}
+}
+
+func TestGeneratorOrder(t *testing.T) {
+	// 23 = 2*11 + 1 is a safe prime
+	p := big.NewInt(23)
+	tests := []struct {
+		g       int64
+		order   int64
+		wantErr bool
+	}{
+		{g: 2, order: 11},
+		{g: 5, order: 22},
+		{g: 1, wantErr: true},
+		{g: 22, wantErr: true},
+	}
+	for _, tt := range tests {
+		order, err := generatorOrder(big.NewInt(tt.g), p)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("generatorOrder(%d) expected an error", tt.g)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("generatorOrder(%d) failed: %v", tt.g, err)
+		}
+		if order.Int64() != tt.order {
+			t.Errorf("generatorOrder(%d) = %d, want %d", tt.g, order.Int64(), tt.order)
+		}
+	}
+}
+
+func TestDHProcessor_ReplacesInvalidPrime(t *testing.T) {
+	primeFile := filepath.Join(t.TempDir(), "test_prime.bin")
+	// Random bytes, as written by older versions, are almost never a safe prime
+	random := make([]byte, 64)
+	random[0] = 0x80
+	if err := os.WriteFile(primeFile, random, 0600); err != nil {
+		t.Fatalf("Failed to write prime file: %v", err)
+	}
+
+	processor := NewDHProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize":   512,
+		"primeFile": primeFile,
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	prime, err := processor.loadOrGeneratePrime()
+	if err != nil {
+		t.Fatalf("Failed to load/generate prime: %v", err)
+	}
+	if !isSafePrime(prime) || prime.BitLen() != 512 {
+		t.Fatalf("Expected a 512-bit safe prime, got %d bits", prime.BitLen())
+	}
+
+	saved, err := os.ReadFile(primeFile)
+	if err != nil {
+		t.Fatalf("Failed to read prime file: %v", err)
+	}
+	if new(big.Int).SetBytes(saved).Cmp(prime) != 0 {
+		t.Error("Expected the new safe prime to be saved")
+	}
+}
+
+func TestDHProcessor_RejectsInvalidGenerator(t *testing.T) {
+	processor := NewDHProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize":   512,
+		"generator": 1,
+		"primeFile": filepath.Join(t.TempDir(), "test_prime.bin"),
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	if _, _, err := processor.Process("", ""); err == nil {
+		t.Error("Expected an error for generator 1")
+	}
+}
+
+func TestDHGroups_AreSafePrimes(t *testing.T) {
+	for name, group := range dhGroups {
+		if !isSafePrime(group.prime) {
+			t.Errorf("%s: prime is not a safe prime", name)
+		}
+		if _, err := generatorOrder(group.generator, group.prime); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestDHProcessor_NamedGroup(t *testing.T) {
+	processor := NewDHProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize":   512,
+		"generator": 5,
+		"group":     DHGroupFFDHE3072,
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+	if processor.keySize != 3072 {
+		t.Errorf("Expected the group to set keySize 3072, got %d", processor.keySize)
+	}
+	if processor.generator.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("Expected the group to set generator 2, got %s", processor.generator.Text(10))
+	}
+
+	_, steps, err := processor.Process("", "")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	found := false
+	for _, step := range steps {
+		if step.Text == "Named Group: ffdhe3072 (RFC 7919)" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("Expected the named group to be shown")
+	}
+
+	if err := NewDHProcessor().Configure(map[string]interface{}{"group": "modp1024"}); err == nil {
+		t.Error("Expected an error for an unsupported group")
+	}
+}
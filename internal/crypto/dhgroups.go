@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Named Diffie-Hellman groups; DHGroupGenerated generates a fresh safe prime instead
+const (
+	DHGroupGenerated = "generated"
+	DHGroupMODP2048  = "modp2048"
+	DHGroupMODP3072  = "modp3072"
+	DHGroupFFDHE2048 = "ffdhe2048"
+	DHGroupFFDHE3072 = "ffdhe3072"
+)
+
+// dhGroup is a standardized MODP group with a fixed safe prime and generator
+type dhGroup struct {
+	name      string
+	standard  string
+	usedBy    string
+	prime     *big.Int
+	generator *big.Int
+}
+
+// dhGroups holds the named groups, keyed by their config name
+var dhGroups = map[string]dhGroup{
+	DHGroupMODP2048: {
+		name:     "modp2048",
+		standard: "RFC 3526 group 14",
+		usedBy:   "IKE/IPsec and SSH (diffie-hellman-group14)",
+		prime: mustParseHex(`
+			FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74
+			020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437
+			4FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED
+			EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF05
+			98DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB
+			9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B
+			E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF695581718
+			3995497CEA956AE515D2261898FA051015728E5A8AACAA68FFFFFFFFFFFFFFFF`),
+		generator: big.NewInt(2),
+	},
+	DHGroupMODP3072: {
+		name:     "modp3072",
+		standard: "RFC 3526 group 15",
+		usedBy:   "IKE/IPsec",
+		prime: mustParseHex(`
+			FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74
+			020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437
+			4FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED
+			EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF05
+			98DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB
+			9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B
+			E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF695581718
+			3995497CEA956AE515D2261898FA051015728E5A8AAAC42DAD33170D04507A33
+			A85521ABDF1CBA64ECFB850458DBEF0A8AEA71575D060C7DB3970F85A6E1E4C7
+			ABF5AE8CDB0933D71E8C94E04A25619DCEE3D2261AD2EE6BF12FFA06D98A0864
+			D87602733EC86A64521F2B18177B200CBBE117577A615D6C770988C0BAD946E2
+			08E24FA074E5AB3143DB5BFCE0FD108E4B82D120A93AD2CAFFFFFFFFFFFFFFFF`),
+		generator: big.NewInt(2),
+	},
+	DHGroupFFDHE2048: {
+		name:     "ffdhe2048",
+		standard: "RFC 7919",
+		usedBy:   "TLS 1.3 finite-field key exchange",
+		prime: mustParseHex(`
+			FFFFFFFFFFFFFFFFADF85458A2BB4A9AAFDC5620273D3CF1D8B9C583CE2D3695
+			A9E13641146433FBCC939DCE249B3EF97D2FE363630C75D8F681B202AEC4617A
+			D3DF1ED5D5FD65612433F51F5F066ED0856365553DED1AF3B557135E7F57C935
+			984F0C70E0E68B77E2A689DAF3EFE8721DF158A136ADE73530ACCA4F483A797A
+			BC0AB182B324FB61D108A94BB2C8E3FBB96ADAB760D7F4681D4F42A3DE394DF4
+			AE56EDE76372BB190B07A7C8EE0A6D709E02FCE1CDF7E2ECC03404CD28342F61
+			9172FE9CE98583FF8E4F1232EEF28183C3FE3B1B4C6FAD733BB5FCBC2EC22005
+			C58EF1837D1683B2C6F34A26C1B2EFFA886B423861285C97FFFFFFFFFFFFFFFF`),
+		generator: big.NewInt(2),
+	},
+	DHGroupFFDHE3072: {
+		name:     "ffdhe3072",
+		standard: "RFC 7919",
+		usedBy:   "TLS 1.3 finite-field key exchange",
+		prime: mustParseHex(`
+			FFFFFFFFFFFFFFFFADF85458A2BB4A9AAFDC5620273D3CF1D8B9C583CE2D3695
+			A9E13641146433FBCC939DCE249B3EF97D2FE363630C75D8F681B202AEC4617A
+			D3DF1ED5D5FD65612433F51F5F066ED0856365553DED1AF3B557135E7F57C935
+			984F0C70E0E68B77E2A689DAF3EFE8721DF158A136ADE73530ACCA4F483A797A
+			BC0AB182B324FB61D108A94BB2C8E3FBB96ADAB760D7F4681D4F42A3DE394DF4
+			AE56EDE76372BB190B07A7C8EE0A6D709E02FCE1CDF7E2ECC03404CD28342F61
+			9172FE9CE98583FF8E4F1232EEF28183C3FE3B1B4C6FAD733BB5FCBC2EC22005
+			C58EF1837D1683B2C6F34A26C1B2EFFA886B4238611FCFDCDE355B3B6519035B
+			BC34F4DEF99C023861B46FC9D6E6C9077AD91D2691F7F7EE598CB0FAC186D91C
+			AEFE130985139270B4130C93BC437944F4FD4452E2D74DD364F2E21E71F54BFF
+			5CAE82AB9C9DF69EE86D2BC522363A0DABC521979B0DEADA1DBF9A42D5C4484E
+			0ABCD06BFA53DDEF3C1B20EE3FD59D7C25E41D2B66C62E37FFFFFFFFFFFFFFFF`),
+		generator: big.NewInt(2),
+	},
+}
+
+// mustParseHex parses a hex constant that may be split across lines, panicking on malformed input
+func mustParseHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(strings.Join(strings.Fields(s), ""), 16)
+	if !ok {
+		panic("invalid hex constant: " + s)
+	}
+	return n
+}
+
+// DHGroupNames returns the names accepted by the group option, sorted
+func DHGroupNames() []string {
+	names := []string{DHGroupGenerated}
+	for name := range dhGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// lookupDHGroup returns the named group, or an error listing the valid names
+func lookupDHGroup(name string) (dhGroup, error) {
+	group, ok := dhGroups[strings.ToLower(name)]
+	if !ok {
+		return dhGroup{}, fmt.Errorf("unsupported DH group: %s (valid: %s)", name, strings.Join(DHGroupNames(), ", "))
+	}
+	return group, nil
+}
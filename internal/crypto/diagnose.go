@@ -0,0 +1,282 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Algorithms tried by the blob diagnostic
+const (
+	BlobAESGCM           = "aes-gcm"
+	BlobChaCha20Poly1305 = "chacha20-poly1305"
+	BlobAESCBC           = "aes-cbc"
+	BlobBlowfish         = "blowfish-cbc"
+	BlobTripleDES        = "3des-cbc"
+	BlobAESCTR           = "aes-ctr"
+	BlobRC4              = "rc4"
+)
+
+// aeadOverhead is the nonce plus tag size of the AEAD formats the diagnostic recognises
+const aeadOverhead = 12 + 16
+
+// BlobAttempt is the outcome of decrypting a blob with one algorithm
+type BlobAttempt struct {
+	Algorithm     string
+	Plaintext     string
+	Printable     bool // The plaintext is non-empty, valid UTF-8 and printable
+	Authenticated bool // An AEAD tag verified, so the algorithm and key are certain
+	Err           error
+}
+
+// Success reports whether the attempt produced a believable plaintext
+func (a BlobAttempt) Success() bool {
+	return a.Err == nil && (a.Authenticated || a.Printable)
+}
+
+// IdentifyBlob lists the algorithms whose ciphertext layout fits the blob, most telling first
+func IdentifyBlob(data []byte) []string {
+	var candidates []string
+	if len(data) >= aeadOverhead {
+		candidates = append(candidates, BlobAESGCM, BlobChaCha20Poly1305)
+	}
+	if len(data) >= 2*aes.BlockSize && len(data)%aes.BlockSize == 0 {
+		candidates = append(candidates, BlobAESCBC)
+	}
+	if len(data) >= 16 && len(data)%8 == 0 {
+		candidates = append(candidates, BlobBlowfish, BlobTripleDES)
+	}
+	if len(data) > aes.BlockSize {
+		candidates = append(candidates, BlobAESCTR)
+	}
+	if len(data) > 0 {
+		candidates = append(candidates, BlobRC4)
+	}
+	return candidates
+}
+
+// decodeBlob accepts Base64 or hex input
+func decodeBlob(blob string) ([]byte, string, error) {
+	blob = strings.TrimSpace(blob)
+	if data, err := hex.DecodeString(blob); err == nil && len(data) > 0 {
+		return data, "hex", nil
+	}
+	if data, err := base64.StdEncoding.DecodeString(blob); err == nil && len(data) > 0 {
+		return data, "Base64", nil
+	}
+	return nil, "", fmt.Errorf("invalid blob: expected Base64 or hex")
+}
+
+// DiagnoseBlob tries every algorithm that fits the blob with the given key and returns the
+// attempts with authenticated and printable results first
+func DiagnoseBlob(blob string, key []byte) ([]BlobAttempt, error) {
+	data, _, err := decodeBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := IdentifyBlob(data)
+	attempts := make([]BlobAttempt, len(candidates))
+	for i, algorithm := range candidates {
+		attempts[i] = tryDecrypt(algorithm, data, key)
+	}
+
+	rank := func(a BlobAttempt) int {
+		switch {
+		case a.Err == nil && a.Authenticated:
+			return 0
+		case a.Err == nil && a.Printable:
+			return 1
+		case a.Err == nil:
+			return 2
+		default:
+			return 3
+		}
+	}
+	sort.SliceStable(attempts, func(i, j int) bool {
+		return rank(attempts[i]) < rank(attempts[j])
+	})
+	return attempts, nil
+}
+
+// tryDecrypt decrypts data with one algorithm
+func tryDecrypt(algorithm string, data, key []byte) BlobAttempt {
+	attempt := BlobAttempt{Algorithm: algorithm}
+	var plaintext []byte
+
+	switch algorithm {
+	case BlobAESGCM, BlobChaCha20Poly1305:
+		var aead cipher.AEAD
+		var err error
+		if algorithm == BlobAESGCM {
+			aead, err = newDerivedKeyGCM(key)
+		} else {
+			aead, err = chacha20poly1305.New(key)
+		}
+		if err != nil {
+			attempt.Err = err
+			return attempt
+		}
+		nonceSize := aead.NonceSize()
+		plaintext, err = aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+		if err != nil {
+			attempt.Err = fmt.Errorf("authentication failed: wrong algorithm or key")
+			return attempt
+		}
+		attempt.Authenticated = true
+	case BlobAESCTR:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			attempt.Err = err
+			return attempt
+		}
+		plaintext = make([]byte, len(data)-aes.BlockSize)
+		cipher.NewCTR(block, data[:aes.BlockSize]).XORKeyStream(plaintext, data[aes.BlockSize:])
+	default:
+		// The CBC and RC4 formats match the educational processors, so decrypt through them
+		processor, err := newKeyedProcessor(algorithm, key)
+		if err != nil {
+			attempt.Err = err
+			return attempt
+		}
+		result, _, err := processor.Process(base64.StdEncoding.EncodeToString(data), OperationDecrypt)
+		if err != nil {
+			attempt.Err = err
+			return attempt
+		}
+		plaintext = []byte(result)
+	}
+
+	attempt.Plaintext = string(plaintext)
+	attempt.Printable = isPrintableText(plaintext)
+	return attempt
+}
+
+// newKeyedProcessor returns a symmetric processor that uses key from memory
+func newKeyedProcessor(algorithm string, key []byte) (Processor, error) {
+	keyManager := &memoryKeyManager{key: key}
+	switch algorithm {
+	case BlobAESCBC:
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, err
+		}
+		return &AESProcessor{keySize: len(key) * 8, keyManager: keyManager}, nil
+	case BlobBlowfish:
+		if len(key) < 4 || len(key) > 56 {
+			return nil, fmt.Errorf("invalid key size for Blowfish: %d bytes (must be 4 to 56)", len(key))
+		}
+		return &BlowfishProcessor{keySize: len(key) * 8, keyManager: keyManager}, nil
+	case BlobTripleDES:
+		if len(key) != 16 && len(key) != 24 {
+			return nil, fmt.Errorf("invalid key size for 3DES: %d bytes (must be 16 or 24)", len(key))
+		}
+		return &TripleDESProcessor{keyingOption: len(key) / 8, keyManager: keyManager}, nil
+	case BlobRC4:
+		if len(key) < 1 || len(key) > 256 {
+			return nil, fmt.Errorf("invalid key size for RC4: %d bytes (must be 1 to 256)", len(key))
+		}
+		return &RC4Processor{keySize: len(key) * 8, keyManager: keyManager}, nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", algorithm)
+	}
+}
+
+// isPrintableText reports whether data is non-empty UTF-8 made of printable characters
+func isPrintableText(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// BlobDiagnosticProcessor tries every known algorithm on an unknown ciphertext with a given key
+type BlobDiagnosticProcessor struct {
+	BaseConfigurableProcessor
+	key []byte
+}
+
+// NewBlobDiagnosticProcessor creates a new blob diagnostic processor
+func NewBlobDiagnosticProcessor() *BlobDiagnosticProcessor {
+	return &BlobDiagnosticProcessor{}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *BlobDiagnosticProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+	if keyHex, ok := config["key"].(string); ok {
+		key, err := hex.DecodeString(strings.TrimSpace(keyHex))
+		if err != nil || len(key) == 0 {
+			return fmt.Errorf("invalid key: must be hex encoded")
+		}
+		p.key = key
+	}
+	return nil
+}
+
+// Process identifies and decrypts the blob, returning the best plaintext
+func (p *BlobDiagnosticProcessor) Process(text string, _ string) (string, []utils.Step, error) {
+	if len(p.key) == 0 {
+		return "", nil, fmt.Errorf("no key configured for the diagnostic")
+	}
+	v := utils.NewVisualizer()
+	v.AddHeader("Unknown Blob Diagnostic")
+	v.AddNote("Ciphertext rarely says which algorithm produced it")
+	v.AddNote("Layout hints narrow the search; decryption attempts settle it")
+	v.AddSeparator()
+
+	data, encoding, err := decodeBlob(text)
+	if err != nil {
+		return "", nil, err
+	}
+	v.AddStep(fmt.Sprintf("Input: %d bytes (%s)", len(data), encoding))
+	v.AddHexStep("Key", p.key)
+	v.AddStep("Layout Hints:")
+	v.AddStep(fmt.Sprintf("  Length mod 16 = %d, length mod 8 = %d", len(data)%16, len(data)%8))
+	v.AddStep(fmt.Sprintf("  Candidates: %s", strings.Join(IdentifyBlob(data), ", ")))
+	v.AddSeparator()
+
+	attempts, err := DiagnoseBlob(text, p.key)
+	if err != nil {
+		return "", nil, err
+	}
+	v.AddStep("Decryption Attempts:")
+	for _, attempt := range attempts {
+		switch {
+		case attempt.Err != nil:
+			v.AddStep(fmt.Sprintf("  ❌ %-18s %v", attempt.Algorithm, attempt.Err))
+		case attempt.Authenticated:
+			v.AddStep(fmt.Sprintf("  ✅ %-18s tag verified: %q", attempt.Algorithm, attempt.Plaintext))
+		case attempt.Printable:
+			v.AddStep(fmt.Sprintf("  🟡 %-18s printable: %q", attempt.Algorithm, attempt.Plaintext))
+		default:
+			v.AddStep(fmt.Sprintf("  ❌ %-18s unreadable output", attempt.Algorithm))
+		}
+	}
+	v.AddSeparator()
+
+	v.AddNote("An AEAD tag that verifies is proof; printable output from CBC, CTR or RC4 is only a strong hint")
+	v.AddNote("Stream modes never fail outright, which is why unauthenticated ciphertext is hard to triage")
+
+	if len(attempts) == 0 || !attempts[0].Success() {
+		v.AddStep("No algorithm produced a plausible plaintext with this key")
+		return "", v.GetSteps(), nil
+	}
+	v.AddStep(fmt.Sprintf("Most likely: %s", attempts[0].Algorithm))
+	return attempts[0].Plaintext, v.GetSteps(), nil
+}
@@ -0,0 +1,81 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDiagnoseBlob_AESGCM(t *testing.T) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	message := "meet at the usual place"
+	ciphertext, err := encryptWithDerivedKey(key, message)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	attempts, err := DiagnoseBlob(base64.StdEncoding.EncodeToString(ciphertext), key)
+	if err != nil {
+		t.Fatalf("DiagnoseBlob failed: %v", err)
+	}
+	if len(attempts) == 0 {
+		t.Fatal("Expected decryption attempts")
+	}
+	best := attempts[0]
+	if best.Algorithm != BlobAESGCM || !best.Authenticated || best.Plaintext != message {
+		t.Errorf("Expected AES-GCM to decrypt %q, got %+v", message, best)
+	}
+
+	// The processor reports the same result
+	processor := NewBlobDiagnosticProcessor()
+	if err := processor.Configure(map[string]interface{}{"key": hex.EncodeToString(key)}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	result, _, err := processor.Process(hex.EncodeToString(ciphertext), OperationDecrypt)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result != message {
+		t.Errorf("Expected %q, got %q", message, result)
+	}
+}
+
+func TestDiagnoseBlob_AESCBC(t *testing.T) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	processor, err := newKeyedProcessor(BlobAESCBC, key)
+	if err != nil {
+		t.Fatalf("newKeyedProcessor failed: %v", err)
+	}
+	ciphertext, _, err := processor.Process("hello from cbc", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	attempts, err := DiagnoseBlob(ciphertext, key)
+	if err != nil {
+		t.Fatalf("DiagnoseBlob failed: %v", err)
+	}
+	if attempts[0].Algorithm != BlobAESCBC || attempts[0].Plaintext != "hello from cbc" {
+		t.Errorf("Expected AES-CBC to be identified, got %+v", attempts[0])
+	}
+}
+
+func TestIdentifyBlob(t *testing.T) {
+	if got := IdentifyBlob(make([]byte, 10)); len(got) != 1 || got[0] != BlobRC4 {
+		t.Errorf("Expected only RC4 for a short odd blob, got %v", got)
+	}
+	got := IdentifyBlob(make([]byte, 48))
+	if len(got) != 7 {
+		t.Errorf("Expected every algorithm to fit a 48-byte blob, got %v", got)
+	}
+	if _, err := DiagnoseBlob("not a blob!", make([]byte, 16)); err == nil {
+		t.Error("Expected an error for undecodable input")
+	}
+}
@@ -29,8 +29,38 @@ func (f *ProcessorFactory) CreateProcessor(algorithm string) (Processor, error)
 		return NewRSAProcessor(), nil
 	case "sha256":
 		return NewSHA256Processor(), nil
+	case "hash":
+		return NewHashProcessor(), nil
 	case "dh":
 		return NewDHProcessor(), nil
+	case "blowfish":
+		return NewBlowfishProcessor(), nil
+	case "3des":
+		return NewTripleDESProcessor(), nil
+	case "aes-gcm-siv":
+		return NewAESGCMSIVProcessor(), nil
+	case "rot":
+		return NewROTProcessor(), nil
+	case "hkdf":
+		return NewHKDFProcessor(), nil
+	case "merkle":
+		return NewMerkleTreeProcessor(), nil
+	case "feistel":
+		return NewFeistelProcessor(), nil
+	case "avalanche":
+		return NewAvalancheProcessor(), nil
+	case "basen":
+		return NewBaseNProcessor(), nil
+	case "totp":
+		return NewTOTPProcessor(), nil
+	case "checksum":
+		return NewChecksumProcessor(), nil
+	case "otp":
+		return NewOneTimePadProcessor(), nil
+	case "rc4":
+		return NewRC4Processor(), nil
+	case "signatures":
+		return NewSignatureMatrixProcessor(), nil
 	default:
 		return nil, fmt.Errorf("unsupported algorithm: %s", algorithm)
 	}
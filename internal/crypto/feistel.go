@@ -0,0 +1,193 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Toy Feistel cipher settings
+const (
+	feistelBlockSize     = 8 // 64-bit blocks split into two 32-bit halves, like DES
+	feistelDefaultRounds = 8
+	feistelMaxRounds     = 32
+	feistelDefaultKey    = "feistel"
+)
+
+// FeistelProcessor is a deliberately weak toy cipher that shows the Feistel structure
+// behind DES: each round mixes one half with a round function and swaps the halves
+type FeistelProcessor struct {
+	BaseConfigurableProcessor
+	rounds int
+	key    string
+}
+
+// NewFeistelProcessor creates a new toy Feistel processor with 8 rounds
+func NewFeistelProcessor() *FeistelProcessor {
+	return &FeistelProcessor{
+		rounds: feistelDefaultRounds,
+		key:    feistelDefaultKey,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *FeistelProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if rounds, ok := config["rounds"].(int); ok && rounds != 0 {
+		if rounds < 1 || rounds > feistelMaxRounds {
+			return fmt.Errorf("invalid number of rounds: %d (must be between 1 and %d)", rounds, feistelMaxRounds)
+		}
+		p.rounds = rounds
+	}
+
+	if key, ok := config["key"].(string); ok && key != "" {
+		p.key = key
+	}
+
+	return nil
+}
+
+// roundKeys derives one 32-bit subkey per round from SHA-256(key || round)
+func (p *FeistelProcessor) roundKeys() []uint32 {
+	keys := make([]uint32, p.rounds)
+	for i := range keys {
+		sum := sha256.Sum256(append([]byte(p.key), byte(i)))
+		keys[i] = binary.BigEndian.Uint32(sum[:4])
+	}
+	return keys
+}
+
+// feistelRound is the toy round function F(R, K) = rotl(R XOR K, 7) + K. It is not
+// invertible in general, and a Feistel network never needs it to be.
+func feistelRound(right, key uint32) uint32 {
+	return bits.RotateLeft32(right^key, 7) + key
+}
+
+// feistelBlock runs the network over one block; decryption passes the keys in reverse
+func feistelBlock(block []byte, keys []uint32, v *utils.Visualizer) []byte {
+	left := binary.BigEndian.Uint32(block[:4])
+	right := binary.BigEndian.Uint32(block[4:])
+	if v != nil {
+		v.AddStep(fmt.Sprintf("Start:    L0 = %08x  R0 = %08x", left, right))
+	}
+
+	for i, key := range keys {
+		f := feistelRound(right, key)
+		left, right = right, left^f
+		if v != nil {
+			v.AddStep(fmt.Sprintf("Round %2d: K = %08x  F(R, K) = %08x", i+1, key, f))
+			v.AddStep(fmt.Sprintf("          L%d = R%d = %08x  R%d = L%d XOR F = %08x  (halves swapped)", i+1, i, left, i+1, i, right))
+		}
+	}
+
+	// Undo the last swap so that decryption is the same network with the keys reversed
+	out := make([]byte, feistelBlockSize)
+	binary.BigEndian.PutUint32(out[:4], right)
+	binary.BigEndian.PutUint32(out[4:], left)
+	if v != nil {
+		v.AddStep(fmt.Sprintf("Output:   R%d || L%d = %x  (final swap undone)", len(keys), len(keys), out))
+	}
+	return out
+}
+
+// Process implements the Processor interface
+func (p *FeistelProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	keys := p.roundKeys()
+
+	var data []byte
+	switch operation {
+	case OperationEncrypt:
+		data = pkcs7Pad([]byte(text), feistelBlockSize)
+	case OperationDecrypt:
+		decoded, err := hex.DecodeString(text)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid ciphertext: must be hex: %w", err)
+		}
+		if len(decoded) == 0 || len(decoded)%feistelBlockSize != 0 {
+			return "", nil, fmt.Errorf("invalid ciphertext: length must be a multiple of %d bytes", feistelBlockSize)
+		}
+		data = decoded
+		// Decryption is the same network with the round keys in reverse order
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	default:
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	v := utils.NewVisualizer()
+	v.AddHeader("Toy Feistel Cipher (Insecure, Educational Only)")
+	v.AddNote("⚠️  This cipher is a teaching toy: its round function and key schedule are trivially weak")
+	v.AddNote("A Feistel network splits each block into halves L and R. Every round computes")
+	v.AddNote("L' = R and R' = L XOR F(R, K), so only half the block changes per round")
+	v.AddSeparator()
+
+	v.AddStep(fmt.Sprintf("Block size: %d bits (two 32-bit halves)", feistelBlockSize*8))
+	v.AddStep(fmt.Sprintf("Rounds: %d", p.rounds))
+	v.AddStep("Round function: F(R, K) = rotl(R XOR K, 7) + K (mod 2^32)")
+	v.AddStep("Round keys: first 4 bytes of SHA-256(key || round number)")
+	if operation == OperationDecrypt {
+		v.AddStep("Decrypting: the round keys are applied in reverse order")
+	}
+	v.AddHexStep("Input Blocks", data)
+	v.AddSeparator()
+
+	v.AddStep("Block 1 Rounds:")
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i += feistelBlockSize {
+		var trace *utils.Visualizer
+		if i == 0 {
+			trace = v
+		}
+		out = append(out, feistelBlock(data[i:i+feistelBlockSize], keys, trace)...)
+	}
+	if blocks := len(data) / feistelBlockSize; blocks > 1 {
+		v.AddNote(fmt.Sprintf("The other %d blocks go through the same rounds independently (ECB mode)", blocks-1))
+	}
+	v.AddSeparator()
+
+	var result string
+	if operation == OperationEncrypt {
+		v.AddHexStep("Ciphertext", out)
+		result = hex.EncodeToString(out)
+	} else {
+		plaintext, err := pkcs7Unpad(out, feistelBlockSize)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to decrypt: wrong key or round count: %w", err)
+		}
+		v.AddTextStep("Plaintext", string(plaintext))
+		result = string(plaintext)
+	}
+	v.AddSeparator()
+
+	v.AddNote("Why Feistel networks matter:")
+	v.AddNote("1. Decryption reuses the encryption circuit with reversed keys, so F need not be invertible")
+	v.AddNote("2. DES runs 16 such rounds on 64-bit blocks; Blowfish and Twofish are also Feistel ciphers")
+	v.AddNote("3. Luby and Rackoff showed 3-4 rounds of a secure pseudorandom F give a secure block cipher")
+	v.AddNote("4. This F is one XOR, rotation, and addition with no S-boxes, so it offers almost no confusion")
+
+	return result, v.GetSteps(), nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *FeistelProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "rounds", Description: fmt.Sprintf("Number of Feistel rounds (1-%d)", feistelMaxRounds), Value: p.rounds},
+		{Name: "key", Description: "Key the round keys are derived from", Value: p.key},
+	}
+}
+
+// AuditSources implements the AuditableProcessor interface
+func (p *FeistelProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		{Kind: AuditInRepository, Package: "internal/crypto/feistel.go", Purpose: "Toy Feistel network and round function"},
+		{Kind: AuditStandardLibrary, Package: "crypto/sha256", Purpose: "Round key derivation"},
+	}
+}
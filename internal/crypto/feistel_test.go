@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestFeistelProcessor_RoundTrip(t *testing.T) {
+	for _, rounds := range []int{1, 3, 16} {
+		for _, input := range []string{"a", "12345678", "Hello, Feistel network!"} {
+			processor := NewFeistelProcessor()
+			if err := processor.Configure(map[string]interface{}{"rounds": rounds, "key": "secret"}); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			ciphertext, steps, err := processor.Process(input, OperationEncrypt)
+			if err != nil {
+				t.Fatalf("Process() encrypt error = %v", err)
+			}
+			if len(ciphertext) != 16*((len(input)/8)+1) {
+				t.Errorf("ciphertext length = %d, want whole padded 8-byte blocks", len(ciphertext)/2)
+			}
+			if got := strings.Count(strings.Join(utils.StepTexts(steps), "\n"), "(halves swapped)"); got != rounds {
+				t.Errorf("rounds shown = %d, want %d", got, rounds)
+			}
+
+			plaintext, _, err := processor.Process(ciphertext, OperationDecrypt)
+			if err != nil {
+				t.Fatalf("Process() decrypt error = %v", err)
+			}
+			if plaintext != input {
+				t.Errorf("round trip = %q, want %q", plaintext, input)
+			}
+		}
+	}
+}
+
+func TestFeistelProcessor_KeyAndRoundsMatter(t *testing.T) {
+	encrypt := func(config map[string]interface{}) string {
+		processor := NewFeistelProcessor()
+		if err := processor.Configure(config); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		ciphertext, _, err := processor.Process("attack at dawn", OperationEncrypt)
+		if err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+		return ciphertext
+	}
+
+	base := encrypt(map[string]interface{}{"key": "one"})
+	if base == encrypt(map[string]interface{}{"key": "two"}) {
+		t.Error("Expected different keys to give different ciphertexts")
+	}
+	if base == encrypt(map[string]interface{}{"key": "one", "rounds": 9}) {
+		t.Error("Expected a different round count to give a different ciphertext")
+	}
+}
+
+func TestFeistelProcessor_Errors(t *testing.T) {
+	processor := NewFeistelProcessor()
+	for _, rounds := range []int{-1, feistelMaxRounds + 1} {
+		if err := processor.Configure(map[string]interface{}{"rounds": rounds}); err == nil {
+			t.Errorf("Expected an error for %d rounds", rounds)
+		}
+	}
+	for _, ciphertext := range []string{"zz", "00112233", ""} {
+		if _, _, err := processor.Process(ciphertext, OperationDecrypt); err == nil {
+			t.Errorf("Expected an error for ciphertext %q", ciphertext)
+		}
+	}
+	if _, _, err := processor.Process("test", "invalid"); err == nil {
+		t.Error("Expected an error for an invalid operation")
+	}
+}
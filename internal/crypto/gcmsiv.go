@@ -0,0 +1,194 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// AES-GCM-SIV sizes from RFC 8452
+const (
+	gcmSIVNonceSize = 12
+	gcmSIVTagSize   = 16
+	gcmSIVMaxLength = 1 << 36 // bytes of plaintext or additional data per message (RFC 8452 section 6)
+)
+
+// errGCMSIVOpen is returned when a ciphertext fails authentication
+var errGCMSIVOpen = errors.New("message authentication failed")
+
+// checkGCMSIVLengths enforces the RFC 8452 limits: beyond 2^36 bytes the 32-bit block counter
+// would wrap and the bit lengths fed to POLYVAL would no longer be unique
+func checkGCMSIVLengths(textLen, additionalDataLen uint64) error {
+	if textLen > gcmSIVMaxLength {
+		return fmt.Errorf("message too large for AES-GCM-SIV: %d bytes (at most 2^36)", textLen)
+	}
+	if additionalDataLen > gcmSIVMaxLength {
+		return fmt.Errorf("additional data too large for AES-GCM-SIV: %d bytes (at most 2^36)", additionalDataLen)
+	}
+	return nil
+}
+
+// gcmSIV implements AEAD_AES_128_GCM_SIV and AEAD_AES_256_GCM_SIV (RFC 8452). The key is a
+// key-generating key: every nonce derives its own authentication and encryption keys.
+type gcmSIV struct {
+	block cipher.Block
+	size  int
+}
+
+// gcmSIVKeys are the per-nonce keys derived from the key-generating key
+type gcmSIVKeys struct {
+	auth []byte
+	enc  []byte
+}
+
+// newGCMSIV creates an AES-GCM-SIV AEAD from a 16- or 32-byte key-generating key
+func newGCMSIV(key []byte) (*gcmSIV, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, fmt.Errorf("invalid AES-GCM-SIV key size: %d bytes (must be 16 or 32)", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	return &gcmSIV{block: block, size: len(key)}, nil
+}
+
+// NonceSize returns the nonce length in bytes
+func (g *gcmSIV) NonceSize() int { return gcmSIVNonceSize }
+
+// Overhead returns the tag length in bytes
+func (g *gcmSIV) Overhead() int { return gcmSIVTagSize }
+
+// deriveKeys encrypts LE32(i) || nonce under the key-generating key and keeps the first half
+// of each block: two blocks make the POLYVAL key and two or four the AES encryption key
+func (g *gcmSIV) deriveKeys(nonce []byte) gcmSIVKeys {
+	blocks := 2 + g.size/8
+	material := make([]byte, 0, blocks*8)
+	var in, out [16]byte
+	copy(in[4:], nonce)
+	for i := 0; i < blocks; i++ {
+		binary.LittleEndian.PutUint32(in[:4], uint32(i))
+		g.block.Encrypt(out[:], in[:])
+		material = append(material, out[:8]...)
+	}
+	return gcmSIVKeys{auth: material[:16], enc: material[16:]}
+}
+
+// tag computes the synthetic IV: POLYVAL over the padded AAD, padded plaintext and their bit
+// lengths, XORed with the nonce, top bit cleared, then encrypted with the message key
+func (g *gcmSIV) tag(keys gcmSIVKeys, encBlock cipher.Block, nonce, plaintext, additionalData []byte) []byte {
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(additionalData))*8)
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(plaintext))*8)
+
+	input := append(padToBlock(additionalData), padToBlock(plaintext)...)
+	input = append(input, lengths[:]...)
+	s := polyval(keys.auth, input)
+	for i := range nonce {
+		s[i] ^= nonce[i]
+	}
+	s[15] &= 0x7f
+
+	tag := make([]byte, gcmSIVTagSize)
+	encBlock.Encrypt(tag, s[:])
+	return tag
+}
+
+// ctr runs AES in counter mode from the tag, with the top bit set and a little-endian 32-bit
+// counter in the first four bytes
+func (g *gcmSIV) ctr(encBlock cipher.Block, tag, in []byte) []byte {
+	var counter, keystream [16]byte
+	copy(counter[:], tag)
+	counter[15] |= 0x80
+	out := make([]byte, len(in))
+	for i := 0; i < len(in); i += 16 {
+		encBlock.Encrypt(keystream[:], counter[:])
+		subtle.XORBytes(out[i:], in[i:], keystream[:])
+		binary.LittleEndian.PutUint32(counter[:4], binary.LittleEndian.Uint32(counter[:4])+1)
+	}
+	return out
+}
+
+// Seal encrypts and authenticates plaintext, appending ciphertext || tag to dst
+func (g *gcmSIV) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("crypto: incorrect nonce length given to AES-GCM-SIV")
+	}
+	if err := checkGCMSIVLengths(uint64(len(plaintext)), uint64(len(additionalData))); err != nil {
+		panic("crypto: " + err.Error())
+	}
+	keys := g.deriveKeys(nonce)
+	encBlock, _ := aes.NewCipher(keys.enc)
+	tag := g.tag(keys, encBlock, nonce, plaintext, additionalData)
+	dst = append(dst, g.ctr(encBlock, tag, plaintext)...)
+	return append(dst, tag...)
+}
+
+// Open decrypts and verifies ciphertext || tag, appending the plaintext to dst
+func (g *gcmSIV) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != gcmSIVNonceSize {
+		return nil, fmt.Errorf("incorrect nonce length: %d bytes (must be %d)", len(nonce), gcmSIVNonceSize)
+	}
+	if len(ciphertext) < gcmSIVTagSize {
+		return nil, errGCMSIVOpen
+	}
+	if err := checkGCMSIVLengths(uint64(len(ciphertext)-gcmSIVTagSize), uint64(len(additionalData))); err != nil {
+		return nil, err
+	}
+	body, tag := ciphertext[:len(ciphertext)-gcmSIVTagSize], ciphertext[len(ciphertext)-gcmSIVTagSize:]
+	keys := g.deriveKeys(nonce)
+	encBlock, _ := aes.NewCipher(keys.enc)
+	plaintext := g.ctr(encBlock, tag, body)
+	if !utils.ConstantTimeCompare(g.tag(keys, encBlock, nonce, plaintext, additionalData), tag) {
+		return nil, errGCMSIVOpen
+	}
+	return append(dst, plaintext...), nil
+}
+
+// padToBlock returns data followed by zeros up to a multiple of 16 bytes
+func padToBlock(data []byte) []byte {
+	padded := make([]byte, (len(data)+15)/16*16)
+	copy(padded, data)
+	return padded
+}
+
+// polyval computes POLYVAL(h, x) over 16-byte blocks (RFC 8452 section 3)
+func polyval(h, x []byte) [16]byte {
+	hv := [2]uint64{binary.LittleEndian.Uint64(h[:8]), binary.LittleEndian.Uint64(h[8:16])}
+	var s [2]uint64
+	for i := 0; i+16 <= len(x); i += 16 {
+		s[0] ^= binary.LittleEndian.Uint64(x[i : i+8])
+		s[1] ^= binary.LittleEndian.Uint64(x[i+8 : i+16])
+		s = polyvalDot(s, hv)
+	}
+	var out [16]byte
+	binary.LittleEndian.PutUint64(out[:8], s[0])
+	binary.LittleEndian.PutUint64(out[8:], s[1])
+	return out
+}
+
+// polyvalDot returns a*b*x^-128 in GF(2^128) modulo x^128 + x^127 + x^126 + x^121 + 1, using
+// bit-serial Montgomery multiplication. Elements are little-endian: bit i is x^i.
+//
+// b is the per-nonce authentication key, so the loop never branches on its bits or on the
+// running value: each conditional XOR is applied through an all-ones or all-zeros mask.
+func polyvalDot(a, b [2]uint64) [2]uint64 {
+	var r0, r1 uint64
+	for i := 0; i < 128; i++ {
+		mask := -((b[i/64] >> (i % 64)) & 1)
+		r0 ^= a[0] & mask
+		r1 ^= a[1] & mask
+		// Make r divisible by x by adding the modulus, then divide by x
+		carry := r0 & 1
+		r0 ^= carry
+		r1 ^= (1<<57 | 1<<62 | 1<<63) & -carry
+		r0 = r0>>1 | r1<<63
+		r1 = r1>>1 | carry<<63
+	}
+	return [2]uint64{r0, r1}
+}
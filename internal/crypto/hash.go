@@ -0,0 +1,201 @@
+package crypto
+
+import (
+	// nolint:gosec // SHA1 is included for educational purposes only, with clear warnings about its insecurity
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/zeebo/blake3"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Additional hash algorithms supported by the HashProcessor
+const (
+	HashSHA384 = "sha384"
+)
+
+// hashAlgorithms lists the HashProcessor algorithms in display order
+var hashAlgorithms = []string{HashSHA1, HashSHA256, HashSHA384, HashSHA512, HashSHA3256, HashBLAKE2b, HashBLAKE2s, HashBLAKE3}
+
+// HashAlgorithms returns the algorithms HashProcessor supports, in display order
+func HashAlgorithms() []string {
+	return append([]string(nil), hashAlgorithms...)
+}
+
+// HashProcessor hashes text with a selectable digest algorithm
+type HashProcessor struct {
+	BaseConfigurableProcessor
+	algorithm  string
+	digestSize int
+}
+
+// NewHashProcessor creates a new hash processor
+func NewHashProcessor() *HashProcessor {
+	return &HashProcessor{
+		algorithm:  HashSHA256,
+		digestSize: 32,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *HashProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	algorithm, size := p.algorithm, p.digestSize
+	if value, ok := config["algorithm"].(string); ok && value != "" {
+		algorithm = value
+	}
+
+	// Configure BLAKE2 digest size if provided
+	if value, ok := config["digestSize"].(int); ok && value != 0 {
+		size = value
+	}
+
+	// Validate the pair, since the size limit depends on the BLAKE2 variant
+	if _, err := newHashFunc(algorithm, size); err != nil {
+		return err
+	}
+	p.algorithm, p.digestSize = algorithm, size
+
+	return nil
+}
+
+// newHashFunc returns a constructor for the named hash algorithm
+func newHashFunc(algorithm string, digestSize int) (func() hash.Hash, error) {
+	switch algorithm {
+	case HashSHA1:
+		return sha1.New, nil
+	case HashSHA256:
+		return sha256.New, nil
+	case HashSHA384:
+		return sha512.New384, nil
+	case HashSHA512:
+		return sha512.New, nil
+	case HashSHA3256:
+		return sha3.New256, nil
+	case HashBLAKE2b, HashBLAKE2s:
+		if _, err := newBLAKE2(algorithm, digestSize, nil); err != nil {
+			return nil, err
+		}
+		return func() hash.Hash {
+			h, _ := newBLAKE2(algorithm, digestSize, nil)
+			return h
+		}, nil
+	case HashBLAKE3:
+		return func() hash.Hash {
+			return blake3.New()
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s (must be one of: sha1, sha256, sha384, sha512, sha3-256, blake2b, blake2s, blake3)", algorithm)
+	}
+}
+
+// hashDisplayName returns a human-readable name for the algorithm
+func hashDisplayName(algorithm string, digestSize int) string {
+	switch algorithm {
+	case HashSHA1:
+		return "SHA-1"
+	case HashSHA256:
+		return "SHA-256"
+	case HashSHA384:
+		return "SHA-384"
+	case HashSHA512:
+		return "SHA-512"
+	case HashSHA3256:
+		return "SHA3-256"
+	case HashBLAKE2b:
+		return fmt.Sprintf("BLAKE2b-%d", digestSize*8)
+	case HashBLAKE2s:
+		return fmt.Sprintf("BLAKE2s-%d", digestSize*8)
+	case HashBLAKE3:
+		return "BLAKE3"
+	default:
+		return algorithm
+	}
+}
+
+// Process implements the Processor interface
+func (p *HashProcessor) Process(text string, _ string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	newHash, err := newHashFunc(p.algorithm, p.digestSize)
+	if err != nil {
+		return "", nil, err
+	}
+	name := hashDisplayName(p.algorithm, p.digestSize)
+	h := newHash()
+
+	// Add introduction
+	v.AddHeader(fmt.Sprintf("%s Hash Process", name))
+	v.AddNote("A cryptographic hash maps any input to a fixed-size digest")
+	v.AddNote(fmt.Sprintf("%s produces a %d-bit (%d-byte) digest from %d-byte blocks", name, h.Size()*8, h.Size(), h.BlockSize()))
+	if p.algorithm == HashSHA1 {
+		v.AddStep("⚠️ SHA-1 is broken (practical collisions since 2017); use it only for legacy compatibility")
+	}
+	if p.algorithm == HashBLAKE2s {
+		v.AddNote("BLAKE2s is optimized for 8- to 32-bit platforms; prefer BLAKE2b on 64-bit CPUs")
+	}
+	v.AddSeparator()
+
+	// Show input
+	v.AddTextStep("Input Text", text)
+	v.AddArrow()
+
+	// Calculate hash
+	h.Write([]byte(text))
+	digest := h.Sum(nil)
+
+	// Show hash in different formats
+	v.AddHexStep(fmt.Sprintf("%s Hash (Hex)", name), digest)
+	v.AddArrow()
+	encoded := base64.StdEncoding.EncodeToString(digest)
+	v.AddTextStep("Base64 Encoded Hash", encoded)
+	v.AddStep(fmt.Sprintf("Output Length: %d bits (%d bytes, %d hex characters)", len(digest)*8, len(digest), len(digest)*2))
+	v.AddSeparator()
+
+	// Compare the same input across all supported algorithms
+	v.AddStep("Comparison Across Algorithms:")
+	for _, algorithm := range hashAlgorithms {
+		// The other BLAKE2 variant keeps its 256-bit default, since a custom size may not fit it
+		size := 32
+		if algorithm == p.algorithm {
+			size = p.digestSize
+		}
+		otherHash, _ := newHashFunc(algorithm, size)
+		other := otherHash()
+		other.Write([]byte(text))
+		marker := "  "
+		if algorithm == p.algorithm {
+			marker = "→ "
+		}
+		v.AddStep(fmt.Sprintf("%s%-12s %4d bits  %s", marker, hashDisplayName(algorithm, size), other.Size()*8, shortHex(other.Sum(nil))))
+	}
+	v.AddNote("Digests of the same input share nothing across algorithms; never compare hashes from different functions")
+	v.AddSeparator()
+
+	// Add security notes
+	v.AddNote("Security Considerations:")
+	v.AddNote("1. Hash functions are one-way - the input cannot be recovered from the digest")
+	v.AddNote("2. Any change in input produces a completely different hash")
+	v.AddNote("3. Collision resistance is about half the output size in bits")
+	v.AddNote("4. Do not hash passwords directly; use a PBKDF such as Argon2id instead")
+
+	return hex.EncodeToString(digest), v.GetSteps(), nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *HashProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "algorithm", Description: "Hash algorithm (sha1, sha256, sha384, sha512, sha3-256, blake2b, blake2s, blake3)", Value: p.algorithm},
+		{Name: "digestSize", Description: "BLAKE2 digest size in bytes (1-64 for blake2b, 1-32 for blake2s)", Value: p.digestSize},
+	}
+}
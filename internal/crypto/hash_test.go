@@ -0,0 +1,90 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestHashProcessor_Process(t *testing.T) {
+	tests := []struct {
+		algorithm  string
+		digestSize int
+		want       string
+	}{
+		{algorithm: HashSHA1, want: "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{algorithm: HashSHA256, want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{algorithm: HashSHA384, want: "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7"},
+		{algorithm: HashSHA3256, want: "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+		{algorithm: HashBLAKE2b, digestSize: 32, want: "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+		{algorithm: HashBLAKE2s, digestSize: 32, want: "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			processor := NewHashProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"algorithm":  tt.algorithm,
+				"digestSize": tt.digestSize,
+			}); err != nil {
+				t.Fatalf("Failed to configure HashProcessor: %v", err)
+			}
+
+			result, steps, err := processor.Process("abc", OperationEncrypt)
+			if err != nil {
+				t.Fatalf("HashProcessor.Process() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("HashProcessor.Process() = %s, want %s", result, tt.want)
+			}
+			if len(steps) == 0 {
+				t.Error("Expected non-empty steps")
+			}
+		})
+	}
+}
+
+func TestHashProcessor_Configure_Invalid(t *testing.T) {
+	processor := NewHashProcessor()
+	if err := processor.Configure(map[string]interface{}{"algorithm": "md5"}); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+	if err := processor.Configure(map[string]interface{}{"algorithm": HashBLAKE2b, "digestSize": 65}); err == nil {
+		t.Error("Expected error for 65-byte BLAKE2b digest")
+	}
+	if err := processor.Configure(map[string]interface{}{"algorithm": HashBLAKE2s, "digestSize": 33}); err == nil {
+		t.Error("Expected error for 33-byte BLAKE2s digest")
+	}
+}
+
+func TestHashProcessor_BLAKE2s(t *testing.T) {
+	processor := NewHashProcessor()
+	if err := processor.Configure(map[string]interface{}{"algorithm": HashBLAKE2s, "digestSize": 32}); err != nil {
+		t.Fatalf("Failed to configure HashProcessor: %v", err)
+	}
+
+	// BLAKE2s-256 known vector for the empty string
+	result, steps, err := processor.Process("", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("HashProcessor.Process() error = %v", err)
+	}
+	if want := "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"; result != want {
+		t.Errorf("BLAKE2s-256(\"\") = %s, want %s", result, want)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "optimized for 8- to 32-bit platforms") {
+		t.Error("Expected the 32-bit platform note for BLAKE2s")
+	}
+
+	// Custom sizes use the variant's own range
+	if err := processor.Configure(map[string]interface{}{"digestSize": 20}); err != nil {
+		t.Fatalf("Failed to configure a 20-byte BLAKE2s digest: %v", err)
+	}
+	result, _, err = processor.Process("abc", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("HashProcessor.Process() error = %v", err)
+	}
+	if want := "5ae3b99be29b01834c3b508521ede60438f8de17"; result != want {
+		t.Errorf("BLAKE2s-160(abc) = %s, want %s", result, want)
+	}
+}
@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestHKDFProcessor_RFC5869(t *testing.T) {
+	tests := []struct {
+		name      string
+		hash      string
+		ikm       string
+		salt      string
+		info      string
+		length    int
+		wantOKM   string
+		wantSteps []string
+	}{
+		{
+			name:    "test case 1",
+			hash:    HashSHA256,
+			ikm:     strings.Repeat("\x0b", 22),
+			salt:    "000102030405060708090a0b0c",
+			info:    "\xf0\xf1\xf2\xf3\xf4\xf5\xf6\xf7\xf8\xf9",
+			length:  42,
+			wantOKM: "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+			wantSteps: []string{
+				"PRK = HMAC-SHA-256(salt, IKM)",
+				"T(2)",
+				"OKM = first 42 bytes of T(1) | T(2)",
+			},
+		},
+		{
+			name:      "test case 3 (no salt, no info)",
+			hash:      HashSHA256,
+			ikm:       strings.Repeat("\x0b", 22),
+			length:    42,
+			wantOKM:   "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+			wantSteps: []string{"Salt: none (HKDF uses 32 zero bytes)"},
+		},
+		{
+			name:    "test case 4 (SHA-1)",
+			hash:    HashSHA1,
+			ikm:     strings.Repeat("\x0b", 11),
+			salt:    "000102030405060708090a0b0c",
+			info:    "\xf0\xf1\xf2\xf3\xf4\xf5\xf6\xf7\xf8\xf9",
+			length:  42,
+			wantOKM: "085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896",
+			wantSteps: []string{
+				"HKDF-SHA-1 Key Derivation",
+				"OKM = first 42 bytes of T(1) | ... | T(3)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewHKDFProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"hashAlgorithm": tt.hash,
+				"salt":          tt.salt,
+				"info":          tt.info,
+				"length":        tt.length,
+			}); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+
+			result, steps, err := processor.Process(tt.ikm, OperationEncrypt)
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if result != tt.wantOKM {
+				t.Errorf("OKM = %s, want %s", result, tt.wantOKM)
+			}
+
+			output := strings.Join(utils.StepTexts(steps), "\n")
+			for _, want := range append(tt.wantSteps, "Step 1: Extract", "Step 2: Expand", "✓ Matches golang.org/x/crypto/hkdf") {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected steps to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestHKDFProcessor_Errors(t *testing.T) {
+	processor := NewHKDFProcessor()
+	if err := processor.Configure(map[string]interface{}{"hashAlgorithm": "md5"}); err == nil {
+		t.Error("Expected an error for an unsupported hash")
+	}
+	if err := processor.Configure(map[string]interface{}{"salt": "not hex or base64!"}); err == nil {
+		t.Error("Expected an error for an invalid salt")
+	}
+	if err := processor.Configure(map[string]interface{}{"length": -1}); err == nil {
+		t.Error("Expected an error for a negative length")
+	}
+	if _, _, err := processor.Process("secret", OperationDecrypt); err == nil {
+		t.Error("Expected an error for decryption")
+	}
+
+	// SHA-256 can expand at most 255 * 32 bytes
+	if err := processor.Configure(map[string]interface{}{"length": 255*32 + 1}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if _, _, err := processor.Process("secret", OperationEncrypt); err == nil {
+		t.Error("Expected an error for a length over the HKDF limit")
+	}
+}
@@ -10,11 +10,14 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash"
+	"strings"
 	"time"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
 	"github.com/zeebo/blake3"
 	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/sha3"
 )
 
 // Available hash algorithms
@@ -22,20 +25,29 @@ const (
 	HashSHA1       = "sha1"
 	HashSHA256     = "sha256"
 	HashSHA512     = "sha512"
+	HashSHA3256    = "sha3-256"
+	HashSHA3512    = "sha3-512"
 	HashBLAKE2b256 = "blake2b-256"
 	HashBLAKE2b512 = "blake2b-512"
+	HashBLAKE2s256 = "blake2s-256"
 	HashBLAKE3     = "blake3"
 )
 
+// hmacVerifySeparator separates the message from the expected MAC for the verify operation
+const hmacVerifySeparator = "||"
+
 type HMACProcessor struct {
 	BaseConfigurableProcessor
-	keyManager    KeyManager
-	hashAlgorithm string
+	keyManager     KeyManager
+	hashAlgorithm  string
+	digestSize     int    // Output size in bytes for the blake2b/blake2s variants
+	outputEncoding string // Encoding of the result; verification accepts any
 }
 
 func NewHMACProcessor() *HMACProcessor {
 	return &HMACProcessor{
 		hashAlgorithm: HashSHA256,
+		digestSize:    32,
 	}
 }
 
@@ -52,7 +64,11 @@ func (p *HMACProcessor) Configure(config map[string]interface{}) error {
 	}
 
 	// Initialize key manager
-	p.keyManager = NewFileKeyManager(256, keyFile) // HMAC-SHA256 uses 256-bit keys
+	keyManager := NewFileKeyManager(256, keyFile) // HMAC-SHA256 uses 256-bit keys
+	if days, ok := config["maxKeyAgeDays"].(int); ok {
+		keyManager.SetMaxKeyAge(days)
+	}
+	p.keyManager = keyManager
 	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
 		return fmt.Errorf("failed to load/generate key: %w", err)
 	}
@@ -61,14 +77,31 @@ func (p *HMACProcessor) Configure(config map[string]interface{}) error {
 	if hashAlgo, ok := config["hashAlgorithm"].(string); ok {
 		if hashAlgo != "" {
 			switch hashAlgo {
-			case HashSHA1, HashSHA256, HashSHA512, HashBLAKE2b256, HashBLAKE2b512, HashBLAKE3:
+			case HashSHA1, HashSHA256, HashSHA512, HashSHA3256, HashSHA3512, HashBLAKE2b256, HashBLAKE2b512, HashBLAKE2s256, HashBLAKE2b, HashBLAKE2s, HashBLAKE3:
 				p.hashAlgorithm = hashAlgo
 			default:
-				return fmt.Errorf("unsupported hash algorithm: %s (must be one of: sha1, sha256, sha512, blake2b-256, blake2b-512, blake3)", hashAlgo)
+				return fmt.Errorf("unsupported hash algorithm: %s (must be one of: sha1, sha256, sha512, sha3-256, sha3-512, blake2b-256, blake2b-512, blake2s-256, blake2b, blake2s, blake3)", hashAlgo)
 			}
 		}
 	}
 
+	if encoding, ok := config["outputEncoding"].(string); ok && encoding != "" {
+		if err := utils.ValidateEncoding(encoding); err != nil {
+			return err
+		}
+		p.outputEncoding = encoding
+	}
+
+	// Configure BLAKE2 digest size if provided
+	if size, ok := config["digestSize"].(int); ok && size != 0 {
+		p.digestSize = size
+	}
+	if p.hashAlgorithm == HashBLAKE2b || p.hashAlgorithm == HashBLAKE2s {
+		if _, err := newBLAKE2(p.hashAlgorithm, p.digestSize, nil); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -81,6 +114,10 @@ func (p *HMACProcessor) getHashFunction() (func() hash.Hash, error) {
 		return sha256.New, nil
 	case HashSHA512:
 		return sha512.New, nil
+	case HashSHA3256:
+		return sha3.New256, nil
+	case HashSHA3512:
+		return sha3.New512, nil
 	case HashBLAKE2b256:
 		return func() hash.Hash {
 			h, _ := blake2b.New256(nil)
@@ -91,6 +128,20 @@ func (p *HMACProcessor) getHashFunction() (func() hash.Hash, error) {
 			h, _ := blake2b.New512(nil)
 			return h
 		}, nil
+	case HashBLAKE2s256:
+		return func() hash.Hash {
+			h, _ := blake2s.New256(nil)
+			return h
+		}, nil
+	case HashBLAKE2b, HashBLAKE2s:
+		if _, err := newBLAKE2(p.hashAlgorithm, p.digestSize, nil); err != nil {
+			return nil, err
+		}
+		variant, size := p.hashAlgorithm, p.digestSize
+		return func() hash.Hash {
+			h, _ := newBLAKE2(variant, size, nil)
+			return h
+		}, nil
 	case HashBLAKE3:
 		return func() hash.Hash {
 			return blake3.New()
@@ -100,6 +151,11 @@ func (p *HMACProcessor) getHashFunction() (func() hash.Hash, error) {
 	}
 }
 
+// HashFunction returns the hash constructor for the configured algorithm
+func (p *HMACProcessor) HashFunction() (func() hash.Hash, error) {
+	return p.getHashFunction()
+}
+
 // getBlockSize returns the block size for the selected hash algorithm
 func (p *HMACProcessor) getBlockSize() int {
 	switch p.hashAlgorithm {
@@ -109,10 +165,18 @@ func (p *HMACProcessor) getBlockSize() int {
 		return 64
 	case HashSHA512:
 		return 128
+	case HashSHA3256:
+		return 136 // Keccak rate for SHA3-256
+	case HashSHA3512:
+		return 72 // Keccak rate for SHA3-512
 	case HashBLAKE2b256:
 		return 64
 	case HashBLAKE2b512:
 		return 128
+	case HashBLAKE2s256, HashBLAKE2s:
+		return 64
+	case HashBLAKE2b:
+		return 128
 	case HashBLAKE3:
 		return 64
 	default:
@@ -129,10 +193,18 @@ func (p *HMACProcessor) getOutputSize() int {
 		return 32 // 256 bits
 	case HashSHA512:
 		return 64 // 512 bits
+	case HashSHA3256:
+		return 32 // 256 bits
+	case HashSHA3512:
+		return 64 // 512 bits
 	case HashBLAKE2b256:
 		return 32 // 256 bits
 	case HashBLAKE2b512:
 		return 64 // 512 bits
+	case HashBLAKE2s256:
+		return 32 // 256 bits
+	case HashBLAKE2b, HashBLAKE2s:
+		return p.digestSize
 	case HashBLAKE3:
 		return 32 // 256 bits by default
 	default:
@@ -140,21 +212,28 @@ func (p *HMACProcessor) getOutputSize() int {
 	}
 }
 
-func (p *HMACProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *HMACProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	// Validate operation type
-	if operation != OperationEncrypt {
-		return "", nil, fmt.Errorf("invalid operation: %s (HMAC only supports encryption)", operation)
+	switch operation {
+	case OperationEncrypt:
+	case OperationVerify:
+		return p.verify(text)
+	default:
+		return "", nil, fmt.Errorf("invalid operation: %s (HMAC supports encrypt and verify)", operation)
 	}
 
 	v := utils.NewVisualizer()
 
 	// Add introduction
-	v.AddStep(fmt.Sprintf("HMAC-%s Process", p.hashAlgorithm))
-	v.AddStep("=============================")
+	v.AddHeader(fmt.Sprintf("HMAC-%s Process", p.algorithmName()))
 	v.AddNote("HMAC (Hash-based Message Authentication Code) is a specific type of message authentication code")
 	v.AddNote("It involves a cryptographic hash function and a secret cryptographic key")
-	v.AddNote(fmt.Sprintf("Using %s as the underlying hash function", p.hashAlgorithm))
+	v.AddNote(fmt.Sprintf("Using %s as the underlying hash function", p.algorithmName()))
+	if p.hashAlgorithm == HashBLAKE2s256 || p.hashAlgorithm == HashBLAKE2s {
+		v.AddNote("BLAKE2s is optimized for 8- to 32-bit platforms; prefer BLAKE2b on 64-bit CPUs")
+	}
 	v.AddNote("Note: HMAC is a one-way function - the original message cannot be recovered from the HMAC value")
+	addKeyRotationNote(v, p.keyManager)
 	v.AddSeparator()
 
 	// Show original text
@@ -225,14 +304,7 @@ func (p *HMACProcessor) Process(text string, operation string) (string, []string
 	// Calculate average time
 	avgTime := totalTime / iterations
 
-	// Format execution time with higher precision
-	var timeStr string
-	if avgTime < time.Millisecond {
-		timeStr = fmt.Sprintf("%.3fµs", float64(avgTime.Nanoseconds())/1000.0)
-	} else {
-		timeStr = fmt.Sprintf("%.3fms", float64(avgTime.Milliseconds()))
-	}
-	v.AddNote(fmt.Sprintf("Current algorithm (%s) execution time: %s (avg of %d iterations)", p.hashAlgorithm, timeStr, iterations))
+	v.AddNote(fmt.Sprintf("Current algorithm (%s) execution time: %s (avg of %d iterations)", p.hashAlgorithm, utils.FormatDuration(avgTime), iterations))
 
 	// Calculate final HMAC for actual use
 	h.Reset()
@@ -258,7 +330,7 @@ func (p *HMACProcessor) Process(text string, operation string) (string, []string
 
 	// Add hash algorithm information
 	v.AddStep("Hash Algorithm Information:")
-	v.AddStep(fmt.Sprintf("Selected Algorithm: %s", p.hashAlgorithm))
+	v.AddStep(fmt.Sprintf("Selected Algorithm: %s", p.algorithmName()))
 	v.AddStep(fmt.Sprintf("Block Size: %d bytes", p.getBlockSize()))
 	v.AddStep(fmt.Sprintf("Output Size: %d bytes", p.getOutputSize()))
 	v.AddStep("")
@@ -292,6 +364,22 @@ func (p *HMACProcessor) Process(text string, operation string) (string, []string
 				"- Provides higher security margin than SHA-256",
 			},
 		},
+		{
+			name: HashSHA3256,
+			details: []string{
+				"- SHA3-256: 256-bit (32 bytes) output",
+				"- Part of the SHA-3 (Keccak) family, standardized in FIPS 202",
+				"- Sponge construction with a 136-byte rate, unrelated to SHA-2's design",
+			},
+		},
+		{
+			name: HashSHA3512,
+			details: []string{
+				"- SHA3-512: 512-bit (64 bytes) output",
+				"- Part of the SHA-3 (Keccak) family, standardized in FIPS 202",
+				"- Sponge construction with a 72-byte rate, slower than SHA-512 in software",
+			},
+		},
 		{
 			name: HashBLAKE2b256,
 			details: []string{
@@ -308,6 +396,28 @@ func (p *HMACProcessor) Process(text string, operation string) (string, []string
 				"- Used in many cryptocurrencies and security applications",
 			},
 		},
+		{
+			name: HashBLAKE2s256,
+			details: []string{
+				"- BLAKE2s-256: 256-bit (32 bytes) output",
+				"- Optimized for 8- to 32-bit platforms such as embedded and mobile CPUs",
+				"- Used in WireGuard and many constrained environments",
+			},
+		},
+		{
+			name: HashBLAKE2b,
+			details: []string{
+				"- BLAKE2b: configurable 1-64 byte output",
+				"- The digest size is part of the parameter block, so outputs are not truncations",
+			},
+		},
+		{
+			name: HashBLAKE2s,
+			details: []string{
+				"- BLAKE2s: configurable 1-32 byte output",
+				"- Optimized for 8- to 32-bit platforms",
+			},
+		},
 		{
 			name: HashBLAKE3,
 			details: []string{
@@ -349,13 +459,75 @@ func (p *HMACProcessor) Process(text string, operation string) (string, []string
 	v.AddNote("3. HMAC is resistant to length extension attacks")
 	v.AddNote("4. The security depends on the underlying hash function")
 	v.AddNote("5. HMAC is a one-way function - the original message cannot be recovered")
-	v.AddNote(fmt.Sprintf("6. Using %s as the underlying hash function", p.hashAlgorithm))
+	v.AddNote(fmt.Sprintf("6. Using %s as the underlying hash function", p.algorithmName()))
+
+	return utils.Encode(hmacResult, p.outputEncoding), v.GetSteps(), nil
+}
+
+// verify checks the MAC in text, given as message||MAC in any supported encoding
+func (p *HMACProcessor) verify(text string) (string, []utils.Step, error) {
+	i := strings.LastIndex(text, hmacVerifySeparator)
+	if i < 0 {
+		return "", nil, fmt.Errorf("invalid input: expected message%sMAC", hmacVerifySeparator)
+	}
+	message := text[:i]
+	expected, encoding, err := utils.DecodeAny(text[i+len(hmacVerifySeparator):], func(data []byte) bool {
+		return len(data) == p.getOutputSize()
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid MAC: %w", err)
+	}
+
+	hashFunc, err := p.getHashFunction()
+	if err != nil {
+		return "", nil, err
+	}
+	h := hmac.New(hashFunc, p.keyManager.GetKey())
+	h.Write([]byte(message))
+	computed := h.Sum(nil)
+
+	v := utils.NewVisualizer()
+	v.AddHeader(fmt.Sprintf("HMAC-%s Verification", p.algorithmName()))
+	v.AddNote("The receiver recomputes the HMAC with the shared key and compares it with the one received")
+	addKeyRotationNote(v, p.keyManager)
+	v.AddSeparator()
+
+	v.AddTextStep("Message", message)
+	v.AddStep(fmt.Sprintf("Detected MAC encoding: %s", utils.EncodingName(encoding)))
+	v.AddHexStep("Expected MAC (received)", expected)
+	v.AddArrow()
+	v.AddHexStep("Computed MAC", computed)
+	v.AddArrow()
+
+	v.AddStep("Constant-Time Comparison:")
+	v.AddStep("Every byte is compared, even after the first mismatch, so the time taken reveals")
+	v.AddStep("nothing about how much of a forged MAC was correct")
+	v.AddNote("Comparing with == or bytes.Equal stops at the first difference, which lets an attacker")
+	v.AddNote("recover a valid MAC byte by byte (see Attack Simulations → Timing Attack)")
+	v.AddArrow()
+
+	result := "MAC is invalid"
+	if utils.ConstantTimeCompare(computed, expected) {
+		v.AddStep("✅ MACs match: the message is authentic and unmodified")
+		result = "MAC is valid"
+	} else {
+		if len(expected) != len(computed) {
+			v.AddStep(fmt.Sprintf("Length mismatch: expected %d bytes, got %d", len(computed), len(expected)))
+		}
+		v.AddStep("❌ MACs differ: the message or the MAC was modified, or a different key was used")
+	}
 
-	// Return both formats in the result
-	result := fmt.Sprintf("Hex: %s\nBase64: %s", hmacHex, hmacBase64)
 	return result, v.GetSteps(), nil
 }
 
+// algorithmName returns the selected hash algorithm including any custom digest size
+func (p *HMACProcessor) algorithmName() string {
+	if p.hashAlgorithm == HashBLAKE2b || p.hashAlgorithm == HashBLAKE2s {
+		return fmt.Sprintf("%s-%d", p.hashAlgorithm, p.digestSize*8)
+	}
+	return p.hashAlgorithm
+}
+
 // Helper function to create padding buffer
 func createPadding(value byte, size int) []byte {
 	padding := make([]byte, size)
@@ -376,3 +548,60 @@ func xorBytes(a, b []byte) []byte {
 	}
 	return result
 }
+
+// KeyFile returns the key file currently in use
+func (p *HMACProcessor) KeyFile() string {
+	return keyFileOf(p.keyManager)
+}
+
+// UseKeyFile switches to an existing key file, such as an archived key
+func (p *HMACProcessor) UseKeyFile(path string) error {
+	keyManager, err := OpenFileKeyManager(256, path)
+	if err != nil {
+		return err
+	}
+	p.keyManager = keyManager
+	return nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *HMACProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "hashAlgorithm", Description: "Underlying hash function", Value: p.hashAlgorithm},
+		{Name: "digestSize", Description: "Digest size in bytes for blake2b (1-64) and blake2s (1-32)", Value: p.digestSize},
+		{Name: "keyFile", Description: "File the key is stored in", Value: keyFileOf(p.keyManager)},
+	}
+}
+
+// AuditSources lists the randomness sources and libraries used for the selected HMAC
+func (p *HMACProcessor) AuditSources() []AuditSource {
+	sources := []AuditSource{
+		keyManagerRandomness,
+		{Kind: AuditStandardLibrary, Package: "crypto/hmac", Purpose: "HMAC construction"},
+	}
+
+	var hashSource AuditSource
+	switch p.hashAlgorithm {
+	case HashSHA1:
+		hashSource = AuditSource{Kind: AuditStandardLibrary, Package: "crypto/sha1", Purpose: "SHA-1 (legacy)"}
+	case HashSHA256:
+		hashSource = AuditSource{Kind: AuditStandardLibrary, Package: "crypto/sha256", Purpose: "SHA-256"}
+	case HashSHA512:
+		hashSource = AuditSource{Kind: AuditStandardLibrary, Package: "crypto/sha512", Purpose: "SHA-512"}
+	case HashSHA3256, HashSHA3512:
+		hashSource = AuditSource{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/sha3", Purpose: p.algorithmName()}
+	case HashBLAKE2b256, HashBLAKE2b512, HashBLAKE2b:
+		hashSource = AuditSource{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/blake2b", Purpose: p.algorithmName()}
+	case HashBLAKE2s256:
+		hashSource = AuditSource{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/blake2s", Purpose: p.algorithmName()}
+	case HashBLAKE2s:
+		if p.digestSize == 32 {
+			hashSource = AuditSource{Kind: AuditExternalLibrary, Package: "golang.org/x/crypto/blake2s", Purpose: p.algorithmName()}
+		} else {
+			hashSource = AuditSource{Kind: AuditInRepository, Package: "internal/crypto (blake2.go)", Purpose: p.algorithmName() + " via the RFC 7693 parameter block"}
+		}
+	case HashBLAKE3:
+		hashSource = AuditSource{Kind: AuditExternalLibrary, Package: "github.com/zeebo/blake3", Purpose: "BLAKE3"}
+	}
+	return append(sources, hashSource)
+}
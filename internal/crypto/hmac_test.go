@@ -1,7 +1,15 @@
 package crypto
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 func TestHMACProcessor_Configure(t *testing.T) {
@@ -51,3 +59,172 @@ func TestHMACProcessor_Process_InvalidOperation(t *testing.T) {
 		t.Error("Expected error for invalid operation, got nil")
 	}
 }
+
+func TestHMACProcessor_Process_BLAKE2(t *testing.T) {
+	tests := []struct {
+		name       string
+		algorithm  string
+		digestSize int
+		wantBytes  int
+		wantNote   bool
+	}{
+		{name: "BLAKE2s-256", algorithm: HashBLAKE2s256, wantBytes: 32, wantNote: true},
+		{name: "custom BLAKE2b-384", algorithm: HashBLAKE2b, digestSize: 48, wantBytes: 48},
+		{name: "custom BLAKE2s-160", algorithm: HashBLAKE2s, digestSize: 20, wantBytes: 20, wantNote: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewHMACProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"hashAlgorithm": tt.algorithm,
+				"digestSize":    tt.digestSize,
+				"keyFile":       "keys/test_hmac_key.bin",
+			}); err != nil {
+				t.Fatalf("Failed to configure HMACProcessor: %v", err)
+			}
+
+			_, steps, err := processor.Process("hello world", OperationEncrypt)
+			if err != nil {
+				t.Fatalf("HMACProcessor.Process() error = %v", err)
+			}
+
+			sizeFound, noteFound := false, false
+			for _, step := range steps {
+				if strings.Contains(step.Text, fmt.Sprintf("HMAC Result (Hex) - %d bytes", tt.wantBytes)) {
+					sizeFound = true
+				}
+				if strings.Contains(step.Text, "optimized for 8- to 32-bit platforms; prefer BLAKE2b") {
+					noteFound = true
+				}
+			}
+			if !sizeFound {
+				t.Errorf("Expected a %d-byte HMAC", tt.wantBytes)
+			}
+			if noteFound != tt.wantNote {
+				t.Errorf("BLAKE2s platform note present = %v, want %v", noteFound, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestHMACProcessor_Configure_InvalidDigestSize(t *testing.T) {
+	processor := NewHMACProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"hashAlgorithm": HashBLAKE2s,
+		"digestSize":    48,
+		"keyFile":       "keys/test_hmac_key.bin",
+	}); err == nil {
+		t.Error("Expected error for 48-byte BLAKE2s digest, got nil")
+	}
+}
+
+func TestHMACProcessor_Process_SHA3(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	keyFile := filepath.Join(t.TempDir(), "hmac_key.bin")
+	if err := os.WriteFile(keyFile, key, 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	tests := []struct {
+		algorithm string
+		blockSize int
+		wantHex   string
+	}{
+		{
+			algorithm: HashSHA3256,
+			blockSize: 136,
+			wantHex:   "f76aacea7480978b8dbeb285452b5bc8f9f016ae33b0f7ff99fcbf73edd02c2c",
+		},
+		{
+			algorithm: HashSHA3512,
+			blockSize: 72,
+			wantHex:   "4c2eae814f13c8be46c2f69511f04286ba440f3d9828b24fc2236096b6cfbfe4b88a9973c3c2719c74791d7f807373e7684333a3b26f7b60e250290b08e217e2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			processor := NewHMACProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"hashAlgorithm":  tt.algorithm,
+				"keyFile":        keyFile,
+				"outputEncoding": "hex",
+			}); err != nil {
+				t.Fatalf("Failed to configure HMACProcessor: %v", err)
+			}
+
+			result, steps, err := processor.Process("hello world", OperationEncrypt)
+			if err != nil {
+				t.Fatalf("HMACProcessor.Process() error = %v", err)
+			}
+			if result != tt.wantHex {
+				t.Errorf("HMAC = %q, want hex %s", result, tt.wantHex)
+			}
+
+			blockFound := false
+			for _, step := range steps {
+				if strings.Contains(step.Text, fmt.Sprintf("Block Size: %d bytes", tt.blockSize)) {
+					blockFound = true
+				}
+			}
+			if !blockFound {
+				t.Errorf("Expected block size %d in steps", tt.blockSize)
+			}
+		})
+	}
+}
+
+func TestHMACProcessor_Process_Verify(t *testing.T) {
+	processor := NewHMACProcessor()
+	config := map[string]interface{}{
+		"hashAlgorithm": HashSHA256,
+		"keyFile":       filepath.Join(t.TempDir(), "hmac_key.bin"),
+	}
+	if err := processor.Configure(config); err != nil {
+		t.Fatalf("Failed to configure HMACProcessor: %v", err)
+	}
+	base64MAC, _, err := processor.Process("pay bob 10", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("HMACProcessor.Process() error = %v", err)
+	}
+	mac, err := base64.StdEncoding.DecodeString(base64MAC)
+	if err != nil {
+		t.Fatalf("Expected a Base64 MAC by default, got %q", base64MAC)
+	}
+	hexMAC := hex.EncodeToString(mac)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"hex MAC", "pay bob 10||" + hexMAC, "MAC is valid"},
+		{"Base64 MAC", "pay bob 10|| " + base64MAC, "MAC is valid"},
+		{"modified message", "pay bob 99||" + hexMAC, "MAC is invalid"},
+		{"truncated MAC", "pay bob 10||" + hexMAC[:32], "MAC is invalid"},
+		{"separator in message", "a||b||" + hexMAC, "MAC is invalid"},
+	}
+	for _, tt := range tests {
+		got, steps, err := processor.Process(tt.input, OperationVerify)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+		if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Constant-Time Comparison") {
+			t.Errorf("%s: expected the steps to explain the constant-time comparison", tt.name)
+		}
+	}
+
+	for _, input := range []string{"no separator", "message||not a mac!"} {
+		if _, _, err := processor.Process(input, OperationVerify); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
@@ -1,9 +1,23 @@
 package crypto
 
+import (
+	"context"
+	"io"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
 // Processor defines the interface for crypto processors
 type Processor interface {
 	// Process handles encryption/decryption/hashing
-	Process(text string, operation string) (string, []string, error)
+	Process(text string, operation string) (string, []utils.Step, error)
+}
+
+// ContextProcessor is implemented by long-running processors that can be interrupted
+type ContextProcessor interface {
+	Processor
+	// ProcessContext is Process, stopping early with an error wrapping ctx.Err() once ctx is done
+	ProcessContext(ctx context.Context, text string, operation string) (string, []utils.Step, error)
 }
 
 // ConfigurableProcessor defines the interface for configurable processors
@@ -13,6 +27,21 @@ type ConfigurableProcessor interface {
 	Configure(config map[string]interface{}) error
 }
 
+// Cipher is implemented by processors that also offer their encryption as a plain byte API,
+// without the visualization steps or interactive prompts of Process
+type Cipher interface {
+	// Encrypt encrypts plaintext under the processor's configured key
+	Encrypt(plaintext []byte) ([]byte, error)
+	// Decrypt reverses Encrypt
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// StreamProcessor is implemented by processors that can handle input too large to hold in memory
+type StreamProcessor interface {
+	// StreamProcess reads in, writes the result to out, and returns the plaintext bytes processed
+	StreamProcess(in io.Reader, out io.Writer, operation string) (int64, error)
+}
+
 // KeyManager defines the interface for key management
 type KeyManager interface {
 	// LoadOrGenerateKey loads an existing key or generates a new one
@@ -23,6 +52,28 @@ type KeyManager interface {
 	SetKey(key []byte) error
 }
 
+// RotatingKeyManager is implemented by key managers that support key rotation
+type RotatingKeyManager interface {
+	KeyManager
+	// RotateKey archives the current key and generates a fresh one
+	RotateKey() error
+	// Rotated reports whether the key has been rotated
+	Rotated() bool
+	// RotationReason describes why the key was rotated
+	RotationReason() string
+	// ArchivedKeyFile returns where the previous key was archived
+	ArchivedKeyFile() string
+}
+
+// KeySelectableProcessor is implemented by processors whose key can be picked at runtime
+type KeySelectableProcessor interface {
+	Processor
+	// KeyFile returns the key file currently in use
+	KeyFile() string
+	// UseKeyFile switches the processor to an existing key file
+	UseKeyFile(path string) error
+}
+
 // BaseConfigurableProcessor provides a base implementation of ConfigurableProcessor
 type BaseConfigurableProcessor struct {
 	config map[string]interface{}
@@ -43,4 +94,5 @@ func (p *BaseConfigurableProcessor) GetConfig() map[string]interface{} {
 const (
 	OperationEncrypt = "encrypt"
 	OperationDecrypt = "decrypt"
+	OperationVerify  = "verify"
 )
@@ -1,7 +1,11 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -24,6 +28,16 @@ type JWTProcessor struct {
 	keyManager KeyManager
 	algorithm  string
 	secretKey  string
+
+	// Claim validation applied when decoding
+	issuer           string
+	audience         string
+	leeway           time.Duration
+	requireNotBefore bool
+
+	// Externally generated key used instead of the generated keys when verifying
+	verificationKeyFile string
+	verificationKeyPEM  string
 }
 
 // NewJWTProcessor creates a new JWT processor
@@ -43,7 +57,7 @@ func (p *JWTProcessor) Configure(config map[string]interface{}) error {
 	if algorithm, ok := config["algorithm"].(string); ok {
 		// Validate algorithm
 		switch algorithm {
-		case "HS256", "RS256", "EdDSA":
+		case "HS256", "RS256", "PS256", "EdDSA", "ES256", "ES384":
 			p.algorithm = algorithm
 		default:
 			return fmt.Errorf("unsupported algorithm: %s", algorithm)
@@ -58,16 +72,38 @@ func (p *JWTProcessor) Configure(config map[string]interface{}) error {
 		p.secretKey = secretKey
 	}
 
+	if issuer, ok := config["issuer"].(string); ok {
+		p.issuer = issuer
+	}
+	if audience, ok := config["audience"].(string); ok {
+		p.audience = audience
+	}
+	if leeway, ok := config["leeway"].(int); ok {
+		if leeway < 0 {
+			return fmt.Errorf("invalid leeway: %d (must be zero or more seconds)", leeway)
+		}
+		p.leeway = time.Duration(leeway) * time.Second
+	}
+	if requireNotBefore, ok := config["requireNotBefore"].(bool); ok {
+		p.requireNotBefore = requireNotBefore
+	}
+
+	if keyFile, ok := config["verificationKeyFile"].(string); ok {
+		p.verificationKeyFile = keyFile
+	}
+	if keyPEM, ok := config["verificationKeyPEM"].(string); ok {
+		p.verificationKeyPEM = keyPEM
+	}
+
 	return nil
 }
 
 // Process implements the Processor interface for JWT
-func (p *JWTProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *JWTProcessor) Process(text string, operation string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 
 	// Add introduction
-	v.AddStep("JWT (JSON Web Token) Processing")
-	v.AddStep("=============================")
+	v.AddHeader("JWT (JSON Web Token) Processing")
 	v.AddNote("JWT is a compact, URL-safe means of representing claims between two parties")
 	v.AddNote("A JWT consists of three parts: Header, Payload, and Signature")
 	v.AddSeparator()
@@ -78,7 +114,7 @@ func (p *JWTProcessor) Process(text string, operation string) (string, []string,
 	return p.decodeJWT(text, v)
 }
 
-func (p *JWTProcessor) encodeJWT(text string, v *utils.Visualizer) (string, []string, error) {
+func (p *JWTProcessor) encodeJWT(text string, v *utils.Visualizer) (string, []utils.Step, error) {
 	// Parse the input text as JSON for claims
 	var claims jwt.MapClaims
 	if err := json.Unmarshal([]byte(text), &claims); err != nil {
@@ -115,10 +151,19 @@ func (p *JWTProcessor) encodeJWT(text string, v *utils.Visualizer) (string, []st
 		}
 		v.AddStep("Security: Key must be kept secret and shared securely")
 		v.AddStep("Use case: Single-party applications or trusted environments")
-	case "RS256":
-		v.AddStep("RSA-SHA256 (Asymmetric Key)")
+	case "RS256", "PS256":
+		if p.algorithm == "PS256" {
+			v.AddStep("RSASSA-PSS with SHA-256 (Asymmetric Key)")
+		} else {
+			v.AddStep("RSA-SHA256 with PKCS#1 v1.5 padding (Asymmetric Key)")
+		}
 		v.AddStep("Uses public/private key pair")
 		v.AddStep("Private key for signing, public key for verification")
+		v.AddStep("RS256 vs PS256:")
+		v.AddStep("- RS256 (PKCS#1 v1.5) pads the hash deterministically: the same token always gets the same signature")
+		v.AddStep("- PS256 (PSS) mixes in a random salt: every signature differs, even for the same token")
+		v.AddStep("- PSS has a security proof tied to RSA itself; v1.5 signatures rely on careful, strict parsing")
+		v.AddStep("- Both use the same RSA key pair, so switching only changes the padding")
 		if key, ok := signingKey.(*rsa.PrivateKey); ok {
 			// Save public key to PEM for educational purposes
 			pubBytes := x509.MarshalPKCS1PublicKey(&key.PublicKey)
@@ -147,6 +192,22 @@ func (p *JWTProcessor) encodeJWT(text string, v *utils.Visualizer) (string, []st
 		v.AddStep("- Has smaller key sizes")
 		v.AddStep("Security: Private key must be kept secure, public key can be shared")
 		v.AddStep("Use case: High-performance applications, modern APIs")
+	case "ES256", "ES384":
+		curve, hash := "P-256", "SHA-256"
+		if p.algorithm == "ES384" {
+			curve, hash = "P-384", "SHA-384"
+		}
+		v.AddStep(fmt.Sprintf("ECDSA with %s and %s (Asymmetric Key)", curve, hash))
+		v.AddStep("Private key for signing, public key for verification")
+		if key, ok := signingKey.(*ecdsa.PrivateKey); ok {
+			v.AddStep(fmt.Sprintf("Key length: %d bits", key.Curve.Params().BitSize))
+		}
+		v.AddStep("Unlike EdDSA, ECDSA:")
+		v.AddStep("- Needs a fresh random nonce for every signature")
+		v.AddStep("- Leaks the private key if a nonce is ever reused or biased")
+		v.AddStep("- Produces a different signature each time the same token is signed")
+		v.AddStep("Security: Private key must be kept secure, public key can be shared")
+		v.AddStep("Use case: Interoperable APIs, WebAuthn, and mobile clients")
 	}
 	v.AddSeparator()
 
@@ -211,7 +272,7 @@ func (p *JWTProcessor) encodeJWT(text string, v *utils.Visualizer) (string, []st
 	return tokenString, v.GetSteps(), nil
 }
 
-func (p *JWTProcessor) decodeJWT(tokenString string, v *utils.Visualizer) (string, []string, error) {
+func (p *JWTProcessor) decodeJWT(tokenString string, v *utils.Visualizer) (string, []utils.Step, error) {
 	// Split token into parts
 	parts := strings.Split(tokenString, ".")
 	if len(parts) != 3 {
@@ -261,13 +322,21 @@ func (p *JWTProcessor) decodeJWT(tokenString string, v *utils.Visualizer) (strin
 	if err != nil {
 		return "", nil, err
 	}
+	if source := p.externalKeySource(); source != "" {
+		v.AddStep(fmt.Sprintf("Verifying with external key: %s", source))
+		v.AddSeparator()
+	}
+
+	if err := p.reportClaims(claims, v); err != nil {
+		return "", v.GetSteps(), err
+	}
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if token.Method.Alg() != p.getSigningMethod().Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return verificationKey, nil
-	})
+	}, p.parserOptions()...)
 
 	if err != nil {
 		v.AddStep("❌ Signature Verification Failed:")
@@ -293,14 +362,99 @@ func (p *JWTProcessor) decodeJWT(tokenString string, v *utils.Visualizer) (strin
 	return string(claimsJSON), v.GetSteps(), nil
 }
 
+// parserOptions returns the claim validation options for the JWT parser
+func (p *JWTProcessor) parserOptions() []jwt.ParserOption {
+	options := []jwt.ParserOption{jwt.WithLeeway(p.leeway)}
+	if p.issuer != "" {
+		options = append(options, jwt.WithIssuer(p.issuer))
+	}
+	if p.audience != "" {
+		options = append(options, jwt.WithAudience(p.audience))
+	}
+	return options
+}
+
+// reportClaims shows which registered claims pass validation. The parser enforces
+// exp, nbf, iss, and aud; only a missing nbf when one is required is rejected here.
+func (p *JWTProcessor) reportClaims(claims map[string]interface{}, v *utils.Visualizer) error {
+	now := time.Now()
+	v.AddStep("Claim Validation:")
+	v.AddStep(fmt.Sprintf("Clock-skew leeway: %s", p.leeway))
+
+	if exp, ok := claims["exp"].(float64); ok {
+		expiresAt := time.Unix(int64(exp), 0)
+		if now.Before(expiresAt.Add(p.leeway)) {
+			v.AddStep(fmt.Sprintf("✅ exp: valid until %s", expiresAt.Format(time.RFC3339)))
+		} else {
+			v.AddStep(fmt.Sprintf("❌ exp: expired at %s", expiresAt.Format(time.RFC3339)))
+		}
+	} else {
+		v.AddStep("➖ exp: not present")
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok {
+		notBefore := time.Unix(int64(nbf), 0)
+		if now.Add(p.leeway).Before(notBefore) {
+			v.AddStep(fmt.Sprintf("❌ nbf: not valid before %s", notBefore.Format(time.RFC3339)))
+		} else {
+			v.AddStep(fmt.Sprintf("✅ nbf: valid since %s", notBefore.Format(time.RFC3339)))
+		}
+	} else if p.requireNotBefore {
+		v.AddStep("❌ nbf: required but not present")
+		return fmt.Errorf("token is missing required nbf claim")
+	} else {
+		v.AddStep("➖ nbf: not present")
+	}
+
+	if p.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss == p.issuer {
+			v.AddStep(fmt.Sprintf("✅ iss: matches %q", p.issuer))
+		} else {
+			v.AddStep(fmt.Sprintf("❌ iss: got %q, expected %q", iss, p.issuer))
+		}
+	}
+
+	if p.audience != "" {
+		if audienceContains(claims["aud"], p.audience) {
+			v.AddStep(fmt.Sprintf("✅ aud: includes %q", p.audience))
+		} else {
+			v.AddStep(fmt.Sprintf("❌ aud: %v does not include %q", claims["aud"], p.audience))
+		}
+	}
+
+	v.AddSeparator()
+	return nil
+}
+
+// audienceContains reports whether an aud claim (a string or a list of strings) includes want
+func audienceContains(aud interface{}, want string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == want
+	case []interface{}:
+		for _, entry := range aud {
+			if s, ok := entry.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (p *JWTProcessor) getSigningMethod() jwt.SigningMethod {
 	switch p.algorithm {
 	case "HS256":
 		return jwt.SigningMethodHS256
 	case "RS256":
 		return jwt.SigningMethodRS256
+	case "PS256":
+		return jwt.SigningMethodPS256
 	case "EdDSA":
 		return jwt.SigningMethodEdDSA
+	case "ES256":
+		return jwt.SigningMethodES256
+	case "ES384":
+		return jwt.SigningMethodES384
 	default:
 		return jwt.SigningMethodHS256
 	}
@@ -326,7 +480,7 @@ func (p *JWTProcessor) getSigningKey() (interface{}, error) {
 		}
 		return p.keyManager.GetKey(), nil
 
-	case "RS256":
+	case "RS256", "PS256":
 		privFile := "keys/jwt_rsa_private.pem"
 		pubFile := "keys/jwt_rsa_public.pem"
 		// Try to load existing private key
@@ -404,12 +558,69 @@ func (p *JWTProcessor) getSigningKey() (interface{}, error) {
 
 		return ed25519.PrivateKey(block.Bytes), nil
 
+	case "ES256", "ES384":
+		curve, privFile, pubFile := p.ecdsaKeyFiles()
+
+		// Try to load existing private key
+		privData, err := os.ReadFile(privFile)
+		if err != nil {
+			// Generate new key pair
+			privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate ECDSA key pair: %w", err)
+			}
+
+			// Save private key
+			privBytes, err := x509.MarshalECPrivateKey(privateKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal private key: %w", err)
+			}
+			privPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes})
+			if err := os.WriteFile(privFile, privPEM, 0600); err != nil {
+				return nil, fmt.Errorf("failed to save private key: %w", err)
+			}
+
+			// Save public key
+			pubBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal public key: %w", err)
+			}
+			pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes})
+			if err := os.WriteFile(pubFile, pubPEM, 0600); err != nil {
+				return nil, fmt.Errorf("failed to save public key: %w", err)
+			}
+
+			return privateKey, nil
+		}
+
+		// Parse PEM
+		block, _ := pem.Decode(privData)
+		if block == nil || block.Type != "EC PRIVATE KEY" {
+			return nil, fmt.Errorf("invalid key: Key must be a PEM encoded EC private key")
+		}
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		if privateKey.Curve != curve {
+			return nil, fmt.Errorf("invalid key: %s requires a %s key", p.algorithm, curve.Params().Name)
+		}
+		return privateKey, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported algorithm: %s", p.algorithm)
 	}
 }
 
 func (p *JWTProcessor) getVerificationKey() (interface{}, error) {
+	if p.externalKeySource() != "" {
+		data, err := p.externalKeyData()
+		if err != nil {
+			return nil, err
+		}
+		return parseVerificationKey(p.algorithm, data)
+	}
+
 	switch p.algorithm {
 	case "HS256":
 		// Use the secret key if provided, otherwise use the key from keyManager
@@ -418,7 +629,7 @@ func (p *JWTProcessor) getVerificationKey() (interface{}, error) {
 		}
 		return p.getSigningKey()
 
-	case "RS256":
+	case "RS256", "PS256":
 		pubFile := "keys/jwt_rsa_public.pem"
 		pubData, err := os.ReadFile(pubFile)
 		if err != nil {
@@ -448,7 +659,101 @@ func (p *JWTProcessor) getVerificationKey() (interface{}, error) {
 
 		return ed25519.PublicKey(block.Bytes), nil
 
+	case "ES256", "ES384":
+		curve, _, pubFile := p.ecdsaKeyFiles()
+		pubData, err := os.ReadFile(pubFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ECDSA public key: %w", err)
+		}
+
+		block, _ := pem.Decode(pubData)
+		if block == nil || block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("invalid key: Key must be a PEM encoded PKIX public key")
+		}
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		publicKey, ok := parsed.(*ecdsa.PublicKey)
+		if !ok || publicKey.Curve != curve {
+			return nil, fmt.Errorf("invalid key: %s requires a %s public key", p.algorithm, curve.Params().Name)
+		}
+		return publicKey, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported algorithm: %s", p.algorithm)
 	}
 }
+
+// ecdsaKeyFiles returns the curve and key file paths for the configured ECDSA algorithm
+func (p *JWTProcessor) ecdsaKeyFiles() (elliptic.Curve, string, string) {
+	if p.algorithm == "ES384" {
+		return elliptic.P384(), "keys/jwt_es384_private.pem", "keys/jwt_es384_public.pem"
+	}
+	return elliptic.P256(), "keys/jwt_es256_private.pem", "keys/jwt_es256_public.pem"
+}
+
+// externalKeySource describes the configured external verification key, or returns "" when none is set
+func (p *JWTProcessor) externalKeySource() string {
+	switch {
+	case p.verificationKeyPEM != "":
+		return "pasted key"
+	case p.verificationKeyFile != "":
+		return p.verificationKeyFile
+	default:
+		return ""
+	}
+}
+
+// externalKeyData returns the external verification key material
+func (p *JWTProcessor) externalKeyData() ([]byte, error) {
+	if p.verificationKeyPEM != "" {
+		return []byte(p.verificationKeyPEM), nil
+	}
+	data, err := os.ReadFile(p.verificationKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification key: %w", err)
+	}
+	return data, nil
+}
+
+// parseVerificationKey parses an externally generated key for the algorithm: the raw secret for
+// HS256, and any format ParseKey understands (raw bytes for Ed25519) for the others
+func parseVerificationKey(algorithm string, data []byte) (interface{}, error) {
+	if algorithm == "HS256" {
+		secret := bytes.TrimRight(data, "\r\n")
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("invalid key: HMAC secret is empty")
+		}
+		return secret, nil
+	}
+
+	rawType := ""
+	if algorithm == "EdDSA" {
+		rawType = RawKeyEd25519Public
+	}
+	publicKey, _, err := ParseKey(data, rawType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verification key: %w", err)
+	}
+	// A private key also carries its public half
+	if private, ok := publicKey.(interface{ Public() crypto.PublicKey }); ok {
+		publicKey = private.Public()
+	}
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if algorithm == "RS256" || algorithm == "PS256" {
+			return key, nil
+		}
+	case *ecdsa.PublicKey:
+		if (algorithm == "ES256" && key.Curve == elliptic.P256()) || (algorithm == "ES384" && key.Curve == elliptic.P384()) {
+			return key, nil
+		}
+	case ed25519.PublicKey:
+		if algorithm == "EdDSA" {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid key: %s cannot verify %s tokens", keyTypeName(publicKey), algorithm)
+}
@@ -1,11 +1,23 @@
 package crypto
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +57,27 @@ func TestJWTProcessor_Configure(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid PS256 config",
+			config: map[string]interface{}{
+				"algorithm": "PS256",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid ES256 config",
+			config: map[string]interface{}{
+				"algorithm": "ES256",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid ES384 config",
+			config: map[string]interface{}{
+				"algorithm": "ES384",
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid algorithm",
 			config: map[string]interface{}{
@@ -144,6 +177,35 @@ func TestJWTProcessor_RS256(t *testing.T) {
 	os.Remove("jwt_rsa_public.pem")
 }
 
+func TestJWTProcessor_PS256(t *testing.T) {
+	processor := NewJWTProcessor()
+	err := processor.Configure(map[string]interface{}{
+		"algorithm": "PS256",
+	})
+	require.NoError(t, err)
+
+	claimsJSON := `{"sub":"1234567890","name":"John Doe"}`
+
+	// PSS signatures are randomized, so the same claims give different tokens
+	first, _, err := processor.Process(claimsJSON, "encrypt")
+	require.NoError(t, err)
+	second, _, err := processor.Process(claimsJSON, "encrypt")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	decoded, _, err := processor.Process(first, "decrypt")
+	require.NoError(t, err)
+	var decodedClaims map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(decoded), &decodedClaims))
+	assert.Equal(t, "1234567890", decodedClaims["sub"])
+
+	// The shared RSA key does not let a PS256 token pass as RS256
+	rs256 := NewJWTProcessor()
+	require.NoError(t, rs256.Configure(map[string]interface{}{"algorithm": "RS256"}))
+	_, _, err = rs256.Process(first, "decrypt")
+	assert.Error(t, err)
+}
+
 func TestJWTProcessor_EdDSA(t *testing.T) {
 	processor := NewJWTProcessor()
 	err := processor.Configure(map[string]interface{}{
@@ -184,6 +246,54 @@ func TestJWTProcessor_EdDSA(t *testing.T) {
 	os.Remove("jwt_ed25519_public.pem")
 }
 
+func TestJWTProcessor_ECDSA(t *testing.T) {
+	for _, algorithm := range []string{"ES256", "ES384"} {
+		t.Run(algorithm, func(t *testing.T) {
+			processor := NewJWTProcessor()
+			err := processor.Configure(map[string]interface{}{
+				"algorithm": algorithm,
+			})
+			require.NoError(t, err)
+
+			claims := map[string]interface{}{
+				"sub":  "1234567890",
+				"name": "John Doe",
+				"iat":  time.Now().Unix(),
+				"exp":  time.Now().Add(time.Hour).Unix(),
+			}
+			claimsJSON, err := json.Marshal(claims)
+			require.NoError(t, err)
+
+			// Test encoding
+			token, steps, err := processor.Process(string(claimsJSON), "encrypt")
+			require.NoError(t, err)
+			assert.NotEmpty(t, token)
+			assert.NotEmpty(t, steps)
+
+			// Test decoding
+			decoded, _, err := processor.Process(token, "decrypt")
+			require.NoError(t, err)
+
+			var decodedClaims map[string]interface{}
+			err = json.Unmarshal([]byte(decoded), &decodedClaims)
+			require.NoError(t, err)
+			assert.Equal(t, claims["sub"], decodedClaims["sub"])
+			assert.Equal(t, claims["name"], decodedClaims["name"])
+		})
+	}
+
+	// A token signed with one curve must not verify under the other algorithm
+	es256 := NewJWTProcessor()
+	require.NoError(t, es256.Configure(map[string]interface{}{"algorithm": "ES256"}))
+	token, _, err := es256.Process(`{"sub":"1234567890"}`, "encrypt")
+	require.NoError(t, err)
+
+	es384 := NewJWTProcessor()
+	require.NoError(t, es384.Configure(map[string]interface{}{"algorithm": "ES384"}))
+	_, _, err = es384.Process(token, "decrypt")
+	assert.Error(t, err)
+}
+
 func TestJWTProcessor_InvalidInput(t *testing.T) {
 	processor := NewJWTProcessor()
 	err := processor.Configure(map[string]interface{}{
@@ -231,7 +341,148 @@ func TestJWTProcessor_ExpiredToken(t *testing.T) {
 	_, steps, err := processor.Process(token, "decrypt")
 	require.Error(t, err, "Expected error for expired token")
 	assert.Contains(t, err.Error(), "token is expired")
-	assert.Contains(t, steps, "❌ Signature Verification Failed:")
+	assert.Contains(t, utils.StepTexts(steps), "❌ Signature Verification Failed:")
+}
+
+func TestJWTProcessor_ClaimValidation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		claims  map[string]interface{}
+		wantErr string
+		step    string
+	}{
+		{
+			name:   "matching issuer and audience",
+			config: map[string]interface{}{"issuer": "cryptolens", "audience": "api"},
+			claims: map[string]interface{}{"iss": "cryptolens", "aud": []string{"web", "api"}},
+			step:   `✅ aud: includes "api"`,
+		},
+		{
+			name:    "wrong issuer",
+			config:  map[string]interface{}{"issuer": "cryptolens"},
+			claims:  map[string]interface{}{"iss": "someone-else"},
+			wantErr: "token has invalid issuer",
+			step:    `❌ iss: got "someone-else", expected "cryptolens"`,
+		},
+		{
+			name:    "wrong audience",
+			config:  map[string]interface{}{"audience": "api"},
+			claims:  map[string]interface{}{"aud": "web"},
+			wantErr: "token has invalid audience",
+		},
+		{
+			name:    "not yet valid",
+			config:  map[string]interface{}{},
+			claims:  map[string]interface{}{"nbf": now.Add(time.Minute).Unix()},
+			wantErr: "token is not valid yet",
+			step:    "❌ nbf: not valid before",
+		},
+		{
+			name:   "leeway covers clock skew",
+			config: map[string]interface{}{"leeway": 120},
+			claims: map[string]interface{}{"nbf": now.Add(time.Minute).Unix(), "exp": now.Add(-time.Minute).Unix()},
+			step:   "✅ exp: valid until",
+		},
+		{
+			name:    "required nbf missing",
+			config:  map[string]interface{}{"requireNotBefore": true},
+			claims:  map[string]interface{}{"sub": "1234567890"},
+			wantErr: "missing required nbf claim",
+			step:    "❌ nbf: required but not present",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewJWTProcessor()
+			require.NoError(t, processor.Configure(map[string]interface{}{
+				"algorithm": "HS256",
+				"secretKey": "test-secret-key",
+			}))
+			require.NoError(t, processor.Configure(tt.config))
+
+			claimsJSON, err := json.Marshal(tt.claims)
+			require.NoError(t, err)
+			token, _, err := processor.Process(string(claimsJSON), "encrypt")
+			require.NoError(t, err)
+
+			_, steps, err := processor.Process(token, "decrypt")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.step != "" {
+				assert.Contains(t, strings.Join(utils.StepTexts(steps), "\n"), tt.step)
+			}
+		})
+	}
+
+	processor := NewJWTProcessor()
+	assert.Error(t, processor.Configure(map[string]interface{}{"leeway": -1}))
+}
+
+func TestJWTProcessor_ExternalVerificationKey(t *testing.T) {
+	claims := jwt.MapClaims{"sub": "external", "exp": time.Now().Add(time.Hour).Unix()}
+	pemKey := func(t *testing.T, publicKey interface{}) string {
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	secretFile := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, os.WriteFile(secretFile, []byte("shared-secret\n"), 0600))
+
+	tests := []struct {
+		name       string
+		algorithm  string
+		method     jwt.SigningMethod
+		signingKey interface{}
+		config     map[string]interface{}
+	}{
+		{"RS256 PKIX PEM", "RS256", jwt.SigningMethodRS256, rsaKey, map[string]interface{}{"verificationKeyPEM": pemKey(t, &rsaKey.PublicKey)}},
+		{"PS256 PKIX PEM", "PS256", jwt.SigningMethodPS256, rsaKey, map[string]interface{}{"verificationKeyPEM": pemKey(t, &rsaKey.PublicKey)}},
+		{"ES256 PKIX PEM", "ES256", jwt.SigningMethodES256, ecKey, map[string]interface{}{"verificationKeyPEM": pemKey(t, &ecKey.PublicKey)}},
+		{"EdDSA raw hex", "EdDSA", jwt.SigningMethodEdDSA, edPrivate, map[string]interface{}{"verificationKeyPEM": hex.EncodeToString(edPublic)}},
+		{"HS256 secret file", "HS256", jwt.SigningMethodHS256, []byte("shared-secret"), map[string]interface{}{"verificationKeyFile": secretFile}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.NewWithClaims(tt.method, claims).SignedString(tt.signingKey)
+			require.NoError(t, err)
+
+			processor := NewJWTProcessor()
+			require.NoError(t, processor.Configure(map[string]interface{}{"algorithm": tt.algorithm, "secretKey": ""}))
+			require.NoError(t, processor.Configure(tt.config))
+
+			decoded, steps, err := processor.Process(token, "decrypt")
+			require.NoError(t, err)
+			assert.Contains(t, decoded, `"sub": "external"`)
+			assert.Contains(t, strings.Join(utils.StepTexts(steps), "\n"), "Verifying with external key")
+		})
+	}
+
+	// A key of the wrong type is rejected rather than silently ignored
+	processor := NewJWTProcessor()
+	require.NoError(t, processor.Configure(map[string]interface{}{
+		"algorithm":          "ES256",
+		"verificationKeyPEM": pemKey(t, &rsaKey.PublicKey),
+	}))
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(ecKey)
+	require.NoError(t, err)
+	_, _, err = processor.Process(token, "decrypt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot verify ES256 tokens")
 }
 
 func TestJWTProcessor_KeyManagement(t *testing.T) {
@@ -0,0 +1,75 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// defaultKeyExchangeMessage is used by the key exchange demos when no message is supplied
+const defaultKeyExchangeMessage = "Hello, this is a secret message!"
+
+// keyExchangeMessage returns the message to encrypt in the key exchange demos
+func keyExchangeMessage(text string) string {
+	if text == "" {
+		return defaultKeyExchangeMessage
+	}
+	return text
+}
+
+// encryptWithDerivedKey encrypts message with AES-GCM under the derived key
+// and returns the nonce-prefixed ciphertext
+func encryptWithDerivedKey(derivedKey []byte, message string) ([]byte, error) {
+	return encryptWithDerivedKeyFrom(rand.Reader, derivedKey, message)
+}
+
+// encryptWithDerivedKeyFrom is encryptWithDerivedKey with the nonce read from random
+func encryptWithDerivedKeyFrom(random io.Reader, derivedKey []byte, message string) ([]byte, error) {
+	gcm, err := newDerivedKeyGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, []byte(message), nil), nil
+}
+
+// decryptWithDerivedKey reverses encryptWithDerivedKey
+func decryptWithDerivedKey(derivedKey []byte, ciphertext []byte) (string, error) {
+	gcm, err := newDerivedKeyGCM(derivedKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// newDerivedKeyGCM creates an AES-GCM AEAD for the derived key
+func newDerivedKeyGCM(derivedKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM mode: %w", err)
+	}
+	return gcm, nil
+}
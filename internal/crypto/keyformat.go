@@ -0,0 +1,303 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+	"golang.org/x/crypto/ssh"
+)
+
+// Key formats supported by ConvertKey
+const (
+	KeyFormatPKCS1   = "pkcs1"   // PEM "RSA PRIVATE KEY" / "RSA PUBLIC KEY"
+	KeyFormatPKCS8   = "pkcs8"   // PEM "PRIVATE KEY"
+	KeyFormatPKIX    = "pkix"    // PEM "PUBLIC KEY"
+	KeyFormatDER     = "der"     // PKCS#8 (private) or PKIX (public) without PEM armor
+	KeyFormatRaw     = "raw"     // Bare Ed25519 seed/public key or X25519 scalar/point
+	KeyFormatOpenSSH = "openssh" // "OPENSSH PRIVATE KEY" or an authorized_keys line
+)
+
+// Key types for raw input, which does not identify its own algorithm
+const (
+	RawKeyEd25519Private = "ed25519-private"
+	RawKeyEd25519Public  = "ed25519-public"
+	RawKeyX25519Private  = "x25519-private"
+	RawKeyX25519Public   = "x25519-public"
+)
+
+// ParseKey parses a key in any supported format and reports the format it was found in.
+// rawType names the key type when the input is raw bytes and may be empty otherwise.
+func ParseKey(data []byte, rawType string) (interface{}, string, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		return parsePEMKey(block, data)
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "ssh-") || strings.HasPrefix(strings.TrimSpace(string(data)), "ecdsa-") {
+		sshKey, _, _, _, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse OpenSSH public key: %w", err)
+		}
+		cryptoKey, ok := sshKey.(ssh.CryptoPublicKey)
+		if !ok {
+			return nil, "", fmt.Errorf("unsupported OpenSSH key type: %s", sshKey.Type())
+		}
+		return cryptoKey.CryptoPublicKey(), KeyFormatOpenSSH, nil
+	}
+
+	if rawType != "" {
+		key, err := parseRawKey(data, rawType)
+		return key, KeyFormatRaw, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(data); err == nil {
+		return normalizeKey(key), KeyFormatDER, nil
+	}
+	if key, err := x509.ParsePKIXPublicKey(data); err == nil {
+		return key, KeyFormatDER, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(data); err == nil {
+		return key, KeyFormatDER, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(data); err == nil {
+		return key, KeyFormatDER, nil
+	}
+	return nil, "", fmt.Errorf("unrecognized key format (raw keys need a key type)")
+}
+
+// parsePEMKey parses a PEM-armored key, certificate, or OpenSSH private key
+func parsePEMKey(block *pem.Block, data []byte) (interface{}, string, error) {
+	var key interface{}
+	var format string
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		format = KeyFormatPKCS1
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		format = KeyFormatPKCS1
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		format = KeyFormatPKCS8
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		format = KeyFormatPKIX
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+		format = "sec1"
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = cert.PublicKey
+		}
+		format = "certificate"
+	case "OPENSSH PRIVATE KEY":
+		key, err = ssh.ParseRawPrivateKey(data)
+		format = KeyFormatOpenSSH
+	case "ED25519 PRIVATE KEY":
+		// Legacy CryptoLens format: the 64-byte private key under a custom PEM type
+		if len(block.Bytes) != ed25519.PrivateKeySize {
+			return nil, "", fmt.Errorf("invalid Ed25519 private key length: %d", len(block.Bytes))
+		}
+		key, format = ed25519.PrivateKey(block.Bytes), "cryptolens"
+	case "ED25519 PUBLIC KEY":
+		key, err = parseRawKey(block.Bytes, RawKeyEd25519Public)
+		format = "cryptolens"
+	default:
+		return nil, "", fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s key: %w", block.Type, err)
+	}
+	return normalizeKey(key), format, nil
+}
+
+// normalizeKey turns pointer forms returned by some parsers into the value types used elsewhere
+func normalizeKey(key interface{}) interface{} {
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		return *k
+	}
+	return key
+}
+
+// parseRawKey parses a bare 32-byte Ed25519 or X25519 key given as bytes, hex, or base64
+func parseRawKey(data []byte, rawType string) (interface{}, error) {
+	raw := data
+	if len(raw) != 32 {
+		text := strings.TrimSpace(string(data))
+		raw = nil
+		for _, decode := range []func(string) ([]byte, error){
+			hex.DecodeString,
+			base64.StdEncoding.DecodeString,
+			base64.RawURLEncoding.DecodeString,
+		} {
+			if decoded, err := decode(text); err == nil && len(decoded) == 32 {
+				raw = decoded
+				break
+			}
+		}
+		if raw == nil {
+			return nil, fmt.Errorf("invalid raw key: must be 32 bytes (raw, hex, or base64)")
+		}
+	}
+
+	switch rawType {
+	case RawKeyEd25519Private:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case RawKeyEd25519Public:
+		return ed25519.PublicKey(raw), nil
+	case RawKeyX25519Private:
+		return ecdh.X25519().NewPrivateKey(raw)
+	case RawKeyX25519Public:
+		return ecdh.X25519().NewPublicKey(raw)
+	default:
+		return nil, fmt.Errorf("invalid raw key type: %s (must be ed25519-private, ed25519-public, x25519-private, or x25519-public)", rawType)
+	}
+}
+
+// EncodeKey encodes a parsed key in the target format. Converting a private key
+// to PKIX extracts its public key.
+func EncodeKey(key interface{}, format string) ([]byte, error) {
+	if format == KeyFormatPKIX && isPrivateKey(key) {
+		key = key.(interface{ Public() crypto.PublicKey }).Public()
+	}
+	private := isPrivateKey(key)
+	switch format {
+	case KeyFormatPKCS1:
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}), nil
+		case *rsa.PublicKey:
+			return pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(k)}), nil
+		}
+		return nil, fmt.Errorf("PKCS#1 only holds RSA keys, not %s", keyTypeName(key))
+
+	case KeyFormatPKCS8, KeyFormatPKIX, KeyFormatDER:
+		if format == KeyFormatPKCS8 && !private {
+			return nil, fmt.Errorf("PKCS#8 holds private keys; use pkix for a public key")
+		}
+		var der []byte
+		var err error
+		blockType := "PUBLIC KEY"
+		if private {
+			der, err = x509.MarshalPKCS8PrivateKey(key)
+			blockType = "PRIVATE KEY"
+		} else {
+			der, err = x509.MarshalPKIXPublicKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", keyTypeName(key), err)
+		}
+		if format == KeyFormatDER {
+			return der, nil
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+
+	case KeyFormatRaw:
+		switch k := key.(type) {
+		case ed25519.PrivateKey:
+			return k.Seed(), nil
+		case ed25519.PublicKey:
+			return []byte(k), nil
+		case *ecdh.PrivateKey:
+			return k.Bytes(), nil
+		case *ecdh.PublicKey:
+			return k.Bytes(), nil
+		}
+		return nil, fmt.Errorf("raw format only holds Ed25519 and X25519 keys, not %s", keyTypeName(key))
+
+	case KeyFormatOpenSSH:
+		if private {
+			block, err := ssh.MarshalPrivateKey(key, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal OpenSSH private key: %w", err)
+			}
+			return pem.EncodeToMemory(block), nil
+		}
+		sshKey, err := ssh.NewPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal OpenSSH public key: %w", err)
+		}
+		return ssh.MarshalAuthorizedKey(sshKey), nil
+
+	default:
+		return nil, fmt.Errorf("invalid key format: %s (must be pkcs1, pkcs8, pkix, der, raw, or openssh)", format)
+	}
+}
+
+// isPrivateKey reports whether key is a private key
+func isPrivateKey(key interface{}) bool {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey, *ecdh.PrivateKey:
+		return true
+	}
+	return false
+}
+
+// keyTypeName returns a human-readable name for a parsed key
+func keyTypeName(key interface{}) string {
+	kind := "public"
+	if isPrivateKey(key) {
+		kind = "private"
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("RSA-%d %s key", k.N.BitLen(), kind)
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d %s key", k.N.BitLen(), kind)
+	case *ecdsa.PrivateKey:
+		return fmt.Sprintf("ECDSA %s %s key", k.Curve.Params().Name, kind)
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA %s %s key", k.Curve.Params().Name, kind)
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return "Ed25519 " + kind + " key"
+	case *ecdh.PrivateKey, *ecdh.PublicKey:
+		return "X25519 " + kind + " key"
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
+
+// ConvertKey converts a key to the target format and explains the conversion
+func ConvertKey(data []byte, rawType, target string) ([]byte, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	v.AddHeader("Key Format Conversion")
+
+	key, source, err := ParseKey(data, rawType)
+	if err != nil {
+		return nil, nil, err
+	}
+	v.AddStep(fmt.Sprintf("Detected: %s in %s format", keyTypeName(key), source))
+	v.AddArrow()
+
+	converted, err := EncodeKey(key, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	v.AddStep(fmt.Sprintf("Converted to %s format (%d bytes)", target, len(converted)))
+	v.AddSeparator()
+
+	v.AddNote("Format Notes:")
+	v.AddNote("1. PKCS#1 is RSA-only; PKCS#8 (private) and PKIX (public) wrap any algorithm with an identifier")
+	v.AddNote("2. DER is the binary encoding; PEM is the same bytes in Base64 with BEGIN/END lines")
+	v.AddNote("3. Raw Ed25519/X25519 keys are just 32 bytes and do not say which algorithm they belong to")
+	v.AddNote("4. Conversion never changes the key itself, only how it is wrapped")
+	if target == KeyFormatPKIX && isPrivateKey(key) {
+		v.AddStep("Only the public half of the private key was exported")
+	} else if isPrivateKey(key) {
+		v.AddStep("⚠️ The output contains private key material and is not encrypted")
+	}
+
+	return converted, v.GetSteps(), nil
+}
@@ -2,15 +2,35 @@ package crypto
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
+// keyArchiveTimeFormat is the timestamp layout used for archived key files. The fraction keeps
+// archives from rotations within the same second apart and still sorts by time.
+const keyArchiveTimeFormat = "20060102T150405.000000000"
+
+// maxKeyArchiveAttempts bounds the numbered names tried when an archive name is taken
+const maxKeyArchiveAttempts = 100
+
 // FileKeyManager implements key management using files
 type FileKeyManager struct {
-	keySize int
-	keyFile string
-	key     []byte
+	keySize      int
+	keyFile      string
+	key          []byte
+	maxKeyAge    time.Duration
+	rotated      bool
+	rotateReason string
+	archivedFile string
 }
 
 // NewFileKeyManager creates a new file-based key manager
@@ -27,11 +47,20 @@ func (m *FileKeyManager) LoadOrGenerateKey() error {
 	if key, err := os.ReadFile(m.keyFile); err == nil {
 		if len(key) == m.keySize/8 {
 			m.key = key
+			slog.Debug("loaded key", "file", m.keyFile, "bits", m.keySize)
+			if m.keyExpired() {
+				days := int(m.maxKeyAge / (24 * time.Hour))
+				return m.rotate(fmt.Sprintf("the previous key exceeded its maximum age of %d days", days))
+			}
 			return nil
 		}
 	}
 
-	// Generate new key
+	return m.generateKey()
+}
+
+// generateKey creates a fresh random key and saves it to the key file
+func (m *FileKeyManager) generateKey() error {
 	key := make([]byte, m.keySize/8)
 	if _, err := rand.Read(key); err != nil {
 		return fmt.Errorf("failed to generate key: %w", err)
@@ -43,6 +72,7 @@ func (m *FileKeyManager) LoadOrGenerateKey() error {
 	}
 
 	m.key = key
+	slog.Debug("generated key", "file", m.keyFile, "bits", m.keySize)
 	return nil
 }
 
@@ -64,3 +94,159 @@ func (m *FileKeyManager) SetKey(key []byte) error {
 	m.key = key
 	return nil
 }
+
+// SetMaxKeyAge sets how old a key file may get before it is rotated on load.
+// A value of zero or less disables automatic rotation.
+func (m *FileKeyManager) SetMaxKeyAge(days int) {
+	if days <= 0 {
+		m.maxKeyAge = 0
+		return
+	}
+	m.maxKeyAge = time.Duration(days) * 24 * time.Hour
+}
+
+// keyExpired reports whether the key file is older than the configured maximum age
+func (m *FileKeyManager) keyExpired() bool {
+	if m.maxKeyAge <= 0 {
+		return false
+	}
+	info, err := os.Stat(m.keyFile)
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) > m.maxKeyAge
+}
+
+// RotateKey archives the current key file to <file>.bak.<timestamp> and generates a fresh key
+func (m *FileKeyManager) RotateKey() error {
+	return m.rotate("a rotation was requested")
+}
+
+// rotate archives the current key and generates a fresh one, recording why for the note
+func (m *FileKeyManager) rotate(reason string) error {
+	if _, err := os.Stat(m.keyFile); err == nil {
+		archived, err := archiveKeyFile(m.keyFile, time.Now())
+		if err != nil {
+			return err
+		}
+		m.archivedFile = archived
+		slog.Debug("archived key", "file", m.keyFile, "archive", archived, "reason", reason)
+	}
+
+	if err := m.generateKey(); err != nil {
+		return err
+	}
+	m.rotated = true
+	m.rotateReason = reason
+	return nil
+}
+
+// archiveKeyFile moves keyFile to <file>.bak.<timestamp>, adding a counter if that name is
+// taken. The archive is hard-linked before the key file is removed, so an existing archive
+// is never overwritten.
+func archiveKeyFile(keyFile string, now time.Time) (string, error) {
+	base := fmt.Sprintf("%s.bak.%s", keyFile, now.Format(keyArchiveTimeFormat))
+	for i := 0; i < maxKeyArchiveAttempts; i++ {
+		archived := base
+		if i > 0 {
+			archived = fmt.Sprintf("%s-%d", base, i)
+		}
+		err := os.Link(keyFile, archived)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to archive key: %w", err)
+		}
+		if err := os.Remove(keyFile); err != nil {
+			return "", fmt.Errorf("failed to remove archived key: %w", err)
+		}
+		return archived, nil
+	}
+	return "", fmt.Errorf("failed to archive key: %s and %d numbered names already exist", base, maxKeyArchiveAttempts-1)
+}
+
+// Rotated reports whether the key was rotated by this key manager
+func (m *FileKeyManager) Rotated() bool {
+	return m.rotated
+}
+
+// RotationReason describes why the key was rotated, or is empty if it was not
+func (m *FileKeyManager) RotationReason() string {
+	return m.rotateReason
+}
+
+// ArchivedKeyFile returns the path the previous key was archived to, if any
+func (m *FileKeyManager) ArchivedKeyFile() string {
+	return m.archivedFile
+}
+
+// addKeyRotationNote adds a visualizer note when the key manager rotated its key
+func addKeyRotationNote(v *utils.Visualizer, km KeyManager) {
+	rotating, ok := km.(RotatingKeyManager)
+	if !ok || !rotating.Rotated() {
+		return
+	}
+	v.AddNote(fmt.Sprintf("🔄 Key rotated: %s, so a fresh key was generated", rotating.RotationReason()))
+	if archived := rotating.ArchivedKeyFile(); archived != "" {
+		v.AddNote(fmt.Sprintf("Previous key archived to %s", archived))
+	}
+	v.AddNote("Data encrypted under the old key must be decrypted with the archived key")
+}
+
+// OpenFileKeyManager opens an existing key file without generating a replacement
+func OpenFileKeyManager(keySize int, keyFile string) (*FileKeyManager, error) {
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	if len(key) != keySize/8 {
+		return nil, fmt.Errorf("invalid key size in %s: got %d bytes, want %d bytes", keyFile, len(key), keySize/8)
+	}
+	return &FileKeyManager{
+		keySize: keySize,
+		keyFile: keyFile,
+		key:     key,
+	}, nil
+}
+
+// KeyFile returns the path of the file backing this key manager
+func (m *FileKeyManager) KeyFile() string {
+	return m.keyFile
+}
+
+// ListKeyFiles lists the key files that sit next to keyFile and share its name,
+// such as archived keys left behind by rotation. The current key file comes first.
+func ListKeyFiles(keyFile string) ([]string, error) {
+	dir := filepath.Dir(keyFile)
+	base := filepath.Base(keyFile)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == base || !strings.HasPrefix(name, stem) || strings.HasSuffix(name, nonceCountSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	sort.Strings(files)
+
+	if _, err := os.Stat(keyFile); err == nil {
+		files = append([]string{keyFile}, files...)
+	}
+	return files, nil
+}
+
+// keyFileOf returns the backing file of a key manager, if it has one
+func keyFileOf(km KeyManager) string {
+	if fm, ok := km.(*FileKeyManager); ok {
+		return fm.KeyFile()
+	}
+	return ""
+}
@@ -0,0 +1,276 @@
+package crypto
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestFileKeyManager_RotateKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "test_key.bin")
+	manager := NewFileKeyManager(256, keyFile)
+	if err := manager.LoadOrGenerateKey(); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	oldKey := append([]byte(nil), manager.GetKey()...)
+
+	if err := manager.RotateKey(); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+
+	if !manager.Rotated() {
+		t.Error("Expected key manager to report rotation")
+	}
+	if bytes.Equal(oldKey, manager.GetKey()) {
+		t.Error("Rotated key should differ from the old key")
+	}
+
+	archived := manager.ArchivedKeyFile()
+	if !strings.HasPrefix(archived, keyFile+".bak.") {
+		t.Errorf("Unexpected archive path: %s", archived)
+	}
+	archivedKey, err := os.ReadFile(archived)
+	if err != nil {
+		t.Fatalf("Failed to read archived key: %v", err)
+	}
+	if !bytes.Equal(oldKey, archivedKey) {
+		t.Error("Archived key does not match the old key")
+	}
+}
+
+func TestArchiveKeyFile_NeverOverwrites(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "test_key.bin")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Two rotations at the same instant must keep both archives
+	var archives []string
+	for _, key := range [][]byte{bytes.Repeat([]byte{0x11}, 32), bytes.Repeat([]byte{0x22}, 32)} {
+		if err := os.WriteFile(keyFile, key, 0600); err != nil {
+			t.Fatalf("Failed to write key: %v", err)
+		}
+		archived, err := archiveKeyFile(keyFile, now)
+		if err != nil {
+			t.Fatalf("archiveKeyFile failed: %v", err)
+		}
+		archives = append(archives, archived)
+	}
+
+	if archives[0] == archives[1] {
+		t.Fatalf("Both rotations archived to %s", archives[0])
+	}
+	for i, want := range []byte{0x11, 0x22} {
+		data, err := os.ReadFile(archives[i])
+		if err != nil {
+			t.Fatalf("Failed to read archive: %v", err)
+		}
+		if data[0] != want {
+			t.Errorf("Archive %s holds the wrong key", archives[i])
+		}
+	}
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Error("Expected the key file to be moved to the archive")
+	}
+}
+
+func TestFileKeyManager_MaxKeyAge(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "test_key.bin")
+	manager := NewFileKeyManager(256, keyFile)
+	if err := manager.LoadOrGenerateKey(); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	// A fresh key is not rotated
+	fresh := NewFileKeyManager(256, keyFile)
+	fresh.SetMaxKeyAge(30)
+	if err := fresh.LoadOrGenerateKey(); err != nil {
+		t.Fatalf("Failed to load key: %v", err)
+	}
+	if fresh.Rotated() {
+		t.Error("Fresh key should not be rotated")
+	}
+
+	// Backdate the key file past the maximum age
+	old := time.Now().Add(-31 * 24 * time.Hour)
+	if err := os.Chtimes(keyFile, old, old); err != nil {
+		t.Fatalf("Failed to backdate key file: %v", err)
+	}
+
+	expired := NewFileKeyManager(256, keyFile)
+	expired.SetMaxKeyAge(30)
+	if err := expired.LoadOrGenerateKey(); err != nil {
+		t.Fatalf("Failed to load key: %v", err)
+	}
+	if !expired.Rotated() {
+		t.Error("Expired key should be rotated on load")
+	}
+	if bytes.Equal(manager.GetKey(), expired.GetKey()) {
+		t.Error("Expired key should have been replaced")
+	}
+}
+
+func TestAESProcessor_KeyRotationNote(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "aes_key.bin")
+	if err := os.WriteFile(keyFile, make([]byte, 32), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	if err := os.Chtimes(keyFile, old, old); err != nil {
+		t.Fatalf("Failed to backdate key file: %v", err)
+	}
+
+	processor := NewAESProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keyFile":       keyFile,
+		"maxKeyAgeDays": 7,
+	}); err != nil {
+		t.Fatalf("Failed to configure AES: %v", err)
+	}
+
+	_, steps, err := processor.Process("hello", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	found := false
+	for _, step := range steps {
+		if strings.Contains(step.Text, "Key rotated: the previous key exceeded its maximum age of 7 days") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected key rotation note in steps")
+	}
+
+	// A requested rotation says so instead of blaming the key's age
+	manager := NewFileKeyManager(256, keyFile)
+	if err := manager.RotateKey(); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	v := utils.NewVisualizer()
+	addKeyRotationNote(v, manager)
+	notes := strings.Join(utils.StepTexts(v.GetSteps()), "\n")
+	if !strings.Contains(notes, "a rotation was requested") || strings.Contains(notes, "maximum age") {
+		t.Errorf("Expected a note for a requested rotation, got %q", notes)
+	}
+}
+
+func TestListKeyFilesAndSelection(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "aes_key.bin")
+	currentKey := bytes.Repeat([]byte{0x11}, 32)
+	archivedKey := bytes.Repeat([]byte{0x22}, 32)
+	if err := os.WriteFile(keyFile, currentKey, 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	archivedFile := keyFile + ".bak.20240101T000000"
+	if err := os.WriteFile(archivedFile, archivedKey, 0600); err != nil {
+		t.Fatalf("Failed to write archived key: %v", err)
+	}
+	// Keys belonging to other processors must not be listed
+	if err := os.WriteFile(filepath.Join(dir, "hmac_key.bin"), currentKey, 0600); err != nil {
+		t.Fatalf("Failed to write unrelated key: %v", err)
+	}
+
+	files, err := ListKeyFiles(keyFile)
+	if err != nil {
+		t.Fatalf("ListKeyFiles failed: %v", err)
+	}
+	if len(files) != 2 || files[0] != keyFile || files[1] != archivedFile {
+		t.Fatalf("Unexpected key files: %v", files)
+	}
+
+	processor := NewAESProcessor()
+	if err := processor.Configure(map[string]interface{}{"keyFile": keyFile}); err != nil {
+		t.Fatalf("Failed to configure AES: %v", err)
+	}
+	if err := processor.UseKeyFile(files[1]); err != nil {
+		t.Fatalf("UseKeyFile failed: %v", err)
+	}
+	if !bytes.Equal(processor.keyManager.GetKey(), archivedKey) {
+		t.Error("Selected key bytes do not match the archived key")
+	}
+	if processor.KeyFile() != archivedFile {
+		t.Errorf("KeyFile = %s, want %s", processor.KeyFile(), archivedFile)
+	}
+
+	// The current key file must be left untouched
+	onDisk, err := os.ReadFile(keyFile)
+	if err != nil || !bytes.Equal(onDisk, currentKey) {
+		t.Error("Selecting a key should not modify the current key file")
+	}
+
+	// A missing key file is rejected instead of being generated
+	if err := processor.UseKeyFile(filepath.Join(dir, "missing.bin")); err == nil {
+		t.Error("Expected error for missing key file")
+	}
+}
+
+func TestSecureDeleteFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "test_key.bin")
+	manager := NewFileKeyManager(256, keyFile)
+	if err := manager.LoadOrGenerateKey(); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	// A hard link keeps the underlying data reachable after the key file is unlinked
+	link := filepath.Join(dir, "link.bin")
+	if err := os.Link(keyFile, link); err != nil {
+		t.Skipf("Hard links not supported: %v", err)
+	}
+
+	if err := SecureDeleteFile(keyFile); err != nil {
+		t.Fatalf("SecureDeleteFile failed: %v", err)
+	}
+
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Errorf("Expected key file to be removed, stat error = %v", err)
+	}
+	remaining, err := os.ReadFile(link)
+	if err != nil {
+		t.Fatalf("Failed to read linked file: %v", err)
+	}
+	if !bytes.Equal(remaining, make([]byte, len(manager.GetKey()))) {
+		t.Errorf("Expected key data to be overwritten with zeros, got %x", remaining)
+	}
+}
+
+func TestSecureDeleteKeys(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"aes_key.bin", "hmac_key.bin"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("secret key material"), 0600); err != nil {
+			t.Fatalf("Failed to write key file: %v", err)
+		}
+	}
+
+	files, err := ListKeyDirectory(dir)
+	if err != nil {
+		t.Fatalf("ListKeyDirectory failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 key files, got %v", files)
+	}
+
+	result, steps, err := SecureDeleteKeys(files)
+	if err != nil {
+		t.Fatalf("SecureDeleteKeys failed: %v", err)
+	}
+	if result != "Securely deleted 2 of 2 key file(s)" {
+		t.Errorf("Unexpected result: %s", result)
+	}
+	if !containsStep(steps, "SSDs") {
+		t.Error("Expected a note about SSD limitations")
+	}
+	if files, _ := ListKeyDirectory(dir); len(files) != 0 {
+		t.Errorf("Expected key directory to be empty, got %v", files)
+	}
+
+	if _, _, err := SecureDeleteKeys([]string{filepath.Join(dir, "missing.bin")}); err == nil {
+		t.Error("Expected error for a missing key file, got nil")
+	}
+}
@@ -0,0 +1,94 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// generatedKeyPatterns match the key files processors create on first use
+var generatedKeyPatterns = []string{
+	"aes_key.bin",
+	"hmac_key.bin",
+	"rsa_*.pem",
+	"jwt_*",
+	"dh_*",
+	"x25519_*",
+	"chacha20poly1305_key.bin*",
+	"aes_gcm_siv_key.bin",
+	"blowfish_key.bin",
+	"3des_*key.bin",
+	"rc4_key.bin",
+	"pbkdf_key.bin",
+}
+
+// isGeneratedKeyFile reports whether name is a generated key file; keys archived by
+// rotation are kept, since data encrypted under them may still need decrypting
+func isGeneratedKeyFile(name string) bool {
+	if strings.Contains(name, ".bak.") {
+		return false
+	}
+	for _, pattern := range generatedKeyPatterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratedKeyFiles lists the generated key files in dir; a missing dir has none
+func GeneratedKeyFiles(dir string) ([]string, error) {
+	files, err := ListKeyDirectory(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var generated []string
+	for _, file := range files {
+		if isGeneratedKeyFile(filepath.Base(file)) {
+			generated = append(generated, file)
+		}
+	}
+	return generated, nil
+}
+
+// ResetKeys deletes the given key files and drops keys cached in memory, so processors
+// generate fresh ones on next use
+func ResetKeys(files []string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	v.AddHeader("Key Reset")
+	v.AddNote("Generated key files are removed; each processor creates a new key the next time it runs")
+	v.AddSeparator()
+
+	deleted := 0
+	var failed []string
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			v.AddStep(fmt.Sprintf("❌ %s: %v", filepath.Base(file), err))
+			failed = append(failed, file)
+			continue
+		}
+		v.AddStep(fmt.Sprintf("🗑️ %s removed", filepath.Base(file)))
+		deleted++
+	}
+	resetDHSessionCache()
+	v.AddSeparator()
+
+	v.AddNote("Anything encrypted under the old keys can no longer be decrypted")
+	v.AddNote("Archived keys from rotation (*.bak.*) are kept; use --wipe-keys to securely erase everything")
+
+	result := fmt.Sprintf("Removed %d of %d key file(s)", deleted, len(files))
+	if len(failed) > 0 {
+		return result, v.GetSteps(), fmt.Errorf("failed to remove %d key file(s)", len(failed))
+	}
+	return result, v.GetSteps(), nil
+}
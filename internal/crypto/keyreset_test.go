@@ -0,0 +1,49 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{
+		"aes_key.bin",
+		"rsa_private.pem",
+		"jwt_es256_private.pem",
+		"dh_prime.bin",
+		"x25519_private.bin",
+		"chacha20poly1305_key.bin.nonces",
+		"aes_key.bin.bak.20240101-120000",
+		"notes.txt",
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("key"), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	files, err := GeneratedKeyFiles(dir)
+	if err != nil {
+		t.Fatalf("GeneratedKeyFiles() error = %v", err)
+	}
+	if len(files) != 6 {
+		t.Fatalf("Expected 6 generated key files, got %d: %v", len(files), files)
+	}
+
+	if _, _, err := ResetKeys(files); err != nil {
+		t.Fatalf("ResetKeys() error = %v", err)
+	}
+	remaining, err := ListKeyDirectory(dir)
+	if err != nil {
+		t.Fatalf("ListKeyDirectory() error = %v", err)
+	}
+	if len(remaining) != 2 {
+		t.Errorf("Expected the archive and unrelated file to remain, got %v", remaining)
+	}
+
+	if files, err := GeneratedKeyFiles(filepath.Join(dir, "missing")); err != nil || len(files) != 0 {
+		t.Errorf("Expected no files and no error for a missing dir, got %v, %v", files, err)
+	}
+}
@@ -0,0 +1,30 @@
+package crypto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain runs the package tests in a temporary directory, so the key files they generate
+// under keys/ never land in the source tree
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "cryptolens-crypto-test")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create test directory: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "keys"), 0700); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create keys directory: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Chdir(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to enter test directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
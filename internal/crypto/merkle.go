@@ -0,0 +1,360 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// Domain separation prefixes from RFC 6962, so a leaf can never be mistaken for an inner node
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// merkleNode is one node of a Merkle tree; leaves have no children
+type merkleNode struct {
+	hash        []byte
+	left, right *merkleNode
+	parent      *merkleNode
+	leaf        int // Leaf position, or -1 for inner nodes
+	data        string
+}
+
+// merkleProofStep is one sibling hash on the path from a leaf to the root
+type merkleProofStep struct {
+	left bool // The sibling is hashed on the left of the running hash
+	hash []byte
+}
+
+// MerkleTreeProcessor builds a Merkle tree over newline-separated inputs and
+// produces and verifies inclusion proofs for single leaves
+type MerkleTreeProcessor struct {
+	BaseConfigurableProcessor
+	hashAlgorithm string
+	leafIndex     int
+	proof         string
+}
+
+// NewMerkleTreeProcessor creates a new Merkle tree processor using SHA-256
+func NewMerkleTreeProcessor() *MerkleTreeProcessor {
+	return &MerkleTreeProcessor{
+		hashAlgorithm: HashSHA256,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *MerkleTreeProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if algorithm, ok := config["hashAlgorithm"].(string); ok && algorithm != "" {
+		switch algorithm {
+		case HashSHA1, HashSHA256, HashSHA384, HashSHA512, HashSHA3256, HashBLAKE3:
+			p.hashAlgorithm = algorithm
+		default:
+			return fmt.Errorf("unsupported Merkle tree hash algorithm: %s (must be one of: sha1, sha256, sha384, sha512, sha3-256, blake3)", algorithm)
+		}
+	}
+
+	if index, ok := config["leafIndex"].(int); ok {
+		if index < 0 {
+			return fmt.Errorf("invalid leaf index: %d (must be 0 or more)", index)
+		}
+		p.leafIndex = index
+	}
+
+	if proof, ok := config["proof"].(string); ok {
+		p.proof = strings.TrimSpace(proof)
+	}
+
+	return nil
+}
+
+// Process implements the Processor interface. Encrypt builds the tree and returns the
+// inclusion proof for the configured leaf; verify checks the configured proof for a leaf.
+func (p *MerkleTreeProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	newHash, err := newHashFunc(p.hashAlgorithm, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch operation {
+	case OperationEncrypt:
+		return p.build(text, newHash)
+	case OperationVerify:
+		return p.verify(text, newHash)
+	case OperationDecrypt:
+		return "", nil, fmt.Errorf("a Merkle tree cannot be decrypted; use the verify operation to check a proof")
+	default:
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+}
+
+// merkleLeaves splits the input into one leaf per non-empty line
+func merkleLeaves(text string) []string {
+	var leaves []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			leaves = append(leaves, line)
+		}
+	}
+	return leaves
+}
+
+// merkleLeafHash returns H(0x00 || data)
+func merkleLeafHash(newHash func() hash.Hash, data []byte) []byte {
+	h := newHash()
+	h.Write([]byte{merkleLeafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// merkleNodeHash returns H(0x01 || left || right)
+func merkleNodeHash(newHash func() hash.Hash, left, right []byte) []byte {
+	h := newHash()
+	h.Write([]byte{merkleNodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// buildMerkleTree hashes the leaves and pairs nodes level by level up to the root.
+// A node left without a partner moves up a level unchanged, as in RFC 6962.
+func buildMerkleTree(newHash func() hash.Hash, leaves []string) (*merkleNode, []*merkleNode) {
+	leafNodes := make([]*merkleNode, len(leaves))
+	for i, data := range leaves {
+		leafNodes[i] = &merkleNode{hash: merkleLeafHash(newHash, []byte(data)), leaf: i, data: data}
+	}
+
+	level := leafNodes
+	for len(level) > 1 {
+		var next []*merkleNode
+		for i := 0; i+1 < len(level); i += 2 {
+			left, right := level[i], level[i+1]
+			parent := &merkleNode{hash: merkleNodeHash(newHash, left.hash, right.hash), left: left, right: right, leaf: -1}
+			left.parent, right.parent = parent, parent
+			next = append(next, parent)
+		}
+		if len(level)%2 == 1 {
+			next = append(next, level[len(level)-1])
+		}
+		level = next
+	}
+	return level[0], leafNodes
+}
+
+// merkleProof collects the sibling hashes from a leaf up to the root
+func merkleProof(leaf *merkleNode) []merkleProofStep {
+	var proof []merkleProofStep
+	for node := leaf; node.parent != nil; node = node.parent {
+		if node.parent.left == node {
+			proof = append(proof, merkleProofStep{left: false, hash: node.parent.right.hash})
+		} else {
+			proof = append(proof, merkleProofStep{left: true, hash: node.parent.left.hash})
+		}
+	}
+	return proof
+}
+
+// formatMerkleProof encodes the root and the proof as "root:<hex>;proof:L<hex>,R<hex>"
+func formatMerkleProof(root []byte, proof []merkleProofStep) string {
+	steps := make([]string, len(proof))
+	for i, step := range proof {
+		side := "R"
+		if step.left {
+			side = "L"
+		}
+		steps[i] = side + hex.EncodeToString(step.hash)
+	}
+	return fmt.Sprintf("root:%s;proof:%s", hex.EncodeToString(root), strings.Join(steps, ","))
+}
+
+// parseMerkleProof decodes a proof written by formatMerkleProof
+func parseMerkleProof(encoded string) ([]byte, []merkleProofStep, error) {
+	rootPart, proofPart, ok := strings.Cut(encoded, ";")
+	if !ok || !strings.HasPrefix(rootPart, "root:") || !strings.HasPrefix(proofPart, "proof:") {
+		return nil, nil, fmt.Errorf("invalid proof: expected root:<hex>;proof:L<hex>,R<hex>")
+	}
+	root, err := hex.DecodeString(strings.TrimPrefix(rootPart, "root:"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid proof root: %w", err)
+	}
+
+	var proof []merkleProofStep
+	if steps := strings.TrimPrefix(proofPart, "proof:"); steps != "" {
+		for _, step := range strings.Split(steps, ",") {
+			if len(step) < 2 || (step[0] != 'L' && step[0] != 'R') {
+				return nil, nil, fmt.Errorf("invalid proof step %q: must start with L or R", step)
+			}
+			sibling, err := hex.DecodeString(step[1:])
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid proof step %q: %w", step, err)
+			}
+			proof = append(proof, merkleProofStep{left: step[0] == 'L', hash: sibling})
+		}
+	}
+	return root, proof, nil
+}
+
+// addMerkleTree draws the tree from the root down, marking the proof path and its siblings
+func addMerkleTree(v *utils.Visualizer, node *merkleNode, prefix, branch string, path, siblings map[*merkleNode]bool) {
+	label := "Node"
+	if node.parent == nil {
+		label = "Root"
+	}
+	if node.leaf >= 0 {
+		label = fmt.Sprintf("Leaf %d %q", node.leaf, node.data)
+	}
+	marker := ""
+	if path[node] {
+		marker = "  ◀ path"
+	} else if siblings[node] {
+		marker = "  ◆ proof"
+	}
+	v.AddStep(fmt.Sprintf("%s%s%s %s%s", prefix, branch, label, shortHex(node.hash), marker))
+
+	if node.left == nil {
+		return
+	}
+	childPrefix := prefix
+	switch branch {
+	case "├── ":
+		childPrefix += "│   "
+	case "└── ":
+		childPrefix += "    "
+	}
+	addMerkleTree(v, node.left, childPrefix, "├── ", path, siblings)
+	addMerkleTree(v, node.right, childPrefix, "└── ", path, siblings)
+}
+
+// build constructs the tree and returns the inclusion proof for the configured leaf
+func (p *MerkleTreeProcessor) build(text string, newHash func() hash.Hash) (string, []utils.Step, error) {
+	leaves := merkleLeaves(text)
+	if len(leaves) == 0 {
+		return "", nil, fmt.Errorf("no leaves: enter one item per line")
+	}
+	if p.leafIndex >= len(leaves) {
+		return "", nil, fmt.Errorf("invalid leaf index: %d (the tree has %d leaves)", p.leafIndex, len(leaves))
+	}
+	hashName := hashDisplayName(p.hashAlgorithm, 0)
+
+	v := utils.NewVisualizer()
+	v.AddHeader(fmt.Sprintf("Merkle Tree Construction (%s)", hashName))
+	v.AddNote("Each leaf is hashed, then pairs of hashes are hashed together until one root remains")
+	v.AddNote("The root commits to every leaf: changing any input changes the root")
+	v.AddSeparator()
+
+	// Step 1: hash the leaves
+	v.AddSubheader("Step 1: Leaf Hashes  H(0x00 || data)")
+	root, leafNodes := buildMerkleTree(newHash, leaves)
+	for _, leaf := range leafNodes {
+		v.AddHexStep(fmt.Sprintf("Leaf %d %q", leaf.leaf, leaf.data), leaf.hash)
+	}
+	v.AddSeparator()
+
+	// Step 2: pair nodes up to the root
+	v.AddSubheader("Step 2: Inner Nodes  H(0x01 || left || right)")
+	if len(leaves)&(len(leaves)-1) != 0 {
+		v.AddNote("The leaf count is not a power of two: an unpaired node moves up a level unchanged")
+	}
+	proof := merkleProof(leafNodes[p.leafIndex])
+	path, siblings := map[*merkleNode]bool{root: true}, map[*merkleNode]bool{}
+	for node := leafNodes[p.leafIndex]; node.parent != nil; node = node.parent {
+		path[node] = true
+		if node.parent.left == node {
+			siblings[node.parent.right] = true
+		} else {
+			siblings[node.parent.left] = true
+		}
+	}
+	addMerkleTree(v, root, "", "", path, siblings)
+	v.AddHexStep("Merkle Root", root.hash)
+	v.AddSeparator()
+
+	// Step 3: the inclusion proof
+	v.AddSubheader(fmt.Sprintf("Step 3: Inclusion Proof for Leaf %d %q", p.leafIndex, leaves[p.leafIndex]))
+	v.AddStep(fmt.Sprintf("The proof holds %d sibling hashes (◆), one per level, instead of all %d leaves", len(proof), len(leaves)))
+	for i, step := range proof {
+		side := "right"
+		if step.left {
+			side = "left"
+		}
+		v.AddHexStep(fmt.Sprintf("Level %d sibling (%s)", i+1, side), step.hash)
+	}
+	encoded := formatMerkleProof(root.hash, proof)
+	v.AddTextStep("Encoded Proof", encoded)
+	v.AddSeparator()
+
+	v.AddNote("A verifier with only the root, the leaf, and the proof can recompute the path (◀) to the root")
+	v.AddNote("The 0x00/0x01 prefixes stop an inner node from being passed off as a leaf (second preimage)")
+	v.AddNote("Duplicating the last node instead (as Bitcoin does) lets two different lists share a root")
+
+	return encoded, v.GetSteps(), nil
+}
+
+// verify recomputes the root from a leaf and the configured proof
+func (p *MerkleTreeProcessor) verify(text string, newHash func() hash.Hash) (string, []utils.Step, error) {
+	if p.proof == "" {
+		return "", nil, fmt.Errorf("no proof to verify against")
+	}
+	root, proof, err := parseMerkleProof(p.proof)
+	if err != nil {
+		return "", nil, err
+	}
+	hashName := hashDisplayName(p.hashAlgorithm, 0)
+
+	v := utils.NewVisualizer()
+	v.AddHeader(fmt.Sprintf("Merkle Inclusion Proof Verification (%s)", hashName))
+	v.AddNote("The verifier hashes the leaf and combines it with each sibling in the proof")
+	v.AddNote("The leaf is included if the result equals the trusted root")
+	v.AddSeparator()
+
+	v.AddTextStep("Leaf", text)
+	current := merkleLeafHash(newHash, []byte(text))
+	v.AddHexStep("H(0x00 || leaf)", current)
+	for i, step := range proof {
+		if step.left {
+			current = merkleNodeHash(newHash, step.hash, current)
+			v.AddHexStep(fmt.Sprintf("Level %d: H(0x01 || sibling || current)", i+1), current)
+		} else {
+			current = merkleNodeHash(newHash, current, step.hash)
+			v.AddHexStep(fmt.Sprintf("Level %d: H(0x01 || current || sibling)", i+1), current)
+		}
+	}
+	v.AddArrow()
+	v.AddHexStep("Computed Root", current)
+	v.AddHexStep("Expected Root", root)
+	v.AddArrow()
+
+	result := "Proof is invalid"
+	if bytes.Equal(current, root) {
+		v.AddStep("✅ Roots match: the leaf is part of the tree")
+		result = "Proof is valid"
+	} else {
+		v.AddStep("❌ Roots differ: the leaf, the proof, or the root was changed, or a different hash was used")
+	}
+
+	return result, v.GetSteps(), nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *MerkleTreeProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "hashAlgorithm", Description: "Hash for leaves and inner nodes", Value: p.hashAlgorithm},
+		{Name: "leafIndex", Description: "Leaf (from 0) to build an inclusion proof for", Value: p.leafIndex},
+	}
+}
+
+// AuditSources implements the AuditableProcessor interface
+func (p *MerkleTreeProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		{Kind: AuditInRepository, Package: "internal/crypto/merkle.go", Purpose: "Tree construction, inclusion proofs, and verification"},
+	}
+}
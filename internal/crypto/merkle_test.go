@@ -0,0 +1,115 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestMerkleTreeProcessor_Root(t *testing.T) {
+	// Three leaves: the third is unpaired and moves up a level unchanged
+	leaf := func(data string) []byte {
+		sum := sha256.Sum256(append([]byte{0x00}, data...))
+		return sum[:]
+	}
+	node := func(left, right []byte) []byte {
+		sum := sha256.Sum256(append(append([]byte{0x01}, left...), right...))
+		return sum[:]
+	}
+	root := node(node(leaf("a"), leaf("b")), leaf("c"))
+
+	processor := NewMerkleTreeProcessor()
+	if err := processor.Configure(map[string]interface{}{"leafIndex": 2}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	result, steps, err := processor.Process("a\nb\nc\n", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := "root:" + hex.EncodeToString(root) + ";proof:L" + hex.EncodeToString(node(leaf("a"), leaf("b")))
+	if result != want {
+		t.Errorf("proof = %s, want %s", result, want)
+	}
+
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{"Merkle Tree Construction (SHA-256)", "Root ", "└── Leaf 2 \"c\"", "◀ path", "◆ proof", "Step 3: Inclusion Proof for Leaf 2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected steps to contain %q", want)
+		}
+	}
+}
+
+func TestMerkleTreeProcessor_ProofRoundTrip(t *testing.T) {
+	leaves := []string{"alice", "bob", "carol", "dave", "erin"}
+	for _, algorithm := range []string{HashSHA256, HashSHA3256, HashBLAKE3} {
+		for i, leaf := range leaves {
+			builder := NewMerkleTreeProcessor()
+			if err := builder.Configure(map[string]interface{}{"hashAlgorithm": algorithm, "leafIndex": i}); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			proof, _, err := builder.Process(strings.Join(leaves, "\n"), OperationEncrypt)
+			if err != nil {
+				t.Fatalf("%s leaf %d: Process() error = %v", algorithm, i, err)
+			}
+
+			verifier := NewMerkleTreeProcessor()
+			if err := verifier.Configure(map[string]interface{}{"hashAlgorithm": algorithm, "proof": proof}); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			result, _, err := verifier.Process(leaf, OperationVerify)
+			if err != nil {
+				t.Fatalf("%s leaf %d: verify error = %v", algorithm, i, err)
+			}
+			if result != "Proof is valid" {
+				t.Errorf("%s leaf %d: result = %q, want a valid proof", algorithm, i, result)
+			}
+
+			// The proof does not hold for a different leaf
+			result, _, err = verifier.Process(leaf+"!", OperationVerify)
+			if err != nil {
+				t.Fatalf("%s leaf %d: verify error = %v", algorithm, i, err)
+			}
+			if result != "Proof is invalid" {
+				t.Errorf("%s leaf %d: tampered leaf result = %q, want an invalid proof", algorithm, i, result)
+			}
+		}
+	}
+}
+
+func TestMerkleTreeProcessor_Errors(t *testing.T) {
+	processor := NewMerkleTreeProcessor()
+	if err := processor.Configure(map[string]interface{}{"hashAlgorithm": "md5"}); err == nil {
+		t.Error("Expected an error for an unsupported hash")
+	}
+	if err := processor.Configure(map[string]interface{}{"leafIndex": -1}); err == nil {
+		t.Error("Expected an error for a negative leaf index")
+	}
+	if _, _, err := processor.Process("\n\n", OperationEncrypt); err == nil {
+		t.Error("Expected an error for empty input")
+	}
+	if _, _, err := processor.Process("a", OperationVerify); err == nil {
+		t.Error("Expected an error when no proof is configured")
+	}
+	if _, _, err := processor.Process("a", OperationDecrypt); err == nil {
+		t.Error("Expected an error for decryption")
+	}
+
+	if err := processor.Configure(map[string]interface{}{"leafIndex": 3}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if _, _, err := processor.Process("a\nb", OperationEncrypt); err == nil {
+		t.Error("Expected an error for a leaf index past the last leaf")
+	}
+
+	for _, proof := range []string{"abc", "root:zz;proof:", "root:00;proof:X00", "root:00;proof:Lzz"} {
+		if err := processor.Configure(map[string]interface{}{"proof": proof}); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		if _, _, err := processor.Process("a", OperationVerify); err == nil {
+			t.Errorf("Expected an error for proof %q", proof)
+		}
+	}
+}
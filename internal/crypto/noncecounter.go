@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Random 96-bit nonces collide with probability about n²/2⁹⁷ after n encryptions.
+// NIST SP 800-38D caps random-nonce use at 2^32 encryptions per key.
+const (
+	randomNonceSafeLimit   uint64 = 1 << 32
+	randomNonceWarnAtLimit uint64 = randomNonceSafeLimit / 2
+)
+
+// nonceCountSuffix is appended to a key file name to store its nonce count
+const nonceCountSuffix = ".nonces"
+
+// nonceCounter keeps a rough persistent count of random-nonce encryptions under one key
+type nonceCounter struct {
+	path string
+}
+
+// newNonceCounter creates a counter stored at path
+func newNonceCounter(path string) *nonceCounter {
+	return &nonceCounter{path: path}
+}
+
+// Count returns the persisted count, treating a missing or unreadable file as zero
+func (c *nonceCounter) Count() uint64 {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return 0
+	}
+	count, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// Increment adds one encryption to the count and persists it
+func (c *nonceCounter) Increment() (uint64, error) {
+	count := c.Count() + 1
+	if err := os.WriteFile(c.path, []byte(strconv.FormatUint(count, 10)), 0600); err != nil {
+		return count, fmt.Errorf("failed to save nonce count: %w", err)
+	}
+	return count, nil
+}
+
+// Reset clears the count, e.g. after the key was rotated
+func (c *nonceCounter) Reset() error {
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to reset nonce count: %w", err)
+	}
+	return nil
+}
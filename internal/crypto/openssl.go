@@ -0,0 +1,151 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// OpenSSL "Salted__" format: magic header, 8-byte salt, then the CBC ciphertext
+const (
+	opensslMagic    = "Salted__"
+	opensslSaltSize = 8
+)
+
+// evpBytesToKey derives a key and IV from a passphrase like OpenSSL's EVP_BytesToKey with
+// one iteration: D_i = HASH(D_(i-1) || passphrase || salt), concatenated until long enough
+func evpBytesToKey(newHash func() hash.Hash, passphrase, salt []byte, keyLen, ivLen int) ([]byte, []byte) {
+	var derived, prev []byte
+	for len(derived) < keyLen+ivLen {
+		h := newHash()
+		h.Write(prev)
+		h.Write(passphrase)
+		h.Write(salt)
+		prev = h.Sum(nil)
+		derived = append(derived, prev...)
+	}
+	return derived[:keyLen], derived[keyLen : keyLen+ivLen]
+}
+
+// opensslEncrypt encrypts plaintext into the raw "Salted__" format with AES-CBC
+func opensslEncrypt(passphrase string, plaintext []byte, keySize int, salt []byte) ([]byte, error) {
+	key, iv := evpBytesToKey(sha256.New, []byte(passphrase), salt, keySize/8, aes.BlockSize)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	padded := pkcs7Pad(plaintext, aes.BlockSize)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(padded, padded)
+
+	out := make([]byte, 0, len(opensslMagic)+len(salt)+len(padded))
+	out = append(out, opensslMagic...)
+	out = append(out, salt...)
+	return append(out, padded...), nil
+}
+
+// opensslDecrypt decrypts the raw "Salted__" format with AES-CBC
+func opensslDecrypt(passphrase string, data []byte, keySize int) ([]byte, error) {
+	headerSize := len(opensslMagic) + opensslSaltSize
+	if len(data) < headerSize || !bytes.HasPrefix(data, []byte(opensslMagic)) {
+		return nil, fmt.Errorf("missing OpenSSL %q header", opensslMagic)
+	}
+	ciphertext := data[headerSize:]
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("ciphertext is not a multiple of the block size")
+	}
+
+	key, iv := evpBytesToKey(sha256.New, []byte(passphrase), data[len(opensslMagic):headerSize], keySize/8, aes.BlockSize)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+	unpadded, err := pkcs7Unpad(plaintext, aes.BlockSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpad (wrong passphrase?): %w", err)
+	}
+	return unpadded, nil
+}
+
+// opensslCommand returns the openssl enc invocation matching the processor's settings
+func opensslCommand(keySize int, decrypt bool) string {
+	direction := "-e"
+	if decrypt {
+		direction = "-d"
+	}
+	return fmt.Sprintf("openssl enc %s -aes-%d-cbc -md sha256 -a -A -pass pass:<passphrase>", direction, keySize)
+}
+
+// processOpenSSL encrypts or decrypts in the format produced by openssl enc
+func (p *AESProcessor) processOpenSSL(v *utils.Visualizer, text, operation string) (string, []utils.Step, error) {
+	if p.passphrase == "" {
+		return "", nil, fmt.Errorf("no passphrase configured for OpenSSL-compatible mode")
+	}
+
+	v.AddStep("OpenSSL-Compatible Format:")
+	v.AddStep(fmt.Sprintf("  %q (8 bytes) || salt (8 bytes) || AES-%d-CBC ciphertext, Base64 encoded", opensslMagic, p.keySize))
+	v.AddStep("Key Derivation (EVP_BytesToKey, SHA-256, 1 iteration):")
+	v.AddStep("  D1 = SHA256(passphrase || salt)")
+	v.AddStep("  D2 = SHA256(D1 || passphrase || salt)")
+	v.AddStep(fmt.Sprintf("  key = first %d bytes of D1 || D2, IV = next 16 bytes", p.keySize/8))
+	v.AddNote("This is what openssl enc uses without -pbkdf2; a single hash is fast to brute-force")
+	v.AddSeparator()
+
+	if operation == OperationDecrypt {
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+		}
+		v.AddHexStep("Decoded Data", data)
+		if len(data) >= len(opensslMagic)+opensslSaltSize {
+			salt := data[len(opensslMagic) : len(opensslMagic)+opensslSaltSize]
+			key, iv := evpBytesToKey(sha256.New, []byte(p.passphrase), salt, p.keySize/8, aes.BlockSize)
+			v.AddHexStep("Salt", salt)
+			v.AddHexStep("Derived Key", key)
+			v.AddHexStep("Derived IV", iv)
+			v.AddArrow()
+		}
+
+		plaintext, err := opensslDecrypt(p.passphrase, data, p.keySize)
+		if err != nil {
+			return "", nil, err
+		}
+		v.AddTextStep("Decrypted Text", string(plaintext))
+		v.AddSeparator()
+		v.AddStep("Equivalent OpenSSL command:")
+		v.AddStep("  " + opensslCommand(p.keySize, false))
+		return string(plaintext), v.GetSteps(), nil
+	}
+
+	salt := make([]byte, opensslSaltSize)
+	if _, err := io.ReadFull(randomSource(p.rand), salt); err != nil {
+		return "", nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, iv := evpBytesToKey(sha256.New, []byte(p.passphrase), salt, p.keySize/8, aes.BlockSize)
+	v.AddTextStep("Input Text", text)
+	v.AddHexStep("Random Salt", salt)
+	v.AddHexStep("Derived Key", key)
+	v.AddHexStep("Derived IV", iv)
+	v.AddArrow()
+
+	data, err := opensslEncrypt(p.passphrase, []byte(text), p.keySize, salt)
+	if err != nil {
+		return "", nil, err
+	}
+	v.AddHexStep("Salted__ || Salt || Ciphertext", data)
+	encoded := base64.StdEncoding.EncodeToString(data)
+	v.AddTextStep("Base64 Encoded Result", encoded)
+	v.AddSeparator()
+	v.AddStep("Decrypt with OpenSSL:")
+	v.AddStep("  echo '<result>' | " + opensslCommand(p.keySize, true))
+	return encoded, v.GetSteps(), nil
+}
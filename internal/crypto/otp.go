@@ -0,0 +1,216 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// otpReuseMessage is encrypted under the same pad to show what a two-time pad leaks
+const otpReuseMessage = "Meet me at the usual place at noon"
+
+// OneTimePadProcessor demonstrates the one-time pad: plaintext XOR a truly random key of the same length
+type OneTimePadProcessor struct {
+	BaseConfigurableProcessor
+	key      []byte
+	usedPads map[string]bool
+	rand     io.Reader
+}
+
+// NewOneTimePadProcessor creates a new one-time pad processor
+func NewOneTimePadProcessor() *OneTimePadProcessor {
+	return &OneTimePadProcessor{
+		usedPads: make(map[string]bool),
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *OneTimePadProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if random, ok := config["rand"].(io.Reader); ok {
+		p.rand = random
+	}
+
+	// Configure the pad (Base64) used to decrypt, or to encrypt instead of a fresh one
+	if key, ok := config["key"].(string); ok {
+		if key == "" {
+			p.key = nil
+			return nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+		if err != nil {
+			return fmt.Errorf("invalid key: must be Base64: %w", err)
+		}
+		p.key = decoded
+	}
+
+	return nil
+}
+
+// Process implements the Processor interface. Encryption returns the ciphertext and the pad,
+// each in Base64 on its own line; decryption takes the ciphertext and needs the pad configured as "key".
+func (p *OneTimePadProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	if text == "" {
+		return "", nil, fmt.Errorf("empty input")
+	}
+	if operation != OperationEncrypt && operation != OperationDecrypt {
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	v := utils.NewVisualizer()
+	v.AddHeader("🔐 One-Time Pad Process")
+	v.AddStep("Ciphertext = Plaintext XOR Key, where the key is as long as the message")
+	v.AddNote("The only cipher with perfect secrecy (Shannon, 1949): the ciphertext reveals nothing")
+	v.AddNote("about the plaintext except its length, even to an attacker with unlimited computing power")
+	v.AddSeparator()
+
+	v.AddStep("Perfect Secrecy Conditions:")
+	v.AddStep("1. The key is truly random (here: crypto/rand)")
+	v.AddStep("2. The key is at least as long as the message")
+	v.AddStep("3. The key is never reused, not even in part")
+	v.AddStep("4. The key is kept secret and shared in advance over a secure channel")
+	v.AddNote("Break any one of these and the security is gone, not just weakened")
+	v.AddSeparator()
+
+	if operation == OperationDecrypt {
+		return p.decrypt(text, v)
+	}
+	return p.encrypt(text, v)
+}
+
+func (p *OneTimePadProcessor) encrypt(text string, v *utils.Visualizer) (string, []utils.Step, error) {
+	plaintext := []byte(text)
+	v.AddSubheader("Step 1: Input")
+	v.AddTextStep("Input Text", text)
+	v.AddHexStep("Plaintext Bytes", plaintext)
+	v.AddArrow()
+
+	v.AddSubheader("Step 2: Key")
+	key, err := p.padFor(plaintext, v)
+	if err != nil {
+		return "", nil, err
+	}
+	v.AddHexStep(fmt.Sprintf("Key (%d bytes)", len(key)), key)
+	v.AddArrow()
+
+	ciphertext := xorBytes(plaintext, key)
+	v.AddSubheader("Step 3: Plaintext XOR Key")
+	addXORSteps(v, plaintext, key, ciphertext)
+	v.AddHexStep("Ciphertext", ciphertext)
+	v.AddArrow()
+
+	p.addReuseDemo(v, plaintext, key, ciphertext)
+
+	result := fmt.Sprintf("Ciphertext: %s\nKey: %s",
+		base64.StdEncoding.EncodeToString(ciphertext),
+		base64.StdEncoding.EncodeToString(key))
+	v.AddStep("Output: the ciphertext and the key, both in Base64")
+	v.AddStep("Decryption needs both; the key must reach the recipient secretly and then be destroyed")
+
+	return result, v.GetSteps(), nil
+}
+
+// padFor returns a fresh random pad, or the configured one with a reuse warning
+func (p *OneTimePadProcessor) padFor(plaintext []byte, v *utils.Visualizer) ([]byte, error) {
+	if p.key == nil {
+		key := make([]byte, len(plaintext))
+		if _, err := io.ReadFull(randomSource(p.rand), key); err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+		p.usedPads[hex.EncodeToString(key)] = true
+		v.AddStep("✅ Generated a fresh random key of the same length as the message")
+		return key, nil
+	}
+
+	if len(p.key) < len(plaintext) {
+		return nil, fmt.Errorf("key too short: %d bytes for a %d-byte message (a one-time pad must be at least as long as the message)", len(p.key), len(plaintext))
+	}
+	key := p.key[:len(plaintext)]
+	v.AddStep("⚠️ WARNING: Using a supplied key instead of a fresh one")
+	if p.usedPads[hex.EncodeToString(key)] {
+		v.AddStep("❌ KEY REUSE: this pad has already encrypted a message in this session")
+		v.AddStep("❌ This is now a two-time pad and offers no secrecy (see the reuse demo below)")
+	} else {
+		v.AddStep("⚠️ If this key has ever encrypted anything else, this is a two-time pad")
+	}
+	p.usedPads[hex.EncodeToString(key)] = true
+	return key, nil
+}
+
+// addXORSteps shows the byte-by-byte XOR for the first few bytes
+func addXORSteps(v *utils.Visualizer, data, key, output []byte) {
+	for i := 0; i < len(data) && i < 8; i++ {
+		v.AddStep(fmt.Sprintf("  %08b XOR %08b = %08b", data[i], key[i], output[i]))
+	}
+	if len(data) > 8 {
+		v.AddStep(fmt.Sprintf("  ... (%d more bytes)", len(data)-8))
+	}
+}
+
+// addReuseDemo encrypts a second message under the same pad to show what key reuse leaks
+func (p *OneTimePadProcessor) addReuseDemo(v *utils.Visualizer, plaintext, key, ciphertext []byte) {
+	v.AddSeparator()
+	v.AddHeader("🚨 Why the Key Must Never Be Reused")
+	second := []byte(otpReuseMessage)
+	n := min(len(second), len(key))
+	secondCiphertext := xorBytes(second[:n], key[:n])
+	leaked := xorBytes(ciphertext[:n], secondCiphertext)
+
+	v.AddStep("Suppose the same key also encrypts a second message:")
+	v.AddTextStep("Second Message", string(second[:n]))
+	v.AddHexStep("Second Ciphertext", secondCiphertext)
+	v.AddStep("An eavesdropper XORs the two ciphertexts and the key cancels out:")
+	v.AddStep("  C1 XOR C2 = (P1 XOR K) XOR (P2 XOR K) = P1 XOR P2")
+	v.AddHexStep("C1 XOR C2", leaked)
+	v.AddHexStep("P1 XOR P2", xorBytes(plaintext[:n], second[:n]))
+	v.AddStep("Guessing a word in one message (crib dragging) now reveals the other message at that spot,")
+	v.AddStep("and knowing either plaintext reveals the key and the other plaintext completely")
+	v.AddNote("This is the Venona break of reused Soviet pads, and the same failure as nonce reuse")
+	v.AddNote("in stream ciphers and AEADs (see Attack Simulations → Nonce Reuse)")
+}
+
+func (p *OneTimePadProcessor) decrypt(text string, v *utils.Visualizer) (string, []utils.Step, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(strings.TrimPrefix(text, "Ciphertext:")))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+	}
+	if p.key == nil {
+		return "", nil, fmt.Errorf("no key: one-time pad decryption needs the key printed at encryption")
+	}
+	if len(p.key) < len(ciphertext) {
+		return "", nil, fmt.Errorf("key too short: %d bytes for a %d-byte ciphertext", len(p.key), len(ciphertext))
+	}
+	key := p.key[:len(ciphertext)]
+
+	v.AddSubheader("Step 1: Input")
+	v.AddTextStep("Ciphertext (Base64)", text)
+	v.AddHexStep("Ciphertext", ciphertext)
+	v.AddHexStep("Key", key)
+	v.AddArrow()
+
+	plaintext := xorBytes(ciphertext, key)
+	v.AddSubheader("Step 2: Ciphertext XOR Key")
+	addXORSteps(v, ciphertext, key, plaintext)
+	v.AddTextStep("Decrypted Text", string(plaintext))
+	v.AddSeparator()
+	v.AddNote("Any key of the right length \"decrypts\" to some message: without the real key,")
+	v.AddNote("every plaintext of this length is equally likely. That is perfect secrecy.")
+	v.AddNote("There is no integrity: flipping a ciphertext bit flips the same plaintext bit")
+
+	return string(plaintext), v.GetSteps(), nil
+}
+
+// AuditSources implements the AuditableProcessor interface
+func (p *OneTimePadProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Random pad as long as the message"},
+		{Kind: AuditInRepository, Package: "internal/crypto/otp.go", Purpose: "XOR of the message with the pad"},
+	}
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestOneTimePadProcessor_Process(t *testing.T) {
+	processor := NewOneTimePadProcessor()
+	plaintext := "Attack at dawn"
+
+	result, steps, err := processor.Process(plaintext, OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	lines := strings.Split(result, "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "Ciphertext: ") || !strings.HasPrefix(lines[1], "Key: ") {
+		t.Fatalf("Expected ciphertext and key lines, got %q", result)
+	}
+	ciphertext := strings.TrimPrefix(lines[0], "Ciphertext: ")
+	key := strings.TrimPrefix(lines[1], "Key: ")
+	if decoded, _ := base64.StdEncoding.DecodeString(key); len(decoded) != len(plaintext) {
+		t.Errorf("Expected a %d-byte key, got %d", len(plaintext), len(decoded))
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "C1 XOR C2") {
+		t.Error("Expected the key reuse demo in the steps")
+	}
+
+	if _, _, err := processor.Process(ciphertext, OperationDecrypt); err == nil {
+		t.Error("Expected decryption without a key to fail")
+	}
+	if err := processor.Configure(map[string]interface{}{"key": key}); err != nil {
+		t.Fatalf("Failed to configure key: %v", err)
+	}
+	decrypted, _, err := processor.Process(ciphertext, OperationDecrypt)
+	if err != nil || decrypted != plaintext {
+		t.Errorf("Decryption = %q, %v, want %q", decrypted, err, plaintext)
+	}
+}
+
+func TestOneTimePadProcessor_KeyReuse(t *testing.T) {
+	processor := NewOneTimePadProcessor()
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x5a}, 8))
+	if err := processor.Configure(map[string]interface{}{"key": key}); err != nil {
+		t.Fatalf("Failed to configure key: %v", err)
+	}
+
+	_, steps, err := processor.Process("first", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	if strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "KEY REUSE") {
+		t.Error("Did not expect a reuse error on first use")
+	}
+	_, steps, err = processor.Process("first", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "KEY REUSE") {
+		t.Error("Expected a reuse error when the pad encrypts twice")
+	}
+
+	if _, _, err := processor.Process("longer than eight bytes", OperationEncrypt); err == nil {
+		t.Error("Expected an error for a key shorter than the message")
+	}
+	if err := processor.Configure(map[string]interface{}{"key": "not base64!"}); err == nil {
+		t.Error("Expected an error for a key that is not Base64")
+	}
+}
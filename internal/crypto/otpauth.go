@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Defaults of the otpauth key URI format, which authenticator apps assume when a
+// parameter is missing
+const (
+	otpAuthDefaultAlgorithm = "SHA1"
+	otpAuthDefaultDigits    = 6
+	otpAuthDefaultPeriod    = 30
+)
+
+// OTPAuthParams describes a TOTP secret for an otpauth:// provisioning URI
+type OTPAuthParams struct {
+	Issuer    string // Service name shown by the authenticator app, e.g. "CryptoLens"
+	Account   string // Account name, usually an email address
+	Secret    []byte // Shared HMAC key
+	Algorithm string // SHA1, SHA256, or SHA512; empty means SHA1
+	Digits    int    // 6 or 8; 0 means 6
+	Period    int    // Seconds per code; 0 means 30
+}
+
+// OTPAuthURI returns the otpauth://totp/ URI that authenticator apps import, usually by
+// scanning it as a QR code. The secret is Base32 without padding, as the format requires.
+func OTPAuthURI(params OTPAuthParams) (string, error) {
+	if params.Account == "" {
+		return "", fmt.Errorf("otpauth URI needs an account name")
+	}
+	if len(params.Secret) == 0 {
+		return "", fmt.Errorf("otpauth URI needs a secret")
+	}
+	if strings.Contains(params.Issuer, ":") || strings.Contains(params.Account, ":") {
+		return "", fmt.Errorf("otpauth issuer and account cannot contain ':', which separates them in the label")
+	}
+
+	algorithm := strings.ToUpper(params.Algorithm)
+	if algorithm == "" {
+		algorithm = otpAuthDefaultAlgorithm
+	}
+	switch algorithm {
+	case "SHA1", "SHA256", "SHA512":
+	default:
+		return "", fmt.Errorf("unsupported otpauth algorithm: %s (must be SHA1, SHA256, or SHA512)", params.Algorithm)
+	}
+
+	digits := params.Digits
+	if digits == 0 {
+		digits = otpAuthDefaultDigits
+	}
+	if digits != 6 && digits != 8 {
+		return "", fmt.Errorf("invalid otpauth digits: %d (must be 6 or 8)", digits)
+	}
+
+	period := params.Period
+	if period == 0 {
+		period = otpAuthDefaultPeriod
+	}
+	if period < 0 {
+		return "", fmt.Errorf("invalid otpauth period: %d (must be positive)", period)
+	}
+
+	label := url.PathEscape(params.Account)
+	if params.Issuer != "" {
+		label = url.PathEscape(params.Issuer) + ":" + label
+	}
+
+	query := url.Values{}
+	query.Set("secret", base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(params.Secret))
+	if params.Issuer != "" {
+		// Apps prefer the issuer parameter; the label prefix is kept for older apps
+		query.Set("issuer", params.Issuer)
+	}
+	query.Set("algorithm", algorithm)
+	query.Set("digits", strconv.Itoa(digits))
+	query.Set("period", strconv.Itoa(period))
+
+	return "otpauth://totp/" + label + "?" + query.Encode(), nil
+}
@@ -0,0 +1,64 @@
+package crypto
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestOTPAuthURI(t *testing.T) {
+	uri, err := OTPAuthURI(OTPAuthParams{
+		Issuer:  "CryptoLens",
+		Account: "alice@example.com",
+		Secret:  []byte("Hello!\xde\xad\xbe\xef"),
+	})
+	if err != nil {
+		t.Fatalf("OTPAuthURI() error = %v", err)
+	}
+	want := "otpauth://totp/CryptoLens:alice@example.com?algorithm=SHA1&digits=6&issuer=CryptoLens&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("OTPAuthURI() = %s, want %s", uri, want)
+	}
+
+	// Spaces in the label are escaped and the parameters round-trip through a URL parser
+	uri, err = OTPAuthURI(OTPAuthParams{
+		Issuer:    "Example Co",
+		Account:   "bob smith",
+		Secret:    []byte{0x01, 0x02, 0x03},
+		Algorithm: "sha256",
+		Digits:    8,
+		Period:    60,
+	})
+	if err != nil {
+		t.Fatalf("OTPAuthURI() error = %v", err)
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("url.Parse(%s) error = %v", uri, err)
+	}
+	if parsed.Scheme != "otpauth" || parsed.Host != "totp" || parsed.Path != "/Example Co:bob smith" {
+		t.Errorf("unexpected scheme, type or label in %s", uri)
+	}
+	query := parsed.Query()
+	for key, want := range map[string]string{"secret": "AEBAG", "issuer": "Example Co", "algorithm": "SHA256", "digits": "8", "period": "60"} {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestOTPAuthURI_Errors(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	tests := []OTPAuthParams{
+		{Issuer: "CryptoLens", Secret: secret},
+		{Issuer: "CryptoLens", Account: "alice"},
+		{Issuer: "Crypto:Lens", Account: "alice", Secret: secret},
+		{Account: "alice", Secret: secret, Algorithm: "MD5"},
+		{Account: "alice", Secret: secret, Digits: 7},
+		{Account: "alice", Secret: secret, Period: -30},
+	}
+	for _, params := range tests {
+		if _, err := OTPAuthURI(params); err == nil {
+			t.Errorf("Expected an error for %+v", params)
+		}
+	}
+}
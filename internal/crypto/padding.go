@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// pkcs7Pad pads data to a multiple of blockSize using PKCS7
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - (len(data) % blockSize)
+	padtext := make([]byte, len(data)+padding)
+	copy(padtext, data)
+	for i := len(data); i < len(padtext); i++ {
+		padtext[i] = byte(padding)
+	}
+	return padtext
+}
+
+// pkcs7Unpad removes PKCS7 padding added for the given block size
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty data")
+	}
+	padding := int(data[len(data)-1])
+	if padding > blockSize || padding == 0 || padding > len(data) {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return data[:len(data)-padding], nil
+}
+
+// addPaddingSteps pads data with PKCS7 and visualizes how the final block is filled
+func addPaddingSteps(v *utils.Visualizer, data []byte, blockSize int) []byte {
+	padded := pkcs7Pad(data, blockSize)
+	padding := len(padded) - len(data)
+
+	v.AddStep(fmt.Sprintf("PKCS7 Padding (block size %d bytes):", blockSize))
+	v.AddStep(fmt.Sprintf("Input length: %d bytes = %d full block(s) + %d byte(s)", len(data), len(data)/blockSize, len(data)%blockSize))
+	if padding == blockSize {
+		v.AddStep(fmt.Sprintf("Input is block-aligned: a full extra block of %d padding bytes (0x%02x each) is appended", blockSize, padding))
+		v.AddNote("Without this block, a message ending in bytes that look like padding could not be unpadded unambiguously")
+	} else {
+		v.AddStep(fmt.Sprintf("Adding %d padding byte(s) of value 0x%02x to fill the final block", padding, padding))
+	}
+
+	for i := 0; i < len(padded); i += blockSize {
+		var b strings.Builder
+		for j := i; j < i+blockSize; j++ {
+			if j == len(data) {
+				b.WriteString("[")
+			}
+			b.WriteString(fmt.Sprintf("%02x", padded[j]))
+			if j < i+blockSize-1 && j != len(data)-1 {
+				b.WriteString(" ")
+			}
+		}
+		if i+blockSize > len(data) {
+			b.WriteString("]")
+		}
+		v.AddStep(fmt.Sprintf("Block %d: %s", i/blockSize+1, b.String()))
+	}
+	v.AddNote("Bytes in [brackets] are padding; each holds the total number of padding bytes")
+
+	return padded
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestAddPaddingSteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		blockSize   int
+		wantLen     int
+		wantPadding byte
+		wantFull    bool
+	}{
+		{name: "partial AES block", input: "hello", blockSize: 16, wantLen: 16, wantPadding: 11},
+		{name: "block-aligned AES input", input: "exactly16bytes!!", blockSize: 16, wantLen: 32, wantPadding: 16, wantFull: true},
+		{name: "block-aligned 8-byte input", input: "8 bytes!", blockSize: 8, wantLen: 16, wantPadding: 8, wantFull: true},
+		{name: "empty input", input: "", blockSize: 8, wantLen: 8, wantPadding: 8, wantFull: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := utils.NewVisualizer()
+			padded := addPaddingSteps(v, []byte(tt.input), tt.blockSize)
+
+			if len(padded) != tt.wantLen {
+				t.Fatalf("padded length = %d, want %d", len(padded), tt.wantLen)
+			}
+			tail := padded[len(tt.input):]
+			if !bytes.Equal(tail, bytes.Repeat([]byte{tt.wantPadding}, int(tt.wantPadding))) {
+				t.Errorf("padding bytes = %x, want %d bytes of 0x%02x", tail, tt.wantPadding, tt.wantPadding)
+			}
+
+			fullBlockStep := false
+			for _, step := range v.GetSteps() {
+				if strings.Contains(step.Text, "a full extra block") {
+					fullBlockStep = true
+				}
+			}
+			if fullBlockStep != tt.wantFull {
+				t.Errorf("full padding block step present = %v, want %v", fullBlockStep, tt.wantFull)
+			}
+
+			unpadded, err := pkcs7Unpad(padded, tt.blockSize)
+			if err != nil || string(unpadded) != tt.input {
+				t.Errorf("pkcs7Unpad() = %q, %v, want %q", unpadded, err, tt.input)
+			}
+		})
+	}
+}
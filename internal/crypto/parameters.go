@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parameter describes an effective, tunable processor setting
+type Parameter struct {
+	// Name is the key passed to Configure
+	Name string
+	// Description explains the setting to the user
+	Description string
+	// Value is the current value; its type decides how edits are parsed
+	Value interface{}
+}
+
+// ParameterizedProcessor is implemented by processors that can list their effective parameters
+type ParameterizedProcessor interface {
+	ConfigurableProcessor
+	// Parameters returns the processor's current settings
+	Parameters() []Parameter
+}
+
+// ParseParameterValue converts raw user input to the type of the parameter's current value
+func ParseParameterValue(param Parameter, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch param.Value.(type) {
+	case int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: expected an integer", param.Name)
+		}
+		return value, nil
+	case bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: expected true or false", param.Name)
+		}
+		return value, nil
+	case string:
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("parameter %s cannot be edited", param.Name)
+	}
+}
+
+// ApplyParameters reconfigures the processor with its current parameters plus the given edits
+func ApplyParameters(processor ParameterizedProcessor, edits map[string]string) error {
+	config := make(map[string]interface{})
+	for _, param := range processor.Parameters() {
+		config[param.Name] = param.Value
+		raw, ok := edits[param.Name]
+		if !ok {
+			continue
+		}
+		value, err := ParseParameterValue(param, raw)
+		if err != nil {
+			return err
+		}
+		config[param.Name] = value
+	}
+
+	for name := range edits {
+		if _, ok := config[name]; !ok {
+			return fmt.Errorf("unknown parameter: %s", name)
+		}
+	}
+
+	return processor.Configure(config)
+}
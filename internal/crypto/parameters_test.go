@@ -0,0 +1,68 @@
+package crypto
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyParameters(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "aes_key.bin")
+	processor := NewAESProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keySize": 256,
+		"keyFile": keyFile,
+	}); err != nil {
+		t.Fatalf("Failed to configure AES: %v", err)
+	}
+
+	if err := ApplyParameters(processor, map[string]string{"keySize": "128"}); err != nil {
+		t.Fatalf("ApplyParameters failed: %v", err)
+	}
+
+	// Unedited parameters keep their values
+	for _, param := range processor.Parameters() {
+		if param.Name == "keyFile" && param.Value != keyFile {
+			t.Errorf("keyFile = %v, want %v", param.Value, keyFile)
+		}
+	}
+
+	_, steps, err := processor.Process("hello", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	found := false
+	for _, step := range steps {
+		if strings.Contains(step.Text, "AES-128") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Edited key size was not applied before Process")
+	}
+	if len(processor.keyManager.GetKey()) != 16 {
+		t.Errorf("key length = %d, want 16", len(processor.keyManager.GetKey()))
+	}
+}
+
+func TestApplyParameters_Errors(t *testing.T) {
+	processor := NewCaesarProcessor()
+
+	if err := ApplyParameters(processor, map[string]string{"shift": "three"}); err == nil {
+		t.Error("Expected error for non-integer shift")
+	}
+	if err := ApplyParameters(processor, map[string]string{"rounds": "3"}); err == nil {
+		t.Error("Expected error for unknown parameter")
+	}
+
+	if err := ApplyParameters(processor, map[string]string{"shift": "5"}); err != nil {
+		t.Fatalf("ApplyParameters failed: %v", err)
+	}
+	result, _, err := processor.Process("abc", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result != "fgh" {
+		t.Errorf("result = %s, want fgh", result)
+	}
+}
@@ -1,32 +1,115 @@
 package crypto
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"math/bits"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
+// Available PBKDF algorithms
+const (
+	PBKDFAlgorithmPBKDF2   = "pbkdf2"
+	PBKDFAlgorithmArgon2id = "argon2id"
+	PBKDFAlgorithmArgon2i  = "argon2i"
+	PBKDFAlgorithmScrypt   = "scrypt"
+	PBKDFAlgorithmBcrypt   = "bcrypt"
+)
+
+// bcryptMaxPasswordLength is the number of password bytes bcrypt actually uses
+const bcryptMaxPasswordLength = 72
+
+// pbkdf2PHCID is the PHC identifier for PBKDF2 with HMAC-SHA256
+const pbkdf2PHCID = "pbkdf2-sha256"
+
+// Derived key length bounds in bytes
+const (
+	pbkdfKeyLength    = 32
+	pbkdfMinKeyLength = 16
+	pbkdfMaxKeyLength = 64
+)
+
+// PBKDF2 iteration counts
+const (
+	pbkdf2DefaultIterations = 100000
+	pbkdf2MinIterations     = 10000
+)
+
+// Argon2id parameters (defaults are the RFC 9106 second recommended option)
+const (
+	argon2idTime      = 3
+	argon2idMaxTime   = 10
+	argon2idMemory    = 64 * 1024 // KiB
+	argon2idThreads   = 4
+	argon2idMaxMemory = 4 * 1024 * 1024
+)
+
+// scrypt parameters; N is derived from the memory setting, since scrypt uses 128*r*N bytes
+const (
+	scryptBlockSize   = 8
+	scryptParallelism = 1
+	scryptMinLogN     = 10
+	scryptMaxLogN     = 24
+	scryptMaxMemory   = uint64(argon2idMaxMemory) * 1024 // bytes, the same ceiling as Argon2
+)
+
+// Approximate working memory per guess for the algorithms without a memory parameter
+const (
+	pbkdf2Footprint = 512  // HMAC-SHA256 inner and outer states and block buffers
+	bcryptFootprint = 4168 // Blowfish S-boxes (4 × 1 KiB) and P-array
+)
+
+// parallelGuesses is the attacker's parallelism used to put memory costs in perspective
+const parallelGuesses = 10000
+
+// pbkdfMinSaltSize is the shortest salt accepted as a fixed salt
+const pbkdfMinSaltSize = 8
+
+// usedSalts counts the salts used by PBKDF derivations in this session, to detect reuse
+var (
+	usedSaltsMu sync.Mutex
+	usedSalts   = make(map[string]int)
+)
+
 // PBKDFProcessor implements password-based key derivation
 type PBKDFProcessor struct {
 	BaseConfigurableProcessor
 	keyManager KeyManager
-	iterations int
+	algorithm  string
+	iterations int // PBKDF2 iterations or Argon2id passes; zero selects the algorithm default
+	memory     int // KiB, for Argon2id and scrypt
+	threads    int // Argon2id lanes
+	keyLength  int // Derived key length in bytes
 	saltSize   int
+	salt       []byte // Fixed salt; nil means a fresh random salt per derivation
+	cost       int
+	storedHash string
+	compare    bool // Encrypt compares the KDF with a fast hash instead of only deriving a key
 }
 
 // NewPBKDFProcessor creates a new PBKDF processor
 func NewPBKDFProcessor() *PBKDFProcessor {
 	return &PBKDFProcessor{
-		iterations: 100000, // Default iterations
-		saltSize:   16,     // Default salt size
+		algorithm: PBKDFAlgorithmPBKDF2,
+		memory:    argon2idMemory,     // Default memory cost
+		threads:   argon2idThreads,    // Default parallelism
+		keyLength: pbkdfKeyLength,     // Default key length
+		saltSize:  16,                 // Default salt size
+		cost:      bcrypt.DefaultCost, // Default bcrypt cost factor
 	}
 }
 
@@ -36,16 +119,84 @@ func (p *PBKDFProcessor) Configure(config map[string]interface{}) error {
 		return err
 	}
 
+	// Configure algorithm if provided
+	if algorithm, ok := config["algorithm"].(string); ok && algorithm != "" {
+		switch algorithm {
+		case PBKDFAlgorithmPBKDF2, PBKDFAlgorithmArgon2id, PBKDFAlgorithmArgon2i, PBKDFAlgorithmScrypt, PBKDFAlgorithmBcrypt:
+			p.algorithm = algorithm
+		default:
+			return fmt.Errorf("unsupported PBKDF algorithm: %s (must be one of: pbkdf2, argon2id, argon2i, scrypt, bcrypt)", algorithm)
+		}
+	}
+
+	// Configure bcrypt cost if provided
+	if cost, ok := config["cost"].(int); ok && cost != 0 {
+		if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return fmt.Errorf("invalid bcrypt cost: %d (must be %d-%d)", cost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		p.cost = cost
+	}
+
+	// Configure the stored value checked by the verify operation
+	if stored, ok := config["storedHash"].(string); ok {
+		p.storedHash = strings.TrimSpace(stored)
+	}
+
+	// Configure the fast hash comparison mode
+	if compare, ok := config["compare"].(bool); ok {
+		p.compare = compare
+	}
+
 	// Configure iterations if provided
-	if iter, ok := config["iterations"].(int); ok {
+	if iter, ok := configInt(config, "iterations"); ok {
+		if iter < 0 {
+			return fmt.Errorf("invalid iterations: %d (must not be negative)", iter)
+		}
 		p.iterations = iter
 	}
 
+	// Configure memory cost if provided
+	if memory, ok := configInt(config, "memory"); ok && memory != 0 {
+		if memory < 8 || memory > argon2idMaxMemory {
+			return fmt.Errorf("invalid memory: %d KiB (must be 8-%d)", memory, argon2idMaxMemory)
+		}
+		p.memory = memory
+	}
+
+	// Configure threads if provided
+	if threads, ok := configInt(config, "threads"); ok && threads != 0 {
+		if threads < 1 || threads > 255 {
+			return fmt.Errorf("invalid threads: %d (must be 1-255)", threads)
+		}
+		p.threads = threads
+	}
+
+	// Configure key length if provided
+	if keyLength, ok := configInt(config, "keyLength"); ok && keyLength != 0 {
+		if keyLength < pbkdfMinKeyLength || keyLength > pbkdfMaxKeyLength {
+			return fmt.Errorf("invalid key length: %d bytes (must be %d-%d)", keyLength, pbkdfMinKeyLength, pbkdfMaxKeyLength)
+		}
+		p.keyLength = keyLength
+	}
+
 	// Configure salt size if provided
 	if size, ok := config["saltSize"].(int); ok {
 		p.saltSize = size
 	}
 
+	// Configure a fixed salt if provided (hex or base64)
+	if salt, ok := config["salt"].(string); ok {
+		if salt == "" {
+			p.salt = nil
+		} else {
+			decoded, err := parseSalt(salt)
+			if err != nil {
+				return err
+			}
+			p.salt = decoded
+		}
+	}
+
 	// Configure key file if provided
 	keyFile := "keys/pbkdf_key.bin"
 	if kf, ok := config["keyFile"].(string); ok {
@@ -62,82 +213,632 @@ func (p *PBKDFProcessor) Configure(config map[string]interface{}) error {
 }
 
 // Process handles password-based key derivation
-func (p *PBKDFProcessor) Process(text string, _ string) (string, []string, error) {
+func (p *PBKDFProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	return p.ProcessContext(context.Background(), text, operation)
+}
+
+// ProcessContext is Process, giving up on the derivation once ctx is done
+func (p *PBKDFProcessor) ProcessContext(ctx context.Context, text string, operation string) (string, []utils.Step, error) {
+	switch operation {
+	case OperationVerify:
+		return p.verify(ctx, text)
+	case OperationDecrypt:
+		return "", nil, fmt.Errorf("key derivation is one-way and cannot be decrypted; use the verify operation to check a password")
+	}
+
+	if p.compare {
+		return p.compareWithHash(ctx, text)
+	}
+	if p.algorithm == PBKDFAlgorithmBcrypt {
+		return p.processBcrypt(ctx, text)
+	}
+
 	v := utils.NewVisualizer()
+	name := pbkdfDisplayName(p.algorithm)
 
 	// Add introduction
-	v.AddStep("PBKDF2-SHA256 Process")
-	v.AddStep("=============================")
-	v.AddNote("PBKDF2 (Password-Based Key Derivation Function 2) is used for key stretching")
-	v.AddNote("Using SHA-256 as the underlying hash function")
+	v.AddHeader(fmt.Sprintf("%s Process", name))
+	switch p.algorithm {
+	case PBKDFAlgorithmArgon2id:
+		v.AddNote("Argon2id won the 2015 Password Hashing Competition and is standardized in RFC 9106")
+		v.AddNote("It is memory-hard, so every guess needs a large block of RAM as well as CPU time")
+	case PBKDFAlgorithmArgon2i:
+		v.AddNote("Argon2i is the data-independent member of the Argon2 family (RFC 9106)")
+		v.AddNote("Its memory access pattern never depends on the password, so cache timing reveals nothing")
+	case PBKDFAlgorithmScrypt:
+		v.AddNote("scrypt (RFC 7914) is a memory-hard key derivation function built on PBKDF2 and Salsa20/8")
+		v.AddNote("Its memory cost makes large-scale GPU and ASIC attacks expensive")
+	default:
+		v.AddNote("PBKDF2 (Password-Based Key Derivation Function 2) is used for key stretching")
+		v.AddNote("Using SHA-256 as the underlying hash function")
+	}
 	v.AddSeparator()
 
 	// Add password strength warnings
-	v.AddStep("Using PBKDF2-SHA256 for key derivation")
+	v.AddStep(fmt.Sprintf("Using %s for key derivation", name))
 
-	// Password strength analysis
-	if len(text) < 8 {
-		v.AddStep("⚠️  Warning: Password is too short (less than 8 characters)")
-		v.AddStep("    This makes it more vulnerable to brute-force attacks")
-		v.AddStep("    Recommendation: Use at least 12 characters")
-	} else if len(text) < 12 {
-		v.AddStep("⚠️  Warning: Password could be stronger")
-		v.AddStep("    Recommendation: Use at least 12 characters")
-	}
+	addPasswordWarnings(v, text)
 
-	// Check for common patterns
-	if isCommonPassword(text) {
-		v.AddStep("⚠️  Warning: This appears to be a common password pattern")
-		v.AddStep("    Recommendation: Use a more unique password")
+	// Use the fixed salt or generate a random one
+	var salt []byte
+	if p.salt != nil {
+		salt = append([]byte(nil), p.salt...)
+		v.AddStep(fmt.Sprintf("Using configured fixed salt (%d bytes)", len(salt)))
+		v.AddStep("⚠️  A fixed salt makes derivations reproducible; use it only for demos and testing")
+	} else {
+		salt = make([]byte, p.saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return "", nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+		v.AddStep(fmt.Sprintf("Generated salt (%d bytes)", p.saltSize))
+	}
+	if uses := recordSalt(salt); uses > 0 {
+		v.AddStep(fmt.Sprintf("⚠️  Salt reuse detected: this salt was already used %d time(s) in this session", uses))
+		v.AddStep("    Identical passwords now produce identical keys, and one precomputed table attacks them all")
 	}
 
-	// Generate salt
-	salt := make([]byte, p.saltSize)
-	if _, err := rand.Read(salt); err != nil {
-		return "", nil, fmt.Errorf("failed to generate salt: %w", err)
+	// Resolve the work factors, raising any that fall below safe minimums
+	params, warnings := p.workFactors(p.algorithm)
+	for _, warning := range warnings {
+		v.AddStep("⚠️  " + warning)
 	}
 
 	// Measure execution time
 	start := time.Now()
-	derivedKey := pbkdf2.Key([]byte(text), salt, p.iterations, 32, sha256.New)
+	hashed, err := p.derive(ctx, []byte(text), salt, params)
+	if err != nil {
+		return "", nil, err
+	}
 	duration := time.Since(start)
 
 	// Show process details
-	v.AddStep(fmt.Sprintf("Generated salt (%d bytes)", p.saltSize))
-	v.AddStep(fmt.Sprintf("Performed %d iterations", p.iterations))
+	v.AddHexStep("Salt", salt)
+	for _, param := range hashed.params {
+		v.AddStep(fmt.Sprintf("Parameter %s = %d (%s)", param.name, param.value, phcParamDescription(param.name)))
+	}
 	v.AddStep(fmt.Sprintf("Derived key in %v", duration))
-	v.AddStep("Base64 encoded the result for safe transmission")
-	v.AddNote("PBKDF2 is designed to be computationally intensive to prevent brute-force attacks")
+	v.AddHexStep("Derived Key", hashed.hash)
+	v.AddArrow()
+
+	// Show the self-describing PHC string
+	encoded := hashed.String()
+	v.AddTextStep("PHC String", encoded)
+	v.AddStep("PHC Format ($id[$v=version]$params$salt$hash):")
+	v.AddStep(fmt.Sprintf("   - Identifier: %s", hashed.id))
+	if hashed.version != 0 {
+		v.AddStep(fmt.Sprintf("   - Version:    %d", hashed.version))
+	}
+	v.AddStep(fmt.Sprintf("   - Salt:       %s (unpadded Base64)", phcEncoding.EncodeToString(hashed.salt)))
+	v.AddStep(fmt.Sprintf("   - Hash:       %s (unpadded Base64)", phcEncoding.EncodeToString(hashed.hash)))
 
 	// Add how it works
 	v.AddSeparator()
-	v.AddStep("How PBKDF2 Works:")
-	v.AddStep("1. Password and Salt:")
-	v.AddStep("   - Password is the input text")
-	v.AddStep("   - Salt is a random value to prevent rainbow table attacks")
-	v.AddStep("2. Iterations:")
-	v.AddStep(fmt.Sprintf("   - Performs %d iterations of SHA-256", p.iterations))
-	v.AddStep("   - Each iteration makes brute-force attacks more expensive")
-	v.AddStep("3. Key Derivation:")
-	v.AddStep("   - Combines password, salt, and iteration count")
-	v.AddStep("   - Produces a 256-bit (32-byte) key")
-	v.AddStep("4. Output:")
-	v.AddStep("   - The derived key is base64 encoded for safe transmission")
+	v.AddStep(fmt.Sprintf("How %s Works:", name))
+	switch p.algorithm {
+	case PBKDFAlgorithmArgon2id:
+		v.AddStep("1. Fill m KiB of memory with blocks derived from the password and salt")
+		v.AddStep("2. Make t passes over memory, mixing each block with earlier ones")
+		v.AddStep("3. The first half of the first pass uses data-independent addressing (side-channel resistance)")
+		v.AddStep("4. The rest uses data-dependent addressing (resistance to time-memory trade-offs)")
+		v.AddStep("5. p lanes are processed in parallel and combined into the final key")
+	case PBKDFAlgorithmArgon2i:
+		v.AddStep("1. Fill m KiB of memory with blocks derived from the password and salt")
+		v.AddStep("2. Make t passes over memory, mixing each block with earlier ones")
+		v.AddStep("3. Every pass picks the earlier blocks from a pseudo-random sequence that ignores the password")
+		v.AddStep("4. p lanes are processed in parallel and combined into the final key")
+	case PBKDFAlgorithmScrypt:
+		v.AddStep("1. PBKDF2-SHA256 expands the password and salt into p blocks")
+		v.AddStep("2. ROMix fills a table of N = 2^ln entries of 128*r bytes each")
+		v.AddStep("3. The table is read back in a password-dependent order, forcing it to stay in memory")
+		v.AddStep("4. A final PBKDF2-SHA256 pass compresses the result into the key")
+	default:
+		v.AddStep("1. Password and Salt:")
+		v.AddStep("   - Password is the input text")
+		v.AddStep("   - Salt is a random value to prevent rainbow table attacks")
+		v.AddStep("2. Iterations:")
+		iterations, _ := hashed.param("i")
+		v.AddStep(fmt.Sprintf("   - Performs %d iterations of SHA-256", iterations))
+		v.AddStep("   - Each iteration makes brute-force attacks more expensive")
+		v.AddStep("3. Key Derivation:")
+		v.AddStep("   - Combines password, salt, and iteration count")
+		v.AddStep(fmt.Sprintf("   - Produces a %d-bit (%d-byte) key", len(hashed.hash)*8, len(hashed.hash)))
+	}
+	if p.algorithm == PBKDFAlgorithmArgon2id || p.algorithm == PBKDFAlgorithmArgon2i {
+		addArgon2Variants(v)
+	}
+	v.AddStep("Output:")
+	v.AddStep("   - Algorithm, parameters, salt, and key are encoded together as a PHC string")
+
+	p.addMemoryHardness(v, hashed)
 
 	// Add security notes
 	v.AddSeparator()
 	v.AddNote("Security Considerations:")
 	v.AddNote("1. The salt must be unique for each password")
-	v.AddNote("2. More iterations make the process slower but more secure")
+	v.AddNote("2. Higher cost parameters make the process slower but more secure")
 	v.AddNote("3. The derived key should be used as input to other cryptographic operations")
-	v.AddNote("4. Never store the original password, only the derived key and salt")
-	v.AddNote("5. The salt can be stored alongside the derived key")
+	v.AddNote("4. Never store the original password, only the PHC string")
+	v.AddNote("5. The PHC string carries everything needed to verify a password later")
 
-	// Base64 encode the result
-	encoded := base64.StdEncoding.EncodeToString(derivedKey)
 	return encoded, v.GetSteps(), nil
 }
 
+// workFactors returns the PHC parameters for algorithm with the configured costs, with values
+// below safe minimums (or above sane maximums) adjusted and a warning describing each change
+func (p *PBKDFProcessor) workFactors(algorithm string) ([]phcParam, []string) {
+	var warnings []string
+	switch algorithm {
+	case PBKDFAlgorithmArgon2id, PBKDFAlgorithmArgon2i:
+		name := pbkdfDisplayName(algorithm)
+		passes := p.iterations
+		if passes == 0 {
+			passes = argon2idTime
+		} else if passes > argon2idMaxTime {
+			warnings = append(warnings, fmt.Sprintf("%d iterations is too many %s passes; using the maximum of %d (raise memory instead)", passes, name, argon2idMaxTime))
+			passes = argon2idMaxTime
+		}
+		memory := p.memory
+		if memory < 8*p.threads {
+			warnings = append(warnings, fmt.Sprintf("%s needs at least 8 KiB per thread; raising memory from %d to %d KiB", name, memory, 8*p.threads))
+			memory = 8 * p.threads
+		}
+		return []phcParam{{"m", memory}, {"t", passes}, {"p", p.threads}}, warnings
+	case PBKDFAlgorithmScrypt:
+		// N = memory / (128 * r) bytes, rounded down to a power of two
+		logN := bits.Len(uint(p.memory*1024/(128*scryptBlockSize))) - 1
+		if logN < scryptMinLogN {
+			warnings = append(warnings, fmt.Sprintf("%d KiB is too little memory for scrypt; raising N to 2^%d", p.memory, scryptMinLogN))
+			logN = scryptMinLogN
+		} else if logN > scryptMaxLogN {
+			warnings = append(warnings, fmt.Sprintf("%d KiB exceeds the scrypt limit; lowering N to 2^%d", p.memory, scryptMaxLogN))
+			logN = scryptMaxLogN
+		}
+		return []phcParam{{"ln", logN}, {"r", scryptBlockSize}, {"p", scryptParallelism}}, warnings
+	default:
+		iterations := p.iterations
+		if iterations == 0 {
+			iterations = pbkdf2DefaultIterations
+		} else if iterations < pbkdf2MinIterations {
+			warnings = append(warnings, fmt.Sprintf("%d iterations is too few for PBKDF2; raising to the minimum of %d", iterations, pbkdf2MinIterations))
+			iterations = pbkdf2MinIterations
+		}
+		return []phcParam{{"i", iterations}}, warnings
+	}
+}
+
+// memoryFootprint estimates the working memory one derivation with params needs, in bytes
+func memoryFootprint(algorithm string, params []phcParam) uint64 {
+	values := make(map[string]int, len(params))
+	for _, param := range params {
+		values[param.name] = param.value
+	}
+	switch algorithm {
+	case PBKDFAlgorithmArgon2id, PBKDFAlgorithmArgon2i:
+		return uint64(values["m"]) * 1024
+	case PBKDFAlgorithmScrypt:
+		// ROMix keeps N blocks of 128*r bytes
+		return 128 * uint64(values["r"]) << values["ln"]
+	case PBKDFAlgorithmBcrypt:
+		return bcryptFootprint
+	default:
+		return pbkdf2Footprint
+	}
+}
+
+// addMemoryHardness shows the memory one guess costs for hashed (nil for bcrypt) and compares
+// it with the other algorithms under the same configuration
+func (p *PBKDFProcessor) addMemoryHardness(v *utils.Visualizer, hashed *phcHash) {
+	var params []phcParam
+	if hashed != nil {
+		params = hashed.params
+	}
+	footprint := memoryFootprint(p.algorithm, params)
+
+	v.AddSeparator()
+	v.AddStep("Memory Hardness:")
+	switch p.algorithm {
+	case PBKDFAlgorithmArgon2id, PBKDFAlgorithmArgon2i:
+		memory, _ := hashed.param("m")
+		v.AddStep(fmt.Sprintf("%s m=%d → ~%s of RAM per guess", pbkdfDisplayName(p.algorithm), memory, utils.FormatBytes(footprint)))
+	case PBKDFAlgorithmScrypt:
+		logN, _ := hashed.param("ln")
+		r, _ := hashed.param("r")
+		v.AddStep(fmt.Sprintf("scrypt N=2^%d, r=%d → 128 × r × N = ~%s of RAM per guess", logN, r, utils.FormatBytes(footprint)))
+	case PBKDFAlgorithmBcrypt:
+		v.AddStep(fmt.Sprintf("bcrypt keeps ~%s of Blowfish state per guess, whatever the cost factor", utils.FormatBytes(footprint)))
+	default:
+		v.AddStep(fmt.Sprintf("PBKDF2 keeps only ~%s of HMAC-SHA256 state per guess, whatever the iteration count", utils.FormatBytes(footprint)))
+	}
+
+	// Compare every algorithm configured with this processor's costs
+	algorithms := []string{PBKDFAlgorithmPBKDF2, PBKDFAlgorithmBcrypt, PBKDFAlgorithmScrypt, PBKDFAlgorithmArgon2i, PBKDFAlgorithmArgon2id}
+	footprints := make([]uint64, len(algorithms))
+	var largest uint64
+	for i, algorithm := range algorithms {
+		algorithmParams, _ := p.workFactors(algorithm)
+		footprints[i] = memoryFootprint(algorithm, algorithmParams)
+		largest = max(largest, footprints[i])
+	}
+
+	width := utils.BarChartWidth(40)
+	v.AddStep("Memory per guess (linear scale):")
+	for i, algorithm := range algorithms {
+		length := max(1, int(float64(footprints[i])/float64(largest)*float64(width)))
+		marker := ""
+		if algorithm == p.algorithm {
+			marker = "  ◀ this run"
+		}
+		v.AddStep(fmt.Sprintf("  %-13s %s%s %9s%s",
+			pbkdfDisplayName(algorithm),
+			strings.Repeat("█", length),
+			strings.Repeat("░", width-length),
+			utils.FormatBytes(footprints[i]),
+			marker))
+	}
+
+	v.AddNote(fmt.Sprintf("Testing %d guesses in parallel would need ~%s of RAM with these settings",
+		parallelGuesses, utils.FormatBytes(footprint*parallelGuesses)))
+	v.AddNote("GPUs and ASICs have thousands of cores but little memory per core, so memory-hard")
+	v.AddNote("functions like scrypt and Argon2id cut their advantage far more than extra iterations do")
+}
+
+// derive computes the key for the configured algorithm and records its parameters as a PHC hash
+func (p *PBKDFProcessor) derive(ctx context.Context, password, salt []byte, params []phcParam) (*phcHash, error) {
+	hashed := &phcHash{salt: salt, params: params}
+	switch p.algorithm {
+	case PBKDFAlgorithmArgon2id, PBKDFAlgorithmArgon2i:
+		hashed.id = p.algorithm
+		hashed.version = argon2.Version
+	case PBKDFAlgorithmScrypt:
+		hashed.id = PBKDFAlgorithmScrypt
+	default:
+		hashed.id = pbkdf2PHCID
+	}
+
+	keyLen := p.keyLength
+	key, err := runContext(ctx, func() ([]byte, error) {
+		return recomputePHC(hashed, password, keyLen)
+	})
+	if err != nil {
+		return nil, err
+	}
+	hashed.hash = key
+	return hashed, nil
+}
+
+// recomputePHC derives a keyLen-byte key using the algorithm and parameters recorded in hashed
+func recomputePHC(hashed *phcHash, password []byte, keyLen int) ([]byte, error) {
+	switch hashed.id {
+	case pbkdf2PHCID:
+		iterations, err := hashed.param("i")
+		if err != nil {
+			return nil, err
+		}
+		if iterations < 1 {
+			return nil, fmt.Errorf("invalid iteration count: %d", iterations)
+		}
+		return pbkdf2.Key(password, hashed.salt, iterations, keyLen, sha256.New), nil
+	case PBKDFAlgorithmArgon2id, PBKDFAlgorithmArgon2i:
+		if hashed.version != argon2.Version {
+			return nil, fmt.Errorf("unsupported %s version: %d", hashed.id, hashed.version)
+		}
+		memory, err := hashed.param("m")
+		if err != nil {
+			return nil, err
+		}
+		passes, err := hashed.param("t")
+		if err != nil {
+			return nil, err
+		}
+		threads, err := hashed.param("p")
+		if err != nil {
+			return nil, err
+		}
+		if passes < 1 || threads < 1 || threads > 255 || memory < 8*threads || memory > argon2idMaxMemory {
+			return nil, fmt.Errorf("invalid %s parameters: m=%d, t=%d, p=%d", hashed.id, memory, passes, threads)
+		}
+		if hashed.id == PBKDFAlgorithmArgon2i {
+			return argon2.Key(password, hashed.salt, uint32(passes), uint32(memory), uint8(threads), uint32(keyLen)), nil
+		}
+		return argon2.IDKey(password, hashed.salt, uint32(passes), uint32(memory), uint8(threads), uint32(keyLen)), nil
+	case PBKDFAlgorithmScrypt:
+		logN, err := hashed.param("ln")
+		if err != nil {
+			return nil, err
+		}
+		r, err := hashed.param("r")
+		if err != nil {
+			return nil, err
+		}
+		parallelism, err := hashed.param("p")
+		if err != nil {
+			return nil, err
+		}
+		if logN < 1 || logN > scryptMaxLogN {
+			return nil, fmt.Errorf("invalid scrypt cost: ln=%d", logN)
+		}
+		// A stored value is untrusted: scrypt allocates 128*r*N bytes for its working
+		// set and 128*r*p bytes for the blocks, so bound both before deriving
+		if r < 1 || parallelism < 1 || parallelism > 255 || uint64(r) > scryptMaxMemory/(128<<logN) ||
+			128*uint64(r)*uint64(parallelism) > scryptMaxMemory {
+			return nil, fmt.Errorf("invalid scrypt parameters: ln=%d, r=%d, p=%d (at most %d MiB of memory)", logN, r, parallelism, scryptMaxMemory>>20)
+		}
+		key, err := scrypt.Key(password, hashed.salt, 1<<logN, r, parallelism, keyLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive scrypt key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported PHC algorithm: %s", hashed.id)
+	}
+}
+
+// parseSalt decodes a fixed salt given as hex or base64
+func parseSalt(encoded string) ([]byte, error) {
+	salt, err := hex.DecodeString(encoded)
+	if err != nil {
+		salt, err = base64.StdEncoding.DecodeString(encoded)
+	}
+	if err != nil {
+		salt, err = base64.RawStdEncoding.DecodeString(encoded)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: must be hex or base64")
+	}
+	if len(salt) < pbkdfMinSaltSize {
+		return nil, fmt.Errorf("invalid salt: %d bytes (must be at least %d)", len(salt), pbkdfMinSaltSize)
+	}
+	return salt, nil
+}
+
+// recordSalt marks a salt as used and returns how many times it was used before
+func recordSalt(salt []byte) int {
+	usedSaltsMu.Lock()
+	defer usedSaltsMu.Unlock()
+	key := string(salt)
+	uses := usedSalts[key]
+	usedSalts[key] = uses + 1
+	return uses
+}
+
+// configInt reads an integer setting that may be stored as int, uint32, or uint8
+func configInt(config map[string]interface{}, key string) (int, bool) {
+	switch value := config[key].(type) {
+	case int:
+		return value, true
+	case uint32:
+		return int(value), true
+	case uint8:
+		return int(value), true
+	default:
+		return 0, false
+	}
+}
+
+// addArgon2Variants compares the three Argon2 variants and when to use each
+func addArgon2Variants(v *utils.Visualizer) {
+	v.AddStep("Argon2 Variants:")
+	v.AddStep("   - Argon2d: password-dependent addressing; strongest against GPUs and time-memory trade-offs,")
+	v.AddStep("     but cache-timing side channels can leak the access pattern (meant for proof-of-work)")
+	v.AddStep("   - Argon2i: password-independent addressing; immune to those side channels, but trade-off")
+	v.AddStep("     attacks are cheaper, so it needs more passes for the same strength")
+	v.AddStep("   - Argon2id: Argon2i for the first half pass, then Argon2d; RFC 9106 recommends it for passwords")
+	v.AddNote("Argon2d is not offered here: Go's golang.org/x/crypto/argon2 implements only Argon2i and Argon2id")
+}
+
+// pbkdfDisplayName returns a human-readable name for a PBKDF algorithm
+func pbkdfDisplayName(algorithm string) string {
+	switch algorithm {
+	case PBKDFAlgorithmArgon2id:
+		return "Argon2id"
+	case PBKDFAlgorithmArgon2i:
+		return "Argon2i"
+	case PBKDFAlgorithmScrypt:
+		return "scrypt"
+	case PBKDFAlgorithmBcrypt:
+		return "bcrypt"
+	default:
+		return "PBKDF2-SHA256"
+	}
+}
+
+// phcParamDescription explains a PHC parameter name
+func phcParamDescription(name string) string {
+	switch name {
+	case "i":
+		return "iterations"
+	case "m":
+		return "memory in KiB"
+	case "t":
+		return "passes over memory"
+	case "p":
+		return "parallelism"
+	case "ln":
+		return "log2 of the CPU/memory cost N"
+	case "r":
+		return "block size"
+	default:
+		return name
+	}
+}
+
+// processBcrypt hashes the password with bcrypt
+func (p *PBKDFProcessor) processBcrypt(ctx context.Context, text string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	// Add introduction
+	v.AddHeader("bcrypt Password Hashing Process")
+	v.AddNote("bcrypt is an adaptive password hash based on the Blowfish key schedule (1999)")
+	v.AddNote("The cost factor doubles the work for every increment, so it can grow with hardware")
+	v.AddSeparator()
+
+	addPasswordWarnings(v, text)
+	if p.salt != nil {
+		v.AddStep("Note: bcrypt always generates its own random salt; the configured fixed salt is not used")
+	}
+
+	password := []byte(text)
+	if len(password) > bcryptMaxPasswordLength {
+		v.AddStep(fmt.Sprintf("⚠️  Warning: Password is %d bytes; bcrypt only uses the first %d bytes", len(password), bcryptMaxPasswordLength))
+		v.AddStep("    Anything after byte 72 is ignored, so longer passwords add no strength")
+		v.AddStep("    Recommendation: Pre-hash long passwords or use Argon2id")
+		password = password[:bcryptMaxPasswordLength]
+	}
+
+	// Measure execution time
+	start := time.Now()
+	configuredCost := p.cost
+	hashed, err := runContext(ctx, func() ([]byte, error) {
+		return bcrypt.GenerateFromPassword(password, configuredCost)
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate bcrypt hash: %w", err)
+	}
+	duration := time.Since(start)
+
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read bcrypt cost: %w", err)
+	}
+
+	// Show process details
+	v.AddStep(fmt.Sprintf("Cost factor: %d (2^%d = %d key expansion rounds)", cost, cost, 1<<cost))
+	v.AddStep(fmt.Sprintf("Hashed password in %v", duration))
+	v.AddTextStep("bcrypt Hash", string(hashed))
+	v.AddArrow()
+
+	// Break down the modular crypt format: $2a$10$<22-char salt><31-char hash>
+	parts := strings.Split(string(hashed), "$")
+	if len(parts) == 4 && len(parts[3]) == 53 {
+		v.AddStep("Hash Format ($version$cost$salt+hash):")
+		v.AddStep(fmt.Sprintf("   - Version: $%s$", parts[1]))
+		v.AddStep(fmt.Sprintf("   - Cost:    %s", parts[2]))
+		v.AddStep(fmt.Sprintf("   - Salt:    %s (128 bits, bcrypt Base64)", parts[3][:22]))
+		v.AddStep(fmt.Sprintf("   - Hash:    %s (184 bits, bcrypt Base64)", parts[3][22:]))
+	}
+
+	// Add how it works
+	v.AddSeparator()
+	v.AddStep("How bcrypt Works:")
+	v.AddStep("1. Generate a random 16-byte salt")
+	v.AddStep("2. EksBlowfishSetup: expand the key 2^cost times using the password and salt")
+	v.AddStep("3. Encrypt the string \"OrpheanBeholderScryDoubt\" 64 times with the expanded state")
+	v.AddStep("4. Encode version, cost, salt, and result into a single string")
+
+	p.addMemoryHardness(v, nil)
+
+	// Add security notes
+	v.AddSeparator()
+	v.AddNote("Security Considerations:")
+	v.AddNote("1. The salt and cost are stored in the hash, so verification needs only the hash string")
+	v.AddNote(fmt.Sprintf("2. Input is truncated at %d bytes", bcryptMaxPasswordLength))
+	v.AddNote("3. bcrypt uses only 4 KB of memory, so GPUs and FPGAs attack it more cheaply than Argon2id")
+	v.AddNote("4. Pick the highest cost that keeps login latency acceptable (10-12 is typical)")
+
+	return string(hashed), v.GetSteps(), nil
+}
+
+// verify recomputes the derived key for a password and compares it with the stored value
+func (p *PBKDFProcessor) verify(ctx context.Context, text string) (string, []utils.Step, error) {
+	if p.storedHash == "" {
+		return "", nil, fmt.Errorf("no stored value to verify against")
+	}
+
+	v := utils.NewVisualizer()
+
+	// Add introduction
+	v.AddHeader("Password Verification Process")
+	v.AddNote("The stored value records the algorithm, parameters, and salt used at registration")
+	v.AddNote("Verification re-derives the key from the candidate password and compares the results")
+	v.AddSeparator()
+
+	v.AddTextStep("Stored Value", p.storedHash)
+	v.AddArrow()
+
+	var match bool
+	start := time.Now()
+	if strings.HasPrefix(p.storedHash, "$2") {
+		cost, err := bcrypt.Cost([]byte(p.storedHash))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid bcrypt hash: %w", err)
+		}
+		v.AddStep("Algorithm: bcrypt")
+		v.AddStep(fmt.Sprintf("Cost factor: %d", cost))
+		password := []byte(text)
+		if len(password) > bcryptMaxPasswordLength {
+			password = password[:bcryptMaxPasswordLength]
+		}
+		// bcrypt re-hashes with the embedded salt and compares in constant time
+		storedHash := []byte(p.storedHash)
+		matched, err := runContext(ctx, func() (bool, error) {
+			return bcrypt.CompareHashAndPassword(storedHash, password) == nil, nil
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		match = matched
+	} else {
+		stored, err := parsePHC(p.storedHash)
+		if err != nil {
+			return "", nil, err
+		}
+		v.AddStep(fmt.Sprintf("Algorithm: %s", stored.id))
+		for _, param := range stored.params {
+			v.AddStep(fmt.Sprintf("Parameter %s = %d (%s)", param.name, param.value, phcParamDescription(param.name)))
+		}
+		v.AddHexStep("Salt", stored.salt)
+		v.AddArrow()
+		derivedKey, err := runContext(ctx, func() ([]byte, error) {
+			return recomputePHC(stored, []byte(text), len(stored.hash))
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		v.AddHexStep("Recomputed Key", derivedKey)
+		v.AddHexStep("Stored Key", stored.hash)
+		match = utils.ConstantTimeCompare(derivedKey, stored.hash)
+	}
+	duration := time.Since(start)
+
+	v.AddArrow()
+	v.AddStep(fmt.Sprintf("Verified in %v", duration))
+	result := "Password does not match"
+	if match {
+		v.AddStep("✅ Keys match: the password is correct")
+		result = "Password matches"
+	} else {
+		v.AddStep("❌ Keys differ: the password is incorrect")
+	}
+
+	// Add security notes
+	v.AddSeparator()
+	v.AddNote("Security Considerations:")
+	v.AddNote("1. Keys are compared in constant time so timing does not reveal how many bytes matched")
+	v.AddNote("2. Verification costs as much as derivation, which throttles online guessing")
+	v.AddNote("3. Storing parameters with the key lets them be raised later without breaking old records")
+
+	return result, v.GetSteps(), nil
+}
+
+// addPasswordWarnings adds password strength warnings to the visualization
+func addPasswordWarnings(v *utils.Visualizer, text string) {
+	// Password strength analysis
+	if len(text) < 8 {
+		v.AddStep("⚠️  Warning: Password is too short (less than 8 characters)")
+		v.AddStep("    This makes it more vulnerable to brute-force attacks")
+		v.AddStep("    Recommendation: Use at least 12 characters")
+	} else if len(text) < 12 {
+		v.AddStep("⚠️  Warning: Password could be stronger")
+		v.AddStep("    Recommendation: Use at least 12 characters")
+	}
+
+	// Check for common patterns
+	if isCommonPassword(text) {
+		v.AddStep("⚠️  Warning: This appears to be a common password pattern")
+		v.AddStep("    Recommendation: Use a more unique password")
+	}
+}
+
 // isCommonPassword checks if the password matches common patterns
 func isCommonPassword(password string) bool {
 	// Convert to lowercase for case-insensitive comparison
@@ -172,3 +873,19 @@ func isCommonPassword(password string) bool {
 
 	return false
 }
+
+// Parameters returns the processor's effective settings
+func (p *PBKDFProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "algorithm", Description: "Algorithm (pbkdf2, argon2id, argon2i, scrypt, bcrypt)", Value: p.algorithm},
+		{Name: "iterations", Description: "PBKDF2 iterations or Argon2 passes (0 = algorithm default)", Value: p.iterations},
+		{Name: "memory", Description: "Memory cost in KiB for Argon2 and scrypt", Value: p.memory},
+		{Name: "threads", Description: "Argon2 parallelism (1-255)", Value: p.threads},
+		{Name: "keyLength", Description: "Derived key length in bytes (16-64)", Value: p.keyLength},
+		{Name: "cost", Description: "bcrypt cost factor (4-31)", Value: p.cost},
+		{Name: "compare", Description: "Compare the KDF with a single SHA-256 instead of only deriving a key", Value: p.compare},
+		{Name: "saltSize", Description: "Salt size in bytes", Value: p.saltSize},
+		{Name: "salt", Description: "Fixed salt in hex or base64 (empty = random)", Value: hex.EncodeToString(p.salt)},
+		{Name: "keyFile", Description: "File the key is stored in", Value: keyFileOf(p.keyManager)},
+	}
+}
@@ -1,7 +1,16 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"strings"
 	"testing"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
 func TestPBKDFProcessor_Configure(t *testing.T) {
@@ -38,3 +47,404 @@ func TestPBKDFProcessor_Process_SHA256(t *testing.T) {
 		t.Error("Expected non-empty steps for PBKDF2-SHA256")
 	}
 }
+
+func TestPBKDFProcessor_Process_Bcrypt(t *testing.T) {
+	processor := NewPBKDFProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"algorithm": PBKDFAlgorithmBcrypt,
+		"cost":      bcrypt.MinCost,
+		"keyFile":   "keys/test_pbkdf_key.bin",
+	}); err != nil {
+		t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+	}
+
+	password := "correct horse battery staple"
+	result, steps, err := processor.Process(password, OperationEncrypt)
+	if err != nil {
+		t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(result), []byte(password)); err != nil {
+		t.Errorf("bcrypt hash does not verify: %v", err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(result)); cost != bcrypt.MinCost {
+		t.Errorf("bcrypt cost = %d, want %d", cost, bcrypt.MinCost)
+	}
+	if containsStep(steps, "only uses the first 72 bytes") {
+		t.Error("Unexpected truncation warning for a short password")
+	}
+
+	// Passwords longer than 72 bytes are truncated with a warning
+	long := strings.Repeat("a", 72) + "ignored"
+	result, steps, err = processor.Process(long, OperationEncrypt)
+	if err != nil {
+		t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+	}
+	if !containsStep(steps, "only uses the first 72 bytes") {
+		t.Error("Expected truncation warning for a password over 72 bytes")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(result), []byte(strings.Repeat("a", 72))); err != nil {
+		t.Errorf("Truncated bcrypt hash does not verify: %v", err)
+	}
+}
+
+func TestPBKDFProcessor_Configure_InvalidBcrypt(t *testing.T) {
+	processor := NewPBKDFProcessor()
+	if err := processor.Configure(map[string]interface{}{"cost": 32, "keyFile": "keys/test_pbkdf_key.bin"}); err == nil {
+		t.Error("Expected error for bcrypt cost 32")
+	}
+	if err := processor.Configure(map[string]interface{}{"algorithm": "md5crypt", "keyFile": "keys/test_pbkdf_key.bin"}); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+}
+
+func TestPBKDFProcessor_Verify(t *testing.T) {
+	salt := []byte("fixed-test-salt!")
+	stored := (&phcHash{
+		id:     pbkdf2PHCID,
+		params: []phcParam{{"i", 1000}},
+		salt:   salt,
+		hash:   pbkdf2.Key([]byte("correct horse"), salt, 1000, 32, sha256.New),
+	}).String()
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to generate bcrypt hash: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		stored   string
+		password string
+		want     string
+	}{
+		{"pbkdf2 match", stored, "correct horse", "Password matches"},
+		{"pbkdf2 mismatch", stored, "wrong horse", "Password does not match"},
+		{"bcrypt match", string(bcryptHash), "correct horse", "Password matches"},
+		{"bcrypt mismatch", string(bcryptHash), "wrong horse", "Password does not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewPBKDFProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"storedHash": tt.stored,
+				"keyFile":    "keys/test_pbkdf_key.bin",
+			}); err != nil {
+				t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+			}
+			result, _, err := processor.Process(tt.password, OperationVerify)
+			if err != nil {
+				t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Verify result = %q, want %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestPBKDFProcessor_PHCRoundTrip(t *testing.T) {
+	tests := []struct {
+		algorithm  string
+		iterations int
+		prefix     string
+	}{
+		{PBKDFAlgorithmPBKDF2, 20000, "$pbkdf2-sha256$i=20000$"},
+		{PBKDFAlgorithmArgon2id, 2, "$argon2id$v=19$m=8192,t=2,p=2$"},
+		{PBKDFAlgorithmArgon2i, 2, "$argon2i$v=19$m=8192,t=2,p=2$"},
+		{PBKDFAlgorithmScrypt, 0, "$scrypt$ln=13,r=8,p=1$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			processor := NewPBKDFProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"algorithm":  tt.algorithm,
+				"iterations": tt.iterations,
+				"memory":     uint32(8192),
+				"threads":    uint8(2),
+				"keyLength":  uint32(24),
+				"keyFile":    "keys/test_pbkdf_key.bin",
+			}); err != nil {
+				t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+			}
+			encoded, _, err := processor.Process("password123", OperationEncrypt)
+			if err != nil {
+				t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+			}
+			if !strings.HasPrefix(encoded, tt.prefix) {
+				t.Errorf("PHC string = %s, want prefix %s", encoded, tt.prefix)
+			}
+			parsed, err := parsePHC(encoded)
+			if err != nil {
+				t.Fatalf("parsePHC() error = %v", err)
+			}
+			if len(parsed.hash) != 24 {
+				t.Errorf("Derived key length = %d, want 24", len(parsed.hash))
+			}
+
+			if err := processor.Configure(map[string]interface{}{"storedHash": encoded}); err != nil {
+				t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+			}
+			for password, want := range map[string]string{
+				"password123": "Password matches",
+				"password124": "Password does not match",
+			} {
+				result, _, err := processor.Process(password, OperationVerify)
+				if err != nil {
+					t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+				}
+				if result != want {
+					t.Errorf("Verify(%q) = %q, want %q", password, result, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPBKDFProcessor_VerifyInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+	}{
+		{"empty", ""},
+		{"not phc", "pbkdf2-sha256$1000$c2FsdA$a2V5"},
+		{"unknown algorithm", "$md5$i=1000$c2FsdA$a2V5"},
+		{"missing parameter", "$pbkdf2-sha256$c2FsdA$a2V5"},
+		{"bad iterations", "$pbkdf2-sha256$i=0$c2FsdA$a2V5"},
+		{"bad salt", "$pbkdf2-sha256$i=1000$!!!$a2V5"},
+		{"argon2 version", "$argon2id$v=16$m=65536,t=3,p=4$c2FsdA$a2V5"},
+		{"argon2 memory", "$argon2id$v=19$m=1,t=3,p=4$c2FsdA$a2V5"},
+		{"scrypt cost", "$scrypt$ln=40,r=8,p=1$c2FsdA$a2V5"},
+		{"scrypt memory", "$scrypt$ln=24,r=4096,p=1$c2FsdA$a2V5"},
+		{"scrypt block size", "$scrypt$ln=14,r=0,p=1$c2FsdA$a2V5"},
+		{"scrypt parallelism", "$scrypt$ln=14,r=8,p=0$c2FsdA$a2V5"},
+		{"bad bcrypt", "$2a$99$tooshort"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewPBKDFProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"storedHash": tt.stored,
+				"keyFile":    "keys/test_pbkdf_key.bin",
+			}); err != nil {
+				t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+			}
+			if _, _, err := processor.Process("password", OperationVerify); err == nil {
+				t.Error("Expected error for invalid stored value, got nil")
+			}
+		})
+	}
+
+	if _, _, err := NewPBKDFProcessor().Process("password", OperationDecrypt); err == nil {
+		t.Error("Expected error for decrypt operation, got nil")
+	}
+}
+
+func TestPBKDFProcessor_WorkFactorBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		prefix  string
+		warning string
+	}{
+		{
+			name:    "pbkdf2 iterations raised",
+			config:  map[string]interface{}{"algorithm": PBKDFAlgorithmPBKDF2, "iterations": 3},
+			prefix:  "$pbkdf2-sha256$i=10000$",
+			warning: "raising to the minimum of 10000",
+		},
+		{
+			name:    "argon2id passes capped",
+			config:  map[string]interface{}{"algorithm": PBKDFAlgorithmArgon2id, "iterations": 100000, "memory": 64, "threads": 1},
+			prefix:  "$argon2id$v=19$m=64,t=10,p=1$",
+			warning: "too many Argon2id passes",
+		},
+		{
+			name:    "argon2id memory raised",
+			config:  map[string]interface{}{"algorithm": PBKDFAlgorithmArgon2id, "iterations": 1, "memory": 8, "threads": 4},
+			prefix:  "$argon2id$v=19$m=32,t=1,p=4$",
+			warning: "at least 8 KiB per thread",
+		},
+		{
+			name:    "scrypt N raised",
+			config:  map[string]interface{}{"algorithm": PBKDFAlgorithmScrypt, "memory": 64},
+			prefix:  "$scrypt$ln=10,r=8,p=1$",
+			warning: "too little memory for scrypt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewPBKDFProcessor()
+			tt.config["keyFile"] = "keys/test_pbkdf_key.bin"
+			if err := processor.Configure(tt.config); err != nil {
+				t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+			}
+			encoded, steps, err := processor.Process("password123", OperationEncrypt)
+			if err != nil {
+				t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+			}
+			if !strings.HasPrefix(encoded, tt.prefix) {
+				t.Errorf("PHC string = %s, want prefix %s", encoded, tt.prefix)
+			}
+			if !containsStep(steps, tt.warning) {
+				t.Errorf("Expected warning containing %q", tt.warning)
+			}
+		})
+	}
+}
+
+func TestPBKDFProcessor_Configure_InvalidWorkFactors(t *testing.T) {
+	for _, config := range []map[string]interface{}{
+		{"iterations": -1},
+		{"memory": uint32(4)},
+		{"threads": 256},
+		{"keyLength": uint32(8)},
+		{"keyLength": 65},
+	} {
+		config["keyFile"] = "keys/test_pbkdf_key.bin"
+		if err := NewPBKDFProcessor().Configure(config); err == nil {
+			t.Errorf("Expected error for %v", config)
+		}
+	}
+}
+
+func TestPBKDFProcessor_FixedSalt(t *testing.T) {
+	newProcessor := func(salt string) *PBKDFProcessor {
+		processor := NewPBKDFProcessor()
+		if err := processor.Configure(map[string]interface{}{
+			"iterations": 20000,
+			"salt":       salt,
+			"keyFile":    "keys/test_pbkdf_key.bin",
+		}); err != nil {
+			t.Fatalf("Failed to configure PBKDFProcessor: %v", err)
+		}
+		return processor
+	}
+
+	// The same salt in hex and base64 reproduces the same derivation
+	first, _, err := newProcessor("000102030405060708090a0b0c0d0e0f").Process("password123", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+	}
+	second, steps, err := newProcessor("AAECAwQFBgcICQoLDA0ODw==").Process("password123", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("Fixed salt derivations differ: %s vs %s", first, second)
+	}
+	if !strings.Contains(first, "$AAECAwQFBgcICQoLDA0ODw$") {
+		t.Errorf("PHC string %s does not contain the fixed salt", first)
+	}
+	if !containsStep(steps, "Salt reuse detected") {
+		t.Error("Expected a salt reuse warning on the second derivation")
+	}
+
+	// Random salts are never reported as reused
+	_, steps, err = newProcessor("").Process("password123", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("PBKDFProcessor.Process() error = %v", err)
+	}
+	if containsStep(steps, "Salt reuse detected") {
+		t.Error("Unexpected salt reuse warning for a random salt")
+	}
+
+	for _, salt := range []string{"not a salt!", "0001020304"} {
+		if err := NewPBKDFProcessor().Configure(map[string]interface{}{"salt": salt, "keyFile": "keys/test_pbkdf_key.bin"}); err == nil {
+			t.Errorf("Expected error for salt %q", salt)
+		}
+	}
+}
+
+func containsStep(steps []utils.Step, text string) bool {
+	for _, step := range steps {
+		if strings.Contains(step.Text, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPBKDFProcessor_MemoryHardness(t *testing.T) {
+	processor := NewPBKDFProcessor()
+	if err := processor.Configure(map[string]interface{}{"algorithm": "argon2id", "memory": 1024}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	_, steps, err := processor.Process("correct horse battery", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	output := strings.Join(utils.StepTexts(steps), "\n")
+	for _, want := range []string{"Memory Hardness:", "Argon2id m=1024 → ~1 MB of RAM per guess", "◀ this run"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the steps, got:\n%s", want, output)
+		}
+	}
+
+	tests := []struct {
+		algorithm string
+		params    []phcParam
+		want      uint64
+	}{
+		{PBKDFAlgorithmArgon2id, []phcParam{{"m", 65536}, {"t", 3}, {"p", 4}}, 64 << 20},
+		{PBKDFAlgorithmScrypt, []phcParam{{"ln", 16}, {"r", 8}, {"p", 1}}, 64 << 20},
+		{PBKDFAlgorithmBcrypt, nil, bcryptFootprint},
+		{PBKDFAlgorithmPBKDF2, []phcParam{{"i", 100000}}, pbkdf2Footprint},
+	}
+	for _, tt := range tests {
+		if got := memoryFootprint(tt.algorithm, tt.params); got != tt.want {
+			t.Errorf("memoryFootprint(%s) = %d, want %d", tt.algorithm, got, tt.want)
+		}
+	}
+}
+
+func TestPBKDFProcessor_Argon2i(t *testing.T) {
+	processor := NewPBKDFProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"algorithm": "argon2i",
+		"memory":    1024,
+		"threads":   1,
+		"salt":      "73616c7473616c74",
+	}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	encoded, steps, err := processor.Process("password123", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	parsed, err := parsePHC(encoded)
+	if err != nil {
+		t.Fatalf("parsePHC() error = %v", err)
+	}
+	want := argon2.Key([]byte("password123"), []byte("saltsalt"), argon2idTime, 1024, 1, pbkdfKeyLength)
+	if !bytes.Equal(parsed.hash, want) {
+		t.Errorf("Argon2i key = %x, want %x", parsed.hash, want)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Argon2 Variants:") {
+		t.Error("Expected the Argon2 variants comparison in the steps")
+	}
+}
+
+func TestPBKDFProcessor_CompareWithHash(t *testing.T) {
+	for _, config := range []map[string]interface{}{
+		{"algorithm": "argon2id", "memory": 1024, "compare": true},
+		{"algorithm": "bcrypt", "cost": 4, "compare": true},
+	} {
+		processor := NewPBKDFProcessor()
+		if err := processor.Configure(config); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		result, steps, err := processor.Process("hunter2", OperationEncrypt)
+		if err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+		if !strings.HasSuffix(result, "slower per guess than SHA-256") {
+			t.Errorf("Unexpected result %q", result)
+		}
+		output := strings.Join(utils.StepTexts(steps), "\n")
+		for _, want := range []string{"1. Fast hash: SHA-256(salt || password)", "Offline Attack on One CPU Core", "8 letters and digits"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected %q in the %s comparison", want, config["algorithm"])
+			}
+		}
+	}
+}
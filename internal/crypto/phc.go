@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// phcParam is one name=value parameter of a PHC string
+type phcParam struct {
+	name  string
+	value int
+}
+
+// phcHash is a password hash in the PHC string format:
+// $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*]$<salt>$<hash>
+type phcHash struct {
+	id      string
+	version int // zero when the format has no version field
+	params  []phcParam
+	salt    []byte
+	hash    []byte
+}
+
+// phcEncoding is the unpadded standard base64 alphabet used by PHC strings
+var phcEncoding = base64.RawStdEncoding
+
+// String encodes the hash as a PHC string
+func (h *phcHash) String() string {
+	var b strings.Builder
+	b.WriteString("$" + h.id)
+	if h.version != 0 {
+		fmt.Fprintf(&b, "$v=%d", h.version)
+	}
+	if len(h.params) > 0 {
+		params := make([]string, len(h.params))
+		for i, param := range h.params {
+			params[i] = fmt.Sprintf("%s=%d", param.name, param.value)
+		}
+		b.WriteString("$" + strings.Join(params, ","))
+	}
+	b.WriteString("$" + phcEncoding.EncodeToString(h.salt))
+	b.WriteString("$" + phcEncoding.EncodeToString(h.hash))
+	return b.String()
+}
+
+// param returns the value of a named parameter
+func (h *phcHash) param(name string) (int, error) {
+	for _, param := range h.params {
+		if param.name == name {
+			return param.value, nil
+		}
+	}
+	return 0, fmt.Errorf("missing %s parameter in %s hash", name, h.id)
+}
+
+// parsePHC parses a PHC string with integer parameters
+func parsePHC(encoded string) (*phcHash, error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) < 4 || fields[0] != "" || fields[1] == "" {
+		return nil, fmt.Errorf("invalid PHC string: expected $<id>$<params>$<salt>$<hash>")
+	}
+
+	h := &phcHash{id: fields[1]}
+	rest := fields[2 : len(fields)-2]
+	if len(rest) > 0 && strings.HasPrefix(rest[0], "v=") {
+		version, err := strconv.Atoi(strings.TrimPrefix(rest[0], "v="))
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid PHC version: %s", rest[0])
+		}
+		h.version = version
+		rest = rest[1:]
+	}
+	if len(rest) > 1 {
+		return nil, fmt.Errorf("invalid PHC string: too many fields")
+	}
+	if len(rest) == 1 {
+		for _, pair := range strings.Split(rest[0], ",") {
+			name, value, ok := strings.Cut(pair, "=")
+			if !ok || name == "" {
+				return nil, fmt.Errorf("invalid PHC parameter: %s", pair)
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid PHC parameter: %s", pair)
+			}
+			h.params = append(h.params, phcParam{name: name, value: n})
+		}
+	}
+
+	salt, err := phcEncoding.DecodeString(fields[len(fields)-2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt encoding: %w", err)
+	}
+	hash, err := phcEncoding.DecodeString(fields[len(fields)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash encoding: %w", err)
+	}
+	if len(hash) == 0 {
+		return nil, fmt.Errorf("invalid PHC string: empty hash")
+	}
+	h.salt = salt
+	h.hash = hash
+	return h, nil
+}
@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPHCHash_StringAndParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    *phcHash
+		encoded string
+	}{
+		{
+			name: "argon2id",
+			hash: &phcHash{
+				id:      "argon2id",
+				version: 19,
+				params:  []phcParam{{"m", 65536}, {"t", 3}, {"p", 4}},
+				salt:    []byte("saltsalt"),
+				hash:    []byte("hash"),
+			},
+			encoded: "$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHQ$aGFzaA",
+		},
+		{
+			name: "pbkdf2 without version",
+			hash: &phcHash{
+				id:     "pbkdf2-sha256",
+				params: []phcParam{{"i", 1000}},
+				salt:   []byte("saltsalt"),
+				hash:   []byte("hash"),
+			},
+			encoded: "$pbkdf2-sha256$i=1000$c2FsdHNhbHQ$aGFzaA",
+		},
+		{
+			name:    "no parameters",
+			hash:    &phcHash{id: "plain", salt: []byte("s"), hash: []byte("h")},
+			encoded: "$plain$cw$aA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hash.String(); got != tt.encoded {
+				t.Errorf("String() = %s, want %s", got, tt.encoded)
+			}
+			parsed, err := parsePHC(tt.encoded)
+			if err != nil {
+				t.Fatalf("parsePHC() error = %v", err)
+			}
+			if parsed.id != tt.hash.id || parsed.version != tt.hash.version || len(parsed.params) != len(tt.hash.params) {
+				t.Errorf("parsePHC() = %+v, want %+v", parsed, tt.hash)
+			}
+			for i, param := range tt.hash.params {
+				if parsed.params[i] != param {
+					t.Errorf("param %d = %+v, want %+v", i, parsed.params[i], param)
+				}
+			}
+			if !bytes.Equal(parsed.salt, tt.hash.salt) || !bytes.Equal(parsed.hash, tt.hash.hash) {
+				t.Error("parsePHC() did not round-trip salt and hash")
+			}
+		})
+	}
+}
+
+func TestParsePHC_Invalid(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"argon2id$v=19$c2FsdA$aGFzaA",
+		"$argon2id$v=x$m=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=1$extra$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=big$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=1$c2FsdA$",
+		"$argon2id$v=19$m=1$c2FsdA==$aGFzaA",
+	} {
+		if _, err := parsePHC(encoded); err == nil {
+			t.Errorf("parsePHC(%q) expected error, got nil", encoded)
+		}
+	}
+}
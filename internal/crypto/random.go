@@ -0,0 +1,15 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"io"
+)
+
+// randomSource returns the injected reader, or crypto/rand.Reader when none was configured.
+// Processors accept a "rand" io.Reader in Configure so tests can make their output reproducible.
+func randomSource(r io.Reader) io.Reader {
+	if r == nil {
+		return rand.Reader
+	}
+	return r
+}
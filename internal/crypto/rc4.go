@@ -0,0 +1,183 @@
+package crypto
+
+import (
+	"crypto/rc4"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// RC4Processor demonstrates the RC4 stream cipher for teaching purposes only
+type RC4Processor struct {
+	BaseConfigurableProcessor
+	keyManager KeyManager
+	keySize    int
+}
+
+// NewRC4Processor creates a new RC4 processor
+func NewRC4Processor() *RC4Processor {
+	return &RC4Processor{
+		keySize: 128, // WEP-era and TLS RC4 suites commonly used 128-bit keys
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *RC4Processor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	// Ensure keys directory exists
+	if err := os.MkdirAll("keys", 0700); err != nil {
+		return fmt.Errorf("failed to create keys directory: %w", err)
+	}
+
+	// Configure key size if provided
+	if keySize, ok := config["keySize"].(int); ok && keySize != 0 {
+		if keySize < 40 || keySize > 2048 || keySize%8 != 0 {
+			return fmt.Errorf("invalid key size: %d (must be a multiple of 8 between 40 and 2048)", keySize)
+		}
+		p.keySize = keySize
+	}
+
+	// Configure key file if provided
+	keyFile := "keys/rc4_key.bin"
+	if kf, ok := config["keyFile"].(string); ok && kf != "" {
+		keyFile = kf
+	}
+
+	// Initialize key manager
+	p.keyManager = NewFileKeyManager(p.keySize, keyFile)
+	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
+		return fmt.Errorf("failed to load/generate key: %w", err)
+	}
+
+	return nil
+}
+
+// Process implements the Processor interface
+func (p *RC4Processor) Process(text string, operation string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	// Check for empty input
+	if text == "" {
+		return "", nil, fmt.Errorf("empty input")
+	}
+
+	// Validate operation type
+	if operation != OperationEncrypt && operation != OperationDecrypt {
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	// Add introduction
+	v.AddHeader("RC4 Stream Cipher Process")
+	v.AddStep("⚠️ INSECURE - EDUCATIONAL ONLY: RC4 is broken and prohibited in TLS (RFC 7465)")
+	v.AddNote("RC4 generates a pseudo-random keystream that is XORed with the data")
+	v.AddNote("Encryption and decryption are the same operation")
+	v.AddSeparator()
+
+	// Show key information
+	v.AddStep("Key Information:")
+	v.AddStep(fmt.Sprintf("Key Size: %d bits", p.keySize))
+	v.AddStep("Nonce/IV: none - the same key always produces the same keystream")
+	v.AddHexStep("Key", p.keyManager.GetKey())
+	v.AddSeparator()
+
+	// Get the input bytes
+	var data []byte
+	if operation == OperationDecrypt {
+		v.AddTextStep("Encrypted Input (Base64)", text)
+		decoded, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+		}
+		data = decoded
+		v.AddHexStep("Ciphertext", data)
+	} else {
+		data = []byte(text)
+		v.AddTextStep("Input Text", text)
+		v.AddHexStep("Plaintext Bytes", data)
+	}
+	v.AddArrow()
+
+	// Generate the keystream by encrypting zero bytes
+	keystream, err := p.keystream(len(data))
+	if err != nil {
+		return "", nil, err
+	}
+	v.AddStep("Keystream Generation (KSA + PRGA):")
+	v.AddStep("1. KSA: permute the 256-byte state S using the key")
+	v.AddStep("2. PRGA: swap state bytes and output S[(S[i] + S[j]) mod 256] per byte")
+	v.AddHexStep("Keystream", keystream)
+	v.AddArrow()
+
+	// XOR data with the keystream
+	output := make([]byte, len(data))
+	for i := range data {
+		output[i] = data[i] ^ keystream[i]
+	}
+	v.AddStep("Data XOR Keystream:")
+	for i := 0; i < len(data) && i < 8; i++ {
+		v.AddStep(fmt.Sprintf("  %02x XOR %02x = %02x", data[i], keystream[i], output[i]))
+	}
+	if len(data) > 8 {
+		v.AddStep(fmt.Sprintf("  ... (%d more bytes)", len(data)-8))
+	}
+	v.AddArrow()
+
+	var result string
+	if operation == OperationDecrypt {
+		result = string(output)
+		v.AddTextStep("Decrypted Text", result)
+	} else {
+		v.AddHexStep("Ciphertext", output)
+		result = base64.StdEncoding.EncodeToString(output)
+		v.AddTextStep("Base64 Encoded Result", result)
+	}
+
+	// Explain why RC4 is broken
+	v.AddSeparator()
+	v.AddHeader("🔒 Why RC4 Is Broken")
+	v.AddStep("1. Biased keystream bytes:")
+	v.AddStep("   • The second output byte is 0x00 with probability 2/256 instead of 1/256 (Mantin-Shamir)")
+	v.AddStep("   • Early bytes leak key information; many biases persist throughout the stream")
+	v.AddStep("   • With many encryptions of the same plaintext, these biases recover it (RC4 NOMORE, Bar Mitzvah)")
+	v.AddStep("2. Fluhrer-Mantin-Shamir (FMS) attack:")
+	v.AddStep("   • When an IV is prepended to the key (as in WEP), weak IVs leak key bytes")
+	v.AddStep("   • Collecting enough packets reveals the full WEP key in minutes")
+	v.AddStep("3. No nonce and no authentication:")
+	v.AddStep("   • Reusing a key reuses the keystream: C1 XOR C2 = P1 XOR P2")
+	v.AddStep("   • Bit flips in the ciphertext flip the same bits in the plaintext")
+	v.AddStep("4. Use ChaCha20-Poly1305 or AES-GCM instead")
+
+	return result, v.GetSteps(), nil
+}
+
+// keystream returns the first n bytes of the RC4 keystream for the current key
+func (p *RC4Processor) keystream(n int) ([]byte, error) {
+	c, err := rc4.NewCipher(p.keyManager.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	keystream := make([]byte, n)
+	c.XORKeyStream(keystream, keystream)
+	return keystream, nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *RC4Processor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "keySize", Description: "Key size in bits (40-2048, multiple of 8)", Value: p.keySize},
+		{Name: "keyFile", Description: "File the key is stored in", Value: keyFileOf(p.keyManager)},
+	}
+}
+
+// AuditSources lists the randomness sources and libraries used for RC4
+func (p *RC4Processor) AuditSources() []AuditSource {
+	return []AuditSource{
+		keyManagerRandomness,
+		{Kind: AuditStandardLibrary, Package: "crypto/rc4", Purpose: "RC4 keystream (insecure, educational only)"},
+	}
+}
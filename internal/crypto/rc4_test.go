@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"crypto/rc4"
+	"encoding/base64"
+	"testing"
+)
+
+func TestRC4Processor_Process(t *testing.T) {
+	processor := NewRC4Processor()
+	if err := processor.Configure(map[string]interface{}{
+		"keyFile": "keys/test_rc4_key.bin",
+	}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+
+	plaintext := "Attack at dawn"
+	encrypted, steps, err := processor.Process(plaintext, OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	if len(steps) == 0 {
+		t.Error("Encryption returned no steps")
+	}
+
+	// Compare against the standard library directly
+	c, err := rc4.NewCipher(processor.keyManager.GetKey())
+	if err != nil {
+		t.Fatalf("Failed to create reference cipher: %v", err)
+	}
+	expected := make([]byte, len(plaintext))
+	c.XORKeyStream(expected, []byte(plaintext))
+	if encrypted != base64.StdEncoding.EncodeToString(expected) {
+		t.Errorf("Ciphertext mismatch with crypto/rc4")
+	}
+
+	decrypted, _, err := processor.Process(encrypted, OperationDecrypt)
+	if err != nil {
+		t.Fatalf("Decryption failed: %v", err)
+	}
+	if decrypted != plaintext {
+		t.Errorf("Decryption result = %v, want %v", decrypted, plaintext)
+	}
+}
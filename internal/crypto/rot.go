@@ -0,0 +1,125 @@
+package crypto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// ROT variants supported by the ROTProcessor
+const (
+	ROT13 = "rot13"
+	ROT47 = "rot47"
+)
+
+// ROTProcessor applies ROT13 or ROT47. Both rotate by half their alphabet, so applying
+// them twice gives back the input and encryption and decryption are the same operation.
+type ROTProcessor struct {
+	BaseConfigurableProcessor
+	variant string
+}
+
+// NewROTProcessor creates a new ROT processor using ROT13
+func NewROTProcessor() *ROTProcessor {
+	return &ROTProcessor{
+		variant: ROT13,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *ROTProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if variant, ok := config["variant"].(string); ok && variant != "" {
+		switch variant {
+		case ROT13, ROT47:
+			p.variant = variant
+		default:
+			return fmt.Errorf("unsupported ROT variant: %s (must be %s or %s)", variant, ROT13, ROT47)
+		}
+	}
+
+	return nil
+}
+
+// rot13 rotates ASCII letters by 13, keeping case and other characters
+func rot13(char rune) rune {
+	switch {
+	case char >= 'a' && char <= 'z':
+		return 'a' + (char-'a'+13)%26
+	case char >= 'A' && char <= 'Z':
+		return 'A' + (char-'A'+13)%26
+	default:
+		return char
+	}
+}
+
+// rot47 rotates the 94 printable ASCII characters from '!' to '~' by 47
+func rot47(char rune) rune {
+	if char >= '!' && char <= '~' {
+		return '!' + (char-'!'+47)%94
+	}
+	return char
+}
+
+// Process implements the Processor interface; encryption and decryption are identical
+func (p *ROTProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	if operation != OperationEncrypt && operation != OperationDecrypt {
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	rotate, name := rot13, "ROT13"
+	if p.variant == ROT47 {
+		rotate, name = rot47, "ROT47"
+	}
+
+	v := utils.NewVisualizer()
+	v.AddHeader(fmt.Sprintf("%s Process", name))
+	if p.variant == ROT47 {
+		v.AddNote("ROT47 rotates the 94 printable ASCII characters ('!' to '~') by 47 positions")
+	} else {
+		v.AddNote("ROT13 is a Caesar cipher with a fixed shift of 13 on the letters A-Z")
+	}
+	v.AddNote("The shift is half the alphabet, so the same operation encrypts and decrypts")
+	v.AddSeparator()
+
+	// Show the mapping table: each half of the alphabet maps onto the other
+	v.AddStep("Mapping Table:")
+	first, second := "ABCDEFGHIJKLM", "NOPQRSTUVWXYZ"
+	if p.variant == ROT47 {
+		var b strings.Builder
+		for char := '!'; char < '!'+47; char++ {
+			b.WriteRune(char)
+		}
+		first = b.String()
+		second = strings.Map(rot47, first)
+	}
+	v.AddStep(fmt.Sprintf("  %s", first))
+	v.AddStep(fmt.Sprintf("  %s", strings.Repeat("↕", len(first))))
+	v.AddStep(fmt.Sprintf("  %s", second))
+	if p.variant == ROT13 {
+		v.AddStep("  (lowercase letters map the same way)")
+	}
+	v.AddSeparator()
+
+	result := strings.Map(rotate, text)
+	v.AddTextStep("Input", text)
+	v.AddTextStep(fmt.Sprintf("%s Output", name), result)
+	v.AddTextStep(fmt.Sprintf("%s Applied Twice", name), strings.Map(rotate, result))
+
+	v.AddSeparator()
+	v.AddNote("ROT13 and ROT47 hide text from a casual glance (spoilers, puzzle answers)")
+	v.AddNote("They have no key: anyone who knows the name can undo them, so they offer no security")
+
+	return result, v.GetSteps(), nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *ROTProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "variant", Description: "rot13 (letters) or rot47 (printable ASCII)", Value: p.variant},
+	}
+}
@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestROTProcessor_Process(t *testing.T) {
+	tests := []struct {
+		variant string
+		input   string
+		want    string
+	}{
+		{ROT13, "Hello, World!", "Uryyb, Jbeyq!"},
+		{ROT13, "Привет 123", "Привет 123"},
+		{ROT47, "Hello, World!", "w6==@[ (@C=5P"},
+		{ROT47, "The Quick Brown Fox", "%96 \"F:4< qC@H? u@I"},
+	}
+	for _, tt := range tests {
+		processor := NewROTProcessor()
+		if err := processor.Configure(map[string]interface{}{"variant": tt.variant}); err != nil {
+			t.Fatalf("Configure(%s) error = %v", tt.variant, err)
+		}
+		result, steps, err := processor.Process(tt.input, OperationEncrypt)
+		if err != nil {
+			t.Fatalf("Process(%s) error = %v", tt.variant, err)
+		}
+		if result != tt.want {
+			t.Errorf("%s(%q) = %q, want %q", tt.variant, tt.input, result, tt.want)
+		}
+		if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Mapping Table:") {
+			t.Errorf("%s: expected the mapping table", tt.variant)
+		}
+
+		// The transform is its own inverse
+		decrypted, _, err := processor.Process(result, OperationDecrypt)
+		if err != nil {
+			t.Fatalf("Process(%s) decrypt error = %v", tt.variant, err)
+		}
+		if decrypted != tt.input {
+			t.Errorf("%s round trip = %q, want %q", tt.variant, decrypted, tt.input)
+		}
+	}
+
+	if err := NewROTProcessor().Configure(map[string]interface{}{"variant": "rot5"}); err == nil {
+		t.Error("Expected an error for an unsupported variant")
+	}
+	if _, _, err := NewROTProcessor().Process("test", "invalid"); err == nil {
+		t.Error("Expected an error for an invalid operation")
+	}
+}
@@ -1,29 +1,54 @@
 package crypto
 
 import (
-	"crypto/rand"
+	"context"
 	"crypto/rsa"
-	"crypto/x509"
-	"encoding/base64"
+	"crypto/sha256"
 	"encoding/pem"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 
 	"github.com/abdorrahmani/cryptolens/internal/utils"
 )
 
+// RSA encryption paddings
+const (
+	RSAPaddingOAEP     = "oaep"
+	RSAPaddingPKCS1v15 = "pkcs1v15"
+)
+
+// RSA encryption modes
+const (
+	// RSAModeDirect encrypts the message itself with RSA
+	RSAModeDirect = "direct"
+	// RSAModeHybrid wraps a random AES key with RSA and encrypts the message with AES-GCM
+	RSAModeHybrid = "hybrid"
+)
+
 // RSAProcessor implements RSA encryption/decryption
 type RSAProcessor struct {
 	BaseConfigurableProcessor
-	keySize    int
-	publicKey  *rsa.PublicKey
-	privateKey *rsa.PrivateKey
+	keySize        int
+	padding        string
+	mode           string
+	keyFormat      string // Format for newly generated keys: pkcs1 or pkcs8 (PKCS#8 private, PKIX public)
+	outputEncoding string // Encoding of encrypted results; decryption accepts any
+	publicKeyFile  string
+	privateKeyFile string
+	publicKey      *rsa.PublicKey
+	privateKey     *rsa.PrivateKey
+	rand           io.Reader
 }
 
 // NewRSAProcessor creates a new RSA processor
 func NewRSAProcessor() *RSAProcessor {
 	return &RSAProcessor{
-		keySize: 2048, // Default to 2048-bit keys
+		keySize:   2048, // Default to 2048-bit keys
+		padding:   RSAPaddingOAEP,
+		mode:      RSAModeDirect,
+		keyFormat: KeyFormatPKCS1,
 	}
 }
 
@@ -38,6 +63,10 @@ func (p *RSAProcessor) Configure(config map[string]interface{}) error {
 		return fmt.Errorf("failed to create keys directory: %w", err)
 	}
 
+	if random, ok := config["rand"].(io.Reader); ok {
+		p.rand = random
+	}
+
 	// Configure key size if provided
 	if keySize, ok := config["keySize"].(int); ok {
 		switch keySize {
@@ -48,54 +77,97 @@ func (p *RSAProcessor) Configure(config map[string]interface{}) error {
 		}
 	}
 
+	// Configure padding if provided
+	if padding, ok := config["padding"].(string); ok && padding != "" {
+		switch padding {
+		case RSAPaddingOAEP, RSAPaddingPKCS1v15:
+			p.padding = padding
+		default:
+			return fmt.Errorf("invalid padding: %s (must be oaep or pkcs1v15)", padding)
+		}
+	}
+
+	if encoding, ok := config["outputEncoding"].(string); ok && encoding != "" {
+		if err := utils.ValidateEncoding(encoding); err != nil {
+			return err
+		}
+		p.outputEncoding = encoding
+	}
+
+	// Configure mode if provided
+	if mode, ok := config["mode"].(string); ok && mode != "" {
+		switch mode {
+		case RSAModeDirect, RSAModeHybrid:
+			p.mode = mode
+		default:
+			return fmt.Errorf("invalid mode: %s (must be direct or hybrid)", mode)
+		}
+	}
+
+	// Configure the format of generated keys if provided
+	if keyFormat, ok := config["keyFormat"].(string); ok && keyFormat != "" {
+		switch keyFormat {
+		case KeyFormatPKCS1, KeyFormatPKCS8:
+			p.keyFormat = keyFormat
+		default:
+			return fmt.Errorf("invalid key format: %s (must be pkcs1 or pkcs8)", keyFormat)
+		}
+	}
+
 	// Get key file paths
 	publicKeyFile := "keys/rsa_public.pem"
 	privateKeyFile := "keys/rsa_private.pem"
-	if pub, ok := config["publicKeyFile"].(string); ok {
+	if pub, ok := config["publicKeyFile"].(string); ok && pub != "" {
 		publicKeyFile = pub
 	}
-	if priv, ok := config["privateKeyFile"].(string); ok {
+	if priv, ok := config["privateKeyFile"].(string); ok && priv != "" {
 		privateKeyFile = priv
 	}
-
-	// Generate or load keys
-	if err := p.loadOrGenerateKeys(publicKeyFile, privateKeyFile); err != nil {
-		return fmt.Errorf("failed to load/generate keys: %w", err)
+	p.publicKeyFile = publicKeyFile
+	p.privateKeyFile = privateKeyFile
+
+	// Load existing keys. A missing pair is generated by the first operation instead, so that a
+	// slow generation (seconds for 4096 bits) can be interrupted through its context.
+	p.privateKey, p.publicKey = nil, nil
+	if err := p.loadKeys(publicKeyFile, privateKeyFile); err != nil {
+		slog.Debug("RSA key pair will be generated on first use", "privateKey", privateKeyFile, "reason", err)
+	} else {
+		slog.Debug("loaded RSA key pair", "privateKey", privateKeyFile, "publicKey", publicKeyFile)
 	}
 
 	return nil
 }
 
-// loadOrGenerateKeys loads existing keys or generates new ones
-func (p *RSAProcessor) loadOrGenerateKeys(publicKeyFile, privateKeyFile string) error {
-	// Try to load existing keys
-	if p.loadKeys(publicKeyFile, privateKeyFile) == nil {
-		return nil
-	}
-
-	// Generate new key pair
-	privateKey, err := rsa.GenerateKey(rand.Reader, p.keySize)
+// generateKeys generates a key pair and saves it to the configured files, giving up once ctx is done
+func (p *RSAProcessor) generateKeys(ctx context.Context) error {
+	slog.Debug("generating RSA key pair", "privateKey", p.privateKeyFile, "bits", p.keySize)
+	random, bits := randomSource(p.rand), p.keySize
+	privateKey, err := runContext(ctx, func() (*rsa.PrivateKey, error) {
+		return rsa.GenerateKey(random, bits)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to generate RSA key pair: %w", err)
 	}
 
 	// Save private key
-	privateKeyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
-	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: privateKeyBytes,
-	})
-	if err := os.WriteFile(privateKeyFile, privateKeyPEM, 0600); err != nil {
+	publicFormat := KeyFormatPKCS1
+	if p.keyFormat == KeyFormatPKCS8 {
+		publicFormat = KeyFormatPKIX
+	}
+	privateKeyPEM, err := EncodeKey(privateKey, p.keyFormat)
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+	if err := os.WriteFile(p.privateKeyFile, privateKeyPEM, 0600); err != nil {
 		return fmt.Errorf("failed to save private key: %w", err)
 	}
 
 	// Save public key
-	publicKeyBytes := x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)
-	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PUBLIC KEY",
-		Bytes: publicKeyBytes,
-	})
-	if err := os.WriteFile(publicKeyFile, publicKeyPEM, 0600); err != nil {
+	publicKeyPEM, err := EncodeKey(&privateKey.PublicKey, publicFormat)
+	if err != nil {
+		return fmt.Errorf("failed to encode public key: %w", err)
+	}
+	if err := os.WriteFile(p.publicKeyFile, publicKeyPEM, 0600); err != nil {
 		return fmt.Errorf("failed to save public key: %w", err)
 	}
 
@@ -104,35 +176,42 @@ func (p *RSAProcessor) loadOrGenerateKeys(publicKeyFile, privateKeyFile string)
 	return nil
 }
 
-// loadKeys attempts to load existing keys
+// loadKeys attempts to load existing keys. Both PKCS#1 ("RSA PRIVATE KEY", "RSA PUBLIC KEY")
+// and the OpenSSL defaults PKCS#8 ("PRIVATE KEY") and PKIX ("PUBLIC KEY") are accepted.
 func (p *RSAProcessor) loadKeys(publicKeyFile, privateKeyFile string) error {
 	// Load private key
 	privateKeyData, err := os.ReadFile(privateKeyFile)
 	if err != nil {
 		return err
 	}
-	privateKeyBlock, _ := pem.Decode(privateKeyData)
-	if privateKeyBlock == nil {
+	if block, _ := pem.Decode(privateKeyData); block == nil {
 		return fmt.Errorf("failed to decode private key PEM block")
 	}
-	privateKey, err := x509.ParsePKCS1PrivateKey(privateKeyBlock.Bytes)
+	key, _, err := ParseKey(privateKeyData, "")
 	if err != nil {
 		return fmt.Errorf("failed to parse private key: %w", err)
 	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("private key is %s, not RSA", keyTypeName(key))
+	}
 
 	// Load public key
 	publicKeyData, err := os.ReadFile(publicKeyFile)
 	if err != nil {
 		return err
 	}
-	publicKeyBlock, _ := pem.Decode(publicKeyData)
-	if publicKeyBlock == nil {
+	if block, _ := pem.Decode(publicKeyData); block == nil {
 		return fmt.Errorf("failed to decode public key PEM block")
 	}
-	publicKey, err := x509.ParsePKCS1PublicKey(publicKeyBlock.Bytes)
+	key, _, err = ParseKey(publicKeyData, "")
 	if err != nil {
 		return fmt.Errorf("failed to parse public key: %w", err)
 	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key is %s, not RSA", keyTypeName(key))
+	}
 
 	p.privateKey = privateKey
 	p.publicKey = publicKey
@@ -140,17 +219,28 @@ func (p *RSAProcessor) loadKeys(publicKeyFile, privateKeyFile string) error {
 }
 
 // Process handles RSA encryption/decryption
-func (p *RSAProcessor) Process(text string, operation string) (string, []string, error) {
+func (p *RSAProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	return p.ProcessContext(context.Background(), text, operation)
+}
+
+// ProcessContext is Process, giving up on generating a missing key pair once ctx is done
+func (p *RSAProcessor) ProcessContext(ctx context.Context, text string, operation string) (string, []utils.Step, error) {
 	// Validate operation type
 	if operation != OperationEncrypt && operation != OperationDecrypt {
 		return "", nil, fmt.Errorf("invalid operation: %s (must be 'encrypt' or 'decrypt')", operation)
 	}
 
+	generated := p.privateKey == nil
+	if generated {
+		if err := p.generateKeys(ctx); err != nil {
+			return "", nil, err
+		}
+	}
+
 	v := utils.NewVisualizer()
 
 	// Add introduction
-	v.AddStep("RSA Encryption Process")
-	v.AddStep("=============================")
+	v.AddHeader("RSA Encryption Process")
 	v.AddNote("RSA is an asymmetric encryption algorithm")
 	v.AddNote(fmt.Sprintf("Using %d-bit keys", p.keySize))
 	v.AddSeparator()
@@ -159,30 +249,44 @@ func (p *RSAProcessor) Process(text string, operation string) (string, []string,
 	v.AddStep("Key Information:")
 	v.AddStep(fmt.Sprintf("Public Key Size: %d bits", p.keySize))
 	v.AddStep(fmt.Sprintf("Private Key Size: %d bits", p.keySize))
+	v.AddStep(fmt.Sprintf("Padding: %s", rsaPaddingName(p.padding)))
+	if generated {
+		v.AddNote(fmt.Sprintf("No key pair was found, so a new one was generated and saved to %s", p.privateKeyFile))
+	}
 	v.AddSeparator()
 
+	if p.mode == RSAModeHybrid {
+		if operation == OperationDecrypt {
+			return p.decryptHybrid(v, text)
+		}
+		return p.encryptHybrid(v, text)
+	}
+
 	if operation == OperationDecrypt {
 		// Add decryption steps
 		v.AddStep("Decryption Process:")
-		v.AddStep("1. Base64 decode the input")
+		v.AddStep("1. Decode the input (hex, Base64, or Base64URL)")
 		v.AddStep("2. Use private key to decrypt")
 		v.AddStep("3. Convert result to text")
 		v.AddSeparator()
 
 		// Show input
-		v.AddTextStep("Encrypted Input (Base64)", text)
+		v.AddTextStep("Encrypted Input", text)
 		v.AddArrow()
 
-		// Decode from base64
-		data, err := base64.StdEncoding.DecodeString(text)
+		// A direct RSA ciphertext is exactly as long as the modulus
+		data, encoding, err := utils.DecodeAny(text, func(data []byte) bool {
+			return len(data) == p.privateKey.Size()
+		})
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+			return "", nil, fmt.Errorf("invalid ciphertext: %w", err)
 		}
+		v.AddStep(fmt.Sprintf("Detected input encoding: %s", utils.EncodingName(encoding)))
 		v.AddHexStep("Decoded Data", data)
 		v.AddArrow()
 
 		// Decrypt
-		plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, p.privateKey, data)
+		plaintext, err := rsaDecrypt(randomSource(p.rand), p.privateKey, data, p.padding)
 		if err != nil {
 			return "", nil, fmt.Errorf("failed to decrypt: %w", err)
 		}
@@ -203,7 +307,7 @@ func (p *RSAProcessor) Process(text string, operation string) (string, []string,
 	v.AddStep("Encryption Process:")
 	v.AddStep("1. Convert text to bytes")
 	v.AddStep("2. Use public key to encrypt")
-	v.AddStep("3. Base64 encode the result")
+	v.AddStep(fmt.Sprintf("3. %s encode the result", utils.EncodingName(p.outputEncoding)))
 	v.AddSeparator()
 
 	// Show input
@@ -214,17 +318,27 @@ func (p *RSAProcessor) Process(text string, operation string) (string, []string,
 	v.AddHexStep("Text as Bytes", []byte(text))
 	v.AddArrow()
 
+	// Check the message fits before handing it to the padding scheme
+	maxSize := rsaMaxMessageSize(p.publicKey, p.padding)
+	v.AddStep(fmt.Sprintf("Maximum message size: %d bytes (%d-byte modulus - %d bytes of %s overhead)",
+		maxSize, p.publicKey.Size(), p.publicKey.Size()-maxSize, rsaPaddingName(p.padding)))
+	v.AddStep(fmt.Sprintf("Message size: %d bytes", len(text)))
+	v.AddArrow()
+	if len(text) > maxSize {
+		return "", nil, fmt.Errorf("message too long for RSA: %d bytes, but %s with a %d-bit key holds at most %d bytes; use hybrid mode or a larger key",
+			len(text), rsaPaddingName(p.padding), p.publicKey.N.BitLen(), maxSize)
+	}
+
 	// Encrypt
-	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, p.publicKey, []byte(text))
+	ciphertext, err := rsaEncrypt(randomSource(p.rand), p.publicKey, []byte(text), p.padding)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to encrypt: %w", err)
 	}
 	v.AddHexStep("Encrypted Data", ciphertext)
 	v.AddArrow()
 
-	// Base64 encode the result
-	encoded := base64.StdEncoding.EncodeToString(ciphertext)
-	v.AddTextStep("Base64 Encoded Result", encoded)
+	encoded := utils.Encode(ciphertext, p.outputEncoding)
+	v.AddTextStep(fmt.Sprintf("%s Encoded Result", utils.EncodingName(p.outputEncoding)), encoded)
 
 	// Add security notes
 	v.AddSeparator()
@@ -249,3 +363,61 @@ func (p *RSAProcessor) Process(text string, operation string) (string, []string,
 
 	return encoded, v.GetSteps(), nil
 }
+
+// AuditSources lists the randomness sources and libraries used for RSA
+func (p *RSAProcessor) AuditSources() []AuditSource {
+	sources := []AuditSource{
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Key pair generation, OAEP/PKCS#1 v1.5 padding, and hybrid AES keys"},
+		{Kind: AuditStandardLibrary, Package: "crypto/rsa", Purpose: "RSA key generation, encryption, and decryption"},
+		{Kind: AuditStandardLibrary, Package: "crypto/x509, encoding/pem", Purpose: "Key serialization"},
+	}
+	if p.mode == RSAModeHybrid {
+		sources = append(sources, AuditSource{Kind: AuditStandardLibrary, Package: "crypto/aes, crypto/cipher", Purpose: "AES-256-GCM encryption of hybrid envelopes"})
+	}
+	return sources
+}
+
+// Parameters returns the processor's effective settings
+func (p *RSAProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "keySize", Description: "Key size in bits (1024, 2048, 4096)", Value: p.keySize},
+		{Name: "padding", Description: "Encryption padding (oaep or pkcs1v15)", Value: p.padding},
+		{Name: "mode", Description: "direct RSA or hybrid RSA key wrapping with AES-GCM", Value: p.mode},
+		{Name: "keyFormat", Description: "Format for generated keys (pkcs1, or pkcs8 with a PKIX public key)", Value: p.keyFormat},
+		{Name: "publicKeyFile", Description: "File the public key is stored in", Value: p.publicKeyFile},
+		{Name: "privateKeyFile", Description: "File the private key is stored in", Value: p.privateKeyFile},
+	}
+}
+
+// rsaPaddingName returns a human-readable name for the padding scheme
+func rsaPaddingName(padding string) string {
+	if padding == RSAPaddingPKCS1v15 {
+		return "PKCS#1 v1.5"
+	}
+	return "OAEP with SHA-256"
+}
+
+// rsaMaxMessageSize returns the longest message the key can encrypt directly with the given padding
+func rsaMaxMessageSize(publicKey *rsa.PublicKey, padding string) int {
+	if padding == RSAPaddingPKCS1v15 {
+		return publicKey.Size() - 11
+	}
+	// OAEP: two hash lengths of label hash and seed, plus two framing bytes
+	return publicKey.Size() - 2*sha256.Size - 2
+}
+
+// rsaEncrypt encrypts data with the public key using the given padding
+func rsaEncrypt(random io.Reader, publicKey *rsa.PublicKey, data []byte, padding string) ([]byte, error) {
+	if padding == RSAPaddingPKCS1v15 {
+		return rsa.EncryptPKCS1v15(random, publicKey, data)
+	}
+	return rsa.EncryptOAEP(sha256.New(), random, publicKey, data, nil)
+}
+
+// rsaDecrypt decrypts data with the private key using the given padding
+func rsaDecrypt(random io.Reader, privateKey *rsa.PrivateKey, data []byte, padding string) ([]byte, error) {
+	if padding == RSAPaddingPKCS1v15 {
+		return rsa.DecryptPKCS1v15(random, privateKey, data)
+	}
+	return rsa.DecryptOAEP(sha256.New(), random, privateKey, data, nil)
+}
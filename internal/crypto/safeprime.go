@@ -0,0 +1,123 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+)
+
+// safePrimeSieve holds the small odd primes used to discard safe-prime candidates cheaply
+var safePrimeSieve = []uint64{
+	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89, 97,
+	101, 103, 107, 109, 113, 127, 131, 137, 139, 149, 151, 157, 163, 167, 173, 179, 181, 191, 193,
+	197, 199, 211, 223, 227, 229, 233, 239, 241, 251, 257, 263, 269, 271, 277, 281, 283, 293,
+}
+
+// safePrimeSearchWindow is how far the search steps from one random starting point
+const safePrimeSearchWindow = 1 << 20
+
+// safePrimeRounds is the number of Miller-Rabin rounds run on top of Baillie-PSW
+const safePrimeRounds = 20
+
+// generateSafePrime returns a prime p of the given bit length where q = (p-1)/2 is also prime.
+// It picks a random odd q, then steps q by 2, sieving q and 2q+1 against small primes before
+// running the expensive primality tests.
+func generateSafePrime(random io.Reader, bits int) (*big.Int, error) {
+	if bits < 16 {
+		return nil, fmt.Errorf("safe prime size must be at least 16 bits, got %d", bits)
+	}
+
+	residues := make([]uint64, len(safePrimeSieve))
+	bigMod := new(big.Int)
+	for {
+		// q has bits-1 bits with the top bit set, so p = 2q+1 has exactly bits bits
+		q, err := rand.Int(random, new(big.Int).Lsh(big.NewInt(1), uint(bits-2)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate safe prime candidate: %w", err)
+		}
+		q.SetBit(q, bits-2, 1)
+		q.SetBit(q, 0, 1)
+
+		for i, s := range safePrimeSieve {
+			residues[i] = bigMod.Mod(q, new(big.Int).SetUint64(s)).Uint64()
+		}
+
+	search:
+		for delta := uint64(0); delta < safePrimeSearchWindow; delta += 2 {
+			for i, s := range safePrimeSieve {
+				r := (residues[i] + delta) % s
+				// Reject when s divides q or s divides 2q+1
+				if r == 0 || (2*r+1)%s == 0 {
+					continue search
+				}
+			}
+
+			candidate := new(big.Int).Add(q, new(big.Int).SetUint64(delta))
+			if candidate.BitLen() != bits-1 {
+				break
+			}
+			p := new(big.Int).Lsh(candidate, 1)
+			p.Add(p, big.NewInt(1))
+			if p.ProbablyPrime(0) && candidate.ProbablyPrime(0) &&
+				p.ProbablyPrime(safePrimeRounds) && candidate.ProbablyPrime(safePrimeRounds) {
+				return p, nil
+			}
+		}
+	}
+}
+
+// isSafePrime reports whether p is a prime where (p-1)/2 is also prime
+func isSafePrime(p *big.Int) bool {
+	if p.Cmp(big.NewInt(5)) < 0 || !p.ProbablyPrime(safePrimeRounds) {
+		return false
+	}
+	q := new(big.Int).Rsh(p, 1)
+	return q.ProbablyPrime(safePrimeRounds)
+}
+
+// generatorOrder returns the order of g modulo the safe prime p = 2q+1: q for the prime-order
+// subgroup or 2q for the full group. Generators of order 1 or 2 are rejected.
+func generatorOrder(g, p *big.Int) (*big.Int, error) {
+	pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+	if g.Cmp(big.NewInt(1)) <= 0 || g.Cmp(pMinusOne) >= 0 {
+		return nil, fmt.Errorf("invalid generator: g must satisfy 1 < g < p-1")
+	}
+	q := new(big.Int).Rsh(p, 1)
+	if new(big.Int).Exp(g, q, p).Cmp(big.NewInt(1)) == 0 {
+		return q, nil
+	}
+	return pMinusOne, nil
+}
+
+// loadSafePrime reads a big-endian prime from path, reporting false when the file is missing
+// or does not hold a safe prime of the given bit length
+func loadSafePrime(path string, bits int) (*big.Int, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read prime: %w", err)
+	}
+	p := new(big.Int).SetBytes(data)
+	if p.BitLen() != bits || !isSafePrime(p) {
+		return nil, false, nil
+	}
+	return p, true, nil
+}
+
+// saveSafePrime writes p to path as big-endian bytes
+func saveSafePrime(path string, p *big.Int) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("failed to create prime directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, p.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to save prime: %w", err)
+	}
+	return nil
+}
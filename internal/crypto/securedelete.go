@@ -0,0 +1,136 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// secureDeletePasses is the number of overwrite passes; the last pass writes zeros
+const secureDeletePasses = 3
+
+// secureDeleteChunkSize is the buffer size used while overwriting a file
+const secureDeleteChunkSize = 32 * 1024
+
+// SecureDeleteFile overwrites a file in place with random data and then zeros,
+// flushing each pass to disk, before removing it
+func SecureDeleteFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("not a regular file: %s", path)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	for pass := 1; pass <= secureDeletePasses; pass++ {
+		var source io.Reader = rand.Reader
+		if pass == secureDeletePasses {
+			source = zeroReader{}
+		}
+		if err := overwriteFile(f, info.Size(), source); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to overwrite %s (pass %d): %w", path, pass, err)
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// overwriteFile writes size bytes from source over the start of f and syncs it
+func overwriteFile(f *os.File, size int64, source io.Reader) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	buf := make([]byte, secureDeleteChunkSize)
+	for remaining := size; remaining > 0; {
+		n := int64(len(buf))
+		if remaining < n {
+			n = remaining
+		}
+		if _, err := io.ReadFull(source, buf[:n]); err != nil {
+			return err
+		}
+		if _, err := f.Write(buf[:n]); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+	return f.Sync()
+}
+
+// zeroReader is an io.Reader that yields an endless stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// ListKeyDirectory lists the regular files in a key directory
+func ListKeyDirectory(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// SecureDeleteKeys securely deletes the given key files and explains the limits of doing so
+func SecureDeleteKeys(files []string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	v.AddHeader("Secure Key Deletion")
+	v.AddNote(fmt.Sprintf("Each file is overwritten %d times (random data, then zeros), flushed to disk, and unlinked", secureDeletePasses))
+	v.AddSeparator()
+
+	deleted := 0
+	var failed []string
+	for _, file := range files {
+		if err := SecureDeleteFile(file); err != nil {
+			v.AddStep(fmt.Sprintf("❌ %s: %v", filepath.Base(file), err))
+			failed = append(failed, file)
+			continue
+		}
+		v.AddStep(fmt.Sprintf("✅ %s wiped and removed", filepath.Base(file)))
+		deleted++
+	}
+	v.AddSeparator()
+
+	v.AddStep("⚠️ Limitations of Secure Deletion:")
+	v.AddStep("1. SSDs and flash storage remap writes (wear leveling), so old blocks may survive the overwrite")
+	v.AddStep("2. Copy-on-write and journaling filesystems (APFS, Btrfs, ZFS) may write new data elsewhere")
+	v.AddStep("3. Snapshots, backups, and cloud sync can hold earlier copies of the keys")
+	v.AddStep("4. Key material may also linger in swap, hibernation files, or memory")
+	v.AddNote("Full-disk encryption with a destroyed key is the only reliable way to erase data on SSDs")
+
+	result := fmt.Sprintf("Securely deleted %d of %d key file(s)", deleted, len(files))
+	if len(failed) > 0 {
+		return result, v.GetSteps(), fmt.Errorf("failed to delete %d key file(s)", len(failed))
+	}
+	return result, v.GetSteps(), nil
+}
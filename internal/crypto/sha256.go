@@ -20,12 +20,11 @@ func (p *SHA256Processor) Configure(config map[string]interface{}) error {
 	return p.BaseConfigurableProcessor.Configure(config)
 }
 
-func (p *SHA256Processor) Process(text string, _ string) (string, []string, error) {
+func (p *SHA256Processor) Process(text string, _ string) (string, []utils.Step, error) {
 	v := utils.NewVisualizer()
 
 	// Add introduction
-	v.AddStep("SHA-256 Hash Process")
-	v.AddStep("=============================")
+	v.AddHeader("SHA-256 Hash Process")
 	v.AddNote("SHA-256 is a cryptographic hash function")
 	v.AddNote("It produces a 256-bit (32-byte) hash value")
 	v.AddSeparator()
@@ -0,0 +1,255 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// signatureScheme signs and verifies messages with one algorithm and key pair
+type signatureScheme struct {
+	name   string
+	sign   func(message []byte) ([]byte, error)
+	verify func(message, signature []byte) bool
+}
+
+// signatureKeys holds one key pair for each supported signature algorithm
+type signatureKeys struct {
+	rsa     *rsa.PrivateKey
+	ecdsa   *ecdsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+// generateSignatureKeys creates fresh key pairs for every signature scheme
+func generateSignatureKeys(rsaKeySize int) (*signatureKeys, error) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ECDSA key: %w", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+	}
+	return &signatureKeys{rsa: rsaKey, ecdsa: ecdsaKey, ed25519: edKey}, nil
+}
+
+// signatureSchemes returns the RSA-PKCS1, RSA-PSS, ECDSA, and Ed25519 schemes for the keys.
+// Both RSA schemes share one key pair, so a mismatch between them is purely a scheme mismatch.
+func signatureSchemes(keys *signatureKeys) []signatureScheme {
+	return []signatureScheme{
+		{
+			name: "RSA-PKCS1v15",
+			sign: func(message []byte) ([]byte, error) {
+				digest := sha256.Sum256(message)
+				return rsa.SignPKCS1v15(rand.Reader, keys.rsa, crypto.SHA256, digest[:])
+			},
+			verify: func(message, signature []byte) bool {
+				digest := sha256.Sum256(message)
+				return rsa.VerifyPKCS1v15(&keys.rsa.PublicKey, crypto.SHA256, digest[:], signature) == nil
+			},
+		},
+		{
+			name: "RSA-PSS",
+			sign: func(message []byte) ([]byte, error) {
+				digest := sha256.Sum256(message)
+				return rsa.SignPSS(rand.Reader, keys.rsa, crypto.SHA256, digest[:], nil)
+			},
+			verify: func(message, signature []byte) bool {
+				digest := sha256.Sum256(message)
+				return rsa.VerifyPSS(&keys.rsa.PublicKey, crypto.SHA256, digest[:], signature, nil) == nil
+			},
+		},
+		{
+			name: "ECDSA-P256",
+			sign: func(message []byte) ([]byte, error) {
+				digest := sha256.Sum256(message)
+				return ecdsa.SignASN1(rand.Reader, keys.ecdsa, digest[:])
+			},
+			verify: func(message, signature []byte) bool {
+				digest := sha256.Sum256(message)
+				return ecdsa.VerifyASN1(&keys.ecdsa.PublicKey, digest[:], signature)
+			},
+		},
+		{
+			name: "Ed25519",
+			sign: func(message []byte) ([]byte, error) {
+				return ed25519.Sign(keys.ed25519, message), nil
+			},
+			verify: func(message, signature []byte) bool {
+				publicKey := keys.ed25519.Public().(ed25519.PublicKey)
+				return ed25519.Verify(publicKey, message, signature)
+			},
+		},
+	}
+}
+
+// SignatureMatrixProcessor verifies one message against signatures from several schemes
+type SignatureMatrixProcessor struct {
+	BaseConfigurableProcessor
+	rsaKeySize int
+}
+
+// NewSignatureMatrixProcessor creates a new signature verification matrix processor
+func NewSignatureMatrixProcessor() *SignatureMatrixProcessor {
+	return &SignatureMatrixProcessor{
+		rsaKeySize: 2048,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *SignatureMatrixProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	if keySize, ok := config["rsaKeySize"].(int); ok && keySize != 0 {
+		if keySize != 2048 && keySize != 3072 && keySize != 4096 {
+			return fmt.Errorf("invalid RSA key size: %d (must be 2048, 3072, or 4096)", keySize)
+		}
+		p.rsaKeySize = keySize
+	}
+
+	return nil
+}
+
+// Process implements the Processor interface
+func (p *SignatureMatrixProcessor) Process(text string, _ string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	if text == "" {
+		return "", nil, fmt.Errorf("empty input")
+	}
+	message := []byte(text)
+
+	v.AddHeader("Signature Verification Matrix")
+	v.AddNote("A signature is bound to one algorithm and one key pair")
+	v.AddNote("Every signature below is checked by every verifier to show which combinations are accepted")
+	v.AddSeparator()
+
+	v.AddTextStep("Message", text)
+	v.AddArrow()
+
+	keys, err := generateSignatureKeys(p.rsaKeySize)
+	if err != nil {
+		return "", nil, err
+	}
+	schemes := signatureSchemes(keys)
+
+	v.AddStep("Generated Key Pairs:")
+	v.AddStep(fmt.Sprintf("RSA:     %d-bit (shared by RSA-PKCS1v15 and RSA-PSS)", p.rsaKeySize))
+	v.AddStep("ECDSA:   P-256 curve with SHA-256")
+	v.AddStep("Ed25519: Edwards curve, hashes internally with SHA-512")
+	v.AddSeparator()
+
+	signatures := make([][]byte, len(schemes))
+	v.AddStep("Signatures:")
+	for i, scheme := range schemes {
+		signature, err := scheme.sign(message)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to sign with %s: %w", scheme.name, err)
+		}
+		signatures[i] = signature
+		v.AddStep(fmt.Sprintf("%-13s %3d bytes  %s", scheme.name, len(signature), shortHex(signature)))
+	}
+	v.AddSeparator()
+
+	matrix := verificationMatrix(schemes, message, signatures)
+
+	v.AddStep("Verification Matrix (rows = signature, columns = verifier):")
+	header := fmt.Sprintf("%-13s", "")
+	for _, scheme := range schemes {
+		header += fmt.Sprintf(" %-13s", scheme.name)
+	}
+	v.AddStep(header)
+	for i, row := range matrix {
+		line := fmt.Sprintf("%-13s", schemes[i].name)
+		for _, ok := range row {
+			mark := "❌ reject"
+			if ok {
+				mark = "✅ accept"
+			}
+			line += fmt.Sprintf(" %-13s", mark)
+		}
+		v.AddStep(line)
+	}
+	v.AddSeparator()
+
+	// Same scheme, different key pair
+	otherKeys, err := generateSignatureKeys(p.rsaKeySize)
+	if err != nil {
+		return "", nil, err
+	}
+	otherSchemes := signatureSchemes(otherKeys)
+	v.AddStep("Same Scheme, Wrong Key:")
+	for i, scheme := range otherSchemes {
+		mark := "❌ reject"
+		if scheme.verify(message, signatures[i]) {
+			mark = "✅ accept"
+		}
+		v.AddStep(fmt.Sprintf("%-13s signature checked with another %s public key: %s", schemes[i].name, scheme.name, mark))
+	}
+	v.AddSeparator()
+
+	// Tampered message
+	tampered := append([]byte(nil), message...)
+	tampered[0] ^= 0x01
+	v.AddStep("Tampered Message (first byte flipped):")
+	for i, scheme := range schemes {
+		mark := "❌ reject"
+		if scheme.verify(tampered, signatures[i]) {
+			mark = "✅ accept"
+		}
+		v.AddStep(fmt.Sprintf("%-13s %s", scheme.name, mark))
+	}
+	v.AddSeparator()
+
+	v.AddStep("Algorithm Binding:")
+	v.AddNote("1. Only the diagonal verifies: a signature is valid solely for its own scheme and key")
+	v.AddNote("2. RSA-PKCS1v15 and RSA-PSS use the same key, yet their encodings are incompatible")
+	v.AddNote("3. Verifiers must pin the expected algorithm instead of trusting one supplied by the sender")
+	v.AddNote("4. Letting the message choose the algorithm enables confusion attacks such as JWT alg switching")
+
+	accepted := make([]string, 0, len(schemes))
+	for i := range schemes {
+		if matrix[i][i] {
+			accepted = append(accepted, schemes[i].name)
+		}
+	}
+	result := fmt.Sprintf("Verified under own scheme and key: %s", strings.Join(accepted, ", "))
+	return result, v.GetSteps(), nil
+}
+
+// verificationMatrix checks every signature against every scheme's verifier
+func verificationMatrix(schemes []signatureScheme, message []byte, signatures [][]byte) [][]bool {
+	matrix := make([][]bool, len(signatures))
+	for i, signature := range signatures {
+		matrix[i] = make([]bool, len(schemes))
+		for j, scheme := range schemes {
+			matrix[i][j] = scheme.verify(message, signature)
+		}
+	}
+	return matrix
+}
+
+// AuditSources lists the randomness sources and libraries used by the signature matrix
+func (p *SignatureMatrixProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Key pair generation, RSA-PSS salts, and ECDSA nonces"},
+		{Kind: AuditStandardLibrary, Package: "crypto/rsa", Purpose: "RSA-PKCS1v15 and RSA-PSS signatures"},
+		{Kind: AuditStandardLibrary, Package: "crypto/ecdsa, crypto/elliptic", Purpose: "ECDSA over P-256"},
+		{Kind: AuditStandardLibrary, Package: "crypto/ed25519", Purpose: "Ed25519 signatures"},
+		{Kind: AuditStandardLibrary, Package: "crypto/sha256", Purpose: "Message digests for RSA and ECDSA"},
+	}
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerificationMatrix_AlgorithmBinding(t *testing.T) {
+	keys, err := generateSignatureKeys(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate keys: %v", err)
+	}
+	otherKeys, err := generateSignatureKeys(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate keys: %v", err)
+	}
+
+	message := []byte("algorithm binding")
+	schemes := signatureSchemes(keys)
+	signatures := make([][]byte, len(schemes))
+	for i, scheme := range schemes {
+		if signatures[i], err = scheme.sign(message); err != nil {
+			t.Fatalf("%s: sign failed: %v", scheme.name, err)
+		}
+	}
+
+	matrix := verificationMatrix(schemes, message, signatures)
+	for i := range matrix {
+		for j, ok := range matrix[i] {
+			if want := i == j; ok != want {
+				t.Errorf("%s signature under %s verifier = %v, want %v", schemes[i].name, schemes[j].name, ok, want)
+			}
+		}
+	}
+
+	// Same scheme with a different key pair must fail
+	for i, scheme := range signatureSchemes(otherKeys) {
+		if scheme.verify(message, signatures[i]) {
+			t.Errorf("%s signature verified under a different key", scheme.name)
+		}
+	}
+
+	// A modified message must fail under its own scheme
+	for i, scheme := range schemes {
+		if scheme.verify([]byte("algorithm bindinG"), signatures[i]) {
+			t.Errorf("%s signature verified for a tampered message", scheme.name)
+		}
+	}
+}
+
+func TestSignatureMatrixProcessor_Process(t *testing.T) {
+	processor := NewSignatureMatrixProcessor()
+	if err := processor.Configure(map[string]interface{}{"rsaKeySize": 2048}); err != nil {
+		t.Fatalf("Failed to configure processor: %v", err)
+	}
+
+	result, steps, err := processor.Process("hello world", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	for _, name := range []string{"RSA-PKCS1v15", "RSA-PSS", "ECDSA-P256", "Ed25519"} {
+		if !strings.Contains(result, name) {
+			t.Errorf("Expected %s to verify under its own key, got %q", name, result)
+		}
+	}
+	if len(steps) == 0 {
+		t.Error("Expected non-empty steps")
+	}
+
+	if _, _, err := processor.Process("", OperationEncrypt); err == nil {
+		t.Error("Expected error for empty input")
+	}
+	if err := processor.Configure(map[string]interface{}{"rsaKeySize": 1024}); err == nil {
+		t.Error("Expected error for 1024-bit RSA key")
+	}
+}
@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// Purposes for subkeys derived from one master key. Each purpose is used as the HKDF
+// info string, so keys for different purposes are independent.
+const (
+	SubkeyEncryption = "encryption"
+	SubkeyMAC        = "mac"
+	SubkeyClientKey  = "client write key"
+	SubkeyServerKey  = "server write key"
+	SubkeyClientIV   = "client write iv"
+	SubkeyServerIV   = "server write iv"
+)
+
+// SubkeyDeriver derives purpose-specific subkeys from a master key with HKDF-SHA256
+type SubkeyDeriver struct {
+	prk []byte
+}
+
+// NewSubkeyDeriver extracts a pseudorandom key from the master key and salt
+func NewSubkeyDeriver(master, salt []byte) *SubkeyDeriver {
+	return &SubkeyDeriver{prk: hkdf.Extract(sha256.New, master, salt)}
+}
+
+// Derive expands a subkey of size bytes for the given purpose
+func (d *SubkeyDeriver) Derive(purpose string, size int) ([]byte, error) {
+	if purpose == "" {
+		return nil, fmt.Errorf("subkey purpose must not be empty")
+	}
+	key := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, d.prk, []byte("CryptoLens "+purpose)), key); err != nil {
+		return nil, fmt.Errorf("failed to derive %s subkey: %w", purpose, err)
+	}
+	return key, nil
+}
+
+// sessionKeys holds the per-direction keys and IVs of a key exchange
+type sessionKeys struct {
+	clientKey, serverKey []byte
+	clientIV, serverIV   []byte
+}
+
+// deriveSessionKeys derives separate write keys and IVs for each direction from a shared secret
+func deriveSessionKeys(shared, salt []byte) (*sessionKeys, error) {
+	deriver := NewSubkeyDeriver(shared, salt)
+	keys := &sessionKeys{}
+	for _, subkey := range []struct {
+		purpose string
+		size    int
+		dst     *[]byte
+	}{
+		{SubkeyClientKey, 32, &keys.clientKey},
+		{SubkeyServerKey, 32, &keys.serverKey},
+		{SubkeyClientIV, 12, &keys.clientIV},
+		{SubkeyServerIV, 12, &keys.serverIV},
+	} {
+		key, err := deriver.Derive(subkey.purpose, subkey.size)
+		if err != nil {
+			return nil, err
+		}
+		*subkey.dst = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSubkeyDeriver(t *testing.T) {
+	master := []byte("0123456789abcdef0123456789abcdef")
+	salt := []byte("salt")
+
+	encKey, err := NewSubkeyDeriver(master, salt).Derive(SubkeyEncryption, 32)
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	again, err := NewSubkeyDeriver(master, salt).Derive(SubkeyEncryption, 32)
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	if !bytes.Equal(encKey, again) {
+		t.Error("Expected derivation to be deterministic")
+	}
+
+	macKey, err := NewSubkeyDeriver(master, salt).Derive(SubkeyMAC, 32)
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	if bytes.Equal(encKey, macKey) {
+		t.Error("Expected different purposes to yield different subkeys")
+	}
+	// A shorter subkey must not be a prefix of another purpose's subkey
+	shortMAC, _ := NewSubkeyDeriver(master, salt).Derive(SubkeyMAC, 16)
+	if bytes.Equal(shortMAC, encKey[:16]) {
+		t.Error("Expected subkeys for different purposes to be independent")
+	}
+
+	otherSalt, _ := NewSubkeyDeriver(master, []byte("other")).Derive(SubkeyEncryption, 32)
+	if bytes.Equal(encKey, otherSalt) {
+		t.Error("Expected the salt to change the subkey")
+	}
+
+	if _, err := NewSubkeyDeriver(master, salt).Derive("", 32); err == nil {
+		t.Error("Expected an error for an empty purpose")
+	}
+}
+
+func TestDeriveSessionKeys(t *testing.T) {
+	keys, err := deriveSessionKeys([]byte("shared secret"), []byte("salt"))
+	if err != nil {
+		t.Fatalf("deriveSessionKeys failed: %v", err)
+	}
+	if len(keys.clientKey) != 32 || len(keys.serverKey) != 32 || len(keys.clientIV) != 12 || len(keys.serverIV) != 12 {
+		t.Error("Unexpected session key sizes")
+	}
+	if bytes.Equal(keys.clientKey, keys.serverKey) || bytes.Equal(keys.clientIV, keys.serverIV) {
+		t.Error("Expected each direction to get its own key and IV")
+	}
+}
@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+func TestTOTPProcessor_RFC6238Vectors(t *testing.T) {
+	// RFC 6238 Appendix B: 8-digit codes with the ASCII seed repeated to the hash size
+	seeds := map[string]string{
+		HashSHA1:   "12345678901234567890",
+		HashSHA256: "12345678901234567890123456789012",
+		HashSHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	tests := []struct {
+		unix      int64
+		algorithm string
+		want      string
+	}{
+		{59, HashSHA1, "94287082"},
+		{59, HashSHA256, "46119246"},
+		{59, HashSHA512, "90693936"},
+		{1111111109, HashSHA1, "07081804"},
+		{1234567890, HashSHA256, "91819424"},
+		{2000000000, HashSHA512, "38618901"},
+		{20000000000, HashSHA1, "65353130"},
+	}
+
+	for _, tt := range tests {
+		processor := NewTOTPProcessor()
+		if err := processor.Configure(map[string]interface{}{"algorithm": tt.algorithm, "digits": 8}); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		processor.now = func() time.Time { return time.Unix(tt.unix, 0) }
+
+		secret := base32.StdEncoding.EncodeToString([]byte(seeds[tt.algorithm]))
+		_, steps, err := processor.Process(secret, OperationEncrypt)
+		if err != nil {
+			t.Fatalf("%s at %d: Process() error = %v", tt.algorithm, tt.unix, err)
+		}
+		if output := strings.Join(utils.StepTexts(steps), "\n"); !strings.Contains(output, "Current code: "+tt.want) {
+			t.Errorf("%s at %d: expected code %s in the steps", tt.algorithm, tt.unix, tt.want)
+		}
+	}
+}
+
+func TestTOTPProcessor_ProvisioningURI(t *testing.T) {
+	processor := NewTOTPProcessor()
+	if err := processor.Configure(map[string]interface{}{"issuer": "Example", "account": "alice@example.com"}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	// Case, spaces, and padding in the secret are ignored, as authenticator apps do
+	result, steps, err := processor.Process("jbsw y3dp ehpk 3pxp", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := "otpauth://totp/Example:alice@example.com?algorithm=SHA1&digits=6&issuer=Example&period=30&secret=JBSWY3DPEHPK3PXP"
+	if result != want {
+		t.Errorf("Process() = %s, want %s", result, want)
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "Step 5: Provisioning URI") {
+		t.Error("Expected the URI to be shown as a step")
+	}
+
+	// An empty input generates a fresh 160-bit secret
+	first, steps, err := processor.Process("", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	second, _, err := processor.Process("", OperationEncrypt)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if first == second {
+		t.Error("Expected a new secret for each empty input")
+	}
+	if !strings.Contains(strings.Join(utils.StepTexts(steps), "\n"), "generated a random 160-bit secret") {
+		t.Error("Expected a note about the generated secret")
+	}
+}
+
+func TestTOTPProcessor_Errors(t *testing.T) {
+	processor := NewTOTPProcessor()
+	for _, config := range []map[string]interface{}{
+		{"algorithm": "md5"},
+		{"digits": 7},
+		{"period": -30},
+		{"issuer": "Bad:Issuer"},
+		{"account": "bad:account"},
+	} {
+		if err := processor.Configure(config); err == nil {
+			t.Errorf("Expected an error for %v", config)
+		}
+	}
+	if _, _, err := processor.Process("not base32!", OperationEncrypt); err == nil {
+		t.Error("Expected an error for a secret that is not Base32")
+	}
+	if _, _, err := processor.Process("JBSWY3DPEHPK3PXP", OperationDecrypt); err == nil {
+		t.Error("Expected an error for decryption")
+	}
+}
@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported sequence diagram export formats
+const (
+	DiagramMermaid  = "mermaid"
+	DiagramPlantUML = "plantuml"
+)
+
+// TranscriptMessage is a single message sent during a key exchange
+type TranscriptMessage struct {
+	From  string
+	To    string
+	Label string
+}
+
+// KeyExchangeTranscript records the messages exchanged during a key exchange demo
+type KeyExchangeTranscript struct {
+	Protocol     string
+	Participants []string
+	Messages     []TranscriptMessage
+}
+
+// TranscriptProvider is implemented by processors that record a key exchange transcript
+type TranscriptProvider interface {
+	// Transcript returns the transcript of the last exchange, or nil if none ran yet
+	Transcript() *KeyExchangeTranscript
+}
+
+// newKeyExchangeTranscript creates an empty transcript between Alice and Bob
+func newKeyExchangeTranscript(protocol string) *KeyExchangeTranscript {
+	return &KeyExchangeTranscript{
+		Protocol:     protocol,
+		Participants: []string{"Alice", "Bob"},
+	}
+}
+
+// Send records a message from one participant to another
+func (t *KeyExchangeTranscript) Send(from, to, label string) {
+	t.Messages = append(t.Messages, TranscriptMessage{From: from, To: to, Label: label})
+}
+
+// Mermaid renders the transcript as a Mermaid sequence diagram
+func (t *KeyExchangeTranscript) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("sequenceDiagram\n")
+	b.WriteString(fmt.Sprintf("    title %s Key Exchange\n", t.Protocol))
+	for _, p := range t.Participants {
+		b.WriteString(fmt.Sprintf("    participant %s\n", p))
+	}
+	for _, m := range t.Messages {
+		b.WriteString(fmt.Sprintf("    %s->>%s: %s\n", m.From, m.To, m.Label))
+	}
+	return b.String()
+}
+
+// PlantUML renders the transcript as a PlantUML sequence diagram
+func (t *KeyExchangeTranscript) PlantUML() string {
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	b.WriteString(fmt.Sprintf("title %s Key Exchange\n", t.Protocol))
+	for _, p := range t.Participants {
+		b.WriteString(fmt.Sprintf("participant %s\n", p))
+	}
+	for _, m := range t.Messages {
+		b.WriteString(fmt.Sprintf("%s -> %s: %s\n", m.From, m.To, m.Label))
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// Export renders the transcript in the requested diagram format
+func (t *KeyExchangeTranscript) Export(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case DiagramMermaid:
+		return t.Mermaid(), nil
+	case DiagramPlantUML:
+		return t.PlantUML(), nil
+	default:
+		return "", fmt.Errorf("unsupported diagram format: %s", format)
+	}
+}
+
+// shortHex abbreviates key material so diagrams stay readable
+func shortHex(data []byte) string {
+	const maxBytes = 8
+	if len(data) <= maxBytes {
+		return fmt.Sprintf("%x", data)
+	}
+	return fmt.Sprintf("%x...", data[:maxBytes])
+}
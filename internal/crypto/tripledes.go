@@ -0,0 +1,240 @@
+package crypto
+
+import (
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/abdorrahmani/cryptolens/internal/utils"
+)
+
+// TripleDESProcessor implements Triple-DES (TDEA) encryption in CBC mode
+type TripleDESProcessor struct {
+	BaseConfigurableProcessor
+	keyManager   KeyManager
+	keyingOption int // 2 for two-key (K1, K2, K1), 3 for three independent keys
+}
+
+// NewTripleDESProcessor creates a new Triple-DES processor
+func NewTripleDESProcessor() *TripleDESProcessor {
+	return &TripleDESProcessor{
+		keyingOption: 3,
+	}
+}
+
+// Configure implements the ConfigurableProcessor interface
+func (p *TripleDESProcessor) Configure(config map[string]interface{}) error {
+	if err := p.BaseConfigurableProcessor.Configure(config); err != nil {
+		return err
+	}
+
+	// Ensure keys directory exists
+	if err := os.MkdirAll("keys", 0700); err != nil {
+		return fmt.Errorf("failed to create keys directory: %w", err)
+	}
+
+	// Configure keying option if provided
+	if keys, ok := config["keyingOption"].(int); ok && keys != 0 {
+		if keys != 2 && keys != 3 {
+			return fmt.Errorf("invalid keying option: %d (must be 2 or 3)", keys)
+		}
+		p.keyingOption = keys
+	}
+
+	// Configure key file if provided
+	keyFile := fmt.Sprintf("keys/3des_%dkey.bin", p.keyingOption)
+	if kf, ok := config["keyFile"].(string); ok && kf != "" {
+		keyFile = kf
+	}
+
+	// Initialize key manager with 64 bits per DES key
+	p.keyManager = NewFileKeyManager(p.keyingOption*64, keyFile)
+	if err := p.keyManager.LoadOrGenerateKey(); err != nil {
+		return fmt.Errorf("failed to load/generate key: %w", err)
+	}
+
+	return nil
+}
+
+// cipherKey expands the stored key to the 24 bytes crypto/des expects
+func (p *TripleDESProcessor) cipherKey() []byte {
+	key := p.keyManager.GetKey()
+	if p.keyingOption == 2 {
+		// Two-key 3DES reuses K1 as K3
+		expanded := make([]byte, 0, 24)
+		expanded = append(expanded, key[:16]...)
+		return append(expanded, key[:8]...)
+	}
+	return key
+}
+
+// Process implements the Processor interface
+func (p *TripleDESProcessor) Process(text string, operation string) (string, []utils.Step, error) {
+	v := utils.NewVisualizer()
+
+	// Check for empty input
+	if text == "" {
+		return "", nil, fmt.Errorf("empty input")
+	}
+
+	// Validate operation type
+	if operation != OperationEncrypt && operation != OperationDecrypt {
+		return "", nil, fmt.Errorf("invalid operation: %s", operation)
+	}
+
+	// Add introduction with a prominent deprecation warning
+	v.AddHeader("Triple-DES (3DES) Encryption Process")
+	v.AddStep("⚠️ DEPRECATED: NIST SP 800-131A Rev. 2 disallows 3DES for encryption after 2023")
+	v.AddStep("⚠️ 64-bit block size: vulnerable to the Sweet32 birthday attack (CVE-2016-2183)")
+	v.AddNote("3DES applies DES three times: Encrypt(K1) → Decrypt(K2) → Encrypt(K3)")
+	if p.keyingOption == 2 {
+		v.AddNote("Using two-key 3DES (K3 = K1): 112-bit key, about 80 bits of effective security")
+	} else {
+		v.AddNote("Using three-key 3DES: 168-bit key, about 112 bits of effective security")
+	}
+	v.AddSeparator()
+
+	// Show key information
+	v.AddStep("Key Information:")
+	v.AddStep(fmt.Sprintf("Keying Option: %d-key", p.keyingOption))
+	v.AddStep(fmt.Sprintf("Key Size: %d bits (%d with parity)", p.keyingOption*56, p.keyingOption*64))
+	v.AddStep(fmt.Sprintf("Block Size: %d bits", des.BlockSize*8))
+	v.AddStep("Mode: CBC (Cipher Block Chaining)")
+	v.AddStep("Padding: PKCS7")
+	v.AddSeparator()
+
+	key := p.cipherKey()
+	v.AddHexStep("K1", key[:8])
+	v.AddHexStep("K2", key[8:16])
+	v.AddHexStep("K3", key[16:24])
+	v.AddArrow()
+
+	block, err := des.NewTripleDESCipher(key)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	var result string
+	if operation == OperationDecrypt {
+		v.AddStep("Decryption Process:")
+		v.AddStep("1. Base64 decode the input")
+		v.AddStep("2. Extract IV from the beginning")
+		v.AddStep("3. Use 3DES-CBC to decrypt (D(K3) → E(K2) → D(K1))")
+		v.AddStep("4. Remove PKCS7 padding")
+		v.AddStep("5. Convert result to text")
+		v.AddSeparator()
+
+		v.AddTextStep("Encrypted Input (Base64)", text)
+		v.AddArrow()
+
+		data, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid base64 string: %w", err)
+		}
+		v.AddHexStep("Decoded Data", data)
+		v.AddArrow()
+
+		if len(data) < 2*des.BlockSize || len(data)%des.BlockSize != 0 {
+			return "", nil, fmt.Errorf("ciphertext too short or not a multiple of the block size")
+		}
+		iv := data[:des.BlockSize]
+		ciphertext := data[des.BlockSize:]
+		v.AddHexStep("Initialization Vector (IV)", iv)
+		v.AddHexStep("Ciphertext", ciphertext)
+		v.AddArrow()
+
+		plaintext := make([]byte, len(ciphertext))
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+		v.AddHexStep("Decrypted Data (with padding)", plaintext)
+		v.AddArrow()
+
+		unpadded, err := pkcs7Unpad(plaintext, des.BlockSize)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to unpad: %w", err)
+		}
+		v.AddTextStep("Decrypted Text", string(unpadded))
+		result = string(unpadded)
+	} else {
+		v.AddStep("Encryption Process:")
+		v.AddStep("1. Convert text to bytes")
+		v.AddStep("2. Generate random IV")
+		v.AddStep("3. Add PKCS7 padding")
+		v.AddStep("4. Use 3DES-CBC to encrypt (E(K1) → D(K2) → E(K3))")
+		v.AddStep("5. Combine IV and ciphertext")
+		v.AddStep("6. Base64 encode the result")
+		v.AddSeparator()
+
+		v.AddTextStep("Input Text", text)
+		v.AddArrow()
+
+		iv := make([]byte, des.BlockSize)
+		if _, err := rand.Read(iv); err != nil {
+			return "", nil, fmt.Errorf("failed to generate IV: %w", err)
+		}
+		v.AddHexStep("Generated IV", iv)
+		v.AddArrow()
+
+		paddedText := addPaddingSteps(v, []byte(text), des.BlockSize)
+		v.AddHexStep("Padded Input", paddedText)
+		v.AddArrow()
+
+		ciphertext := make([]byte, len(paddedText))
+		cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, paddedText)
+		v.AddHexStep("Encrypted Data", ciphertext)
+		v.AddArrow()
+
+		combined := make([]byte, len(iv)+len(ciphertext))
+		copy(combined, iv)
+		copy(combined[len(iv):], ciphertext)
+		v.AddHexStep("Combined IV and Ciphertext", combined)
+		v.AddArrow()
+
+		result = base64.StdEncoding.EncodeToString(combined)
+		v.AddTextStep("Base64 Encoded Result", result)
+	}
+
+	// Explain why 3DES is being retired
+	v.AddSeparator()
+	v.AddStep("⚠️ Why 3DES Is Being Retired:")
+	v.AddNote("1. 64-bit blocks collide after about 2^32 blocks (32 GB), which Sweet32 turns into plaintext recovery")
+	v.AddNote("2. Meet-in-the-middle attacks cut three-key 3DES to about 112 bits and two-key to about 80 bits")
+	v.AddNote("3. It is roughly three times slower than single DES and far slower than AES")
+	v.AddNote("4. NIST deprecated 3DES in 2019 and disallowed it for encryption after 2023; use AES-GCM instead")
+
+	return result, v.GetSteps(), nil
+}
+
+// KeyFile returns the key file currently in use
+func (p *TripleDESProcessor) KeyFile() string {
+	return keyFileOf(p.keyManager)
+}
+
+// UseKeyFile switches to an existing key file, such as an archived key
+func (p *TripleDESProcessor) UseKeyFile(path string) error {
+	keyManager, err := OpenFileKeyManager(p.keyingOption*64, path)
+	if err != nil {
+		return err
+	}
+	p.keyManager = keyManager
+	return nil
+}
+
+// Parameters returns the processor's effective settings
+func (p *TripleDESProcessor) Parameters() []Parameter {
+	return []Parameter{
+		{Name: "keyingOption", Description: "Number of independent DES keys (2 or 3)", Value: p.keyingOption},
+		{Name: "keyFile", Description: "File the key is stored in", Value: keyFileOf(p.keyManager)},
+	}
+}
+
+// AuditSources lists the randomness sources and libraries used for Triple DES
+func (p *TripleDESProcessor) AuditSources() []AuditSource {
+	return []AuditSource{
+		keyManagerRandomness,
+		{Kind: AuditRandomness, Package: "crypto/rand", Purpose: "Random IV for each encryption"},
+		{Kind: AuditStandardLibrary, Package: "crypto/des, crypto/cipher", Purpose: "Triple DES block cipher in CBC mode"},
+	}
+}
@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTripleDESProcessor_Configure(t *testing.T) {
+	processor := NewTripleDESProcessor()
+	if err := processor.Configure(map[string]interface{}{
+		"keyingOption": 4,
+	}); err == nil {
+		t.Error("Expected error for invalid keying option, got nil")
+	}
+}
+
+func TestTripleDESProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name         string
+		keyingOption int
+		keyFile      string
+	}{
+		{name: "two-key", keyingOption: 2, keyFile: "keys/test_3des_2key.bin"},
+		{name: "three-key", keyingOption: 3, keyFile: "keys/test_3des_3key.bin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewTripleDESProcessor()
+			if err := processor.Configure(map[string]interface{}{
+				"keyingOption": tt.keyingOption,
+				"keyFile":      tt.keyFile,
+			}); err != nil {
+				t.Fatalf("Failed to configure processor: %v", err)
+			}
+
+			key := processor.cipherKey()
+			if len(key) != 24 {
+				t.Fatalf("cipher key length = %d, want 24", len(key))
+			}
+			if tt.keyingOption == 2 && !bytes.Equal(key[:8], key[16:]) {
+				t.Error("two-key 3DES should reuse K1 as K3")
+			}
+
+			plaintext := "Sweet32 says hello"
+			encrypted, _, err := processor.Process(plaintext, OperationEncrypt)
+			if err != nil {
+				t.Fatalf("Encryption failed: %v", err)
+			}
+			decrypted, _, err := processor.Process(encrypted, OperationDecrypt)
+			if err != nil {
+				t.Fatalf("Decryption failed: %v", err)
+			}
+			if decrypted != plaintext {
+				t.Errorf("Decryption result = %v, want %v", decrypted, plaintext)
+			}
+		})
+	}
+}
@@ -65,10 +65,10 @@ func TestX25519Processor_Process(t *testing.T) {
 	keyExchangeFound := false
 	sharedSecretFound := false
 	for _, step := range steps {
-		if step == "Step 2: Public Key Calculation" {
+		if step.Text == "Step 2: Public Key Calculation" {
 			keyExchangeFound = true
 		}
-		if step == "Step 3: Shared Secret Calculation" {
+		if step.Text == "Step 3: Shared Secret Calculation" {
 			sharedSecretFound = true
 		}
 	}
@@ -168,7 +168,7 @@ func TestX25519TLSHandshake(t *testing.T) {
 	for _, expectedStep := range tlsSteps {
 		found := false
 		for _, step := range steps {
-			if step == expectedStep {
+			if step.Text == expectedStep {
 				found = true
 				break
 			}
@@ -202,7 +202,7 @@ func TestX25519SecurityWarnings(t *testing.T) {
 	for _, warning := range expectedWarnings {
 		found := false
 		for _, step := range steps {
-			if strings.Contains(step, warning) {
+			if strings.Contains(step.Text, warning) {
 				found = true
 				break
 			}
@@ -212,3 +212,128 @@ func TestX25519SecurityWarnings(t *testing.T) {
 		}
 	}
 }
+
+func TestX25519Processor_ProcessCustomMessage(t *testing.T) {
+	processor := NewX25519Processor()
+	message := "Meet me at the usual place"
+
+	_, steps, err := processor.Process(message, "")
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	decryptedFound := false
+	for _, step := range steps {
+		if strings.Contains(step.Text, "Decrypted Message: "+message) {
+			decryptedFound = true
+		}
+		if strings.Contains(step.Text, defaultKeyExchangeMessage) {
+			t.Error("Default message used even though a message was provided")
+		}
+	}
+	if !decryptedFound {
+		t.Error("Provided message did not round-trip through the derived key")
+	}
+}
+
+func TestDerivedKeyEncryptionRoundTrip(t *testing.T) {
+	derivedKey := make([]byte, 32)
+	if _, err := rand.Read(derivedKey); err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	message := "user supplied message"
+	ciphertext, err := encryptWithDerivedKey(derivedKey, message)
+	if err != nil {
+		t.Fatalf("encryptWithDerivedKey failed: %v", err)
+	}
+	if bytes.Contains(ciphertext, []byte(message)) {
+		t.Error("Ciphertext contains the plaintext")
+	}
+
+	plaintext, err := decryptWithDerivedKey(derivedKey, ciphertext)
+	if err != nil {
+		t.Fatalf("decryptWithDerivedKey failed: %v", err)
+	}
+	if plaintext != message {
+		t.Errorf("Expected %q, got %q", message, plaintext)
+	}
+
+	if keyExchangeMessage("") != defaultKeyExchangeMessage {
+		t.Error("Empty input should fall back to the default message")
+	}
+}
+
+func TestX25519Processor_TranscriptExport(t *testing.T) {
+	processor := NewX25519Processor()
+	if processor.Transcript() != nil {
+		t.Error("Transcript should be nil before the exchange runs")
+	}
+
+	if _, _, err := processor.Process("", ""); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	transcript := processor.Transcript()
+	if transcript == nil {
+		t.Fatal("Transcript not recorded")
+	}
+
+	mermaid, err := transcript.Export(DiagramMermaid)
+	if err != nil {
+		t.Fatalf("Mermaid export failed: %v", err)
+	}
+	for _, want := range []string{"sequenceDiagram", "participant Alice", "participant Bob", "Alice->>Bob: Public key A", "Bob->>Alice: Public key B"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid diagram missing %q:\n%s", want, mermaid)
+		}
+	}
+
+	plantUML, err := transcript.Export(DiagramPlantUML)
+	if err != nil {
+		t.Fatalf("PlantUML export failed: %v", err)
+	}
+	for _, want := range []string{"@startuml", "participant Alice", "participant Bob", "Alice -> Bob: Public key A", "Bob -> Alice: Public key B", "@enduml"} {
+		if !strings.Contains(plantUML, want) {
+			t.Errorf("PlantUML diagram missing %q:\n%s", want, plantUML)
+		}
+	}
+
+	if _, err := transcript.Export("svg"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestX25519Processor_AuditSources(t *testing.T) {
+	processor := NewX25519Processor()
+	result, steps, err := AuditReport(processor)
+	if err != nil {
+		t.Fatalf("AuditReport() error = %v", err)
+	}
+
+	for _, want := range []string{"golang.org/x/crypto/curve25519", "crypto/rand"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Audit result %q does not mention %s", result, want)
+		}
+	}
+
+	randomness := false
+	for _, source := range processor.AuditSources() {
+		if source.Kind == AuditRandomness && source.Package == "crypto/rand" {
+			randomness = true
+		}
+	}
+	if !randomness {
+		t.Error("Expected crypto/rand to be reported as a randomness source")
+	}
+
+	found := false
+	for _, step := range steps {
+		if strings.Contains(step.Text, "golang.org/x/crypto/curve25519") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected curve25519 in the audit steps")
+	}
+}
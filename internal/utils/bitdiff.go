@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// bitDiffRowBytes is the number of bytes BitDiff renders per row
+const bitDiffRowBytes = 8
+
+// BitDiff returns the Hamming distance between a and b and a rendering of where they differ.
+// Each row covers eight bytes: the hex of a, the hex of b, "^^" under differing bytes, and a
+// bit map with █ for a differing bit and · for an equal one. When the lengths differ, the
+// bytes past the end of the shorter slice show as "--" and all of their bits count as different.
+func BitDiff(a, b []byte) (hamming int, rendered string) {
+	length := max(len(a), len(b))
+	var rows []string
+	for start := 0; start < length; start += bitDiffRowBytes {
+		end := min(start+bitDiffRowBytes, length)
+		var hexA, hexB, markers, bitMap []string
+		for i := start; i < end; i++ {
+			var diff byte
+			if i < len(a) && i < len(b) {
+				diff = a[i] ^ b[i]
+			} else {
+				diff = 0xff
+			}
+			hamming += bits.OnesCount8(diff)
+			hexA = append(hexA, bitDiffHex(a, i))
+			hexB = append(hexB, bitDiffHex(b, i))
+			if diff != 0 {
+				markers = append(markers, "^^")
+			} else {
+				markers = append(markers, "  ")
+			}
+			var cells strings.Builder
+			for bit := 7; bit >= 0; bit-- {
+				if diff&(1<<bit) != 0 {
+					cells.WriteString("█")
+				} else {
+					cells.WriteString("·")
+				}
+			}
+			bitMap = append(bitMap, cells.String())
+		}
+		rows = append(rows,
+			fmt.Sprintf("  %04x  %s", start, strings.Join(hexA, " ")),
+			fmt.Sprintf("        %s", strings.Join(hexB, " ")),
+			fmt.Sprintf("        %s", strings.Join(markers, " ")),
+			fmt.Sprintf("        %s", strings.Join(bitMap, " ")),
+		)
+	}
+	return hamming, strings.Join(rows, "\n")
+}
+
+// bitDiffHex returns the hex of data[i], or "--" past the end of data
+func bitDiffHex(data []byte, i int) string {
+	if i >= len(data) {
+		return "--"
+	}
+	return fmt.Sprintf("%02x", data[i])
+}
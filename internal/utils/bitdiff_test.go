@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBitDiff_EqualLength(t *testing.T) {
+	tests := []struct {
+		a, b []byte
+		want int
+	}{
+		{nil, nil, 0},
+		{[]byte{0x00}, []byte{0x00}, 0},
+		{[]byte{0x00}, []byte{0x01}, 1},
+		{[]byte{0x0f, 0xf0}, []byte{0xf0, 0xf0}, 8},
+		{[]byte{0x00, 0x00}, []byte{0xff, 0xff}, 16},
+	}
+	for _, tt := range tests {
+		if got, _ := BitDiff(tt.a, tt.b); got != tt.want {
+			t.Errorf("BitDiff(%x, %x) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	_, rendered := BitDiff([]byte{0x00, 0xff}, []byte{0x01, 0xff})
+	lines := strings.Split(rendered, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines for one row, got %d:\n%s", len(lines), rendered)
+	}
+	for i, want := range []string{"  0000  00 ff", "        01 ff", "        ^^   ", "        ·······█ ········"} {
+		if lines[i] != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+
+	// Nine bytes need a second row starting at offset 8
+	_, rendered = BitDiff(make([]byte, 9), make([]byte, 9))
+	if !strings.Contains(rendered, "  0008  00") {
+		t.Errorf("Expected a second row at offset 0008:\n%s", rendered)
+	}
+}
+
+func TestBitDiff_DifferentLength(t *testing.T) {
+	hamming, rendered := BitDiff([]byte{0xaa, 0xbb}, []byte{0xaa})
+	if hamming != 8 {
+		t.Errorf("hamming = %d, want 8 for one missing byte", hamming)
+	}
+	if !strings.Contains(rendered, "aa --") || !strings.Contains(rendered, "········ ████████") {
+		t.Errorf("Expected the missing byte to show as -- with every bit marked:\n%s", rendered)
+	}
+
+	if hamming, _ := BitDiff(nil, []byte{0x01, 0x02, 0x03}); hamming != 24 {
+		t.Errorf("hamming = %d, want 24 against an empty slice", hamming)
+	}
+}
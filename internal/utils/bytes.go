@@ -0,0 +1,26 @@
+package utils
+
+import "fmt"
+
+// byteUnits are the suffixes used by FormatBytes, each 1024 times the previous one
+var byteUnits = []string{"KB", "MB", "GB", "TB", "PB", "EB"}
+
+// FormatBytes formats a size in bytes using binary multiples, with one decimal place when the
+// size is not a whole number of units (e.g. "512 B", "64 KB", "1.5 MB")
+func FormatBytes(n uint64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	unit := uint64(1024)
+	i := 0
+	for n/unit >= 1024 && i < len(byteUnits)-1 {
+		unit *= 1024
+		i++
+	}
+	if n%unit == 0 {
+		return fmt.Sprintf("%d %s", n/unit, byteUnits[i])
+	}
+	// Truncate to tenths so a size just under the next unit never shows as 1024.0
+	tenths := n/unit*10 + n%unit*10/unit
+	return fmt.Sprintf("%d.%d %s", tenths/10, tenths%10, byteUnits[i])
+}
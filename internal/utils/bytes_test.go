@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0 B"},
+		{64, "64 B"},
+		{1023, "1023 B"},
+		{1024, "1 KB"},
+		{1536, "1.5 KB"},
+		{64 * 1024, "64 KB"},
+		{1024*1024 - 1, "1023.9 KB"},
+		{1024 * 1024, "1 MB"},
+		{5*1024*1024 + 1, "5.0 MB"},
+		{1 << 30, "1 GB"},
+		{1 << 40, "1 TB"},
+		{math.MaxUint64, "15.9 EB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/atotto/clipboard"
+)
+
+// ErrClipboardUnavailable is returned when the system has no clipboard to write to, such as
+// a Linux session without xclip, xsel, or wl-clipboard installed
+var ErrClipboardUnavailable = errors.New("no clipboard available (on Linux, install xclip, xsel, or wl-clipboard)")
+
+// CopyToClipboard puts text on the system clipboard
+func CopyToClipboard(text string) error {
+	if clipboard.Unsupported {
+		return ErrClipboardUnavailable
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
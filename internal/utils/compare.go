@@ -0,0 +1,10 @@
+package utils
+
+import "crypto/subtle"
+
+// ConstantTimeCompare reports whether a and b are equal, taking the same time wherever they differ.
+// Use it for MACs, tags, and derived keys; it only returns early when the lengths differ, and the
+// length of a MAC or key is not secret.
+func ConstantTimeCompare(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,20 @@
+package utils
+
+import "testing"
+
+func TestConstantTimeCompare(t *testing.T) {
+	tests := []struct {
+		a, b []byte
+		want bool
+	}{
+		{[]byte("tag"), []byte("tag"), true},
+		{[]byte("tag"), []byte("tah"), false},
+		{[]byte("tag"), []byte("tags"), false},
+		{nil, []byte{}, true},
+	}
+	for _, tt := range tests {
+		if got := ConstantTimeCompare(tt.a, tt.b); got != tt.want {
+			t.Errorf("ConstantTimeCompare(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
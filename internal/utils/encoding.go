@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Encodings for binary results such as ciphertexts and MACs
+const (
+	EncodingHex       = "hex"
+	EncodingBase64    = "base64"
+	EncodingBase64URL = "base64url" // URL-safe alphabet without padding, as in JWTs
+)
+
+// Encodings returns the supported output encodings
+func Encodings() []string {
+	return []string{EncodingHex, EncodingBase64, EncodingBase64URL}
+}
+
+// ValidateEncoding returns an error unless encoding is one of Encodings
+func ValidateEncoding(encoding string) error {
+	switch encoding {
+	case EncodingHex, EncodingBase64, EncodingBase64URL:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output encoding: %s (must be one of: %s)", encoding, strings.Join(Encodings(), ", "))
+	}
+}
+
+// EncodingName returns the display name of an encoding
+func EncodingName(encoding string) string {
+	switch encoding {
+	case EncodingHex:
+		return "Hex"
+	case EncodingBase64URL:
+		return "Base64URL"
+	default:
+		return "Base64"
+	}
+}
+
+// Encode encodes data in the given encoding, falling back to Base64 for an unknown one
+func Encode(data []byte, encoding string) string {
+	switch encoding {
+	case EncodingHex:
+		return hex.EncodeToString(data)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(data)
+	default:
+		return base64.StdEncoding.EncodeToString(data)
+	}
+}
+
+// ErrUnknownEncoding is returned when input decodes in none of the supported encodings
+var ErrUnknownEncoding = errors.New("input is not valid Base64, Base64URL, or hex")
+
+// Decoding is one way of reading an encoded string
+type Decoding struct {
+	Data     []byte
+	Encoding string
+}
+
+// DecodeCandidates returns every way s decodes, trying Base64, then Base64URL (with or
+// without padding), then hex. A string of hex digits is usually also valid Base64, so it
+// can have several decodings; hex then comes first, since Base64 of random bytes almost
+// never uses only hex digits.
+func DecodeCandidates(s string) []Decoding {
+	s = strings.TrimSpace(s)
+	var candidates []Decoding
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		candidates = append(candidates, Decoding{data, EncodingBase64})
+	} else if data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "=")); err == nil {
+		candidates = append(candidates, Decoding{data, EncodingBase64URL})
+	}
+	if data, err := hex.DecodeString(s); err == nil {
+		candidates = append([]Decoding{{data, EncodingHex}}, candidates...)
+	}
+	return candidates
+}
+
+// DecodeAny decodes s and reports the encoding used. It picks the first candidate whose data
+// fits (for example, a ciphertext of a valid length), or the first candidate if none fits or
+// fits is nil, so the caller's own checks report the problem.
+func DecodeAny(s string, fits func([]byte) bool) ([]byte, string, error) {
+	candidates := DecodeCandidates(s)
+	if len(candidates) == 0 {
+		return nil, "", ErrUnknownEncoding
+	}
+	if fits != nil {
+		for _, candidate := range candidates {
+			if fits(candidate.Data) {
+				return candidate.Data, candidate.Encoding, nil
+			}
+		}
+	}
+	return candidates[0].Data, candidates[0].Encoding, nil
+}
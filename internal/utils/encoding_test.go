@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeDecodeAny(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x00, 0x10, 0x7e, 0x3f}
+	for _, encoding := range Encodings() {
+		encoded := Encode(data, encoding)
+		decoded, detected, err := DecodeAny(encoded, nil)
+		if err != nil {
+			t.Fatalf("%s: DecodeAny(%q) error = %v", encoding, encoded, err)
+		}
+		if !bytes.Equal(decoded, data) || detected != encoding {
+			t.Errorf("%s: DecodeAny(%q) = %x, %s", encoding, encoded, decoded, detected)
+		}
+	}
+
+	if got := Encode(data, EncodingBase64URL); got != "-_8AEH4_" {
+		t.Errorf("Expected the URL-safe alphabet without padding, got %q", got)
+	}
+	if _, encoding, err := DecodeAny("aGk=\n", nil); err != nil || encoding != EncodingBase64 {
+		t.Errorf("Expected padded Base64 with a trailing newline to decode, got %s, %v", encoding, err)
+	}
+	if _, _, err := DecodeAny("not base64!", nil); err == nil {
+		t.Error("Expected an error for input in no supported encoding")
+	}
+}
+
+func TestDecodeCandidates(t *testing.T) {
+	// Hex digits are also valid Base64; hex is tried first, but a length check can pick Base64
+	candidates := DecodeCandidates("deadbeef")
+	if len(candidates) != 2 || candidates[0].Encoding != EncodingHex || candidates[1].Encoding != EncodingBase64 {
+		t.Fatalf("Expected hex then Base64 candidates, got %+v", candidates)
+	}
+	data, encoding, err := DecodeAny("deadbeef", func(data []byte) bool { return len(data) == 6 })
+	if err != nil || encoding != EncodingBase64 || len(data) != 6 {
+		t.Errorf("Expected the 6-byte Base64 reading, got %x, %s, %v", data, encoding, err)
+	}
+	if _, encoding, _ := DecodeAny("deadbeef", func([]byte) bool { return false }); encoding != EncodingHex {
+		t.Errorf("Expected the first candidate when none fits, got %s", encoding)
+	}
+
+	// Unpadded URL-safe input only decodes as Base64URL
+	if candidates := DecodeCandidates("-_8"); len(candidates) != 1 || candidates[0].Encoding != EncodingBase64URL {
+		t.Errorf("Expected a single Base64URL candidate, got %+v", candidates)
+	}
+}
+
+func TestValidateEncoding(t *testing.T) {
+	for _, encoding := range Encodings() {
+		if err := ValidateEncoding(encoding); err != nil {
+			t.Errorf("ValidateEncoding(%q) error = %v", encoding, err)
+		}
+	}
+	if err := ValidateEncoding("base32"); err == nil {
+		t.Error("Expected an error for an unsupported encoding")
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ParseLogLevel parses a general.logLevel value: debug, info, warn, or error
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("invalid log level: %s (must be debug, info, warn, or error)", level)
+	}
+}
+
+// ConfigureLogger makes the default slog logger write text records to w at level, or at debug
+// level when debug is set. Until it runs, slog's default logs info and above.
+func ConfigureLogger(w io.Writer, level string, debug bool) error {
+	parsed, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	if debug {
+		parsed = slog.LevelDebug
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: parsed})))
+	return nil
+}
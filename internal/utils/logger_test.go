@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestConfigureLogger(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	tests := []struct {
+		level     string
+		debug     bool
+		wantDebug bool
+		wantWarn  bool
+	}{
+		{"info", false, false, true},
+		{"DEBUG", false, true, true},
+		{"error", false, false, false},
+		{"warn", true, true, true}, // debug overrides the level
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := ConfigureLogger(&buf, tt.level, tt.debug); err != nil {
+			t.Fatalf("ConfigureLogger(%q) error = %v", tt.level, err)
+		}
+		slog.Debug("debug record")
+		slog.Warn("warn record")
+		if got := strings.Contains(buf.String(), "debug record"); got != tt.wantDebug {
+			t.Errorf("level %q debug %v: debug record logged = %v, want %v", tt.level, tt.debug, got, tt.wantDebug)
+		}
+		if got := strings.Contains(buf.String(), "warn record"); got != tt.wantWarn {
+			t.Errorf("level %q debug %v: warn record logged = %v, want %v", tt.level, tt.debug, got, tt.wantWarn)
+		}
+	}
+
+	if err := ConfigureLogger(&bytes.Buffer{}, "verbose", false); err == nil {
+		t.Error("Expected an error for an invalid level")
+	}
+}
@@ -0,0 +1,34 @@
+package utils
+
+// Prompter is implemented by input handlers that let a processor ask the user for choices mid-operation.
+// Processors configured without one run non-interactively with their defaults.
+type Prompter interface {
+	// Choose shows a titled list of options and returns the 1-based option picked
+	Choose(title string, options []string) (int, error)
+	// Prompt shows a prompt and returns the trimmed line entered
+	Prompt(prompt string) (string, error)
+}
+
+// PromptChoice asks p to pick an option, returning 1 (the default) when p is nil or the answer is invalid
+func PromptChoice(p Prompter, title string, options ...string) int {
+	if p == nil {
+		return 1
+	}
+	choice, err := p.Choose(title, options)
+	if err != nil || choice < 1 || choice > len(options) {
+		return 1
+	}
+	return choice
+}
+
+// PromptLine asks p for a line of text, returning an empty string when p is nil or reading fails
+func PromptLine(p Prompter, prompt string) string {
+	if p == nil {
+		return ""
+	}
+	line, err := p.Prompt(prompt)
+	if err != nil {
+		return ""
+	}
+	return line
+}
@@ -0,0 +1,22 @@
+//go:build qr
+
+package utils
+
+import (
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// QRAvailable reports whether this build can render QR codes
+const QRAvailable = true
+
+// RenderQR encodes text as a QR code drawn with half-block characters, two modules per
+// line. Light modules are drawn as blocks, so the code scans on a dark terminal.
+func RenderQR(text string) (string, error) {
+	code, err := qrcode.New(text, qrcode.Medium)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return code.ToSmallString(false), nil
+}
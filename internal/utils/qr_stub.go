@@ -0,0 +1,16 @@
+//go:build !qr
+
+package utils
+
+import "errors"
+
+// QRAvailable reports whether this build can render QR codes
+const QRAvailable = false
+
+// ErrQRUnavailable is returned by RenderQR in builds without the qr tag
+var ErrQRUnavailable = errors.New("QR output is not included in this build; rebuild with -tags qr")
+
+// RenderQR encodes text as a terminal QR code; this build has no QR encoder
+func RenderQR(text string) (string, error) {
+	return "", ErrQRUnavailable
+}
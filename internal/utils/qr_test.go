@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderQR(t *testing.T) {
+	code, err := RenderQR("otpauth://totp/CryptoLens:alice?secret=JBSWY3DPEHPK3PXP")
+	if !QRAvailable {
+		if err == nil {
+			t.Fatal("Expected an error from a build without the qr tag")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("RenderQR() error = %v", err)
+	}
+
+	// Half-block rendering packs two module rows into each line of equal width
+	lines := strings.Split(strings.TrimSuffix(code, "\n"), "\n")
+	if len(lines) < 10 {
+		t.Fatalf("Expected a QR code of at least 10 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if len([]rune(line)) != len([]rune(lines[0])) {
+			t.Fatalf("line %d has %d modules, want %d", i, len([]rune(line)), len([]rune(lines[0])))
+		}
+	}
+
+	if _, err := RenderQR(strings.Repeat("x", 4000)); err == nil {
+		t.Error("Expected an error for text beyond the QR capacity")
+	}
+}
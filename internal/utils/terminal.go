@@ -1,11 +1,19 @@
 package utils
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 
 	"golang.org/x/term"
 )
 
+// Bar chart widths, in characters
+const (
+	maxBarWidth = 50
+	minBarWidth = 10
+)
+
 // GetTerminalWidth returns the width of the terminal window
 func GetTerminalWidth() int {
 	width, _, err := term.GetSize(int(os.Stdout.Fd()))
@@ -14,3 +22,47 @@ func GetTerminalWidth() int {
 	}
 	return width
 }
+
+// GetTerminalHeight returns the height of the terminal window. When stdout is not a terminal
+// it falls back to the LINES environment variable, then to 24 rows.
+func GetTerminalHeight() int {
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err == nil && height > 0 {
+		return height
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	return 24
+}
+
+// BarChartWidth returns the longest bar to draw when reserved columns of each line hold the
+// label and value: 50 characters, shrunk to fit narrow terminals but never below 10
+func BarChartWidth(reserved int) int {
+	width := GetTerminalWidth() - reserved
+	if width > maxBarWidth {
+		return maxBarWidth
+	}
+	if width < minBarWidth {
+		return minBarWidth
+	}
+	return width
+}
+
+// StdoutIsTerminal reports whether stdout is an interactive terminal rather than a pipe or file
+func StdoutIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// StdinIsTerminal reports whether stdin is an interactive terminal rather than a pipe or file
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// RestoreTerminal clears a half-drawn spinner or progress line and shows the cursor again with
+// default colors, for use after an operation is interrupted mid-output
+func RestoreTerminal() {
+	if StdoutIsTerminal() {
+		fmt.Print("\r\033[K\033[?25h\033[0m")
+	}
+}
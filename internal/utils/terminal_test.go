@@ -0,0 +1,38 @@
+package utils
+
+import "testing"
+
+func TestGetTerminalHeight(t *testing.T) {
+	if StdoutIsTerminal() {
+		t.Skip("stdout is a terminal, so its real size is used")
+	}
+	t.Setenv("LINES", "40")
+	if got := GetTerminalHeight(); got != 40 {
+		t.Errorf("GetTerminalHeight() = %d, want 40 from LINES", got)
+	}
+	t.Setenv("LINES", "")
+	if got := GetTerminalHeight(); got != 24 {
+		t.Errorf("GetTerminalHeight() = %d, want the 24-row fallback", got)
+	}
+}
+
+func TestBarChartWidth(t *testing.T) {
+	if StdoutIsTerminal() {
+		t.Skip("stdout is a terminal, so its real width is used")
+	}
+	// Without a terminal the width falls back to 80 columns
+	tests := []struct {
+		reserved int
+		want     int
+	}{
+		{0, 50},
+		{30, 50},
+		{50, 30},
+		{75, 10},
+	}
+	for _, tt := range tests {
+		if got := BarChartWidth(tt.reserved); got != tt.want {
+			t.Errorf("BarChartWidth(%d) = %d, want %d", tt.reserved, got, tt.want)
+		}
+	}
+}
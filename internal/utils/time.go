@@ -5,15 +5,72 @@ import (
 	"time"
 )
 
-// FormatDuration formats a duration for display
+// FormatDuration formats a duration for display, from nanoseconds up to hours. Fractional values
+// are truncated rather than rounded, so a value never shows as the next unit's boundary (1000.0ms).
 func FormatDuration(d time.Duration) string {
-	if d < time.Second {
-		return fmt.Sprintf("%.0fms", float64(d.Milliseconds()))
+	if d < 0 {
+		return "-" + FormatDuration(-d)
 	}
-	if d < time.Minute {
-		return fmt.Sprintf("%.1fs", d.Seconds())
+	switch {
+	case d < time.Microsecond:
+		return fmt.Sprintf("%dns", d.Nanoseconds())
+	case d < time.Millisecond:
+		return formatTenths(d, time.Microsecond, "µs")
+	case d < time.Second:
+		return formatTenths(d, time.Millisecond, "ms")
+	case d < time.Minute:
+		return formatTenths(d, time.Second, "s")
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh %dm %ds", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	}
+}
+
+// formatTenths formats d in unit with one decimal place, truncated
+func formatTenths(d, unit time.Duration, suffix string) string {
+	tenths := d / (unit / 10)
+	return fmt.Sprintf("%d.%d%s", tenths/10, tenths%10, suffix)
+}
+
+// FormatCrackTime formats a number of seconds from "instant" up to multiples of the age of the universe
+func FormatCrackTime(seconds float64) string {
+	const (
+		minute = 60
+		hour   = 60 * minute
+		day    = 24 * hour
+		year   = 365.25 * day
+	)
+	switch {
+	case seconds < 1:
+		return "instant"
+	case seconds < minute:
+		return fmt.Sprintf("%.0f seconds", seconds)
+	case seconds < hour:
+		return fmt.Sprintf("%.0f minutes", seconds/minute)
+	case seconds < day:
+		return fmt.Sprintf("%.0f hours", seconds/hour)
+	case seconds < year:
+		return fmt.Sprintf("%.0f days", seconds/day)
+	case seconds < 1000*year:
+		return fmt.Sprintf("%.0f years", seconds/year)
+	case seconds < 1.38e10*year:
+		return fmt.Sprintf("%.1e years", seconds/year)
+	default:
+		return "longer than the age of the universe"
+	}
+}
+
+// FormatRate formats a rate such as guesses per second with an SI suffix
+func FormatRate(rate float64) string {
+	switch {
+	case rate >= 1e9:
+		return fmt.Sprintf("%.0fG", rate/1e9)
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk", rate/1e3)
+	default:
+		return fmt.Sprintf("%.0f", rate)
 	}
-	minutes := int(d.Minutes())
-	seconds := int(d.Seconds()) % 60
-	return fmt.Sprintf("%dm %ds", minutes, seconds)
 }
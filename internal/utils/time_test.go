@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0ns"},
+		{999 * time.Nanosecond, "999ns"},
+		{time.Microsecond, "1.0µs"},
+		{1550 * time.Nanosecond, "1.5µs"},
+		{time.Millisecond - 1, "999.9µs"},
+		{time.Millisecond, "1.0ms"},
+		{250 * time.Millisecond, "250.0ms"},
+		{time.Second - 1, "999.9ms"},
+		{time.Second, "1.0s"},
+		{time.Minute - 1, "59.9s"},
+		{time.Minute, "1m 0s"},
+		{90 * time.Second, "1m 30s"},
+		{time.Hour - 1, "59m 59s"},
+		{time.Hour, "1h 0m 0s"},
+		{26*time.Hour + 3*time.Minute + 4*time.Second, "26h 3m 4s"},
+		{-1500 * time.Millisecond, "-1.5s"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCrackTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0.5, "instant"},
+		{30, "30 seconds"},
+		{7200, "2 hours"},
+		{3 * 365.25 * 86400, "3 years"},
+		{1e30, "longer than the age of the universe"},
+	}
+	for _, tt := range tests {
+		if got := FormatCrackTime(tt.seconds); got != tt.want {
+			t.Errorf("FormatCrackTime(%v) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
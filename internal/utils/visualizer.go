@@ -5,34 +5,95 @@ import (
 	"strings"
 )
 
+// StepType tells the display how to format a step without guessing from its text
+type StepType int
+
+// Step types
+const (
+	StepData      StepType = iota // Plain content; the default for AddStep
+	StepHeader                    // Section title
+	StepSubheader                 // Title of a step or subsection within a section
+	StepNote                      // Explanatory note
+	StepDiagram                   // Line of an ASCII diagram, printed as is
+	StepArrow                     // Arrow between transformation stages
+	StepSeparator                 // Line between sections
+)
+
+// Step is one typed line of a visualization
+type Step struct {
+	Type StepType
+	Text string
+}
+
+// DataSteps wraps plain lines, such as a plugin's output, as data steps
+func DataSteps(lines []string) []Step {
+	steps := make([]Step, len(lines))
+	for i, line := range lines {
+		steps[i] = Step{Type: StepData, Text: line}
+	}
+	return steps
+}
+
+// StepTexts returns the text of each step, for output that does not format by type
+func StepTexts(steps []Step) []string {
+	texts := make([]string, len(steps))
+	for i, step := range steps {
+		texts[i] = step.Text
+	}
+	return texts
+}
+
 // Visualizer helps display encryption steps in a graphical format
 type Visualizer struct {
-	steps []string
-	theme Theme
+	entries []Step
+	theme   Theme
 }
 
 // NewVisualizer creates a new visualizer instance
 func NewVisualizer() *Visualizer {
 	return &Visualizer{
-		steps: make([]string, 0),
-		theme: DefaultTheme,
+		entries: make([]Step, 0),
+		theme:   DefaultTheme,
 	}
 }
 
-// AddStep adds a step to the visualization
+// add appends a typed step
+func (v *Visualizer) add(stepType StepType, text string) {
+	v.entries = append(v.entries, Step{Type: stepType, Text: text})
+}
+
+// AddStep adds a data step to the visualization; use the typed methods for titles, notes,
+// and diagrams
 func (v *Visualizer) AddStep(step string) {
 	if strings.HasPrefix(step, "Note:") {
-		v.steps = append(v.steps, v.theme.Format(step, "dim"))
+		v.add(StepData, v.theme.Format(step, "dim"))
 	} else if strings.HasPrefix(step, "How to use") || strings.HasPrefix(step, "Security considerations") {
-		v.steps = append(v.steps, v.theme.Format(step, "bold"))
+		v.add(StepData, v.theme.Format(step, "bold"))
 	} else if strings.Contains(step, "->") {
-		v.steps = append(v.steps, v.theme.Format(step, "brightYellow"))
+		v.add(StepData, v.theme.Format(step, "brightYellow"))
 	} else if strings.HasPrefix(step, "Character:") {
-		v.steps = append(v.steps, v.theme.Format(step, "brightPurple"))
+		v.add(StepData, v.theme.Format(step, "brightPurple"))
 	} else if strings.HasPrefix(step, "ASCII:") || strings.HasPrefix(step, "Binary:") {
-		v.steps = append(v.steps, v.theme.Format(step, "brightBlue"))
+		v.add(StepData, v.theme.Format(step, "brightBlue"))
 	} else {
-		v.steps = append(v.steps, step)
+		v.add(StepData, step)
+	}
+}
+
+// AddHeader adds a section title; the display styles and underlines it
+func (v *Visualizer) AddHeader(title string) {
+	v.add(StepHeader, title)
+}
+
+// AddSubheader adds the title of a step or subsection, such as "Step 1: Key Generation"
+func (v *Visualizer) AddSubheader(title string) {
+	v.add(StepSubheader, title)
+}
+
+// AddDiagram adds each line of an ASCII diagram
+func (v *Visualizer) AddDiagram(diagram string) {
+	for _, line := range strings.Split(strings.TrimRight(diagram, "\n"), "\n") {
+		v.add(StepDiagram, line)
 	}
 }
 
@@ -42,7 +103,7 @@ func (v *Visualizer) AddBinaryStep(label string, data []byte) {
 	for i, b := range data {
 		binary[i] = v.theme.Format(fmt.Sprintf("%08b", b), "brightYellow")
 	}
-	v.steps = append(v.steps, v.theme.Format(fmt.Sprintf("%s: %s", label, strings.Join(binary, " ")), "brightBlue"))
+	v.add(StepData, v.theme.Format(fmt.Sprintf("%s: %s", label, strings.Join(binary, " ")), "brightBlue"))
 }
 
 // AddHexStep adds a step showing hexadecimal representation
@@ -51,40 +112,45 @@ func (v *Visualizer) AddHexStep(label string, data []byte) {
 	for i, b := range data {
 		hex[i] = v.theme.Format(fmt.Sprintf("%02x", b), "brightGreen")
 	}
-	v.steps = append(v.steps, v.theme.Format(fmt.Sprintf("%s: %s", label, strings.Join(hex, " ")), "brightBlue"))
+	v.add(StepData, v.theme.Format(fmt.Sprintf("%s: %s", label, strings.Join(hex, " ")), "brightBlue"))
 }
 
 // AddTextStep adds a step showing text representation
 func (v *Visualizer) AddTextStep(label string, text string) {
-	v.steps = append(v.steps, v.theme.Format(fmt.Sprintf("%s: %s", label, text), "brightPurple"))
+	v.add(StepData, v.theme.Format(fmt.Sprintf("%s: %s", label, text), "brightPurple"))
 }
 
 // AddArrow adds a visual arrow to show transformation
 func (v *Visualizer) AddArrow() {
-	v.steps = append(v.steps, v.theme.Format("    ↓↓↓", "brightYellow"))
+	v.add(StepArrow, v.theme.Format("    ↓↓↓", "brightYellow"))
 }
 
 // AddSeparator adds a visual separator
 func (v *Visualizer) AddSeparator() {
-	v.steps = append(v.steps, v.theme.Format("----------------------------------------", "blue"))
+	v.add(StepSeparator, v.theme.Format("----------------------------------------", "blue"))
 }
 
 // AddNote adds an explanatory note
 func (v *Visualizer) AddNote(note string) {
-	v.steps = append(v.steps, v.theme.Format(fmt.Sprintf("Note: %s", note), "yellow"))
+	v.add(StepNote, v.theme.Format(fmt.Sprintf("Note: %s", note), "yellow"))
+}
+
+// AddSteps appends steps from another visualization, keeping their types
+func (v *Visualizer) AddSteps(steps []Step) {
+	v.entries = append(v.entries, steps...)
 }
 
-// GetSteps returns all visualization steps
-func (v *Visualizer) GetSteps() []string {
-	return v.steps
+// GetSteps returns all visualization steps with their types
+func (v *Visualizer) GetSteps() []Step {
+	return v.entries
 }
 
 // Display prints the visualization to the console
 func (v *Visualizer) Display() {
 	fmt.Printf("\n%s\n", v.theme.Format("Encryption Process Visualization:", "brightCyan"))
 	fmt.Printf("%s\n", v.theme.Format("=================================", "blue"))
-	for _, step := range v.steps {
-		fmt.Println(step)
+	for _, entry := range v.entries {
+		fmt.Println(entry.Text)
 	}
 	fmt.Printf("%s\n", v.theme.Format("=================================", "blue"))
 }
@@ -4,6 +4,12 @@ package utils
 type VisualizerInterface interface {
 	// AddStep adds a step to the visualization
 	AddStep(step string)
+	// AddHeader adds a section title
+	AddHeader(title string)
+	// AddSubheader adds the title of a step or subsection
+	AddSubheader(title string)
+	// AddDiagram adds each line of an ASCII diagram
+	AddDiagram(diagram string)
 	// AddBinaryStep adds a step showing binary representation
 	AddBinaryStep(label string, data []byte)
 	// AddHexStep adds a step showing hexadecimal representation
@@ -16,8 +22,8 @@ type VisualizerInterface interface {
 	AddSeparator()
 	// AddNote adds an explanatory note
 	AddNote(note string)
-	// GetSteps returns all visualization steps
-	GetSteps() []string
+	// GetSteps returns all visualization steps with their types
+	GetSteps() []Step
 	// Display prints the visualization to the console
 	Display()
 }
@@ -10,10 +10,10 @@ func TestNewVisualizer(t *testing.T) {
 	if v == nil {
 		t.Fatal("NewVisualizer returned nil")
 	}
-	if v.steps == nil {
+	if v.entries == nil {
 		t.Fatal("Visualizer steps slice is nil")
 	}
-	if len(v.steps) != 0 {
+	if len(v.entries) != 0 {
 		t.Fatal("New visualizer should have empty steps")
 	}
 	if v.theme == nil {
@@ -41,7 +41,7 @@ func TestAddStep(t *testing.T) {
 
 	for _, tc := range testCases {
 		v.AddStep(tc.step)
-		lastStep := v.steps[len(v.steps)-1]
+		lastStep := v.entries[len(v.entries)-1].Text
 		if tc.expected != "" && !strings.Contains(lastStep, tc.expected) {
 			t.Errorf("Step '%s' was not formatted with expected style '%s'", tc.step, tc.expected)
 		}
@@ -53,7 +53,7 @@ func TestAddBinaryStep(t *testing.T) {
 	data := []byte{65, 66, 67} // ABC
 	v.AddBinaryStep("Test", data)
 
-	lastStep := v.steps[len(v.steps)-1]
+	lastStep := v.entries[len(v.entries)-1].Text
 	if !strings.Contains(lastStep, "Test:") {
 		t.Error("Binary step does not contain label")
 	}
@@ -67,7 +67,7 @@ func TestAddHexStep(t *testing.T) {
 	data := []byte{65, 66, 67} // ABC
 	v.AddHexStep("Test", data)
 
-	lastStep := v.steps[len(v.steps)-1]
+	lastStep := v.entries[len(v.entries)-1].Text
 	if !strings.Contains(lastStep, "Test:") {
 		t.Error("Hex step does not contain label")
 	}
@@ -80,7 +80,7 @@ func TestAddTextStep(t *testing.T) {
 	v := NewVisualizer()
 	v.AddTextStep("Test", "Hello")
 
-	lastStep := v.steps[len(v.steps)-1]
+	lastStep := v.entries[len(v.entries)-1].Text
 	if !strings.Contains(lastStep, "Test:") {
 		t.Error("Text step does not contain label")
 	}
@@ -93,7 +93,7 @@ func TestAddArrow(t *testing.T) {
 	v := NewVisualizer()
 	v.AddArrow()
 
-	lastStep := v.steps[len(v.steps)-1]
+	lastStep := v.entries[len(v.entries)-1].Text
 	if !strings.Contains(lastStep, "↓") {
 		t.Error("Arrow step does not contain arrow symbol")
 	}
@@ -103,7 +103,7 @@ func TestAddSeparator(t *testing.T) {
 	v := NewVisualizer()
 	v.AddSeparator()
 
-	lastStep := v.steps[len(v.steps)-1]
+	lastStep := v.entries[len(v.entries)-1].Text
 	if !strings.Contains(lastStep, "----------------------------------------") {
 		t.Error("Separator step does not contain separator line")
 	}
@@ -113,7 +113,7 @@ func TestAddNote(t *testing.T) {
 	v := NewVisualizer()
 	v.AddNote("Test note")
 
-	lastStep := v.steps[len(v.steps)-1]
+	lastStep := v.entries[len(v.entries)-1].Text
 	if !strings.Contains(lastStep, "Note: Test note") {
 		t.Error("Note step does not contain note text")
 	}
@@ -128,7 +128,48 @@ func TestGetSteps(t *testing.T) {
 	if len(steps) != 2 {
 		t.Errorf("GetSteps returned %d steps, expected 2", len(steps))
 	}
-	if steps[0] != v.steps[0] || steps[1] != v.steps[1] {
+	if steps[0].Text != v.entries[0].Text || steps[1].Text != v.entries[1].Text {
 		t.Error("GetSteps returned incorrect steps")
 	}
 }
+
+func TestStepTypes(t *testing.T) {
+	v := NewVisualizer()
+	v.AddHeader("Key Schedule")
+	v.AddSubheader("Step 1: Expand the key")
+	v.AddStep("plain data")
+	v.AddNote("a note")
+	v.AddDiagram("┌─┐\n└─┘\n")
+	v.AddArrow()
+	v.AddSeparator()
+
+	want := []StepType{StepHeader, StepSubheader, StepData, StepNote, StepDiagram, StepDiagram, StepArrow, StepSeparator}
+	steps := v.GetSteps()
+	if len(steps) != len(want) {
+		t.Fatalf("GetSteps returned %d steps, expected %d", len(steps), len(want))
+	}
+	for i, step := range steps {
+		if step.Type != want[i] {
+			t.Errorf("Step %d has type %d, want %d", i, step.Type, want[i])
+		}
+		if strings.ContainsRune(step.Text, '\x1f') {
+			t.Errorf("Step %d carries a control character: %q", i, step.Text)
+		}
+	}
+	if got := StepTexts(steps)[2]; got != "plain data" {
+		t.Errorf("StepTexts()[2] = %q, want %q", got, "plain data")
+	}
+
+	// AddStep no longer guesses a type from the text
+	v = NewVisualizer()
+	v.AddStep("Note: looks like a note")
+	v.AddStep("┌─┐")
+	for _, step := range v.GetSteps() {
+		if step.Type != StepData {
+			t.Errorf("AddStep(%q) has type %d, want StepData", step.Text, step.Type)
+		}
+	}
+	if got := DataSteps([]string{"plugin line"}); len(got) != 1 || got[0] != (Step{Type: StepData, Text: "plugin line"}) {
+		t.Errorf("DataSteps() = %+v", got)
+	}
+}
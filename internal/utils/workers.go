@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// WorkerCount returns how many goroutines to use for a limit; zero or less means one per CPU
+func WorkerCount(limit int) int {
+	if limit <= 0 {
+		return runtime.NumCPU()
+	}
+	return limit
+}
+
+// RunWorkers calls task for every index in [0, n) on at most WorkerCount(limit) goroutines.
+// A task returns true to stop the remaining work early. It returns the number of workers started.
+func RunWorkers(n, limit int, task func(i int) (stop bool)) int {
+	workers := WorkerCount(limit)
+	if workers > n {
+		workers = n
+	}
+
+	var next atomic.Int64
+	var stopped atomic.Bool
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stopped.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if task(i) {
+					stopped.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return workers
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// peakConcurrency runs n short tasks with the given limit and reports the most that ran at once
+func peakConcurrency(n, limit int) (int64, int) {
+	var running, peak atomic.Int64
+	workers := RunWorkers(n, limit, func(i int) bool {
+		current := running.Add(1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return false
+	})
+	return peak.Load(), workers
+}
+
+func TestRunWorkers_Limit(t *testing.T) {
+	peak, workers := peakConcurrency(8, 1)
+	if workers != 1 || peak != 1 {
+		t.Errorf("limit 1: workers = %d, peak concurrency = %d, want 1 and 1", workers, peak)
+	}
+
+	peak, workers = peakConcurrency(8, 4)
+	if workers != 4 {
+		t.Errorf("limit 4: workers = %d, want 4", workers)
+	}
+	if peak < 2 || peak > 4 {
+		t.Errorf("limit 4: peak concurrency = %d, want between 2 and 4", peak)
+	}
+
+	// A limit above the task count never starts idle workers
+	if _, workers = peakConcurrency(2, 16); workers != 2 {
+		t.Errorf("limit 16 with 2 tasks: workers = %d, want 2", workers)
+	}
+}
+
+func TestRunWorkers_StopAndDefault(t *testing.T) {
+	var calls atomic.Int64
+	RunWorkers(1000, 1, func(i int) bool {
+		calls.Add(1)
+		return i == 9
+	})
+	if got := calls.Load(); got != 10 {
+		t.Errorf("calls after stop = %d, want 10", got)
+	}
+
+	if got := WorkerCount(0); got != runtime.NumCPU() {
+		t.Errorf("WorkerCount(0) = %d, want %d", got, runtime.NumCPU())
+	}
+}